@@ -0,0 +1,271 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Xref prefixes used when a record is built without an explicit xref.
+const (
+	IndividualPrefix = "I"
+	FamilyPrefix     = "F"
+	SourcePrefix     = "S"
+	NotePrefix       = "N"
+)
+
+// Builder creates records and adds them to a tree.
+type Builder struct {
+	tree *types.GedcomTree
+	next map[string]int // Last number allocated per xref prefix
+}
+
+// New returns a Builder that adds records to tree. A nil tree starts a new one.
+func New(tree *types.GedcomTree) *Builder {
+	if tree == nil {
+		tree = types.NewGedcomTree()
+	}
+	return &Builder{
+		tree: tree,
+		next: make(map[string]int),
+	}
+}
+
+// Tree returns the tree records are added to.
+func (b *Builder) Tree() *types.GedcomTree {
+	return b.tree
+}
+
+// NextXref returns the next xref with prefix that is not used in the tree,
+// e.g. "@I3@" for prefix "I".
+func (b *Builder) NextXref(prefix string) string {
+	for {
+		b.next[prefix]++
+		xref := fmt.Sprintf("@%s%d@", prefix, b.next[prefix])
+		if b.tree.GetRecordByXref(xref) == nil {
+			return xref
+		}
+	}
+}
+
+// Note creates a note record holding text and adds it to the tree: a NOTE
+// record, or an SNOTE record when the tree is GEDCOM 7.0. Text may span
+// several lines; the exporter writes them as CONT lines.
+func (b *Builder) Note(text string) types.Record {
+	var note types.Record
+	if b.tree.IsGedcom7() {
+		note = types.NewSharedNoteRecord(types.NewGedcomLine(0, "SNOTE", text, b.NextXref(NotePrefix)))
+	} else {
+		note = types.NewNoteRecord(types.NewGedcomLine(0, "NOTE", text, b.NextXref(NotePrefix)))
+	}
+	b.tree.AddRecord(note)
+	return note
+}
+
+// allocateXref returns xref, or a new one with prefix if xref is empty. An
+// explicit xref must be well formed and unused.
+func (b *Builder) allocateXref(xref, prefix string) (string, error) {
+	if xref == "" {
+		return b.NextXref(prefix), nil
+	}
+	if len(xref) < 3 || !strings.HasPrefix(xref, "@") || !strings.HasSuffix(xref, "@") ||
+		strings.ContainsAny(xref[1:len(xref)-1], "@ \t\r\n") {
+		return "", fmt.Errorf("invalid xref %q: must be enclosed in @", xref)
+	}
+	if b.tree.GetRecordByXref(xref) != nil {
+		return "", fmt.Errorf("xref %s is already in use", xref)
+	}
+	return xref, nil
+}
+
+// checkInTree returns an error unless record was added to the builder's tree.
+func (b *Builder) checkInTree(record types.Record, what string) error {
+	if record == nil {
+		return fmt.Errorf("%s is nil", what)
+	}
+	if b.tree.GetRecordByXref(record.XrefID()) != record {
+		return fmt.Errorf("%s %s is not in the tree", what, record.XrefID())
+	}
+	return nil
+}
+
+// Name is a personal name with its parts, written as a NAME line with
+// NPFX, GIVN, NICK, SPFX, SURN and NSFX sub-lines for the parts that are set.
+type Name struct {
+	Prefix        string         // NPFX: Dr., Mr., ...
+	Given         string         // GIVN: first and middle names
+	Nickname      string         // NICK
+	SurnamePrefix string         // SPFX: van, de, ...
+	Surname       string         // SURN
+	Suffix        string         // NSFX: Jr., III, ...
+	Type          types.NameType // TYPE; omitted when empty or unknown
+}
+
+// Value returns the NAME line value, e.g. "Dr. John /van Doe/ Jr.".
+func (n Name) Value() string {
+	var parts []string
+	for _, part := range []string{n.Prefix, n.Given} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if n.Surname != "" || n.SurnamePrefix != "" {
+		parts = append(parts, "/"+strings.TrimSpace(n.SurnamePrefix+" "+n.Surname)+"/")
+	}
+	if n.Suffix != "" {
+		parts = append(parts, n.Suffix)
+	}
+	return strings.Join(parts, " ")
+}
+
+// enum returns an enumeration value in the case of the tree's GEDCOM version:
+// lower case for 5.5.1, upper case for 7.0.
+func (b *Builder) enum(value string) string {
+	if b.tree.IsGedcom7() {
+		return strings.ToUpper(value)
+	}
+	return strings.ToLower(value)
+}
+
+// nameLine returns the NAME line for n at level 1.
+func (b *Builder) nameLine(n Name) (*types.GedcomLine, error) {
+	if strings.TrimSpace(n.Given) == "" && strings.TrimSpace(n.Surname) == "" {
+		return nil, fmt.Errorf("name has no given name or surname")
+	}
+
+	line := types.NewGedcomLine(1, "NAME", n.Value(), "")
+	if n.Type != "" && n.Type != types.NameTypeUnknown {
+		line.AddChild(types.NewGedcomLine(2, "TYPE", b.enum(string(n.Type)), ""))
+	}
+	for _, part := range []struct{ tag, value string }{
+		{"NPFX", n.Prefix},
+		{"GIVN", n.Given},
+		{"NICK", n.Nickname},
+		{"SPFX", n.SurnamePrefix},
+		{"SURN", n.Surname},
+		{"NSFX", n.Suffix},
+	} {
+		if part.value != "" {
+			line.AddChild(types.NewGedcomLine(2, part.tag, part.value, ""))
+		}
+	}
+	return line, nil
+}
+
+// Event is an event or attribute of an individual or family.
+type Event struct {
+	Type       types.EventType // BIRT, MARR, OCCU, ... or EVEN for a custom event
+	CustomType string          // TYPE: required for EVEN, a descriptor otherwise
+	Value      string          // Line value, e.g. the occupation of OCCU
+	Date       string          // DATE, in GEDCOM date syntax
+	Place      string          // PLAC, jurisdictions separated by commas
+	Citations  []Citation      // SOUR citations for the event
+}
+
+// familyEvents are the event types of families. The types marked false are
+// shared with individuals; all other types are individual events.
+var familyEvents = map[types.EventType]bool{
+	types.EventTypeMarriage:           true,
+	types.EventTypeDivorce:            true,
+	types.EventTypeAnnulment:          true,
+	types.EventTypeMarriageBann:       true,
+	types.EventTypeMarriageContract:   true,
+	types.EventTypeMarriageLicense:    true,
+	types.EventTypeMarriageSettlement: true,
+	types.EventTypeEngagement:         true,
+	"DIVF":                            true,
+	types.EventTypeCustom:             false,
+	types.EventTypeCensus:             false,
+	types.EventTypeResidence:          false,
+}
+
+// eventLine returns the line for e at level 1. forFamily selects which event
+// types are allowed.
+func (b *Builder) eventLine(e Event, forFamily bool) (*types.GedcomLine, error) {
+	familyOnly, isFamilyEvent := familyEvents[e.Type]
+	switch {
+	case e.Type == "":
+		return nil, fmt.Errorf("event has no type")
+	case !e.Type.IsValid() && !isFamilyEvent:
+		return nil, fmt.Errorf("unknown event type %s", e.Type)
+	case e.Type == types.EventTypeCustom && e.CustomType == "":
+		return nil, fmt.Errorf("EVEN event needs a custom type")
+	case forFamily && !isFamilyEvent:
+		return nil, fmt.Errorf("%s is not a family event", e.Type)
+	case !forFamily && familyOnly:
+		return nil, fmt.Errorf("%s is not an individual event", e.Type)
+	}
+	if e.Date != "" {
+		if _, err := types.ParseDate(e.Date); err != nil {
+			return nil, fmt.Errorf("%s date: %w", e.Type, err)
+		}
+	}
+
+	line := types.NewGedcomLine(1, string(e.Type), e.Value, "")
+	if e.CustomType != "" {
+		line.AddChild(types.NewGedcomLine(2, "TYPE", e.CustomType, ""))
+	}
+	if e.Date != "" {
+		line.AddChild(types.NewGedcomLine(2, "DATE", e.Date, ""))
+	}
+	if e.Place != "" {
+		line.AddChild(types.NewGedcomLine(2, "PLAC", e.Place, ""))
+	}
+	for _, citation := range e.Citations {
+		sour, err := b.citationLine(citation, 2)
+		if err != nil {
+			return nil, fmt.Errorf("%s citation: %w", e.Type, err)
+		}
+		line.AddChild(sour)
+	}
+	return line, nil
+}
+
+// Citation cites a source record, optionally with the page or other place in
+// the source where the information was found.
+type Citation struct {
+	Source *types.SourceRecord // Cited source; must be in the tree
+	Page   string              // PAGE
+}
+
+// citationLine returns the SOUR pointer line for c at level.
+func (b *Builder) citationLine(c Citation, level int) (*types.GedcomLine, error) {
+	if c.Source == nil {
+		return nil, fmt.Errorf("citation has no source")
+	}
+	if err := b.checkInTree(c.Source, "source"); err != nil {
+		return nil, err
+	}
+	line := types.NewGedcomLine(level, "SOUR", c.Source.XrefID(), "")
+	if c.Page != "" {
+		line.AddChild(types.NewGedcomLine(level+1, "PAGE", c.Page, ""))
+	}
+	return line, nil
+}
+
+// noteLine returns the pointer line for note at level 1: NOTE for a NOTE
+// record, SNOTE for a GEDCOM 7.0 shared note.
+func (b *Builder) noteLine(note types.Record) (*types.GedcomLine, error) {
+	var tag string
+	switch n := note.(type) {
+	case nil:
+		return nil, fmt.Errorf("note is nil")
+	case *types.NoteRecord:
+		if n == nil {
+			return nil, fmt.Errorf("note is nil")
+		}
+		tag = "NOTE"
+	case *types.SharedNoteRecord:
+		if n == nil {
+			return nil, fmt.Errorf("note is nil")
+		}
+		tag = "SNOTE"
+	default:
+		return nil, fmt.Errorf("%s is not a note", note.XrefID())
+	}
+	if err := b.checkInTree(note, "note"); err != nil {
+		return nil, err
+	}
+	return types.NewGedcomLine(1, tag, note.XrefID(), ""), nil
+}
@@ -0,0 +1,268 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/exporter"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+func TestName_Value(t *testing.T) {
+	tests := []struct {
+		name Name
+		want string
+	}{
+		{Name{Given: "John", Surname: "Doe"}, "John /Doe/"},
+		{Name{Prefix: "Dr.", Given: "Jan", SurnamePrefix: "van", Surname: "Berg", Suffix: "Jr."}, "Dr. Jan /van Berg/ Jr."},
+		{Name{Given: "Cher"}, "Cher"},
+		{Name{Surname: "Doe"}, "/Doe/"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.name.Value(); got != tt.want {
+			t.Errorf("Value() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestBuilder_Individual(t *testing.T) {
+	b := New(nil)
+	census := b.Source().Title("1900 Census").Author("US Census Bureau").MustBuild()
+	note := b.Note("Emigrated from Ireland.\nSettled in Boston.")
+
+	indi, err := b.Individual().
+		Name(Name{Prefix: "Dr.", Given: "John", Surname: "Doe", Type: types.NameTypeBirth}).
+		Sex("M").
+		Event(Event{
+			Type:      types.EventTypeBirth,
+			Date:      "12 MAR 1872",
+			Place:     "Boston, Suffolk, Massachusetts, USA",
+			Citations: []Citation{{Source: census, Page: "Sheet 4A"}},
+		}).
+		Event(Event{Type: types.EventTypeOccupation, Value: "Physician"}).
+		Event(Event{Type: types.EventTypeCustom, CustomType: "Military Service", Date: "1898"}).
+		Cite(Citation{Source: census}).
+		Note(note).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if indi.XrefID() != "@I1@" || b.Tree().GetIndividual("@I1@") != indi {
+		t.Errorf("xref = %s, want @I1@ in the tree", indi.XrefID())
+	}
+	name, err := indi.GetPrimaryName()
+	if err != nil || name.Given != "John" || name.Surname != "Doe" || name.Prefix != "Dr." || name.Type != types.NameTypeBirth {
+		t.Errorf("GetPrimaryName() = %+v, %v", name, err)
+	}
+	if got := indi.GetSex(); got != "M" {
+		t.Errorf("GetSex() = %q", got)
+	}
+	birth := indi.Birth()
+	if birth == nil || !birth.HasDate() || birth.Place == nil || len(birth.Sources) != 1 || birth.Sources[0] != census.XrefID() {
+		t.Errorf("Birth() = %+v", birth)
+	}
+	if got := indi.GetValue("BIRT.SOUR.PAGE"); got != "Sheet 4A" {
+		t.Errorf("citation page = %q", got)
+	}
+	if got := indi.GetOccupation(); got != "Physician" {
+		t.Errorf("GetOccupation() = %q", got)
+	}
+	if events := indi.CustomEventsByType("Military Service"); len(events) != 1 {
+		t.Errorf("CustomEventsByType() = %v", events)
+	}
+	if got := indi.GetValues("SOUR"); len(got) != 1 || got[0] != "@S1@" {
+		t.Errorf("SOUR = %v", got)
+	}
+	if got := indi.GetValue("NOTE"); got != "@N1@" {
+		t.Errorf("NOTE = %q", got)
+	}
+
+	var tags []string
+	for _, line := range indi.FirstLine().ChildLines() {
+		tags = append(tags, line.Tag)
+	}
+	if got := strings.Join(tags, " "); got != "NAME SEX BIRT OCCU EVEN SOUR NOTE" {
+		t.Errorf("line order = %s", got)
+	}
+}
+
+func TestBuilder_Family(t *testing.T) {
+	b := New(nil)
+	john := b.Individual().Name(Name{Given: "John", Surname: "Doe"}).Sex("M").MustBuild()
+	jane := b.Individual().Name(Name{Given: "Jane", Surname: "Roe"}).Sex("F").MustBuild()
+	jim := b.Individual().Name(Name{Given: "Jim", Surname: "Doe"}).MustBuild()
+	ann := b.Individual().Name(Name{Given: "Ann", Surname: "Doe"}).MustBuild()
+
+	fam, err := b.Family().
+		Husband(john).
+		Wife(jane).
+		Child(jim).
+		ChildWithPedigree(ann, types.PedigreeAdopted).
+		Event(Event{Type: types.EventTypeMarriage, Date: "ABT 1895", Place: "Boston"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if fam.XrefID() != "@F1@" || fam.GetHusband() != "@I1@" || fam.GetWife() != "@I2@" {
+		t.Errorf("family = %s HUSB %s WIFE %s", fam.XrefID(), fam.GetHusband(), fam.GetWife())
+	}
+	if got := fam.GetChildren(); len(got) != 2 || got[0] != "@I3@" || got[1] != "@I4@" {
+		t.Errorf("GetChildren() = %v", got)
+	}
+	if got := fam.GetMarriageDate(); got != "ABT 1895" {
+		t.Errorf("GetMarriageDate() = %q", got)
+	}
+
+	for _, spouse := range []*types.IndividualRecord{john, jane} {
+		if got := spouse.GetFamiliesAsSpouse(); len(got) != 1 || got[0] != "@F1@" {
+			t.Errorf("%s FAMS = %v", spouse.XrefID(), got)
+		}
+	}
+	if got := jim.GetFamiliesAsChild(); len(got) != 1 || got[0] != "@F1@" {
+		t.Errorf("FAMC = %v", got)
+	}
+	if jim.GetPedigree("@F1@") != types.PedigreeUnknown || ann.GetPedigree("@F1@") != types.PedigreeAdopted {
+		t.Error("pedigree not written")
+	}
+
+	children, err := fam.GetChildrenRecords()
+	if err != nil || len(children) != 2 || children[0] != jim {
+		t.Errorf("GetChildrenRecords() = %v, %v", children, err)
+	}
+}
+
+func TestBuilder_NextXref(t *testing.T) {
+	tree := types.NewGedcomTree()
+	tree.AddRecord(types.CreateTestIndividual("@I1@", "Existing /Person/"))
+	tree.AddRecord(types.CreateTestIndividual("@I2@", "Other /Person/"))
+
+	b := New(tree)
+	indi := b.Individual().Name(Name{Given: "New"}).MustBuild()
+	if indi.XrefID() != "@I3@" {
+		t.Errorf("xref = %s, want @I3@", indi.XrefID())
+	}
+	if got := b.NextXref("X"); got != "@X1@" {
+		t.Errorf("NextXref(X) = %s", got)
+	}
+
+	explicit := b.Individual().Xref("@P100@").Name(Name{Given: "Named"}).MustBuild()
+	if tree.GetIndividual("@P100@") != explicit {
+		t.Error("explicit xref not used")
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	b := New(nil)
+	john := b.Individual().Name(Name{Given: "John"}).MustBuild()
+	outside := types.CreateTestIndividual("@I99@", "Not /InTree/")
+	otherSource := New(nil).Source().Title("Elsewhere").MustBuild()
+
+	tests := []struct {
+		name  string
+		build func() error
+	}{
+		{"empty name", func() error { _, err := b.Individual().Name(Name{Prefix: "Dr."}).Build(); return err }},
+		{"invalid sex", func() error { _, err := b.Individual().Sex("male").Build(); return err }},
+		{"family event on individual", func() error {
+			_, err := b.Individual().Event(Event{Type: types.EventTypeMarriage}).Build()
+			return err
+		}},
+		{"individual event on family", func() error {
+			_, err := b.Family().Event(Event{Type: types.EventTypeBirth}).Build()
+			return err
+		}},
+		{"EVEN without type", func() error {
+			_, err := b.Individual().Event(Event{Type: types.EventTypeCustom}).Build()
+			return err
+		}},
+		{"unknown event type", func() error {
+			_, err := b.Individual().Event(Event{Type: "XYZZ"}).Build()
+			return err
+		}},
+		{"invalid date", func() error {
+			_, err := b.Individual().Event(Event{Type: types.EventTypeBirth, Date: "sometime"}).Build()
+			return err
+		}},
+		{"source from another tree", func() error {
+			_, err := b.Individual().Cite(Citation{Source: otherSource}).Build()
+			return err
+		}},
+		{"xref in use", func() error { _, err := b.Individual().Xref(john.XrefID()).Build(); return err }},
+		{"malformed xref", func() error { _, err := b.Individual().Xref("I5").Build(); return err }},
+		{"member not in tree", func() error { _, err := b.Family().Husband(outside).Build(); return err }},
+		{"spouse is child", func() error { _, err := b.Family().Husband(john).Child(john).Build(); return err }},
+		{"child twice", func() error { _, err := b.Family().Child(john).Child(john).Build(); return err }},
+		{"empty title", func() error { _, err := b.Source().Title(" ").Build(); return err }},
+	}
+
+	before := len(b.Tree().GetAllRecords())
+	for _, tt := range tests {
+		if err := tt.build(); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+	if after := len(b.Tree().GetAllRecords()); after != before {
+		t.Errorf("failed builds added %d records", after-before)
+	}
+	if got := john.GetFamiliesAsSpouse(); len(got) != 0 {
+		t.Errorf("failed family build linked %v", got)
+	}
+}
+
+func TestBuilder_ExportRoundTrip(t *testing.T) {
+	b := New(nil)
+	src := b.Source().Title("Parish Register").MustBuild()
+	note := b.Note("Emigrated from Ireland.\nSettled in Boston.")
+	john := b.Individual().
+		Name(Name{Given: "John", Surname: "Doe"}).
+		Event(Event{Type: types.EventTypeBirth, Date: "1 JAN 1900", Citations: []Citation{{Source: src, Page: "f. 3"}}}).
+		Note(note).
+		MustBuild()
+	jim := b.Individual().Name(Name{Given: "Jim", Surname: "Doe"}).MustBuild()
+	b.Family().Husband(john).Child(jim).MustBuild()
+
+	ged, err := exporter.NewGedcomExporter(types.NewErrorManager(), "Test", "1.0").ExportToString(b.Tree())
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(ged))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	parsed, ok := tree.GetIndividual("@I2@").(*types.IndividualRecord)
+	if !ok || parsed.GetName() != "Jim /Doe/" || parsed.GetFamiliesAsChild()[0] != "@F1@" {
+		t.Errorf("round trip lost the child:\n%s", ged)
+	}
+	if got := tree.GetIndividual("@I1@").GetValue("BIRT.SOUR.PAGE"); got != "f. 3" {
+		t.Errorf("round trip lost the citation:\n%s", ged)
+	}
+	if !strings.Contains(ged, "0 @N1@ NOTE Emigrated from Ireland.\n1 CONT Settled in Boston.\n") {
+		t.Errorf("note not written with a CONT line:\n%s", ged)
+	}
+	if got := tree.GetRecordByXref("@N1@"); got == nil || got.GetValue("") != "Emigrated from Ireland.\nSettled in Boston." {
+		t.Errorf("round trip lost the note text:\n%s", ged)
+	}
+}
+
+func TestBuilder_Gedcom7Note(t *testing.T) {
+	tree := types.NewGedcomTree()
+	tree.SetVersion("7.0")
+	b := New(tree)
+
+	note := b.Note("Emigrated from Ireland.")
+	if _, ok := note.(*types.SharedNoteRecord); !ok || note.Type() != types.RecordTypeSNOTE {
+		t.Fatalf("Note() in a GEDCOM 7.0 tree = %T, want *types.SharedNoteRecord", note)
+	}
+	indi := b.Individual().Name(Name{Given: "John"}).Note(note).MustBuild()
+	if got := indi.GetValue("SNOTE"); got != note.XrefID() {
+		t.Errorf("SNOTE = %q, want %s", got, note.XrefID())
+	}
+	if got := indi.GetValue("NOTE"); got != "" {
+		t.Errorf("NOTE = %q, want none in GEDCOM 7.0", got)
+	}
+}
@@ -0,0 +1,40 @@
+// Package builder creates GEDCOM records in code without assembling
+// GedcomLine hierarchies by hand.
+//
+// A Builder adds the records it creates to a GedcomTree and allocates their
+// xrefs (@I1@, @F1@, @S1@, @N1@, ...), skipping any already used in the tree.
+// Each record builder is fluent: its methods return the builder, the first
+// error is kept and Build reports it without changing the tree.
+//
+// Basic Usage:
+//
+//	b := builder.New(nil)
+//	census := b.Source().Title("1900 United States Federal Census").MustBuild()
+//
+//	john := b.Individual().
+//		Name(builder.Name{Given: "John", Surname: "Doe"}).
+//		Sex("M").
+//		Event(builder.Event{
+//			Type:      types.EventTypeBirth,
+//			Date:      "12 MAR 1872",
+//			Place:     "Boston, Suffolk, Massachusetts, USA",
+//			Citations: []builder.Citation{{Source: census, Page: "Sheet 4A"}},
+//		}).
+//		MustBuild()
+//	jane := b.Individual().Name(builder.Name{Given: "Jane", Surname: "Roe"}).Sex("F").MustBuild()
+//	jim := b.Individual().Name(builder.Name{Given: "Jim", Surname: "Doe"}).MustBuild()
+//
+//	b.Family().
+//		Husband(john).
+//		Wife(jane).
+//		Child(jim).
+//		Event(builder.Event{Type: types.EventTypeMarriage, Date: "1895"}).
+//		MustBuild()
+//
+//	tree := b.Tree()
+//
+// Family links are written in both directions: HUSB, WIFE and CHIL on the
+// family, FAMS and FAMC on the individuals.
+//
+// A Builder is not safe for concurrent use.
+package builder
@@ -0,0 +1,174 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// FamilyBuilder builds a FAM record and links its members to it.
+type FamilyBuilder struct {
+	b        *Builder
+	xref     string
+	husband  *types.IndividualRecord
+	wife     *types.IndividualRecord
+	children []familyChild
+	lines    []*types.GedcomLine
+	err      error
+}
+
+// familyChild is a child of the family with the pedigree of its link.
+type familyChild struct {
+	record   *types.IndividualRecord
+	pedigree types.PedigreeType
+}
+
+// Family starts a new family record.
+func (b *Builder) Family() *FamilyBuilder {
+	return &FamilyBuilder{b: b}
+}
+
+// Xref sets the xref of the record instead of allocating one.
+func (fb *FamilyBuilder) Xref(xref string) *FamilyBuilder {
+	fb.xref = xref
+	return fb
+}
+
+// Husband sets the husband. The individual gets a FAMS link to the family.
+func (fb *FamilyBuilder) Husband(indi *types.IndividualRecord) *FamilyBuilder {
+	if err := fb.checkMember(indi, "husband"); err != nil {
+		return fb.fail(err)
+	}
+	fb.husband = indi
+	return fb
+}
+
+// Wife sets the wife. The individual gets a FAMS link to the family.
+func (fb *FamilyBuilder) Wife(indi *types.IndividualRecord) *FamilyBuilder {
+	if err := fb.checkMember(indi, "wife"); err != nil {
+		return fb.fail(err)
+	}
+	fb.wife = indi
+	return fb
+}
+
+// Child adds a child. The individual gets a FAMC link to the family.
+func (fb *FamilyBuilder) Child(indi *types.IndividualRecord) *FamilyBuilder {
+	return fb.ChildWithPedigree(indi, types.PedigreeUnknown)
+}
+
+// ChildWithPedigree adds a child whose FAMC link carries a PEDI line, e.g.
+// types.PedigreeAdopted. PedigreeUnknown writes no PEDI.
+func (fb *FamilyBuilder) ChildWithPedigree(indi *types.IndividualRecord, pedigree types.PedigreeType) *FamilyBuilder {
+	if err := fb.checkMember(indi, "child"); err != nil {
+		return fb.fail(err)
+	}
+	for _, child := range fb.children {
+		if child.record == indi {
+			return fb.fail(fmt.Errorf("child %s added twice", indi.XrefID()))
+		}
+	}
+	fb.children = append(fb.children, familyChild{record: indi, pedigree: pedigree})
+	return fb
+}
+
+// Event adds a family event, such as a marriage.
+func (fb *FamilyBuilder) Event(event Event) *FamilyBuilder {
+	line, err := fb.b.eventLine(event, true)
+	if err != nil {
+		return fb.fail(err)
+	}
+	fb.lines = append(fb.lines, line)
+	return fb
+}
+
+// Cite adds a source citation for the family as a whole.
+func (fb *FamilyBuilder) Cite(citation Citation) *FamilyBuilder {
+	line, err := fb.b.citationLine(citation, 1)
+	if err != nil {
+		return fb.fail(err)
+	}
+	fb.lines = append(fb.lines, line)
+	return fb
+}
+
+// Note attaches a note record made by Builder.Note.
+func (fb *FamilyBuilder) Note(note types.Record) *FamilyBuilder {
+	line, err := fb.b.noteLine(note)
+	if err != nil {
+		return fb.fail(err)
+	}
+	fb.lines = append(fb.lines, line)
+	return fb
+}
+
+// checkMember returns an error unless indi can be linked to the family.
+func (fb *FamilyBuilder) checkMember(indi *types.IndividualRecord, role string) error {
+	if indi == nil {
+		return fmt.Errorf("%s is nil", role)
+	}
+	return fb.b.checkInTree(indi, role)
+}
+
+// fail keeps err if it is the first error.
+func (fb *FamilyBuilder) fail(err error) *FamilyBuilder {
+	if fb.err == nil {
+		fb.err = err
+	}
+	return fb
+}
+
+// Build creates the record, adds it to the tree and adds the FAMS and FAMC
+// links to its members. HUSB, WIFE and CHIL come first in the record,
+// followed by the other lines in the order they were added.
+func (fb *FamilyBuilder) Build() (*types.FamilyRecord, error) {
+	if fb.err != nil {
+		return nil, fmt.Errorf("family: %w", fb.err)
+	}
+	if fb.husband != nil && fb.husband == fb.wife {
+		return nil, fmt.Errorf("family: %s is both husband and wife", fb.husband.XrefID())
+	}
+	for _, child := range fb.children {
+		if child.record == fb.husband || child.record == fb.wife {
+			return nil, fmt.Errorf("family: %s is both spouse and child", child.record.XrefID())
+		}
+	}
+	xref, err := fb.b.allocateXref(fb.xref, FamilyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("family: %w", err)
+	}
+
+	line := types.NewGedcomLine(0, "FAM", "", xref)
+	if fb.husband != nil {
+		line.AddChild(types.NewGedcomLine(1, "HUSB", fb.husband.XrefID(), ""))
+		fb.husband.FirstLine().AddChild(types.NewGedcomLine(1, "FAMS", xref, ""))
+	}
+	if fb.wife != nil {
+		line.AddChild(types.NewGedcomLine(1, "WIFE", fb.wife.XrefID(), ""))
+		fb.wife.FirstLine().AddChild(types.NewGedcomLine(1, "FAMS", xref, ""))
+	}
+	for _, child := range fb.children {
+		line.AddChild(types.NewGedcomLine(1, "CHIL", child.record.XrefID(), ""))
+		famc := types.NewGedcomLine(1, "FAMC", xref, "")
+		if child.pedigree != types.PedigreeUnknown {
+			famc.AddChild(types.NewGedcomLine(2, "PEDI", fb.b.enum(string(child.pedigree)), ""))
+		}
+		child.record.FirstLine().AddChild(famc)
+	}
+	for _, child := range fb.lines {
+		line.AddChild(child)
+	}
+
+	record := types.NewFamilyRecord(line)
+	fb.b.tree.AddRecord(record)
+	return record, nil
+}
+
+// MustBuild is like Build but panics on error. It is meant for fixtures.
+func (fb *FamilyBuilder) MustBuild() *types.FamilyRecord {
+	record, err := fb.Build()
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
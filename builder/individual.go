@@ -0,0 +1,114 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// IndividualBuilder builds an INDI record. Lines are written in the order
+// the methods are called.
+type IndividualBuilder struct {
+	b     *Builder
+	xref  string
+	lines []*types.GedcomLine
+	err   error
+}
+
+// Individual starts a new individual record.
+func (b *Builder) Individual() *IndividualBuilder {
+	return &IndividualBuilder{b: b}
+}
+
+// Xref sets the xref of the record instead of allocating one.
+func (ib *IndividualBuilder) Xref(xref string) *IndividualBuilder {
+	ib.xref = xref
+	return ib
+}
+
+// Name adds a personal name. The first name added is the primary name.
+func (ib *IndividualBuilder) Name(name Name) *IndividualBuilder {
+	line, err := ib.b.nameLine(name)
+	if err != nil {
+		return ib.fail(err)
+	}
+	ib.lines = append(ib.lines, line)
+	return ib
+}
+
+// Sex sets the sex: M, F, U or X.
+func (ib *IndividualBuilder) Sex(sex string) *IndividualBuilder {
+	if sex != "M" && sex != "F" && sex != "U" && sex != "X" {
+		return ib.fail(fmt.Errorf("invalid sex %q", sex))
+	}
+	ib.lines = append(ib.lines, types.NewGedcomLine(1, "SEX", sex, ""))
+	return ib
+}
+
+// Event adds an individual event or attribute, such as a birth or an
+// occupation.
+func (ib *IndividualBuilder) Event(event Event) *IndividualBuilder {
+	line, err := ib.b.eventLine(event, false)
+	if err != nil {
+		return ib.fail(err)
+	}
+	ib.lines = append(ib.lines, line)
+	return ib
+}
+
+// Cite adds a source citation for the individual as a whole.
+func (ib *IndividualBuilder) Cite(citation Citation) *IndividualBuilder {
+	line, err := ib.b.citationLine(citation, 1)
+	if err != nil {
+		return ib.fail(err)
+	}
+	ib.lines = append(ib.lines, line)
+	return ib
+}
+
+// Note attaches a note record made by Builder.Note.
+func (ib *IndividualBuilder) Note(note types.Record) *IndividualBuilder {
+	line, err := ib.b.noteLine(note)
+	if err != nil {
+		return ib.fail(err)
+	}
+	ib.lines = append(ib.lines, line)
+	return ib
+}
+
+// fail keeps err if it is the first error.
+func (ib *IndividualBuilder) fail(err error) *IndividualBuilder {
+	if ib.err == nil {
+		ib.err = err
+	}
+	return ib
+}
+
+// Build creates the record and adds it to the tree. Family links are added
+// with FamilyBuilder.
+func (ib *IndividualBuilder) Build() (*types.IndividualRecord, error) {
+	if ib.err != nil {
+		return nil, fmt.Errorf("individual: %w", ib.err)
+	}
+	xref, err := ib.b.allocateXref(ib.xref, IndividualPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("individual: %w", err)
+	}
+
+	line := types.NewGedcomLine(0, "INDI", "", xref)
+	for _, child := range ib.lines {
+		line.AddChild(child)
+	}
+	record := types.NewIndividualRecord(line)
+	ib.b.tree.AddRecord(record)
+	return record, nil
+}
+
+// MustBuild is like Build but panics on error. It is meant for fixtures.
+func (ib *IndividualBuilder) MustBuild() *types.IndividualRecord {
+	record, err := ib.Build()
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
@@ -0,0 +1,129 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// SourceBuilder builds a SOUR record.
+type SourceBuilder struct {
+	b     *Builder
+	xref  string
+	lines []*types.GedcomLine
+	err   error
+}
+
+// Source starts a new source record.
+func (b *Builder) Source() *SourceBuilder {
+	return &SourceBuilder{b: b}
+}
+
+// Xref sets the xref of the record instead of allocating one.
+func (sb *SourceBuilder) Xref(xref string) *SourceBuilder {
+	sb.xref = xref
+	return sb
+}
+
+// Title sets the title (TITL).
+func (sb *SourceBuilder) Title(title string) *SourceBuilder {
+	return sb.set("TITL", title)
+}
+
+// Author sets the author or originator (AUTH).
+func (sb *SourceBuilder) Author(author string) *SourceBuilder {
+	return sb.set("AUTH", author)
+}
+
+// Publication sets the publication facts (PUBL).
+func (sb *SourceBuilder) Publication(publication string) *SourceBuilder {
+	return sb.set("PUBL", publication)
+}
+
+// Abbreviation sets the short title used for sorting and display (ABBR).
+func (sb *SourceBuilder) Abbreviation(abbreviation string) *SourceBuilder {
+	return sb.set("ABBR", abbreviation)
+}
+
+// Text sets a transcription of the source (TEXT).
+func (sb *SourceBuilder) Text(text string) *SourceBuilder {
+	return sb.set("TEXT", text)
+}
+
+// Repository links the repository holding the source (REPO), optionally with
+// the call number of the source there.
+func (sb *SourceBuilder) Repository(repo *types.RepositoryRecord, callNumber string) *SourceBuilder {
+	if repo == nil {
+		return sb.fail(fmt.Errorf("repository is nil"))
+	}
+	if err := sb.b.checkInTree(repo, "repository"); err != nil {
+		return sb.fail(err)
+	}
+	line := types.NewGedcomLine(1, "REPO", repo.XrefID(), "")
+	if callNumber != "" {
+		line.AddChild(types.NewGedcomLine(2, "CALN", callNumber, ""))
+	}
+	sb.lines = append(sb.lines, line)
+	return sb
+}
+
+// Note attaches a note record made by Builder.Note.
+func (sb *SourceBuilder) Note(note types.Record) *SourceBuilder {
+	line, err := sb.b.noteLine(note)
+	if err != nil {
+		return sb.fail(err)
+	}
+	sb.lines = append(sb.lines, line)
+	return sb
+}
+
+// set adds a line with tag and value. Each tag may be set once.
+func (sb *SourceBuilder) set(tag, value string) *SourceBuilder {
+	if strings.TrimSpace(value) == "" {
+		return sb.fail(fmt.Errorf("%s is empty", tag))
+	}
+	for _, line := range sb.lines {
+		if line.Tag == tag {
+			return sb.fail(fmt.Errorf("%s set twice", tag))
+		}
+	}
+	sb.lines = append(sb.lines, types.NewGedcomLine(1, tag, value, ""))
+	return sb
+}
+
+// fail keeps err if it is the first error.
+func (sb *SourceBuilder) fail(err error) *SourceBuilder {
+	if sb.err == nil {
+		sb.err = err
+	}
+	return sb
+}
+
+// Build creates the record and adds it to the tree.
+func (sb *SourceBuilder) Build() (*types.SourceRecord, error) {
+	if sb.err != nil {
+		return nil, fmt.Errorf("source: %w", sb.err)
+	}
+	xref, err := sb.b.allocateXref(sb.xref, SourcePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+
+	line := types.NewGedcomLine(0, "SOUR", "", xref)
+	for _, child := range sb.lines {
+		line.AddChild(child)
+	}
+	record := types.NewSourceRecord(line)
+	sb.b.tree.AddRecord(record)
+	return record, nil
+}
+
+// MustBuild is like Build but panics on error. It is meant for fixtures.
+func (sb *SourceBuilder) MustBuild() *types.SourceRecord {
+	record, err := sb.Build()
+	if err != nil {
+		panic(err)
+	}
+	return record
+}
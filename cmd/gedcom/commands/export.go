@@ -7,6 +7,7 @@ import (
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/cmd/gedcom/internal"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/exporter"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/query"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 	"github.com/spf13/cobra"
 )
@@ -14,7 +15,7 @@ import (
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export GEDCOM to different formats",
-	Long:  "Export GEDCOM data to JSON, XML, YAML, or GEDCOM format",
+	Long:  "Export GEDCOM data to JSON, XML, YAML, GEDCOM, or GEDZIP format",
 }
 
 var exportJsonCmd = &cobra.Command{
@@ -49,6 +50,14 @@ var exportGedcomCmd = &cobra.Command{
 	RunE:  runExportGEDCOM,
 }
 
+var exportGedzipCmd = &cobra.Command{
+	Use:   "gedzip [input.ged]",
+	Short: "Export to GEDZIP",
+	Long:  "Export a GEDCOM file and its media files to a GEDZIP (.gdz) archive",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExportGEDZIP,
+}
+
 var exportCsvCmd = &cobra.Command{
 	Use:   "csv [input.ged]",
 	Short: "Export to CSV",
@@ -59,18 +68,27 @@ var exportCsvCmd = &cobra.Command{
 
 func init() {
 	// Add flags to all export commands
-	for _, cmd := range []*cobra.Command{exportJsonCmd, exportXmlCmd, exportYamlCmd, exportGedcomCmd, exportCsvCmd} {
+	for _, cmd := range []*cobra.Command{exportJsonCmd, exportXmlCmd, exportYamlCmd, exportGedcomCmd, exportGedzipCmd, exportCsvCmd} {
 		cmd.Flags().StringP("output", "o", "", "Output file (required)")
 		cmd.MarkFlagRequired("output")
 		cmd.Flags().Bool("pretty", true, "Pretty-print output")
 		cmd.Flags().Int("indent", 2, "Indentation level")
 	}
 
+	exportGedcomCmd.Flags().Bool("lossless", false, "Reproduce unmodified records byte-for-byte")
+	for _, cmd := range []*cobra.Command{exportGedcomCmd, exportGedzipCmd} {
+		cmd.Flags().Bool("write-uids", false, "Write record UUIDs as UID (GEDCOM 7.0) or _UID lines where records have no identifier")
+	}
+	for _, cmd := range []*cobra.Command{exportJsonCmd, exportCsvCmd} {
+		cmd.Flags().Bool("estimate-dates", false, "Add estimated birth dates for individuals without one")
+	}
+
 	// Add subcommands
 	exportCmd.AddCommand(exportJsonCmd)
 	exportCmd.AddCommand(exportXmlCmd)
 	exportCmd.AddCommand(exportYamlCmd)
 	exportCmd.AddCommand(exportGedcomCmd)
+	exportCmd.AddCommand(exportGedzipCmd)
 	exportCmd.AddCommand(exportCsvCmd)
 }
 
@@ -90,6 +108,10 @@ func runExportGEDCOM(cmd *cobra.Command, args []string) error {
 	return runExport(cmd, args, "gedcom")
 }
 
+func runExportGEDZIP(cmd *cobra.Command, args []string) error {
+	return runExport(cmd, args, "gedzip")
+}
+
 func runExportCSV(cmd *cobra.Command, args []string) error {
 	return runExport(cmd, args, "csv")
 }
@@ -118,13 +140,29 @@ func runExport(cmd *cobra.Command, args []string, format string) error {
 	// Parse file
 	internal.PrintInfo("ℹ Parsing: %s\n", inputFile)
 
-	p := parser.NewHierarchicalParser()
+	// NewParser also accepts GEDZIP (.gdz) archives
+	p := parser.NewParser()
+	lossless, _ := cmd.Flags().GetBool("lossless")    // only defined for gedcom
+	writeUIDs, _ := cmd.Flags().GetBool("write-uids") // only defined for gedcom and gedzip
+	p.SetLossless(lossless)
 	tree, err := p.Parse(inputFile)
 	if err != nil {
 		internal.PrintError("✗ Parse failed: %v\n", err)
 		return err
 	}
 
+	// Estimate missing birth dates; only defined for json and csv
+	var estimates types.DateEstimates
+	if estimateDates, _ := cmd.Flags().GetBool("estimate-dates"); estimateDates {
+		graph, err := query.BuildGraph(tree)
+		if err != nil {
+			internal.PrintError("✗ Failed to build graph: %v\n", err)
+			return err
+		}
+		estimates = query.NewDateEstimator(graph, nil).Estimate()
+		internal.PrintInfo("ℹ Estimated %d birth dates\n", len(estimates))
+	}
+
 	// Show progress
 	var progressBar *internal.ProgressBar
 	if config.Output.Progress && !internal.IsQuietMode() {
@@ -140,6 +178,7 @@ func runExport(cmd *cobra.Command, args []string, format string) error {
 	switch format {
 	case "json":
 		exporter := exporter.NewJsonExporter(errorManager)
+		exporter.SetEstimatedDates(estimates)
 		if progressBar != nil {
 			progressBar.Set(50)
 		}
@@ -170,6 +209,8 @@ func runExport(cmd *cobra.Command, args []string, format string) error {
 
 	case "gedcom":
 		gedcomExporter := exporter.NewGedcomExporter(errorManager, "gedcom-cli", "1.0.0")
+		gedcomExporter.SetLossless(lossless)
+		gedcomExporter.SetWriteUIDs(writeUIDs)
 		if progressBar != nil {
 			progressBar.Set(50)
 		}
@@ -178,8 +219,20 @@ func runExport(cmd *cobra.Command, args []string, format string) error {
 			progressBar.Set(100)
 		}
 
+	case "gedzip":
+		gedzipExporter := exporter.NewGedzipExporter(errorManager, "gedcom-cli", "1.0.0")
+		gedzipExporter.SetWriteUIDs(writeUIDs)
+		if progressBar != nil {
+			progressBar.Set(50)
+		}
+		err = gedzipExporter.ExportToFile(tree, outputFile)
+		if progressBar != nil {
+			progressBar.Set(100)
+		}
+
 	case "csv":
 		csvExporter := exporter.NewCSVExporter(errorManager)
+		csvExporter.SetEstimatedDates(estimates)
 		if progressBar != nil {
 			progressBar.Set(50)
 		}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -51,6 +52,8 @@ func init() {
 
 	// Add flags to parse validate command
 	parseValidateCmd.Flags().Bool("strict", false, "Fail on errors")
+	parseValidateCmd.Flags().String("recovery", "lenient", "What to do with problem lines (strict/lenient/repair)")
+	parseValidateCmd.Flags().StringSlice("fail-on", nil, "Fail if any issue has one of these codes (e.g. level-jump,invalid-level)")
 
 	// Add subcommands
 	parseCmd.AddCommand(parseFileCmd)
@@ -92,13 +95,15 @@ func runParseFile(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create parser
-	// Note: HierarchicalParser automatically enables parallel processing for files >= 32KB
+	// The parallel parser splits the file at level-0 records and parses the parts on all CPUs.
 	// For streaming, use StreamingHierarchicalParser explicitly
-	p := parser.NewHierarchicalParser()
+	var p interface {
+		ParseContext(ctx context.Context, filePath string) (*types.GedcomTree, error)
+	} = parser.NewHierarchicalParser()
 	if parserType == "stream" {
 		internal.PrintInfo("  Note: Streaming parser available via StreamingHierarchicalParser, using hierarchical with auto-parallel\n")
 	} else if parserType == "parallel" {
-		internal.PrintInfo("  Note: Parallel processing is automatically enabled in HierarchicalParser for files >= 32KB\n")
+		p = parser.NewParallelHierarchicalParser()
 	}
 
 	// Show progress
@@ -116,18 +121,19 @@ func runParseFile(cmd *cobra.Command, args []string) error {
 		internal.PrintInfo("  Parser type: %s\n", parserType)
 	}
 
-	tree, err := p.Parse(inputFile)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if progressBar != nil {
+		ctx = types.WithProgress(ctx, progressBar)
+	}
+	tree, err := p.ParseContext(ctx, inputFile)
 	if err != nil {
 		internal.PrintError("✗ Parse failed: %v\n", err)
 		return err
 	}
 
-	// Update progress
-	if progressBar != nil {
-		fileInfo, _ := os.Stat(inputFile)
-		progressBar.Set(int(fileInfo.Size()))
-	}
-
 	// Get statistics
 	individuals := tree.GetAllIndividuals()
 	families := tree.GetAllFamilies()
@@ -152,6 +158,13 @@ func runParseFile(cmd *cobra.Command, args []string) error {
 func runParseValidate(cmd *cobra.Command, args []string) error {
 	inputFile := args[0]
 	strict, _ := cmd.Flags().GetBool("strict")
+	recoveryName, _ := cmd.Flags().GetString("recovery")
+	failOn, _ := cmd.Flags().GetStringSlice("fail-on")
+
+	recovery, err := parser.ParseRecoveryPolicy(recoveryName)
+	if err != nil {
+		return err
+	}
 
 	// Load config
 	config, err := internal.LoadConfig("")
@@ -172,9 +185,10 @@ func runParseValidate(cmd *cobra.Command, args []string) error {
 	internal.PrintInfo("ℹ Parsing and validating: %s\n", inputFile)
 
 	p := parser.NewHierarchicalParser()
+	p.SetRecoveryPolicy(recovery)
 	_, err = p.Parse(inputFile)
 	if err != nil {
-		if strict {
+		if strict || recovery == parser.RecoveryStrict {
 			internal.PrintError("✗ Parse failed: %v\n", err)
 			return err
 		}
@@ -190,22 +204,37 @@ func runParseValidate(cmd *cobra.Command, args []string) error {
 
 	// Report errors
 	internal.PrintWarning("⚠ Found %d validation issues\n", len(errors))
+	failCodes := make(map[types.ErrorCode]bool)
+	for _, code := range failOn {
+		failCodes[types.ErrorCode(code)] = true
+	}
+	failed := 0
 	for _, err := range errors {
+		message := formatDiagnostic(err)
 		switch err.Severity {
 		case "severe":
-			internal.PrintError("  ✗ [SEVERE] %s\n", err.Message)
+			internal.PrintError("  ✗ [SEVERE] %s\n", message)
 		case "warning":
-			internal.PrintWarning("  ⚠ [WARNING] %s\n", err.Message)
+			internal.PrintWarning("  ⚠ [WARNING] %s\n", message)
 		case "info":
-			internal.PrintInfo("  ℹ [INFO] %s\n", err.Message)
+			internal.PrintInfo("  ℹ [INFO] %s\n", message)
 		case "hint":
-			internal.PrintHint("  💡 [HINT] %s\n", err.Message)
+			internal.PrintHint("  💡 [HINT] %s\n", message)
+		}
+		if err.Suggestion != "" {
+			internal.PrintInfo("      fix: %s\n", err.Suggestion)
+		}
+		if failCodes[err.Code] {
+			failed++
 		}
 	}
 
 	if strict && len(errors) > 0 {
 		return fmt.Errorf("validation failed with %d errors", len(errors))
 	}
+	if failed > 0 {
+		return fmt.Errorf("validation failed with %d issues matching --fail-on", failed)
+	}
 
 	return nil
 }
@@ -243,6 +272,21 @@ func runParseCheck(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatDiagnostic returns an issue's message prefixed with its code and position.
+func formatDiagnostic(err *types.GedcomError) string {
+	prefix := ""
+	if err.Code != "" {
+		prefix = string(err.Code) + " "
+	}
+	switch {
+	case err.LineNumber > 0 && err.Column > 0:
+		prefix += fmt.Sprintf("%d:%d: ", err.LineNumber, err.Column)
+	case err.LineNumber > 0:
+		prefix += fmt.Sprintf("%d: ", err.LineNumber)
+	}
+	return prefix + err.Message
+}
+
 func exportTree(tree *types.GedcomTree, outputFile string, format string, config *internal.Config) error {
 	switch format {
 	case "json":
@@ -4,14 +4,20 @@ import (
 	"io"
 	"os"
 
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 	"github.com/schollz/progressbar/v3"
 )
 
 // ProgressBar wraps the progressbar library
 type ProgressBar struct {
-	bar *progressbar.ProgressBar
+	bar   *progressbar.ProgressBar
+	stage string // Stage last shown by ReportProgress
 }
 
+// ProgressBar follows library operations run with a context from
+// types.WithProgress
+var _ types.ProgressReporter = (*ProgressBar)(nil)
+
 // NewProgressBar creates a new progress bar
 func NewProgressBar(max int64, description string) *ProgressBar {
 	if !ShouldShowProgress() {
@@ -51,6 +57,27 @@ func (p *ProgressBar) Set(n int) {
 	}
 }
 
+// ReportProgress implements types.ProgressReporter. A new stage restarts the
+// bar with the stage as description; an unknown total (0) shows a spinner.
+func (p *ProgressBar) ReportProgress(stage string, done, total int64) {
+	if p.bar == nil {
+		return
+	}
+	if stage != p.stage {
+		p.stage = stage
+		p.bar.Reset()
+		p.bar.Describe(stage + "...")
+	}
+	max := total
+	if max <= 0 {
+		max = -1
+	}
+	if p.bar.GetMax64() != max {
+		p.bar.ChangeMax64(max)
+	}
+	p.bar.Set64(done)
+}
+
 // Finish completes the progress bar
 func (p *ProgressBar) Finish() {
 	if p.bar != nil {
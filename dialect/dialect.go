@@ -0,0 +1,164 @@
+package dialect
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Dialect describes the GEDCOM written by one application.
+type Dialect struct {
+	Name string
+
+	// Products are matched case-insensitively against the product name in the
+	// header (HEAD.SOUR.NAME, or HEAD.SOUR when there is no name). A dialect
+	// matches if the product name contains any of them.
+	Products []string
+
+	// Extensions lists the underscore tags the application is known to write.
+	// Tags that Normalize maps are known to every dialect and need not be listed.
+	Extensions []string
+}
+
+// mappedTags are the extensions Normalize rewrites into standard structures.
+var mappedTags = []string{"_MARNM", "_PRIM", "_FREL", "_MREL"}
+
+// IsKnown reports whether tag is an extension the dialect is known to write
+// or one that Normalize maps.
+func (d *Dialect) IsKnown(tag string) bool {
+	for _, known := range mappedTags {
+		if tag == known {
+			return true
+		}
+	}
+	for _, known := range d.Extensions {
+		if strings.EqualFold(tag, known) {
+			return true
+		}
+	}
+	return false
+}
+
+// Generic is used for files whose producing application is not recognized.
+var Generic = &Dialect{
+	Name:       "Generic",
+	Extensions: []string{"_UID"},
+}
+
+// Ancestry describes Ancestry.com tree exports.
+var Ancestry = &Dialect{
+	Name:     "Ancestry",
+	Products: []string{"Ancestry"},
+	Extensions: []string{
+		"_APID", "_TREE", "_ENCR", "_ATL", "_CRE", "_OID", "_PHOTO", "_SCBK",
+		"_TYPE", "_MTTAG", "_META", "_MSER", "_MSTAT", "_LINK", "_ORIG",
+		"_FSFTID", "_UID",
+	},
+}
+
+// FamilySearch describes FamilySearch Family Tree exports.
+var FamilySearch = &Dialect{
+	Name:       "FamilySearch",
+	Products:   []string{"FamilySearch"},
+	Extensions: []string{"_FSFTID", "_FSID", "_UID", "_CONTRIBUTOR"},
+}
+
+// RootsMagic describes RootsMagic exports.
+var RootsMagic = &Dialect{
+	Name:     "RootsMagic",
+	Products: []string{"RootsMagic"},
+	Extensions: []string{
+		"_UID", "_FSFTID", "_SDATE", "_TMPLT", "_FIELD", "_SUBQ", "_BIBL",
+		"_WEBTAG", "_NAME", "_URL", "_DNA", "_EVDEF", "_SENT", "_PLAC",
+	},
+}
+
+// Legacy describes Legacy Family Tree exports.
+var Legacy = &Dialect{
+	Name:     "Legacy",
+	Products: []string{"Legacy"},
+	Extensions: []string{
+		"_UID", "_PRIV", "_TAG", "_TAG2", "_TAG3", "_TAG4", "_TAG5", "_TAG6",
+		"_TAG7", "_TAG8", "_TAG9", "_TODO", "_STAT", "_EVENT_DEFN",
+		"_PLAC_DEFN", "_SCBK", "_DATE", "_LIST", "_VERI", "_TAG_DEFN",
+	},
+}
+
+// FTM describes Family Tree Maker exports.
+var FTM = &Dialect{
+	Name:     "Family Tree Maker",
+	Products: []string{"Family Tree Maker", "FTM", "FTW"},
+	Extensions: []string{
+		"_APID", "_MSTAT", "_FOOT", "_PHOTO", "_SCBK", "_TYPE", "_MTTAG",
+		"_META", "_FILESIZE", "_DETS", "_SDATE", "_UID", "_SEPR", "_DEST",
+	},
+}
+
+// dialects is the registry used by Detect.
+var dialects = struct {
+	sync.RWMutex
+	list []*Dialect
+}{}
+
+func init() {
+	for _, d := range []*Dialect{Ancestry, FamilySearch, RootsMagic, Legacy, FTM} {
+		Register(d)
+	}
+}
+
+// Register adds a dialect used by Detect. Ancestry, FamilySearch, RootsMagic,
+// Legacy and FTM are registered by default. When several dialects match a
+// product name, the first registered wins.
+func Register(d *Dialect) {
+	if d == nil {
+		return
+	}
+
+	dialects.Lock()
+	defer dialects.Unlock()
+
+	dialects.list = append(dialects.list, d)
+}
+
+// Dialects returns the registered dialects in registration order.
+func Dialects() []*Dialect {
+	dialects.RLock()
+	defer dialects.RUnlock()
+
+	return append([]*Dialect(nil), dialects.list...)
+}
+
+// Detect returns the dialect of the application that produced tree, from the
+// header's product name (HeaderRecord.GetSourceName). Returns Generic if the
+// tree has no header or the application is not recognized.
+func Detect(tree *types.GedcomTree) *Dialect {
+	header, ok := tree.GetHeader().(*types.HeaderRecord)
+	if !ok {
+		return Generic
+	}
+
+	product := header.GetSourceName()
+	if product == "" {
+		product = header.GetValue("SOUR")
+	}
+	return DetectProduct(product)
+}
+
+// DetectProduct returns the dialect whose products match the product name,
+// or Generic if none does.
+func DetectProduct(product string) *Dialect {
+	product = strings.ToLower(strings.TrimSpace(product))
+	if product == "" {
+		return Generic
+	}
+
+	for _, d := range Dialects() {
+		for _, name := range d.Products {
+			if strings.Contains(product, strings.ToLower(name)) {
+				return d
+			}
+		}
+	}
+	return Generic
+}
@@ -0,0 +1,227 @@
+package dialect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/exporter"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+const ftmFile = `0 HEAD
+1 SOUR FTM
+2 NAME Family Tree Maker for Windows
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME Mary /Smith/
+2 _MARNM Jones
+1 NAME Polly /Smith/
+2 _PRIM Y
+1 SEX F
+1 FAMS @F1@
+1 _XYZ custom
+0 @I2@ INDI
+1 NAME John /Jones/
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Anne /Jones/
+1 FAMC @F1@
+0 @I4@ INDI
+1 NAME Tom /Jones/
+1 FAMC @F1@
+0 @F1@ FAM
+1 HUSB @I2@
+1 WIFE @I1@
+1 _MSTAT Married
+1 CHIL @I3@
+2 _FREL Natural
+2 _MREL Natural
+1 CHIL @I4@
+2 _FREL Step
+2 _MREL Natural
+0 TRLR
+`
+
+func parseString(t *testing.T, data string) *types.GedcomTree {
+	t.Helper()
+	p := parser.NewHierarchicalParser()
+	p.SetLossless(true)
+	tree, err := p.ParseReader(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	return tree
+}
+
+func TestDetectProduct(t *testing.T) {
+	tests := []struct {
+		product string
+		want    *Dialect
+	}{
+		{"Ancestry.com Family Trees", Ancestry},
+		{"FamilySearch Family Tree", FamilySearch},
+		{"RootsMagic", RootsMagic},
+		{"Legacy Family Tree", Legacy},
+		{"Family Tree Maker for Windows", FTM},
+		{"FTW", FTM},
+		{"Gramps", Generic},
+		{"", Generic},
+	}
+
+	for _, tt := range tests {
+		if got := DetectProduct(tt.product); got != tt.want {
+			t.Errorf("DetectProduct(%q) = %s, want %s", tt.product, got.Name, tt.want.Name)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tree := parseString(t, ftmFile)
+	if got := Detect(tree); got != FTM {
+		t.Errorf("Detect() = %s, want %s", got.Name, FTM.Name)
+	}
+	if got := Detect(types.NewGedcomTree()); got != Generic {
+		t.Errorf("Detect() without header = %s, want Generic", got.Name)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	custom := &Dialect{Name: "Custom", Products: []string{"CustomApp"}}
+	Register(custom)
+	defer func() {
+		dialects.Lock()
+		dialects.list = dialects.list[:len(dialects.list)-1]
+		dialects.Unlock()
+	}()
+
+	if got := DetectProduct("CustomApp 2.0"); got != custom {
+		t.Errorf("DetectProduct() = %s, want Custom", got.Name)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tree := parseString(t, ftmFile)
+	result := Normalize(tree)
+	if result.Dialect != FTM {
+		t.Fatalf("Dialect = %s, want %s", result.Dialect.Name, FTM.Name)
+	}
+
+	mary := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	primary, err := mary.GetPrimaryName()
+	if err != nil || primary.Given != "Polly" {
+		t.Errorf("GetPrimaryName() = %+v, %v; want Polly", primary, err)
+	}
+	married, err := mary.GetMarriedName()
+	if err != nil {
+		t.Fatalf("GetMarriedName() error = %v", err)
+	}
+	if married.Given != "Mary" || married.Surname != "Jones" {
+		t.Errorf("married name = %s %s, want Mary Jones", married.Given, married.Surname)
+	}
+	if len(mary.GetLines("NAME._PRIM")) != 0 || len(mary.GetLines("NAME._MARNM")) != 0 {
+		t.Error("expected _PRIM and _MARNM to be removed")
+	}
+
+	anne := tree.GetIndividual("@I3@").(*types.IndividualRecord)
+	if got := anne.GetPedigree("@F1@"); got != types.PedigreeBirth {
+		t.Errorf("GetPedigree() = %q, want birth", got)
+	}
+
+	// Disagreeing relationships cannot be expressed with PEDI and are kept
+	tom := tree.GetIndividual("@I4@").(*types.IndividualRecord)
+	if got := tom.GetPedigree("@F1@"); got != types.PedigreeUnknown {
+		t.Errorf("GetPedigree() = %q, want unknown", got)
+	}
+
+	summary := result.Summary
+	mapped := extensionCounts(summary.Mapped)
+	wantMapped := map[string]int{
+		"INDI.NAME._MARNM": 1,
+		"INDI.NAME._PRIM":  1,
+		"FAM.CHIL._FREL":   1,
+		"FAM.CHIL._MREL":   1,
+	}
+	for path, count := range wantMapped {
+		if mapped[path] != count {
+			t.Errorf("Mapped[%s] = %d, want %d", path, mapped[path], count)
+		}
+	}
+
+	known := extensionCounts(summary.Known)
+	if known["FAM._MSTAT"] != 1 || known["FAM.CHIL._FREL"] != 1 || known["FAM.CHIL._MREL"] != 1 {
+		t.Errorf("Known = %+v", summary.Known)
+	}
+	if !summary.HasUnknown() || len(summary.Unknown) != 1 || summary.Unknown[0].Path != "INDI._XYZ" {
+		t.Errorf("Unknown = %+v, want INDI._XYZ", summary.Unknown)
+	}
+	if summary.Unknown[0].FirstLine != 14 {
+		t.Errorf("Unknown[0].FirstLine = %d, want 14", summary.Unknown[0].FirstLine)
+	}
+}
+
+func TestNormalize_LevelOneMarriedName(t *testing.T) {
+	tree := parseString(t, `0 HEAD
+1 SOUR Legacy
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME Mary /Smith/
+1 _MARNM Jones
+0 @I2@ INDI
+1 NAME John /Doe/
+1 FAMC @F1@
+2 _FREL Adopted
+0 TRLR
+`)
+	result := Normalize(tree)
+	if result.Dialect != Legacy {
+		t.Errorf("Dialect = %s, want %s", result.Dialect.Name, Legacy.Name)
+	}
+
+	mary := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	names := mary.GetLines("NAME")
+	if len(names) != 2 || names[1].Value != "Mary /Jones/" || names[1].GetValue("TYPE") != "MARRIED" {
+		t.Errorf("expected a married NAME with GEDCOM 7.0 TYPE, got %v", mary.FirstLine().ToGED())
+	}
+
+	john := tree.GetIndividual("@I2@").(*types.IndividualRecord)
+	if got := john.GetPedigree("@F1@"); got != types.PedigreeAdopted {
+		t.Errorf("GetPedigree() = %q, want adopted", got)
+	}
+	if john.GetValue("FAMC.PEDI") != "ADOPTED" {
+		t.Errorf("PEDI = %q, want ADOPTED", john.GetValue("FAMC.PEDI"))
+	}
+}
+
+func TestResult_Revert(t *testing.T) {
+	tree := parseString(t, ftmFile)
+	result := Normalize(tree)
+	result.Revert()
+
+	ge := exporter.NewGedcomExporter(nil, "test", "1.0")
+	ge.SetLossless(true)
+	output, err := ge.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	if output != ftmFile {
+		t.Errorf("export after Revert differs from the original:\n%s", output)
+	}
+
+	// A second Revert does nothing
+	result.Revert()
+	if len(tree.GetIndividual("@I1@").GetLines("NAME")) != 2 {
+		t.Error("expected second Revert to have no effect")
+	}
+}
+
+func extensionCounts(extensions []Extension) map[string]int {
+	counts := make(map[string]int)
+	for _, ext := range extensions {
+		counts[ext.Path] = ext.Count
+	}
+	return counts
+}
@@ -0,0 +1,32 @@
+// Package dialect normalizes the vendor extensions written by genealogy
+// applications into standard GEDCOM structures.
+//
+// Applications such as Ancestry, FamilySearch, RootsMagic, Legacy and Family
+// Tree Maker add their own underscore tags to exported files. The parser keeps
+// them as ordinary GedcomLine children, so the rest of the library does not
+// see what they mean. This package detects the producing application from the
+// header (HEAD.SOUR.NAME, falling back to HEAD.SOUR) and rewrites the
+// extensions that have a standard equivalent:
+//
+//   - _MARNM (married surname) becomes a NAME with TYPE married
+//   - _PRIM Y (preferred name) moves that NAME to the front, where
+//     GetPrimaryName finds it
+//   - _FREL / _MREL (relationship of a child to the father / mother) become
+//     the PEDI of the child's FAMC link
+//
+// All other underscore tags are left in place and listed in a Summary, as
+// known to the dialect or unknown.
+//
+// Basic Usage:
+//
+//	result := dialect.Normalize(tree)
+//	fmt.Println("Produced by", result.Dialect.Name)
+//	for _, ext := range result.Summary.Unknown {
+//		fmt.Printf("%s: %d\n", ext.Path, ext.Count)
+//	}
+//
+//	// Restore the vendor form, e.g. before exporting back to the same dialect
+//	result.Revert()
+//
+// Additional dialects can be added with Register.
+package dialect
@@ -0,0 +1,274 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Result describes what Normalize did to a tree and can undo it.
+type Result struct {
+	Dialect *Dialect
+	Summary *Summary
+
+	changes []change
+}
+
+// change is one line inserted into or removed from a parent line.
+type change struct {
+	parent   *types.GedcomLine
+	line     *types.GedcomLine
+	index    int // position in parent.ChildLines() when removed
+	inserted bool
+}
+
+// Normalize detects the dialect of tree and normalizes it with NormalizeAs.
+func Normalize(tree *types.GedcomTree) *Result {
+	return NormalizeAs(tree, Detect(tree))
+}
+
+// NormalizeAs rewrites the extensions of tree that have a standard GEDCOM
+// equivalent, treating the file as written by d (Generic if nil):
+//
+//   - "2 _MARNM Jones" under "1 NAME Mary /Smith/" adds "1 NAME Mary /Jones/"
+//     with TYPE married after it; a level 1 _MARNM becomes such a NAME
+//   - the NAME marked "_PRIM Y" is moved before the other names, and all
+//     _PRIM lines of names are removed
+//   - _FREL and _MREL under FAM.CHIL (or INDI.FAMC) become the PEDI of the
+//     child's FAMC link when they agree; links that already have a PEDI or
+//     whose relationship has no PEDI value are left unchanged
+//
+// The remaining extensions are listed in the result's Summary. Result.Revert
+// restores the original lines.
+func NormalizeAs(tree *types.GedcomTree, d *Dialect) *Result {
+	if d == nil {
+		d = Generic
+	}
+
+	n := &normalizer{
+		tree:    tree,
+		gedcom7: tree.IsGedcom7(),
+		result:  &Result{Dialect: d},
+		mapped:  make(map[string]*Extension),
+	}
+	for _, record := range tree.GetAllRecords() {
+		switch record.Type() {
+		case types.RecordTypeINDI:
+			n.normalizeIndividual(record.FirstLine())
+		case types.RecordTypeFAM:
+			n.normalizeFamily(record.FirstLine())
+		}
+	}
+
+	n.result.Summary = summarize(tree, d, n.mapped)
+	return n.result
+}
+
+// Revert undoes the normalization, restoring the lines as the dialect wrote
+// them. Lines are restored with their original text, so a lossless export
+// reproduces the original file. Calling Revert again has no effect.
+func (r *Result) Revert() {
+	for i := len(r.changes) - 1; i >= 0; i-- {
+		c := r.changes[i]
+		if c.inserted {
+			c.parent.RemoveChild(c.line)
+		} else {
+			c.parent.InsertChild(c.index, c.line)
+		}
+	}
+	r.changes = nil
+}
+
+// normalizer holds the state of one NormalizeAs call.
+type normalizer struct {
+	tree    *types.GedcomTree
+	gedcom7 bool
+	result  *Result
+	mapped  map[string]*Extension
+}
+
+// insert inserts line into parent at index and records the change.
+func (n *normalizer) insert(parent *types.GedcomLine, index int, line *types.GedcomLine) {
+	parent.InsertChild(index, line)
+	n.result.changes = append(n.result.changes, change{parent: parent, line: line, inserted: true})
+}
+
+// remove removes line from its parent and records the change.
+func (n *normalizer) remove(line *types.GedcomLine) {
+	parent := line.Parent
+	index := indexOf(parent.ChildLines(), line)
+	parent.RemoveChild(line)
+	n.result.changes = append(n.result.changes, change{parent: parent, line: line, index: index})
+}
+
+// count records that an extension line at path was mapped.
+func (n *normalizer) count(path string, line *types.GedcomLine) {
+	addExtension(n.mapped, path, line)
+}
+
+// enum returns an enumeration value in the case used by the tree's version:
+// upper case for GEDCOM 7.0, lower case for 5.5.1.
+func (n *normalizer) enum(value string) string {
+	if n.gedcom7 {
+		return strings.ToUpper(value)
+	}
+	return strings.ToLower(value)
+}
+
+// normalizeIndividual maps the preferred name, married name and pedigree
+// extensions of an INDI record.
+func (n *normalizer) normalizeIndividual(indi *types.GedcomLine) {
+	n.normalizePreferredName(indi)
+
+	for _, name := range indi.GetLines("NAME") {
+		index := indexOf(indi.ChildLines(), name)
+		for _, marnm := range name.GetLines("_MARNM") {
+			if marnm.Value == "" || len(marnm.ChildLines()) > 0 {
+				continue
+			}
+			index++
+			n.insert(indi, index, n.marriedName(name, marnm.Value))
+			n.remove(marnm)
+			n.count("INDI.NAME._MARNM", marnm)
+		}
+	}
+
+	for _, marnm := range indi.GetLines("_MARNM") {
+		if marnm.Value == "" || len(marnm.ChildLines()) > 0 {
+			continue
+		}
+		var primary *types.GedcomLine
+		if names := indi.GetLines("NAME"); len(names) > 0 {
+			primary = names[0]
+		}
+		index := indexOf(indi.ChildLines(), marnm)
+		n.remove(marnm)
+		n.insert(indi, index, n.marriedName(primary, marnm.Value))
+		n.count("INDI._MARNM", marnm)
+	}
+
+	for _, famc := range indi.GetLines("FAMC") {
+		n.normalizePedigree(famc, famc, "INDI.FAMC")
+	}
+}
+
+// normalizePreferredName moves the NAME marked "_PRIM Y" to the front and
+// removes the _PRIM lines of all names.
+func (n *normalizer) normalizePreferredName(indi *types.GedcomLine) {
+	names := indi.GetLines("NAME")
+	var preferred *types.GedcomLine
+	for _, name := range names {
+		if preferred == nil && isYes(name.GetValue("_PRIM")) {
+			preferred = name
+		}
+	}
+
+	if preferred != nil && preferred != names[0] {
+		index := indexOf(indi.ChildLines(), names[0])
+		n.remove(preferred)
+		n.insert(indi, index, preferred)
+	}
+
+	for _, name := range names {
+		for _, prim := range name.GetLines("_PRIM") {
+			if len(prim.ChildLines()) > 0 {
+				continue
+			}
+			n.remove(prim)
+			n.count("INDI.NAME._PRIM", prim)
+		}
+	}
+}
+
+// marriedName returns a NAME line with TYPE married for surname, taking the
+// given name from name. surname may also be a full name ("Mary /Jones/").
+func (n *normalizer) marriedName(name *types.GedcomLine, surname string) *types.GedcomLine {
+	level := 1
+	value := strings.TrimSpace(surname)
+	if name != nil {
+		level = name.Level
+		if !strings.Contains(value, "/") {
+			given := ""
+			if parsed, _ := types.ParseName(name); parsed != nil {
+				given = parsed.Given
+			}
+			value = strings.TrimSpace(fmt.Sprintf("%s /%s/", given, value))
+		}
+	}
+
+	line := types.NewGedcomLine(level, "NAME", value, "")
+	line.AddChild(types.NewGedcomLine(level+1, "TYPE", n.enum(string(types.NameTypeMarried)), ""))
+	return line
+}
+
+// normalizeFamily maps the pedigree extensions under the CHIL lines of a FAM
+// record onto the children's FAMC links.
+func (n *normalizer) normalizeFamily(fam *types.GedcomLine) {
+	for _, chil := range fam.GetLines("CHIL") {
+		if len(chil.GetLines("_FREL"))+len(chil.GetLines("_MREL")) == 0 {
+			continue
+		}
+		indi, ok := n.tree.GetIndividual(chil.Value).(*types.IndividualRecord)
+		if !ok {
+			continue
+		}
+		for _, famc := range indi.GetLines("FAMC") {
+			if famc.Value == fam.XrefID {
+				n.normalizePedigree(chil, famc, "FAM.CHIL")
+				break
+			}
+		}
+	}
+}
+
+// normalizePedigree turns the _FREL and _MREL lines under link into a PEDI
+// line under famc. path is the path of link, for the summary.
+func (n *normalizer) normalizePedigree(link, famc *types.GedcomLine, path string) {
+	frel, mrel := link.GetLines("_FREL"), link.GetLines("_MREL")
+	if len(frel)+len(mrel) == 0 || len(frel) > 1 || len(mrel) > 1 || len(famc.GetLines("PEDI")) > 0 {
+		return
+	}
+
+	pedigree := types.PedigreeUnknown
+	relations := append(frel, mrel...)
+	for _, relation := range relations {
+		if len(relation.ChildLines()) > 0 {
+			return
+		}
+		value := types.ParsePedigree(relation.Value)
+		if pedigree != types.PedigreeUnknown && value != pedigree {
+			return
+		}
+		pedigree = value
+	}
+	if pedigree == types.PedigreeUnknown || pedigree == types.PedigreeOther {
+		return
+	}
+
+	pedi := types.NewGedcomLine(famc.Level+1, "PEDI", n.enum(string(pedigree)), "")
+	n.insert(famc, -1, pedi)
+	for _, relation := range relations {
+		n.remove(relation)
+		n.count(path+"."+relation.Tag, relation)
+	}
+}
+
+// isYes reports whether an extension flag value means yes.
+func isYes(value string) bool {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "Y", "YES", "TRUE", "1":
+		return true
+	}
+	return false
+}
+
+// indexOf returns the position of line in lines, or -1.
+func indexOf(lines []*types.GedcomLine, line *types.GedcomLine) int {
+	for i, l := range lines {
+		if l == line {
+			return i
+		}
+	}
+	return -1
+}
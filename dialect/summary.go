@@ -0,0 +1,87 @@
+package dialect
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Extension counts the uses of an extension tag at one place in the record
+// structure.
+type Extension struct {
+	Tag       string // Extension tag, e.g. "_APID"
+	Path      string // Tags from the record down, e.g. "INDI.SOUR._APID"
+	Count     int    // Number of lines
+	FirstLine int    // Line number of the first use (0 for lines not from a file)
+}
+
+// Summary lists the extensions of a normalized tree. Each list is sorted by path.
+type Summary struct {
+	Mapped  []Extension // Rewritten into standard structures
+	Known   []Extension // Left in place; known to the dialect or declared in HEAD.SCHMA
+	Unknown []Extension // Left in place; not known to the dialect
+}
+
+// HasUnknown reports whether the tree uses extensions the dialect does not know.
+func (s *Summary) HasUnknown() bool {
+	return len(s.Unknown) > 0
+}
+
+// summarize builds the summary for a tree normalized as d.
+func summarize(tree *types.GedcomTree, d *Dialect, mapped map[string]*Extension) *Summary {
+	declared := make(map[string]string)
+	if header, ok := tree.GetHeader().(*types.HeaderRecord); ok {
+		declared = header.GetSchemaTags()
+	}
+
+	known := make(map[string]*Extension)
+	unknown := make(map[string]*Extension)
+	var walk func(line *types.GedcomLine, path string)
+	walk = func(line *types.GedcomLine, path string) {
+		if strings.HasPrefix(line.Tag, "_") {
+			if _, ok := declared[line.Tag]; ok || d.IsKnown(line.Tag) {
+				addExtension(known, path, line)
+			} else {
+				addExtension(unknown, path, line)
+			}
+		}
+		for _, child := range line.ChildLines() {
+			walk(child, path+"."+child.Tag)
+		}
+	}
+	for _, record := range tree.GetAllRecords() {
+		walk(record.FirstLine(), record.FirstLine().Tag)
+	}
+
+	return &Summary{
+		Mapped:  sortedExtensions(mapped),
+		Known:   sortedExtensions(known),
+		Unknown: sortedExtensions(unknown),
+	}
+}
+
+// addExtension counts line, an extension at path, in extensions.
+func addExtension(extensions map[string]*Extension, path string, line *types.GedcomLine) {
+	ext, ok := extensions[path]
+	if !ok {
+		ext = &Extension{Tag: line.Tag, Path: path}
+		extensions[path] = ext
+	}
+	ext.Count++
+	if line.LineNumber > 0 && (ext.FirstLine == 0 || line.LineNumber < ext.FirstLine) {
+		ext.FirstLine = line.LineNumber
+	}
+}
+
+// sortedExtensions returns the extensions sorted by path.
+func sortedExtensions(extensions map[string]*Extension) []Extension {
+	result := make([]Extension, 0, len(extensions))
+	for _, ext := range extensions {
+		result = append(result, *ext)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
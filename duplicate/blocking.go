@@ -1,6 +1,7 @@
 package duplicate
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
 	"sort"
@@ -165,25 +166,9 @@ func (dd *DuplicateDetector) computePersonBlock(id uint32, indi *types.Individua
 
 	// Compute surname soundex
 	// Handle multi-part surnames: "van der Berg" -> use "Berg" for Soundex
-	surname := normalizeString(indi.GetSurname())
-	if surname == "" {
-		// Fallback: Extract surname from NAME value if SURN sub-tag is missing
-		// GEDCOM format: "Given Name /Surname/" or "Given Name /Surname1/Surname2/"
-		nameValue := indi.GetName()
-		if nameValue != "" {
-			// Try to extract surname between slashes
-			// Pattern: "text /surname/" or "text /surname1/surname2/"
-			slashIdx := strings.Index(nameValue, "/")
-			if slashIdx >= 0 {
-				// Find closing slash
-				closeIdx := strings.Index(nameValue[slashIdx+1:], "/")
-				if closeIdx >= 0 {
-					surname = strings.TrimSpace(nameValue[slashIdx+1 : slashIdx+1+closeIdx])
-					surname = normalizeString(surname)
-				}
-			}
-		}
-	}
+	// The family name comes from the naming convention, so the paternal
+	// surname of "García Márquez" and the stem of "Jensdatter" are used
+	_, surname := dd.nameParts(indi)
 	if surname != "" {
 		// Extract last significant word (handles "van der Berg", "de la Cruz", etc.)
 		surnameParts := strings.Fields(surname)
@@ -559,7 +544,8 @@ func absInt(x int) int {
 
 // generateBlockedComparisonJobs generates comparison jobs using blocking.
 // This replaces the O(n²) approach with O(n * avg_block_size).
-func (dd *DuplicateDetector) generateBlockedComparisonJobs(individuals []*types.IndividualRecord) ([]comparisonJob, *BlockingMetrics) {
+// It stops with ctx's error once ctx is done.
+func (dd *DuplicateDetector) generateBlockedComparisonJobs(ctx context.Context, individuals []*types.IndividualRecord) ([]comparisonJob, *BlockingMetrics, error) {
 	// Build block index
 	blockIndex := dd.buildBlockIndex(individuals)
 
@@ -571,8 +557,13 @@ func (dd *DuplicateDetector) generateBlockedComparisonJobs(individuals []*types.
 	}
 
 	candidatesPerPerson := make([]int, len(individuals))
+	tracker := types.NewProgressTracker(ctx, "Finding candidates", int64(len(individuals)))
 
 	for i := uint32(0); i < uint32(len(individuals)); i++ {
+		if err := tracker.Add(1); err != nil {
+			return nil, nil, err
+		}
+
 		candidates := blockIndex.findCandidates(i, maxCandidatesPerPerson)
 		candidatesPerPerson[i] = len(candidates)
 		for candidateID := range candidates {
@@ -587,7 +578,8 @@ func (dd *DuplicateDetector) generateBlockedComparisonJobs(individuals []*types.
 	// Compute metrics
 	metrics := blockIndex.computeBlockingMetrics(len(individuals), candidatesPerPerson)
 	blockIndex.metrics = metrics
+	tracker.Finish()
 
-	return jobs, metrics
+	return jobs, metrics, nil
 }
 
@@ -0,0 +1,54 @@
+package duplicate
+
+import (
+	"sync"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// individualData holds what comparisons need from one individual that is
+// costly to work out, so it is done once per run rather than once per pair.
+type individualData struct {
+	given        string // Given name key of the primary name (see nameParts)
+	family       string // Family name key of the primary name
+	birthPlaceID string // Gazetteer ID of the birth place, "" if unresolved
+}
+
+// individualCache holds the individualData of the individuals compared in
+// one run. It is safe for concurrent use by the comparison workers.
+type individualCache struct {
+	entries sync.Map // *types.IndividualRecord -> *individualData
+}
+
+// startRun keeps individualData until the returned function is called.
+// Records may change between runs, so nothing is kept across them.
+func (dd *DuplicateDetector) startRun() func() {
+	dd.cache = &individualCache{}
+	return func() { dd.cache = nil }
+}
+
+// dataFor returns the data of indi, working it out on first use in a
+// run. Outside a run (a single Compare) it is worked out every time.
+func (dd *DuplicateDetector) dataFor(indi *types.IndividualRecord) *individualData {
+	cache := dd.cache
+	if cache == nil {
+		return dd.newIndividualData(indi)
+	}
+	if data, ok := cache.entries.Load(indi); ok {
+		return data.(*individualData)
+	}
+	data, _ := cache.entries.LoadOrStore(indi, dd.newIndividualData(indi))
+	return data.(*individualData)
+}
+
+// newIndividualData works out the data of indi.
+func (dd *DuplicateDetector) newIndividualData(indi *types.IndividualRecord) *individualData {
+	data := &individualData{}
+	data.given, data.family = dd.parseNameParts(indi)
+	if g := dd.config.Gazetteer; g != nil {
+		if place, err := indi.GetBirthPlaceParsed(); err == nil && place != nil {
+			data.birthPlaceID = g.PlaceID(place)
+		}
+	}
+	return data
+}
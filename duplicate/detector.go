@@ -1,9 +1,11 @@
 package duplicate
 
 import (
+	"context"
 	"strings"
 	"time"
 
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/gazetteer"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
@@ -30,6 +32,15 @@ type DuplicateConfig struct {
 	MaxComparisons        int  // Limit comparisons for performance (0 = unlimited)
 	MaxCandidatesPerPerson int // Max candidates per person when blocking (default: 200)
 	NumWorkers            int  // Number of worker goroutines (0 = auto-detect)
+
+	// NameConvention, if set, is the naming convention names are parsed
+	// with; otherwise names use the tree's convention, selected by the
+	// header's LANG (see types.GedcomTree.NameConvention).
+	NameConvention *types.NameConvention
+
+	// Gazetteer, if set, resolves places to canonical places: places that
+	// resolve to the same one match fully, whatever their spelling.
+	Gazetteer *gazetteer.Gazetteer
 }
 
 // DefaultConfig returns a default configuration.
@@ -84,6 +95,7 @@ type DuplicateResult struct {
 type DuplicateDetector struct {
 	config *DuplicateConfig
 	tree   *types.GedcomTree // Optional: for relationship matching
+	cache  *individualCache  // Per-individual data during a run (see startRun)
 }
 
 // NewDuplicateDetector creates a new duplicate detector with the given configuration.
@@ -105,8 +117,18 @@ func (dd *DuplicateDetector) SetTree(tree *types.GedcomTree) {
 
 // FindDuplicates finds potential duplicates within a single GEDCOM tree.
 func (dd *DuplicateDetector) FindDuplicates(tree *types.GedcomTree) (*DuplicateResult, error) {
+	return dd.FindDuplicatesContext(context.Background(), tree)
+}
+
+// FindDuplicatesContext is like FindDuplicates but stops with ctx's error once
+// ctx is cancelled or its deadline passes. Progress is reported to the
+// ProgressReporter attached with types.WithProgress: per individual under the
+// stage "Finding candidates", then per comparison under "Comparing" (per
+// individual when processing sequentially).
+func (dd *DuplicateDetector) FindDuplicatesContext(ctx context.Context, tree *types.GedcomTree) (*DuplicateResult, error) {
 	// Set tree for relationship matching
 	dd.SetTree(tree)
+	defer dd.startRun()()
 	startTime := time.Now()
 
 	// Get all individuals
@@ -141,14 +163,14 @@ func (dd *DuplicateDetector) FindDuplicates(tree *types.GedcomTree) (*DuplicateR
 	if dd.config.UseParallelProcessing && len(individuals) > 10 {
 		// Use parallel processing for larger datasets
 		numWorkers = dd.getNumWorkers()
-		matches, comparisonCount, blockingMetrics, err = dd.findDuplicatesParallel(individuals)
+		matches, comparisonCount, blockingMetrics, err = dd.findDuplicatesParallel(ctx, individuals)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		// Use sequential processing for small datasets
 		numWorkers = 1
-		matches, comparisonCount, blockingMetrics, err = dd.findDuplicatesSequential(individuals)
+		matches, comparisonCount, blockingMetrics, err = dd.findDuplicatesSequential(ctx, individuals)
 		if err != nil {
 			return nil, err
 		}
@@ -184,6 +206,7 @@ func (dd *DuplicateDetector) FindDuplicates(tree *types.GedcomTree) (*DuplicateR
 
 // FindDuplicatesBetween finds potential duplicates between two GEDCOM trees.
 func (dd *DuplicateDetector) FindDuplicatesBetween(tree1, tree2 *types.GedcomTree) (*DuplicateResult, error) {
+	defer dd.startRun()()
 	startTime := time.Now()
 
 	// Get all individuals from both trees
@@ -269,6 +292,7 @@ func (dd *DuplicateDetector) FindDuplicatesBetween(tree1, tree2 *types.GedcomTre
 func (dd *DuplicateDetector) FindMatches(individual *types.IndividualRecord, tree *types.GedcomTree) ([]DuplicateMatch, error) {
 	// Set tree for relationship matching
 	dd.SetTree(tree)
+	defer dd.startRun()()
 	startTime := time.Now()
 	defer func() { _ = time.Since(startTime) }()
 
@@ -470,7 +494,13 @@ func (dd *DuplicateDetector) shouldCompare(indi1, indi2 *types.IndividualRecord,
 	surname1 := normalizeString(indi1.GetSurname())
 	surname2 := normalizeString(indi2.GetSurname())
 	if surname1 != "" && surname2 != "" && surname1 != surname2 {
-		return false
+		// Different surnames may still name one family ("García Márquez"
+		// and "García", "Jensdatter" and "Jensen")
+		_, family1 := dd.nameParts(indi1)
+		_, family2 := dd.nameParts(indi2)
+		if family1 != family2 {
+			return false
+		}
 	}
 
 	// Check if birth years are within tolerance
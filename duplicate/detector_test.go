@@ -1,6 +1,9 @@
 package duplicate
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -54,6 +57,45 @@ func TestFindDuplicates_SingleFile(t *testing.T) {
 	}
 }
 
+func TestFindDuplicatesContext_Cancelled(t *testing.T) {
+	tree := types.NewGedcomTree()
+	for i := 1; i <= 20; i++ {
+		indi := createTestIndividual("John /Doe/", "John", "Doe", "1800", "New York")
+		indi.FirstLine().XrefID = fmt.Sprintf("@I%d@", i)
+		tree.AddRecord(indi)
+	}
+
+	for _, parallel := range []bool{true, false} {
+		config := DefaultConfig()
+		config.UseParallelProcessing = parallel
+		detector := NewDuplicateDetector(config)
+
+		var stages []string
+		ctx, cancel := context.WithCancel(context.Background())
+		ctx = types.WithProgress(ctx, types.ProgressFunc(func(stage string, done, total int64) {
+			if len(stages) == 0 || stages[len(stages)-1] != stage {
+				stages = append(stages, stage)
+			}
+		}))
+
+		result, err := detector.FindDuplicatesContext(ctx, tree)
+		if err != nil {
+			t.Fatalf("parallel=%v: unexpected error: %v", parallel, err)
+		}
+		if len(result.Matches) == 0 {
+			t.Errorf("parallel=%v: expected matches", parallel)
+		}
+		if len(stages) == 0 || stages[len(stages)-1] != "Comparing" {
+			t.Errorf("parallel=%v: expected progress to end in Comparing, got %v", parallel, stages)
+		}
+
+		cancel()
+		if _, err := detector.FindDuplicatesContext(ctx, tree); !errors.Is(err, context.Canceled) {
+			t.Errorf("parallel=%v: expected context.Canceled, got %v", parallel, err)
+		}
+	}
+}
+
 func TestFindDuplicates_NoDuplicates(t *testing.T) {
 	detector := NewDuplicateDetector(DefaultConfig())
 	tree := types.NewGedcomTree()
@@ -1,6 +1,7 @@
 package duplicate
 
 import (
+	"context"
 	"runtime"
 	"sync"
 
@@ -22,7 +23,8 @@ type comparisonResult struct {
 }
 
 // findDuplicatesParallel finds duplicates using parallel processing.
-func (dd *DuplicateDetector) findDuplicatesParallel(individuals []*types.IndividualRecord) ([]DuplicateMatch, int, *BlockingMetrics, error) {
+// Once ctx is done, workers skip the remaining jobs and ctx's error is returned.
+func (dd *DuplicateDetector) findDuplicatesParallel(ctx context.Context, individuals []*types.IndividualRecord) ([]DuplicateMatch, int, *BlockingMetrics, error) {
 	if len(individuals) < 2 {
 		return []DuplicateMatch{}, 0, nil, nil
 	}
@@ -35,7 +37,10 @@ func (dd *DuplicateDetector) findDuplicatesParallel(individuals []*types.Individ
 
 	// Use blocking-based candidate generation (much faster than O(n²))
 	// This replaces the old index-based approach with proper blocking
-	jobs, blockingMetrics := dd.generateBlockedComparisonJobs(individuals)
+	jobs, blockingMetrics, err := dd.generateBlockedComparisonJobs(ctx, individuals)
+	if err != nil {
+		return nil, 0, nil, err
+	}
 	if len(jobs) == 0 {
 		return []DuplicateMatch{}, 0, blockingMetrics, nil
 	}
@@ -53,7 +58,7 @@ func (dd *DuplicateDetector) findDuplicatesParallel(individuals []*types.Individ
 	var wg sync.WaitGroup
 	for i := 0; i < numWorkers; i++ {
 		wg.Add(1)
-		go dd.worker(jobChan, resultChan, &wg)
+		go dd.worker(ctx, jobChan, resultChan, &wg)
 	}
 
 	// Send jobs
@@ -74,6 +79,7 @@ func (dd *DuplicateDetector) findDuplicatesParallel(individuals []*types.Individ
 	matches := make([]DuplicateMatch, 0)
 	comparisonCount := 0
 	results := make([]comparisonResult, 0, len(jobs))
+	tracker := types.NewProgressTracker(ctx, "Comparing", int64(len(jobs)))
 
 	for result := range resultChan {
 		comparisonCount++
@@ -82,7 +88,13 @@ func (dd *DuplicateDetector) findDuplicatesParallel(individuals []*types.Individ
 				results = append(results, result)
 			}
 		}
+		// Workers stop on cancellation; ctx is checked once they are done
+		_ = tracker.Add(1)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, 0, nil, err
 	}
+	tracker.Finish()
 
 	// Convert results to matches (maintain order if needed)
 	for _, result := range results {
@@ -92,11 +104,15 @@ func (dd *DuplicateDetector) findDuplicatesParallel(individuals []*types.Individ
 	return matches, comparisonCount, blockingMetrics, nil
 }
 
-// worker processes comparison jobs.
-func (dd *DuplicateDetector) worker(jobChan <-chan comparisonJob, resultChan chan<- comparisonResult, wg *sync.WaitGroup) {
+// worker processes comparison jobs. Once ctx is done, the remaining jobs are
+// skipped without sending a result.
+func (dd *DuplicateDetector) worker(ctx context.Context, jobChan <-chan comparisonJob, resultChan chan<- comparisonResult, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for job := range jobChan {
+		if ctx.Err() != nil {
+			continue
+		}
 		match, err := dd.compare(job.indi1, job.indi2)
 		resultChan <- comparisonResult{
 			match: match,
@@ -207,7 +223,7 @@ func (dd *DuplicateDetector) findDuplicatesBetweenParallel(
 	var wg sync.WaitGroup
 	for i := 0; i < numWorkers; i++ {
 		wg.Add(1)
-		go dd.worker(jobChan, resultChan, &wg)
+		go dd.worker(context.Background(), jobChan, resultChan, &wg)
 	}
 
 	// Send jobs
@@ -1,11 +1,14 @@
 package duplicate
 
 import (
+	"context"
+
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
 // findDuplicatesSequential finds duplicates using sequential processing.
-func (dd *DuplicateDetector) findDuplicatesSequential(individuals []*types.IndividualRecord) ([]DuplicateMatch, int, *BlockingMetrics, error) {
+// It stops with ctx's error once ctx is done.
+func (dd *DuplicateDetector) findDuplicatesSequential(ctx context.Context, individuals []*types.IndividualRecord) ([]DuplicateMatch, int, *BlockingMetrics, error) {
 	if len(individuals) < 2 {
 		return []DuplicateMatch{}, 0, nil, nil
 	}
@@ -21,8 +24,13 @@ func (dd *DuplicateDetector) findDuplicatesSequential(individuals []*types.Indiv
 	matches := make([]DuplicateMatch, 0)
 	comparisonCount := 0
 	candidatesPerPerson := make([]int, len(individuals))
+	tracker := types.NewProgressTracker(ctx, "Comparing", int64(len(individuals)))
 
 	for i := uint32(0); i < uint32(len(individuals)); i++ {
+		if err := tracker.Add(1); err != nil {
+			return nil, 0, nil, err
+		}
+
 		candidates := blockIndex.findCandidates(i, maxCandidatesPerPerson)
 		candidatesPerPerson[i] = len(candidates)
 		for candidateID := range candidates {
@@ -52,6 +60,7 @@ func (dd *DuplicateDetector) findDuplicatesSequential(individuals []*types.Indiv
 
 	// Compute blocking metrics
 	blockingMetrics := blockIndex.computeBlockingMetrics(len(individuals), candidatesPerPerson)
+	tracker.Finish()
 
 	return matches, comparisonCount, blockingMetrics, nil
 }
@@ -26,10 +26,8 @@ func (dd *DuplicateDetector) calculateNameSimilarity(indi1, indi2 *types.Individ
 	}
 
 	// Try component match (given name + surname)
-	given1 := normalizeString(indi1.GetGivenName())
-	given2 := normalizeString(indi2.GetGivenName())
-	surname1 := normalizeString(indi1.GetSurname())
-	surname2 := normalizeString(indi2.GetSurname())
+	given1, surname1 := dd.nameParts(indi1)
+	given2, surname2 := dd.nameParts(indi2)
 
 	givenScore := 0.0
 	surnameScore := 0.0
@@ -72,6 +70,32 @@ func (dd *DuplicateDetector) calculateNameSimilarity(indi1, indi2 *types.Individ
 	return 0.0
 }
 
+// nameParts returns the given name and family name of an individual's
+// primary name, as compared by the naming convention: the primary surname
+// of a compound surname and the stem of a patronymic, whatever the order
+// the name is written in (see types.GedcomName.FamilyKey). Falls back to the
+// GIVN and SURN values if the name cannot be parsed. The parts are worked
+// out once per run (see dataFor).
+func (dd *DuplicateDetector) nameParts(indi *types.IndividualRecord) (given, surname string) {
+	data := dd.dataFor(indi)
+	return data.given, data.family
+}
+
+// parseNameParts parses the primary name of indi for nameParts.
+func (dd *DuplicateDetector) parseNameParts(indi *types.IndividualRecord) (given, surname string) {
+	var name *types.GedcomName
+	var err error
+	if dd.config.NameConvention == nil {
+		name, err = indi.GetPrimaryName()
+	} else if nameLines := indi.GetLines("NAME"); len(nameLines) > 0 {
+		name, err = types.ParseNameWithConvention(nameLines[0], dd.config.NameConvention)
+	}
+	if name != nil && err == nil {
+		return name.GivenKey(), name.FamilyKey()
+	}
+	return normalizeString(indi.GetGivenName()), normalizeString(indi.GetSurname())
+}
+
 // calculateDateSimilarity calculates the similarity between two individuals' dates.
 func (dd *DuplicateDetector) calculateDateSimilarity(indi1, indi2 *types.IndividualRecord) float64 {
 	birthDate1 := indi1.GetBirthDate()
@@ -121,7 +145,8 @@ func (dd *DuplicateDetector) calculatePlaceSimilarity(indi1, indi2 *types.Indivi
 	place2, err2 := indi2.GetBirthPlaceParsed()
 
 	if err1 == nil && err2 == nil {
-		return placeComponentSimilarity(place1, place2)
+		return placeComponentSimilarity(place1, place2,
+			dd.dataFor(indi1).birthPlaceID, dd.dataFor(indi2).birthPlaceID)
 	}
 
 	// Fallback to string similarity
@@ -509,11 +534,17 @@ func extractYear(dateStr string) int {
 }
 
 // placeComponentSimilarity calculates similarity between parsed places.
-func placeComponentSimilarity(place1, place2 *types.GedcomPlace) float64 {
+// Places with the same gazetteer ID (id1, id2; empty if not resolved) match
+// fully; otherwise their components are compared.
+func placeComponentSimilarity(place1, place2 *types.GedcomPlace, id1, id2 string) float64 {
 	if place1 == nil || place2 == nil {
 		return 0.0
 	}
 
+	if id1 != "" && id1 == id2 {
+		return 1.0
+	}
+
 	// Compare components
 	scores := make([]float64, 0)
 
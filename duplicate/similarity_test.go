@@ -1,8 +1,10 @@
 package duplicate
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/gazetteer"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
@@ -282,3 +284,88 @@ func createTestIndividualWithSex(name, sex string) *types.IndividualRecord {
 	}
 	return indi
 }
+
+func TestCalculatePlaceSimilarity_Gazetteer(t *testing.T) {
+	g := gazetteer.New()
+	err := g.ReadCSV(strings.NewReader("id,name,alternate_names,state,state_code,country,country_code,lat,lon\n" +
+		"4407066,Saint Louis,St. Louis,Missouri,MO,United States,US,38.62727,-90.19789\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	indi1 := createTestIndividual("", "", "", "", "St. Louis, MO")
+	indi2 := createTestIndividual("", "", "", "", "Saint Louis, Missouri, United States")
+
+	without := NewDuplicateDetector(DefaultConfig()).calculatePlaceSimilarity(indi1, indi2)
+	if without >= 1.0 {
+		t.Fatalf("similarity without gazetteer = %f, want < 1", without)
+	}
+
+	config := DefaultConfig()
+	config.Gazetteer = g
+	detector := NewDuplicateDetector(config)
+	if score := detector.calculatePlaceSimilarity(indi1, indi2); score != 1.0 {
+		t.Errorf("similarity with gazetteer = %f, want 1", score)
+	}
+
+	// During a run each individual's place is resolved once
+	endRun := detector.startRun()
+	data := detector.dataFor(indi1)
+	if data.birthPlaceID != "4407066" || detector.dataFor(indi1) != data {
+		t.Errorf("dataFor() = %+v, want the cached gazetteer ID 4407066", data)
+	}
+	endRun()
+	if detector.cache != nil {
+		t.Error("cache kept after the run")
+	}
+}
+
+func TestCalculateNameSimilarity_Conventions(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention *types.NameConvention
+		name1      string
+		name2      string
+	}{
+		{"surname first", types.NameConventionSurnameFirst, "/Yamada/ Taro", "Taro /Yamada/"},
+		{"compound surname", types.NameConventionSpanish, "Gabriel /García Márquez/", "Gabriel /García/"},
+		{"diacritics", nil, "Søren /Kierkegaard/", "Soren /Kierkegaard/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.NameConvention = tt.convention
+			indi1 := createTestIndividual(tt.name1, "", "", "", "")
+			indi2 := createTestIndividual(tt.name2, "", "", "", "")
+			if score := NewDuplicateDetector(config).calculateNameSimilarity(indi1, indi2); score != 1.0 {
+				t.Errorf("calculateNameSimilarity(%q, %q) = %f, want 1", tt.name1, tt.name2, score)
+			}
+		})
+	}
+
+	// Without a convention, compound surnames are compared as written
+	detector := NewDuplicateDetector(DefaultConfig())
+	indi1 := createTestIndividual("Gabriel /García Márquez/", "", "", "", "")
+	indi2 := createTestIndividual("Gabriel /García/", "", "", "", "")
+	if score := detector.calculateNameSimilarity(indi1, indi2); score == 1.0 {
+		t.Error("expected Western names to compare the whole surname")
+	}
+
+	// Patronymics match by stem when the convention is known
+	indi1 = createTestIndividual("Maren /Jensdatter/", "", "", "", "")
+	indi2 = createTestIndividual("Maren /Jensen/", "", "", "", "")
+	detected := detector.calculateNameSimilarity(indi1, indi2)
+
+	config := DefaultConfig()
+	config.NameConvention = types.NameConventionScandinavian
+	scandinavian := NewDuplicateDetector(config).calculateNameSimilarity(indi1, indi2)
+	if scandinavian != 1.0 || detected >= scandinavian {
+		t.Errorf("patronymic similarity = %f detected, %f Scandinavian", detected, scandinavian)
+	}
+
+	// Blocking puts both in the same surname block
+	block1 := NewDuplicateDetector(config).computePersonBlock(1, indi1)
+	block2 := NewDuplicateDetector(config).computePersonBlock(2, indi2)
+	if block1.SurnameSoundex != block2.SurnameSoundex {
+		t.Errorf("SurnameSoundex = %q/%q, want equal", block1.SurnameSoundex, block2.SurnameSoundex)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -12,6 +13,7 @@ import (
 // CSVExporter exports GEDCOM data to CSV format
 type CSVExporter struct {
 	*BaseExporter
+	estimates types.DateEstimates
 }
 
 // NewCSVExporter creates a new CSV exporter
@@ -21,6 +23,16 @@ func NewCSVExporter(errorManager *types.ErrorManager) *CSVExporter {
 	}
 }
 
+// SetEstimatedDates adds a "Birth Date Estimate" column holding the
+// estimates of individuals without a birth date, such as those from
+// query.DateEstimator, written as "EST BET ... AND ..." (or "BEF ...
+// (estimated)" or "AFT ... (estimated)" when only one bound is known). The
+// Birth Date column only ever holds recorded dates. A nil map removes the
+// column.
+func (ce *CSVExporter) SetEstimatedDates(estimates types.DateEstimates) {
+	ce.estimates = estimates
+}
+
 // ExportToFile exports the tree to a CSV file
 func (ce *CSVExporter) ExportToFile(tree *types.GedcomTree, filePath string) error {
 	file, err := os.Create(filePath)
@@ -33,11 +45,7 @@ func (ce *CSVExporter) ExportToFile(tree *types.GedcomTree, filePath string) err
 	defer writer.Flush()
 
 	// Write header
-	header := []string{
-		"XREF", "Type", "Name", "Sex", "Birth Date", "Birth Place",
-		"Death Date", "Death Place", "Father XREF", "Mother XREF",
-		"Spouse XREFs", "Children XREFs", "Notes",
-	}
+	header := ce.header()
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write CSV header: %w", err)
 	}
@@ -66,11 +74,7 @@ func (ce *CSVExporter) ExportToString(tree *types.GedcomTree) (string, error) {
 	defer writer.Flush()
 
 	// Write header
-	header := []string{
-		"XREF", "Type", "Name", "Sex", "Birth Date", "Birth Place",
-		"Death Date", "Death Place", "Father XREF", "Mother XREF",
-		"Spouse XREFs", "Children XREFs", "Notes",
-	}
+	header := ce.header()
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write CSV header: %w", err)
 	}
@@ -93,6 +97,20 @@ func (ce *CSVExporter) ExportToString(tree *types.GedcomTree) (string, error) {
 	return sb.String(), nil
 }
 
+// header returns the CSV header row
+func (ce *CSVExporter) header() []string {
+	header := []string{
+		"XREF", "Type", "Name", "Sex", "Birth Date", "Birth Place",
+		"Death Date", "Death Place", "Father XREF", "Mother XREF",
+		"Spouse XREFs", "Children XREFs", "Notes",
+		"Birth Latitude", "Birth Longitude", "Death Latitude", "Death Longitude",
+	}
+	if ce.estimates != nil {
+		header = append(header, "Birth Date Estimate")
+	}
+	return header
+}
+
 // individualToCSVRow converts an individual record to a CSV row
 func (ce *CSVExporter) individualToCSVRow(xrefID string, indi *types.IndividualRecord, tree *types.GedcomTree) []string {
 	// Get basic info
@@ -102,6 +120,8 @@ func (ce *CSVExporter) individualToCSVRow(xrefID string, indi *types.IndividualR
 	birthPlace := indi.GetBirthPlace()
 	deathDate := indi.GetDeathDate()
 	deathPlace := indi.GetDeathPlace()
+	birthLatitude, birthLongitude := placeCoordinates(indi, "BIRT.PLAC")
+	deathLatitude, deathLongitude := placeCoordinates(indi, "DEAT.PLAC")
 
 	// Get family relationships
 	fatherXref := ""
@@ -169,8 +189,32 @@ func (ce *CSVExporter) individualToCSVRow(xrefID string, indi *types.IndividualR
 		strings.Join(spouseXrefs, ";"),
 		strings.Join(childrenXrefs, ";"),
 		strings.Join(notes, " | "),
+		birthLatitude,
+		birthLongitude,
+		deathLatitude,
+		deathLongitude,
+	}
+	if ce.estimates != nil {
+		estimate := ""
+		if ce.estimates[xrefID] != nil {
+			estimate = ce.estimates[xrefID].String()
+		}
+		row = append(row, estimate)
 	}
 
 	return row
 }
 
+// placeCoordinates returns the PLAC.MAP latitude and longitude of the place
+// matching selector as decimal degrees, or empty strings if it has none.
+func placeCoordinates(record types.Record, selector string) (string, string) {
+	placeLines := record.GetLines(selector)
+	if len(placeLines) == 0 {
+		return "", ""
+	}
+	latitude, longitude, ok := types.PlaceCoordinates(placeLines[0])
+	if !ok {
+		return "", ""
+	}
+	return strconv.FormatFloat(latitude, 'f', -1, 64), strconv.FormatFloat(longitude, 'f', -1, 64)
+}
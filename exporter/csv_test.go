@@ -1,9 +1,11 @@
 package exporter
 
 import (
+	"encoding/csv"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -109,3 +111,77 @@ func TestCSVExporter_ExportToString(t *testing.T) {
 	}
 }
 
+
+func TestCSVExporter_PlaceCoordinates(t *testing.T) {
+	tree := types.NewGedcomTree()
+	tree.AddRecord(mappedIndividual())
+
+	csvExporter := NewCSVExporter(types.NewErrorManager())
+	result, err := csvExporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("Failed to export to CSV string: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(result)).ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected header and one row, got %d records (%v)", len(records), err)
+	}
+	row := make(map[string]string)
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if row["Birth Latitude"] != "64.1466" || row["Birth Longitude"] != "-21.9426" {
+		t.Errorf("Expected birth coordinates 64.1466, -21.9426, got %q, %q", row["Birth Latitude"], row["Birth Longitude"])
+	}
+	if row["Death Latitude"] != "" || row["Death Longitude"] != "" {
+		t.Errorf("Expected empty death coordinates, got %q, %q", row["Death Latitude"], row["Death Longitude"])
+	}
+
+	// Coordinate columns come after the original ones, which keep their positions
+	want := "XREF,Type,Name,Sex,Birth Date,Birth Place,Death Date,Death Place,Father XREF,Mother XREF," +
+		"Spouse XREFs,Children XREFs,Notes,Birth Latitude,Birth Longitude,Death Latitude,Death Longitude"
+	if got := strings.Join(records[0], ","); got != want {
+		t.Errorf("header = %s, want %s", got, want)
+	}
+}
+
+func TestCSVExporter_SetEstimatedDates(t *testing.T) {
+	tree := types.NewGedcomTree()
+	tree.AddRecord(types.NewIndividualRecord(types.NewGedcomLine(0, "INDI", "", "@I2@")))
+
+	csvExporter := NewCSVExporter(types.NewErrorManager())
+	csvExporter.SetEstimatedDates(testEstimates())
+	result, err := csvExporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("Failed to export to CSV string: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(result)).ReadAll()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected header and one row, got %d records (%v)", len(records), err)
+	}
+	row := make(map[string]string)
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if row["Birth Date"] != "" {
+		t.Errorf("Expected an empty birth date, got %q", row["Birth Date"])
+	}
+	if row["Birth Date Estimate"] != "EST BET 1 JAN 1826 AND 31 DEC 1830" {
+		t.Errorf("Expected the estimate, got %q", row["Birth Date Estimate"])
+	}
+	if last := records[0][len(records[0])-1]; last != "Birth Date Estimate" {
+		t.Errorf("Expected the estimate in the last column, got %q", last)
+	}
+}
+
+// testEstimates returns an estimated birth for @I2@.
+func testEstimates() types.DateEstimates {
+	return types.DateEstimates{
+		"@I2@": types.NewDateEstimate(
+			time.Date(1826, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(1830, 12, 31, 0, 0, 0, 0, time.UTC),
+			[]string{"Earliest 1 JAN 1826: listed after sibling @I3@ (born no earlier than 1 JAN 1826) in @F1@"},
+		),
+	}
+}
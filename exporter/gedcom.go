@@ -22,6 +22,16 @@ type GedcomExporter struct {
 	*BaseExporter
 	appName    string
 	appVersion string
+
+	// gedcom7 is set while exporting a GEDCOM 7.x tree. GEDCOM 7.0 has no
+	// line length limit and no CONC, so long values are written unsplit.
+	gedcom7 bool
+
+	// lossless writes unmodified lines with their original text (see SetLossless)
+	lossless bool
+
+	// writeUIDs adds a UID or _UID line to records without an identifier (see SetWriteUIDs)
+	writeUIDs bool
 }
 
 // NewGedcomExporter creates a new GedcomExporter.
@@ -33,35 +43,105 @@ func NewGedcomExporter(errorManager *types.ErrorManager, appName, appVersion str
 	}
 }
 
+// SetLossless enables lossless export. Records are written in their original
+// file order and every line that was not modified since parsing is written
+// with its original text, including line terminators and CONC/CONT split
+// points, so an unmodified tree reproduces the input byte-for-byte. Modified
+// and new lines are formatted as usual. The header is not updated.
+//
+// Original text is only available for trees parsed with
+// HierarchicalParser.SetLossless(true); other trees are written in record
+// order with every line formatted.
+func (ge *GedcomExporter) SetLossless(enabled bool) {
+	ge.lossless = enabled
+}
+
+// SetWriteUIDs makes the exporter write the UUID of every record that has no
+// _UID, UID or EXID line at its end: as a "1 UID" line in a GEDCOM 7.x tree,
+// and as a "1 _UID" line (see types.FormatUID) in older versions.
+// Applications that read the line then keep the same identity for the record,
+// and so does this library when the file is parsed again. The tree is not
+// changed.
+func (ge *GedcomExporter) SetWriteUIDs(enabled bool) {
+	ge.writeUIDs = enabled
+}
+
+// uidLine returns the UID or _UID line to write for record, or "" if none is
+// needed. GEDCOM 7.0 defines UID with a UUID value; 5.5.1 only has the _UID
+// extension.
+func (ge *GedcomExporter) uidLine(record types.Record) string {
+	if !ge.writeUIDs || record.XrefID() == "" || types.HasIdentityTag(record) {
+		return ""
+	}
+	if ge.gedcom7 {
+		if uuid := record.UUID(); uuid != "" {
+			return "1 UID " + uuid
+		}
+		return ""
+	}
+	if uid := types.FormatUID(record.UUID()); uid != "" {
+		return "1 _UID " + uid
+	}
+	return ""
+}
+
+// recordToGED converts a record to GEDCOM format, see lineToGED and uidLine.
+func (ge *GedcomExporter) recordToGED(record types.Record) []string {
+	lines := ge.lineToGED(record.FirstLine())
+	if uid := ge.uidLine(record); uid != "" {
+		lines = append(lines, uid)
+	}
+	return lines
+}
+
 // ExportToFile exports the tree to a GEDCOM file.
 func (ge *GedcomExporter) ExportToFile(tree *types.GedcomTree, filePath string) error {
+	content, err := ge.exportDataset(tree, filePath)
+	if err != nil {
+		return err
+	}
+
+	// Write to file
+	if err := ge.writeToFile(filePath, content); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// exportDataset updates the header for a file named filePath, checks the
+// submitter and returns the GEDCOM content.
+func (ge *GedcomExporter) exportDataset(tree *types.GedcomTree, filePath string) (string, error) {
+	if ge.lossless {
+		return ge.ExportToString(tree)
+	}
+
 	// Update header with metadata
 	if err := ge.updateHeader(tree, filePath); err != nil {
-		return fmt.Errorf("failed to update header: %w", err)
+		return "", fmt.Errorf("failed to update header: %w", err)
 	}
 
 	// Ensure submitter exists
 	if err := ge.ensureSubmitter(tree); err != nil {
-		return fmt.Errorf("failed to ensure submitter: %w", err)
+		return "", fmt.Errorf("failed to ensure submitter: %w", err)
 	}
 
 	// Generate GEDCOM content
 	content, err := ge.ExportToString(tree)
 	if err != nil {
-		return fmt.Errorf("failed to generate GEDCOM content: %w", err)
-	}
-
-	// Write to file
-	if err := ge.writeToFile(filePath, content); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return "", fmt.Errorf("failed to generate GEDCOM content: %w", err)
 	}
-
-	return nil
+	return content, nil
 }
 
 // ExportToString exports the tree to a GEDCOM format string.
 func (ge *GedcomExporter) ExportToString(tree *types.GedcomTree) (string, error) {
 	var lines []string
+	ge.gedcom7 = tree.IsGedcom7()
+
+	if ge.lossless {
+		return ge.exportLossless(tree), nil
+	}
 
 	// Add header
 	header := tree.GetHeader()
@@ -75,47 +155,53 @@ func (ge *GedcomExporter) ExportToString(tree *types.GedcomTree) (string, error)
 	if len(submitters) > 0 {
 		// Get first submitter
 		for _, subm := range submitters {
-			submLines := ge.lineToGED(subm.FirstLine())
+			submLines := ge.recordToGED(subm)
 			lines = append(lines, submLines...)
 			break // Only add first submitter
 		}
 	}
 
 	// Add all other records in order
-	// INDI, FAM, SOUR, REPO, NOTE, OBJE
+	// INDI, FAM, SOUR, REPO, NOTE, SNOTE, OBJE
 	individuals := tree.GetAllIndividuals()
 	for _, indi := range individuals {
-		indiLines := ge.lineToGED(indi.FirstLine())
+		indiLines := ge.recordToGED(indi)
 		lines = append(lines, indiLines...)
 	}
 
 	families := tree.GetAllFamilies()
 	for _, fam := range families {
-		famLines := ge.lineToGED(fam.FirstLine())
+		famLines := ge.recordToGED(fam)
 		lines = append(lines, famLines...)
 	}
 
 	sources := tree.GetAllSources()
 	for _, src := range sources {
-		srcLines := ge.lineToGED(src.FirstLine())
+		srcLines := ge.recordToGED(src)
 		lines = append(lines, srcLines...)
 	}
 
 	repositories := tree.GetAllRepositories()
 	for _, repo := range repositories {
-		repoLines := ge.lineToGED(repo.FirstLine())
+		repoLines := ge.recordToGED(repo)
 		lines = append(lines, repoLines...)
 	}
 
 	notes := tree.GetAllNotes()
 	for _, note := range notes {
-		noteLines := ge.lineToGED(note.FirstLine())
+		noteLines := ge.recordToGED(note)
 		lines = append(lines, noteLines...)
 	}
 
+	sharedNotes := tree.GetAllSharedNotes()
+	for _, snote := range sharedNotes {
+		snoteLines := ge.recordToGED(snote)
+		lines = append(lines, snoteLines...)
+	}
+
 	multimedia := tree.GetAllMultimedia()
 	for _, obje := range multimedia {
-		objeLines := ge.lineToGED(obje.FirstLine())
+		objeLines := ge.recordToGED(obje)
 		lines = append(lines, objeLines...)
 	}
 
@@ -126,27 +212,104 @@ func (ge *GedcomExporter) ExportToString(tree *types.GedcomTree) (string, error)
 }
 
 // lineToGED converts a GedcomLine to GEDCOM format, handling CONC/CONT for long lines.
+// Children are written in their original order.
 func (ge *GedcomExporter) lineToGED(line *types.GedcomLine) []string {
-	lines := []string{}
-	
-	// Convert the line itself
+	lines := ge.ownLineToGED(line)
+
+	// Process children
+	for _, child := range line.ChildLines() {
+		lines = append(lines, ge.lineToGED(child)...)
+	}
+
+	return lines
+}
+
+// ownLineToGED converts a single line, without its children, to GEDCOM format.
+// Newlines in the value (CONT lines when the file was parsed) are written as
+// CONT lines.
+func (ge *GedcomExporter) ownLineToGED(line *types.GedcomLine) []string {
+	if strings.Contains(line.Value, "\n") {
+		values := strings.Split(line.Value, "\n")
+		lines := ge.ownLineToGED(types.NewGedcomLine(line.Level, line.Tag, values[0], line.XrefID))
+		for _, value := range values[1:] {
+			cont := types.NewGedcomLine(line.Level+1, "CONT", value, "")
+			if lineStr := ge.formatGEDLine(cont); len(lineStr) <= MaxLineLength || ge.gedcom7 {
+				lines = append(lines, lineStr)
+			} else {
+				// CONC lines continuing a CONT are at the same level
+				lines = append(lines, ge.splitValue(fmt.Sprintf("%d CONT", cont.Level), value, cont.Level)...)
+			}
+		}
+		return lines
+	}
+
 	lineStr := ge.formatGEDLine(line)
-	
-	// Handle long lines with CONC/CONT
-	if len(lineStr) > MaxLineLength {
-		lines = append(lines, ge.splitLongLine(line)...)
-	} else {
-		lines = append(lines, lineStr)
+
+	// Handle long lines with CONC/CONT (GEDCOM 5.5.x only)
+	if len(lineStr) > MaxLineLength && !ge.gedcom7 {
+		return ge.splitLongLine(line)
 	}
-	
-	// Process children
-	for _, children := range line.Children {
-		for _, child := range children {
-			lines = append(lines, ge.lineToGED(child)...)
+	return []string{lineStr}
+}
+
+// exportLossless writes all records in file order, reusing the original text
+// of unmodified lines.
+func (ge *GedcomExporter) exportLossless(tree *types.GedcomTree) string {
+	var b strings.Builder
+
+	eol := "\n"
+	if layout := tree.GetSourceLayout(); layout != nil {
+		if layout.LineEnding != "" {
+			eol = layout.LineEnding
+		}
+		if layout.BOM {
+			b.WriteString("\uFEFF")
 		}
 	}
-	
-	return lines
+
+	hasTrailer := false
+	for _, record := range tree.GetAllRecords() {
+		if record.Type() == types.RecordTypeTRLR {
+			hasTrailer = true
+		}
+		ge.writeLossless(&b, record.FirstLine(), eol)
+		if uid := ge.uidLine(record); uid != "" {
+			terminateLine(&b, eol)
+			b.WriteString(uid + eol)
+		}
+	}
+	if !hasTrailer {
+		terminateLine(&b, eol)
+		b.WriteString("0 TRLR" + eol)
+	}
+
+	return b.String()
+}
+
+// writeLossless writes line and its children, using original text where the
+// line is unmodified.
+func (ge *GedcomExporter) writeLossless(b *strings.Builder, line *types.GedcomLine, eol string) {
+	terminateLine(b, eol)
+	if raw := line.Raw(); raw != "" && !line.IsModified() {
+		b.WriteString(raw)
+	} else {
+		for _, lineStr := range ge.ownLineToGED(line) {
+			b.WriteString(lineStr + eol)
+		}
+	}
+
+	for _, child := range line.ChildLines() {
+		ge.writeLossless(b, child, eol)
+	}
+}
+
+// terminateLine ends the current output line if the last original text
+// written had no terminator (the last line of a file without a final newline).
+func terminateLine(b *strings.Builder, eol string) {
+	s := b.String()
+	if s != "" && !strings.HasSuffix(s, "\n") && s != "\uFEFF" {
+		b.WriteString(eol)
+	}
 }
 
 // formatGEDLine formats a single line to GEDCOM format.
@@ -169,8 +332,6 @@ func (ge *GedcomExporter) formatGEDLine(line *types.GedcomLine) string {
 
 // splitLongLine splits a long line using CONC/CONT continuation lines.
 func (ge *GedcomExporter) splitLongLine(line *types.GedcomLine) []string {
-	lines := []string{}
-	
 	// Format the base line
 	var parts []string
 	parts = append(parts, fmt.Sprintf("%d", line.Level))
@@ -179,8 +340,13 @@ func (ge *GedcomExporter) splitLongLine(line *types.GedcomLine) []string {
 	}
 	parts = append(parts, line.Tag)
 	
-	baseLine := strings.Join(parts, " ")
-	value := line.Value
+	return ge.splitValue(strings.Join(parts, " "), line.Value, line.Level+1)
+}
+
+// splitValue writes value after baseLine ("1 NOTE"), continued on CONC lines
+// at level.
+func (ge *GedcomExporter) splitValue(baseLine, value string, level int) []string {
+	lines := []string{}
 	
 	// Calculate how much value we can fit on the first line
 	firstLineMaxLen := MaxLineLength - len(baseLine) - 1 // -1 for space
@@ -203,7 +369,6 @@ func (ge *GedcomExporter) splitLongLine(line *types.GedcomLine) []string {
 	// Remaining lines with CONC (no newline) or CONT (with newline)
 	// Use CONC for continuation on same line, CONT for new line
 	// For simplicity, we'll use CONC for all continuations
-	level := line.Level + 1
 	concTag := "CONC"
 	
 	for len(value) > 0 {
@@ -262,8 +427,11 @@ func (ge *GedcomExporter) updateHeader(tree *types.GedcomTree, filePath string)
 		firstLine.AddChild(gedcLine)
 	}
 
+	// GEDCOM 7.0 files are always UTF-8 and have no CHAR or FILE
+	gedcom7 := types.IsGedcom7(firstLine.GetValue("GEDC.VERS"))
+
 	// Update CHAR (character encoding)
-	if firstLine.GetValue("CHAR") == "" {
+	if !gedcom7 && firstLine.GetValue("CHAR") == "" {
 		firstLine.SetValue("CHAR", "UTF-8")
 	}
 
@@ -283,8 +451,10 @@ func (ge *GedcomExporter) updateHeader(tree *types.GedcomTree, filePath string)
 
 	// Update FILE (file name)
 	// Extract just the filename from the path
-	fileName := filepath.Base(filePath)
-	firstLine.SetValue("FILE", fileName)
+	if !gedcom7 {
+		fileName := filepath.Base(filePath)
+		firstLine.SetValue("FILE", fileName)
+	}
 
 	return nil
 }
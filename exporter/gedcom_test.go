@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
@@ -253,3 +254,275 @@ func TestGedcomExporter_FormatGEDLine(t *testing.T) {
 		})
 	}
 }
+
+func TestGedcomExporter_MultiLineValue(t *testing.T) {
+	exporter := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0")
+
+	line := types.NewGedcomLine(0, "NOTE", "First line\n\n"+strings.Repeat("B", 300), "@N1@")
+	lines := exporter.ownLineToGED(line)
+
+	if lines[0] != "0 @N1@ NOTE First line" || lines[1] != "1 CONT" {
+		t.Errorf("ownLineToGED() = %q, want the text before each newline on its own line", lines[:2])
+	}
+	if !strings.HasPrefix(lines[2], "1 CONT B") || len(lines) < 4 {
+		t.Fatalf("ownLineToGED() = %q, want a long CONT line continued with CONC", lines)
+	}
+	for _, l := range lines[3:] {
+		if !strings.HasPrefix(l, "1 CONC B") {
+			t.Errorf("continuation of a CONT line = %q, want CONC at level 1", l)
+		}
+		if len(l) > MaxLineLength {
+			t.Errorf("line exceeds max length: %d", len(l))
+		}
+	}
+}
+
+func TestGedcomExporter_Gedcom7(t *testing.T) {
+	tree := types.NewGedcomTree()
+	head := types.NewGedcomLine(0, "HEAD", "", "")
+	gedc := types.NewGedcomLine(1, "GEDC", "", "")
+	gedc.AddChild(types.NewGedcomLine(2, "VERS", "7.0", ""))
+	head.AddChild(gedc)
+	tree.AddRecord(types.NewHeaderRecord(head))
+	tree.SetVersion("7.0")
+
+	longText := strings.Repeat("word ", 80)
+	tree.AddRecord(types.NewSharedNoteRecord(types.NewGedcomLine(0, "SNOTE", longText, "@N1@")))
+
+	exporter := NewGedcomExporter(types.NewErrorManager(), "Test", "1.0")
+	output, err := exporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+
+	if !strings.Contains(output, "0 @N1@ SNOTE "+longText) {
+		t.Error("expected shared note to be exported on a single line")
+	}
+	if strings.Contains(output, "CONC") {
+		t.Error("GEDCOM 7.0 export should not contain CONC")
+	}
+}
+
+func TestGedcomExporter_Lossless(t *testing.T) {
+	input := "\uFEFF0 HEAD\r\n" +
+		"1 CHAR UTF-8\r\n" +
+		"0 @I1@ INDI\r\n" +
+		"1 SEX M\r\n" +
+		"1 NAME John /Doe/\r\n" +
+		"1 NOTE A note that was spl\r\n" +
+		"2 CONC it in the middle\r\n" +
+		"\r\n" +
+		"1 BIRT\r\n" +
+		"2 DATE 1 JAN 1900\r\n" +
+		"0 @I2@ INDI\r\n" +
+		"1 NAME Jane /Doe/\r\n" +
+		"0 TRLR\r\n"
+
+	p := parser.NewHierarchicalParser()
+	p.SetLossless(true)
+	tree, err := p.ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	exporter := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0")
+	exporter.SetLossless(true)
+
+	output, err := exporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	if output != input {
+		t.Errorf("unmodified tree not reproduced:\ngot  %q\nwant %q", output, input)
+	}
+
+	// Only the modified line is regenerated; the rest keeps its original text
+	indi := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	indi.FirstLine().SetValue("BIRT.DATE", "2 JAN 1900")
+	indi.FirstLine().AddChild(types.NewGedcomLine(1, "OCCU", "Farmer", ""))
+
+	output, err = exporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	want := strings.Replace(input, "2 DATE 1 JAN 1900\r\n", "2 DATE 2 JAN 1900\r\n1 OCCU Farmer\r\n", 1)
+	if output != want {
+		t.Errorf("modified tree:\ngot  %q\nwant %q", output, want)
+	}
+}
+
+func TestGedcomExporter_LosslessTestdata(t *testing.T) {
+	for _, name := range []string{"xavier.ged", "gracis.ged", "tree1.ged"} {
+		t.Run(name, func(t *testing.T) {
+			path := findTestDataFile(name)
+			if path == "" {
+				t.Skipf("%s not found", name)
+			}
+			input, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", name, err)
+			}
+
+			p := parser.NewHierarchicalParser()
+			p.SetLossless(true)
+			tree, err := p.Parse(path)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			exporter := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0")
+			exporter.SetLossless(true)
+			output, err := exporter.ExportToString(tree)
+			if err != nil {
+				t.Fatalf("ExportToString() error = %v", err)
+			}
+			if output != string(input) {
+				t.Errorf("round trip of %s differs from input (%d bytes, want %d)", name, len(output), len(input))
+			}
+		})
+	}
+}
+
+func TestGedcomExporter_DatePhrases(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n" +
+		"1 BIRT\n2 DATE INT 1850 (about the time of the war)\n" +
+		"1 DEAT\n2 DATE (during the great flood)\n" +
+		"0 TRLR\n"
+
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	output, err := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0").ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	reparsed, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	indi := reparsed.GetIndividual("@I1@").(*types.IndividualRecord)
+	birth, err := indi.GetBirthDateParsed()
+	if err != nil {
+		t.Fatalf("GetBirthDateParsed() error = %v", err)
+	}
+	if !birth.IsInterpreted() || birth.Year != 1850 || birth.Phrase != "about the time of the war" {
+		t.Errorf("birth date did not round-trip: %+v", birth)
+	}
+	death, err := indi.GetDeathDateParsed()
+	if err != nil {
+		t.Fatalf("GetDeathDateParsed() error = %v", err)
+	}
+	if !death.IsPhrase() || death.String() != "(during the great flood)" {
+		t.Errorf("death date did not round-trip: %+v", death)
+	}
+
+	jsonOutput, err := NewJsonExporter(types.NewErrorManager()).ExportToString(tree)
+	if err != nil {
+		t.Fatalf("JSON ExportToString() error = %v", err)
+	}
+	for _, want := range []string{"INT 1850 (about the time of the war)", "(during the great flood)"} {
+		if !strings.Contains(jsonOutput, want) {
+			t.Errorf("JSON output missing date %q", want)
+		}
+	}
+}
+
+func TestGedcomExporter_Gedcom7DatePhrase(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 7.0\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n" +
+		"1 BIRT\n2 DATE 1850\n3 PHRASE about the time of the war\n" +
+		"0 TRLR\n"
+
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	dates := tree.GetIndividual("@I1@").FirstLine().GetLines("BIRT.DATE")
+	if len(dates) != 1 {
+		t.Fatalf("expected one BIRT.DATE line, got %d", len(dates))
+	}
+	birth := types.NewDateNodeFromLine(dates[0]).Date
+	if birth == nil || birth.IsInterpreted() || birth.String() != "1850" || birth.Phrase != "about the time of the war" {
+		t.Errorf("unexpected birth date %+v", birth)
+	}
+
+	output, err := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0").ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	if !strings.Contains(output, "2 DATE 1850\n3 PHRASE about the time of the war\n") {
+		t.Errorf("expected DATE and PHRASE lines, got:\n%s", output)
+	}
+	if strings.Contains(output, "INT ") {
+		t.Errorf("GEDCOM 7.0 output should not contain INT dates:\n%s", output)
+	}
+}
+
+func TestGedcomExporter_WriteUIDs(t *testing.T) {
+	input := "0 HEAD\n1 FILE family.ged\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n" +
+		"0 @I2@ INDI\n1 NAME Jane /Doe/\n1 UID 0f7d2e1c-4a6b-4c55-9e3d-2b1a09f8e7d6\n" +
+		"0 TRLR\n"
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	exporter := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0")
+	exporter.SetWriteUIDs(true)
+	output, err := exporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	if got := strings.Count(output, "_UID"); got != 1 {
+		t.Errorf("wrote %d _UID lines, want 1 for @I1@ only:\n%s", got, output)
+	}
+
+	// Parse the copy as another file: only the identifiers keep the UUIDs
+	p := parser.NewHierarchicalParser()
+	p.SetUUIDNamespace("copy.ged")
+	reparsed, err := p.ParseReader(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	for _, xref := range []string{"@I1@", "@I2@"} {
+		if got, want := reparsed.GetIndividual(xref).UUID(), tree.GetIndividual(xref).UUID(); got != want {
+			t.Errorf("%s UUID after round trip = %q, want %q", xref, got, want)
+		}
+	}
+}
+
+func TestGedcomExporter_WriteUIDsGedcom7(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 7.0\n1 FILE family.ged\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n" +
+		"0 TRLR\n"
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	exporter := NewGedcomExporter(types.NewErrorManager(), "TestApp", "1.0.0")
+	exporter.SetWriteUIDs(true)
+	output, err := exporter.ExportToString(tree)
+	if err != nil {
+		t.Fatalf("ExportToString() error = %v", err)
+	}
+	want := "1 UID " + tree.GetIndividual("@I1@").UUID() + "\n"
+	if !strings.Contains(output, want) || strings.Contains(output, "_UID") {
+		t.Errorf("expected %q and no _UID line:\n%s", want, output)
+	}
+
+	p := parser.NewHierarchicalParser()
+	p.SetUUIDNamespace("copy.ged")
+	reparsed, err := p.ParseReader(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	if got, want := reparsed.GetIndividual("@I1@").UUID(), tree.GetIndividual("@I1@").UUID(); got != want {
+		t.Errorf("UUID after round trip = %q, want %q", got, want)
+	}
+}
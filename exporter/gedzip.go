@@ -0,0 +1,148 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// gedzipDatasetName is the name of the GEDCOM dataset inside a GEDZIP archive.
+const gedzipDatasetName = "gedcom.ged"
+
+// GedzipExporter exports a GEDCOM tree and its media files to a GEDZIP (.gdz)
+// archive. The dataset is written as gedcom.ged and every local OBJE FILE
+// reference is copied from the tree's media source into the entry of the
+// same (relative) name, so the references resolve inside the archive.
+type GedzipExporter struct {
+	*BaseExporter
+	gedcomExporter *GedcomExporter
+}
+
+// NewGedzipExporter creates a new GedzipExporter.
+func NewGedzipExporter(errorManager *types.ErrorManager, appName, appVersion string) *GedzipExporter {
+	return &GedzipExporter{
+		BaseExporter:   NewBaseExporter(errorManager),
+		gedcomExporter: NewGedcomExporter(errorManager, appName, appVersion),
+	}
+}
+
+// SetWriteUIDs writes a UID or _UID line for records without an identifier (see
+// GedcomExporter.SetWriteUIDs).
+func (ze *GedzipExporter) SetWriteUIDs(enabled bool) {
+	ze.gedcomExporter.SetWriteUIDs(enabled)
+}
+
+// ExportToFile exports the tree and its media to a GEDZIP archive.
+func (ze *GedzipExporter) ExportToFile(tree *types.GedcomTree, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		ze.AddError(types.SeveritySevere,
+			fmt.Sprintf("Failed to create file: %s", err.Error()),
+			0,
+			"GEDZIP Export")
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if err := ze.ExportToWriter(tree, file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ExportToString exports the tree to a GEDZIP archive and returns its bytes.
+func (ze *GedzipExporter) ExportToString(tree *types.GedcomTree) (string, error) {
+	var buf bytes.Buffer
+	if err := ze.ExportToWriter(tree, &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ExportToWriter writes the GEDZIP archive to w.
+// Media files that cannot be read are reported as warnings and left out.
+func (ze *GedzipExporter) ExportToWriter(tree *types.GedcomTree, w io.Writer) error {
+	content, err := ze.gedcomExporter.exportDataset(tree, gedzipDatasetName)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+
+	dataset, err := archive.Create(gedzipDatasetName)
+	if err != nil {
+		return fmt.Errorf("failed to create %s entry: %w", gedzipDatasetName, err)
+	}
+	if _, err := io.WriteString(dataset, content); err != nil {
+		return fmt.Errorf("failed to write %s entry: %w", gedzipDatasetName, err)
+	}
+
+	for _, ref := range ze.mediaRefs(tree) {
+		if err := ze.addMedia(archive, tree, ref); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// mediaRefs returns the distinct local FILE references of all OBJE records,
+// sorted so archives are reproducible.
+func (ze *GedzipExporter) mediaRefs(tree *types.GedcomTree) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	for xrefID, record := range tree.GetAllMultimedia() {
+		for _, fileLine := range record.GetLines("FILE") {
+			ref := fileLine.Value
+			if ref == "" || seen[ref] {
+				continue
+			}
+			if _, ok := types.LocalMediaPath(ref); !ok {
+				ze.AddError(types.SeverityInfo,
+					fmt.Sprintf("Media file %q of %s is not a relative path and was not added to the archive", ref, xrefID),
+					fileLine.LineNumber,
+					"GEDZIP Export")
+				continue
+			}
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// addMedia copies one media file from the tree's media source into the archive.
+func (ze *GedzipExporter) addMedia(archive *zip.Writer, tree *types.GedcomTree, ref string) error {
+	name, _ := types.LocalMediaPath(ref)
+	if name == gedzipDatasetName {
+		return nil
+	}
+
+	source, err := tree.OpenMedia(ref)
+	if err != nil {
+		ze.AddError(types.SeverityWarning,
+			fmt.Sprintf("Media file %q could not be read: %s", ref, err.Error()),
+			0,
+			"GEDZIP Export")
+		return nil
+	}
+	defer source.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create archive entry %s: %w", name, err)
+	}
+	if _, err := io.Copy(entry, source); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+func TestGedzipExporter_ExportToWriter(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "photos"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "photos", "john.jpg"), []byte("JPEGDATA"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tree := types.NewGedcomTree()
+	head := types.NewGedcomLine(0, "HEAD", "", "")
+	head.AddChild(types.NewGedcomLine(1, "SUBM", "@U1@", ""))
+	tree.AddRecord(types.NewHeaderRecord(head))
+	tree.AddRecord(types.NewSubmitterRecord(types.NewGedcomLine(0, "SUBM", "", "@U1@")))
+	obje := types.NewGedcomLine(0, "OBJE", "", "@O1@")
+	obje.AddChild(types.NewGedcomLine(1, "FILE", "photos/john.jpg", ""))
+	tree.AddRecord(types.NewMultimediaRecord(obje))
+	missing := types.NewGedcomLine(0, "OBJE", "", "@O2@")
+	missing.AddChild(types.NewGedcomLine(1, "FILE", "photos/gone.jpg", ""))
+	tree.AddRecord(types.NewMultimediaRecord(missing))
+	tree.SetMediaSource(types.NewDirMediaSource(dir))
+
+	errorManager := types.NewErrorManager()
+	var buf bytes.Buffer
+	if err := NewGedzipExporter(errorManager, "Test", "1.0").ExportToWriter(tree, &buf); err != nil {
+		t.Fatalf("ExportToWriter() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		entries[file.Name] = string(data)
+	}
+
+	if !strings.Contains(entries["gedcom.ged"], "1 FILE photos/john.jpg") {
+		t.Errorf("gedcom.ged missing OBJE FILE, got %q", entries["gedcom.ged"])
+	}
+	if entries["photos/john.jpg"] != "JPEGDATA" {
+		t.Errorf("photos/john.jpg = %q, want %q", entries["photos/john.jpg"], "JPEGDATA")
+	}
+	if _, ok := entries["photos/gone.jpg"]; ok {
+		t.Error("unreadable media should not be added")
+	}
+	if len(errorManager.GetErrorsBySeverity(types.SeverityWarning)) != 1 {
+		t.Errorf("expected one warning for the unreadable media file, got %v", errorManager.Errors())
+	}
+}
@@ -12,6 +12,7 @@ import (
 // JsonExporter exports a GEDCOM tree to JSON format.
 type JsonExporter struct {
 	*BaseExporter
+	estimates types.DateEstimates
 }
 
 // NewJsonExporter creates a new JsonExporter.
@@ -21,6 +22,14 @@ func NewJsonExporter(errorManager *types.ErrorManager) *JsonExporter {
 	}
 }
 
+// SetEstimatedDates adds an "estimatedBirth" object to individuals without
+// a birth date that have an estimate, such as those from
+// query.DateEstimator. It holds the estimate as a date flagged with EST, its
+// bounds and the explanation; "birth" only ever holds recorded dates.
+func (je *JsonExporter) SetEstimatedDates(estimates types.DateEstimates) {
+	je.estimates = estimates
+}
+
 // ExportToFile exports the tree to a JSON file.
 func (je *JsonExporter) ExportToFile(tree *types.GedcomTree, filePath string) error {
 	jsonData, err := je.createJSONStructure(tree)
@@ -151,7 +160,7 @@ func (je *JsonExporter) individualsToJSON(tree *types.GedcomTree) map[string]int
 
 // individualToJSON converts an individual to JSON.
 func (je *JsonExporter) individualToJSON(individual types.Record) map[string]interface{} {
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"id":         individual.XrefID(),
 		"names":      je.getNames(individual),
 		"sex":        individual.GetValue("SEX"),
@@ -165,6 +174,15 @@ func (je *JsonExporter) individualToJSON(individual types.Record) map[string]int
 		},
 		"notes": individual.GetValues("NOTE"),
 	}
+	if estimate := je.estimates[individual.XrefID()]; estimate != nil {
+		result["estimatedBirth"] = map[string]interface{}{
+			"date":        estimate.String(),
+			"earliest":    estimate.Birth.StartDate().String(),
+			"latest":      estimate.Birth.EndDate().String(),
+			"explanation": estimate.Explanation,
+		}
+	}
+	return result
 }
 
 // familiesToJSON converts all families to JSON.
@@ -296,11 +314,13 @@ func (je *JsonExporter) getEvent(record types.Record, eventTag string) map[strin
 	}
 	
 	eventLine := eventLines[0]
-	return map[string]interface{}{
+	event := map[string]interface{}{
 		"date":  eventLine.GetValue("DATE"),
 		"place": eventLine.GetValue("PLAC"),
 		"notes": je.getNoteValues(eventLine),
 	}
+	je.addCoordinates(event, eventLine)
+	return event
 }
 
 // getEvents extracts all events from a record.
@@ -311,12 +331,14 @@ func (je *JsonExporter) getEvents(record types.Record) []map[string]interface{}
 	for _, tag := range eventTags {
 		eventLines := record.GetLines(tag)
 		for _, eventLine := range eventLines {
-			events = append(events, map[string]interface{}{
+			event := map[string]interface{}{
 				"type":  tag,
 				"date":  eventLine.GetValue("DATE"),
 				"place": eventLine.GetValue("PLAC"),
 				"notes": je.getNoteValues(eventLine),
-			})
+			}
+			je.addCoordinates(event, eventLine)
+			events = append(events, event)
 		}
 	}
 	
@@ -331,19 +353,34 @@ func (je *JsonExporter) getAttributes(individual types.Record) []map[string]inte
 	for _, tag := range attrTags {
 		attrLines := individual.GetLines(tag)
 		for _, attrLine := range attrLines {
-			attributes = append(attributes, map[string]interface{}{
+			attribute := map[string]interface{}{
 				"type":  tag,
 				"value": attrLine.Value,
 				"date":  attrLine.GetValue("DATE"),
 				"place": attrLine.GetValue("PLAC"),
 				"notes": je.getNoteValues(attrLine),
-			})
+			}
+			je.addCoordinates(attribute, attrLine)
+			attributes = append(attributes, attribute)
 		}
 	}
 	
 	return attributes
 }
 
+// addCoordinates adds the latitude and longitude of the event's
+// PLAC.MAP to data, if it has them.
+func (je *JsonExporter) addCoordinates(data map[string]interface{}, eventLine *types.GedcomLine) {
+	placeLines := eventLine.GetLines("PLAC")
+	if len(placeLines) == 0 {
+		return
+	}
+	if latitude, longitude, ok := types.PlaceCoordinates(placeLines[0]); ok {
+		data["latitude"] = latitude
+		data["longitude"] = longitude
+	}
+}
+
 // formatAddress formats an address from a record.
 func (je *JsonExporter) formatAddress(record types.Record) map[string]interface{} {
 	addrLines := record.GetLines("ADDR")
@@ -187,3 +187,56 @@ func TestJsonExporter_FamilyToJSON(t *testing.T) {
 }
 
 
+
+// mappedIndividual returns an individual born at a place with PLAC.MAP
+// coordinates.
+func mappedIndividual() *types.IndividualRecord {
+	indiLine := types.NewGedcomLine(0, "INDI", "", "@I1@")
+	birtLine := types.NewGedcomLine(1, "BIRT", "", "")
+	placLine := types.NewGedcomLine(2, "PLAC", "Reykjavik, Iceland", "")
+	mapLine := types.NewGedcomLine(3, "MAP", "", "")
+	mapLine.AddChild(types.NewGedcomLine(4, "LATI", "N64.1466", ""))
+	mapLine.AddChild(types.NewGedcomLine(4, "LONG", "W21.9426", ""))
+	placLine.AddChild(mapLine)
+	birtLine.AddChild(placLine)
+	indiLine.AddChild(birtLine)
+	return types.NewIndividualRecord(indiLine)
+}
+
+func TestJsonExporter_PlaceCoordinates(t *testing.T) {
+	exporter := NewJsonExporter(types.NewErrorManager())
+	jsonData := exporter.individualToJSON(mappedIndividual())
+
+	birth, ok := jsonData["birth"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected birth event")
+	}
+	if birth["latitude"] != 64.1466 || birth["longitude"] != -21.9426 {
+		t.Errorf("Expected coordinates 64.1466, -21.9426, got %v, %v", birth["latitude"], birth["longitude"])
+	}
+
+	events, _ := jsonData["events"].([]map[string]interface{})
+	if len(events) != 1 || events[0]["latitude"] != 64.1466 {
+		t.Errorf("Expected coordinates on the BIRT event, got %v", events)
+	}
+}
+
+func TestJsonExporter_SetEstimatedDates(t *testing.T) {
+	exporter := NewJsonExporter(types.NewErrorManager())
+	individual := types.NewIndividualRecord(types.NewGedcomLine(0, "INDI", "", "@I2@"))
+	if _, ok := exporter.individualToJSON(individual)["estimatedBirth"]; ok {
+		t.Error("Expected no estimatedBirth without SetEstimatedDates")
+	}
+
+	exporter.SetEstimatedDates(testEstimates())
+	estimate, ok := exporter.individualToJSON(individual)["estimatedBirth"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected estimatedBirth")
+	}
+	if estimate["date"] != "EST BET 1 JAN 1826 AND 31 DEC 1830" || estimate["earliest"] != "1 JAN 1826" || estimate["latest"] != "31 DEC 1830" {
+		t.Errorf("Unexpected estimate: %v", estimate)
+	}
+	if explanation, _ := estimate["explanation"].([]string); len(explanation) != 1 {
+		t.Errorf("Expected one explanation line, got %v", estimate["explanation"])
+	}
+}
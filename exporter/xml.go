@@ -167,10 +167,12 @@ type XMLName struct {
 
 // XMLEvent represents an event.
 type XMLEvent struct {
-	Type  string   `xml:"type,attr"`
-	Date  string   `xml:"date,omitempty"`
-	Place string   `xml:"place,omitempty"`
-	Notes []string `xml:"notes>note,omitempty"`
+	Type      string   `xml:"type,attr"`
+	Date      string   `xml:"date,omitempty"`
+	Place     string   `xml:"place,omitempty"`
+	Latitude  *float64 `xml:"latitude,omitempty"`
+	Longitude *float64 `xml:"longitude,omitempty"`
+	Notes     []string `xml:"notes>note,omitempty"`
 }
 
 // XMLAttribute represents an attribute.
@@ -311,26 +313,32 @@ func (xe *XMLExporter) individualToXML(individual types.Record) *XMLIndividual {
 
 	if birth, ok := jsonData["birth"].(map[string]interface{}); ok {
 		xmlIndi.Birth = &XMLEvent{
-			Type:  "BIRT",
-			Date:  getString(birth, "date"),
-			Place: getString(birth, "place"),
+			Type:      "BIRT",
+			Date:      getString(birth, "date"),
+			Place:     getString(birth, "place"),
+			Latitude:  getFloat(birth, "latitude"),
+			Longitude: getFloat(birth, "longitude"),
 		}
 	}
 
 	if death, ok := jsonData["death"].(map[string]interface{}); ok {
 		xmlIndi.Death = &XMLEvent{
-			Type:  "DEAT",
-			Date:  getString(death, "date"),
-			Place: getString(death, "place"),
+			Type:      "DEAT",
+			Date:      getString(death, "date"),
+			Place:     getString(death, "place"),
+			Latitude:  getFloat(death, "latitude"),
+			Longitude: getFloat(death, "longitude"),
 		}
 	}
 
 	if events, ok := jsonData["events"].([]map[string]interface{}); ok {
 		for _, event := range events {
 			xmlIndi.Events = append(xmlIndi.Events, &XMLEvent{
-				Type:  getString(event, "type"),
-				Date:  getString(event, "date"),
-				Place: getString(event, "place"),
+				Type:      getString(event, "type"),
+				Date:      getString(event, "date"),
+				Place:     getString(event, "place"),
+				Latitude:  getFloat(event, "latitude"),
+				Longitude: getFloat(event, "longitude"),
 			})
 		}
 	}
@@ -423,6 +431,15 @@ func getString(m map[string]interface{}, key string) string {
 	return ""
 }
 
+func getFloat(m map[string]interface{}, key string) *float64 {
+	if val, ok := m[key]; ok {
+		if f, ok := val.(float64); ok {
+			return &f
+		}
+	}
+	return nil
+}
+
 func getStringSlice(m map[string]interface{}, key string) []string {
 	if val, ok := m[key]; ok {
 		if slice, ok := val.([]string); ok {
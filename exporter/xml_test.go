@@ -3,6 +3,7 @@ package exporter
 import (
 	"encoding/xml"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -85,3 +86,31 @@ func TestXMLExporter_ExportToFile(t *testing.T) {
 }
 
 
+
+func TestXMLExporter_PlaceCoordinates(t *testing.T) {
+	exporter := NewXMLExporter(types.NewErrorManager())
+	xmlIndi := exporter.individualToXML(mappedIndividual())
+
+	birth := xmlIndi.Birth
+	if birth == nil || birth.Latitude == nil || birth.Longitude == nil {
+		t.Fatalf("Expected birth coordinates, got %+v", birth)
+	}
+	if *birth.Latitude != 64.1466 || *birth.Longitude != -21.9426 {
+		t.Errorf("Expected coordinates 64.1466, -21.9426, got %v, %v", *birth.Latitude, *birth.Longitude)
+	}
+
+	output, err := xml.Marshal(xmlIndi)
+	if err != nil {
+		t.Fatalf("xml.Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(output), "<latitude>64.1466</latitude>") {
+		t.Errorf("Expected <latitude> element in %s", output)
+	}
+
+	// Events without MAP have no coordinate elements
+	noMap := types.NewIndividualRecord(types.NewGedcomLine(0, "INDI", "", "@I2@"))
+	output, _ = xml.Marshal(exporter.individualToXML(noMap))
+	if strings.Contains(string(output), "latitude") {
+		t.Errorf("Unexpected <latitude> element in %s", output)
+	}
+}
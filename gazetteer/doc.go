@@ -0,0 +1,36 @@
+// Package gazetteer standardizes GEDCOM places against an offline gazetteer.
+//
+// Trees collected over years spell the same place many ways ("St. Louis, MO",
+// "Saint Louis, Missouri, USA"). A Gazetteer holds canonical places, each
+// with an ID, a name and its alternate names, the enclosing county, state and
+// country, and coordinates. It is loaded from local files, so no network
+// service is needed:
+//
+//   - GeoNames dumps (allCountries.txt, or a per-country file such as
+//     US.txt) with ReadGeoNames, plus admin1CodesASCII.txt, admin2Codes.txt
+//     and countryInfo.txt to resolve "MO", "Missouri" and "USA" alike
+//   - a CSV file with a header row (name, latitude, longitude and optional
+//     id, alternate names, county, state, country columns) with ReadCSV
+//
+// Resolve matches a parsed place to a canonical place with a confidence
+// score. Names are compared after folding case and diacritics, dropping
+// punctuation and expanding "St."/"Ste."/"Mt."/"Ft.", so "St. Louis" and
+// "Saint-Louis" match "Saint Louis".
+//
+// Basic Usage:
+//
+//	g, err := gazetteer.LoadFile("US.txt")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	report := gazetteer.Standardize(tree, g, nil)
+//	for _, cluster := range report.Unresolved {
+//		fmt.Println(cluster.Key, cluster.Variants)
+//	}
+//
+//	// Rewrite every resolved PLAC to its canonical form
+//	changed, err := report.Rewrite(nil)
+//
+// Query and duplicate detection can compare places by canonical ID: see
+// query.PlaceCollectionQuery.WithGazetteer and duplicate.DuplicateConfig.
+package gazetteer
@@ -0,0 +1,368 @@
+package gazetteer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// DefaultMinConfidence is the confidence a match needs to count as resolved
+// unless Gazetteer.MinConfidence is changed.
+const DefaultMinConfidence = 0.6
+
+// Place is a canonical place of the gazetteer.
+type Place struct {
+	ID             string
+	Name           string
+	AlternateNames []string // Other spellings and languages
+	Admin2         string   // County or district
+	Admin1         string   // State or province
+	Country        string
+	Latitude       float64
+	Longitude      float64
+	Population     int64
+
+	// Codes, such as GeoNames admin codes ("MO") and ISO country codes
+	// ("US"). They are matched like the names.
+	Admin2Code  string
+	Admin1Code  string
+	CountryCode string
+}
+
+// DefaultForm is the place form FullName writes, and the one Standardize
+// writes places in when neither the tree nor the PLAC line has a FORM.
+var DefaultForm = []string{"City", "County", "State", "Country"}
+
+// FullName returns the place as a GEDCOM place value in DefaultForm, e.g.
+// "Saint Louis, City of Saint Louis, Missouri, United States". A level the
+// place has no name for is left empty: "Québec, , Québec, Canada".
+func (p *Place) FullName() string {
+	return p.FormatForm(DefaultForm)
+}
+
+// FormatForm returns the place as a GEDCOM place value in form, the
+// jurisdiction names of a PLAC.FORM (see types.GedcomPlace.FormatWithForm).
+// The place's name fills the city jurisdiction, Admin2 the county, Admin1
+// the state and Country the country; codes are used where a name is missing.
+// Jurisdictions the place has nothing for are left empty. A nil form is
+// DefaultForm.
+func (p *Place) FormatForm(form []string) string {
+	if len(form) == 0 {
+		form = DefaultForm
+	}
+	place := &types.GedcomPlace{
+		City:    p.Name,
+		County:  p.Admin2,
+		State:   p.Admin1,
+		Country: p.Country,
+	}
+	if place.State == "" {
+		place.State = p.Admin1Code
+	}
+	if place.Country == "" {
+		place.Country = p.CountryCode
+	}
+	return place.FormatWithForm(form)
+}
+
+// country holds the names of a country from countryInfo.txt.
+type country struct {
+	name string
+	iso3 string
+}
+
+// Gazetteer is an in-memory index of canonical places.
+type Gazetteer struct {
+	// MinConfidence is the confidence a match needs for Resolve to return
+	// its place (default DefaultMinConfidence).
+	MinConfidence float64
+
+	places     []*Place
+	byID       map[string]*Place
+	byName     map[string][]*Place
+	adminNames map[string]string // "US.MO" and "US.MO.510" -> name
+	countries  map[string]*country
+}
+
+// New creates an empty gazetteer.
+func New() *Gazetteer {
+	return &Gazetteer{
+		MinConfidence: DefaultMinConfidence,
+		byID:          make(map[string]*Place),
+		byName:        make(map[string][]*Place),
+		adminNames:    make(map[string]string),
+		countries:     make(map[string]*country),
+	}
+}
+
+// Add adds place to the gazetteer, indexed by its name and alternate names.
+// A place with the ID of an existing place replaces it in lookups by ID.
+func (g *Gazetteer) Add(place *Place) {
+	g.fillNames(place)
+	g.places = append(g.places, place)
+	if place.ID != "" {
+		g.byID[place.ID] = place
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range append([]string{place.Name}, place.AlternateNames...) {
+		key := normalize(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		g.byName[key] = append(g.byName[key], place)
+	}
+}
+
+// Get returns the place with the given ID, or nil.
+func (g *Gazetteer) Get(id string) *Place {
+	return g.byID[id]
+}
+
+// Len returns the number of places in the gazetteer.
+func (g *Gazetteer) Len() int {
+	return len(g.places)
+}
+
+// fillNames sets the admin and country names of place from its codes, when
+// the admin code and country files have been read.
+func (g *Gazetteer) fillNames(place *Place) {
+	if place.CountryCode == "" {
+		return
+	}
+	if place.Admin1 == "" && place.Admin1Code != "" {
+		place.Admin1 = g.adminNames[place.CountryCode+"."+place.Admin1Code]
+	}
+	if place.Admin2 == "" && place.Admin2Code != "" {
+		place.Admin2 = g.adminNames[place.CountryCode+"."+place.Admin1Code+"."+place.Admin2Code]
+	}
+	if c := g.countries[place.CountryCode]; place.Country == "" && c != nil {
+		place.Country = c.name
+	}
+}
+
+// LoadFile loads a gazetteer file: CSV if its extension is .csv, otherwise a
+// GeoNames dump.
+func LoadFile(path string) (*Gazetteer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gazetteer: %w", err)
+	}
+	defer file.Close()
+
+	g := New()
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = g.ReadCSV(file)
+	} else {
+		err = g.ReadGeoNames(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return g, nil
+}
+
+// ReadGeoNames adds the places of a GeoNames dump: tab-separated lines of
+// geonameid, name, asciiname, alternatenames (comma-separated), latitude,
+// longitude, feature class, feature code, country code, cc2, admin1 code,
+// admin2 code, admin3 code, admin4 code, population, and further columns
+// that are ignored.
+func (g *Gazetteer) ReadGeoNames(r io.Reader) error {
+	return readLines(r, func(lineNum int, fields []string) error {
+		if len(fields) < 15 {
+			return fmt.Errorf("line %d: expected at least 15 columns, got %d", lineNum, len(fields))
+		}
+		latitude, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid latitude %q", lineNum, fields[4])
+		}
+		longitude, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid longitude %q", lineNum, fields[5])
+		}
+		population, _ := strconv.ParseInt(fields[14], 10, 64)
+
+		place := &Place{
+			ID:          fields[0],
+			Name:        fields[1],
+			Latitude:    latitude,
+			Longitude:   longitude,
+			Population:  population,
+			CountryCode: fields[8],
+			Admin1Code:  fields[10],
+			Admin2Code:  fields[11],
+		}
+		if fields[2] != "" && fields[2] != fields[1] {
+			place.AlternateNames = append(place.AlternateNames, fields[2])
+		}
+		if fields[3] != "" {
+			place.AlternateNames = append(place.AlternateNames, strings.Split(fields[3], ",")...)
+		}
+		g.Add(place)
+		return nil
+	})
+}
+
+// ReadAdminCodes reads the names of administrative divisions from a GeoNames
+// admin1CodesASCII.txt or admin2Codes.txt file ("US.MO<TAB>Missouri<TAB>...")
+// and sets them on the places with those codes.
+func (g *Gazetteer) ReadAdminCodes(r io.Reader) error {
+	err := readLines(r, func(lineNum int, fields []string) error {
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: expected a code and a name", lineNum)
+		}
+		g.adminNames[fields[0]] = fields[1]
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, place := range g.places {
+		g.fillNames(place)
+	}
+	return nil
+}
+
+// ReadCountryInfo reads country names and ISO 3166 alpha-3 codes from a
+// GeoNames countryInfo.txt file and sets them on the places of those
+// countries, so that "USA" and "United States" match country code "US".
+func (g *Gazetteer) ReadCountryInfo(r io.Reader) error {
+	err := readLines(r, func(lineNum int, fields []string) error {
+		if len(fields) < 5 {
+			return fmt.Errorf("line %d: expected at least 5 columns, got %d", lineNum, len(fields))
+		}
+		g.countries[fields[0]] = &country{name: fields[4], iso3: fields[1]}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, place := range g.places {
+		g.fillNames(place)
+	}
+	return nil
+}
+
+// readLines calls fn with the tab-separated fields of each line of r,
+// skipping blank lines and "#" comments.
+func readLines(r io.Reader, fn func(lineNum int, fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024) // alternatenames can be long
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := fn(lineNum, strings.Split(line, "\t")); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read gazetteer: %w", err)
+	}
+	return nil
+}
+
+// csvColumns maps the accepted CSV header names, lowercased and with spaces
+// as underscores, to Place fields.
+var csvColumns = map[string]string{
+	"id": "id", "geonameid": "id",
+	"name":            "name",
+	"alternate_names": "alternate_names", "alternatenames": "alternate_names", "aliases": "alternate_names",
+	"admin2": "admin2", "county": "admin2", "district": "admin2",
+	"admin1": "admin1", "state": "admin1", "province": "admin1", "region": "admin1",
+	"admin1_code": "admin1_code", "state_code": "admin1_code",
+	"country":      "country",
+	"country_code": "country_code",
+	"latitude":     "latitude", "lat": "latitude",
+	"longitude": "longitude", "lon": "longitude", "lng": "longitude", "long": "longitude",
+	"population": "population",
+}
+
+// ReadCSV adds the places of a CSV file. The first row names the columns:
+// name, latitude and longitude are required; id, alternate_names (separated
+// by ";" or "|"), county, state, state_code, country, country_code and
+// population are optional, and other columns are ignored. Rows without an id
+// get their row number.
+func (g *Gazetteer) ReadCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if field, ok := csvColumns[name]; ok {
+			columns[field] = i
+		}
+	}
+	for _, required := range []string{"name", "latitude", "longitude"} {
+		if _, ok := columns[required]; !ok {
+			return fmt.Errorf("CSV header has no %s column", required)
+		}
+	}
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV row %d: %w", row, err)
+		}
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		latitude, err := strconv.ParseFloat(get("latitude"), 64)
+		if err != nil {
+			return fmt.Errorf("row %d: invalid latitude %q", row, get("latitude"))
+		}
+		longitude, err := strconv.ParseFloat(get("longitude"), 64)
+		if err != nil {
+			return fmt.Errorf("row %d: invalid longitude %q", row, get("longitude"))
+		}
+		population, _ := strconv.ParseInt(get("population"), 10, 64)
+
+		place := &Place{
+			ID:          get("id"),
+			Name:        get("name"),
+			Admin2:      get("admin2"),
+			Admin1:      get("admin1"),
+			Admin1Code:  get("admin1_code"),
+			Country:     get("country"),
+			CountryCode: get("country_code"),
+			Latitude:    latitude,
+			Longitude:   longitude,
+			Population:  population,
+		}
+		if place.Name == "" {
+			return fmt.Errorf("row %d: empty name", row)
+		}
+		if place.ID == "" {
+			place.ID = strconv.Itoa(row)
+		}
+		for _, name := range strings.FieldsFunc(get("alternate_names"), func(r rune) bool { return r == ';' || r == '|' }) {
+			if name = strings.TrimSpace(name); name != "" {
+				place.AlternateNames = append(place.AlternateNames, name)
+			}
+		}
+		g.Add(place)
+	}
+}
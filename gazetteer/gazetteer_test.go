@@ -0,0 +1,206 @@
+package gazetteer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testGeoNames = "4407066\tSaint Louis\tSaint Louis\tSt. Louis,St Louis,Saint-Louis\t38.62727\t-90.19789\tP\tPPLA2\tUS\t\tMO\t510\t\t\t315685\n" +
+	"4409896\tSpringfield\tSpringfield\t\t37.21533\t-93.29824\tP\tPPLA2\tUS\t\tMO\t077\t\t\t169176\n" +
+	"4250542\tSpringfield\tSpringfield\t\t39.80172\t-89.64371\tP\tPPLA\tUS\t\tIL\t167\t\t\t116250\n" +
+	"2620214\tHjørring\tHjorring\tHjorring\t57.46417\t9.98229\tP\tPPLA2\tDK\t\t81\t\t\t\t25251\n"
+
+const testAdminCodes = "US.MO\tMissouri\tMissouri\t4398678\n" +
+	"US.IL\tIllinois\tIllinois\t4896861\n" +
+	"US.MO.510\tCity of Saint Louis\tCity of Saint Louis\t4407084\n" +
+	"US.MO.077\tGreene County\tGreene County\t4396915\n" +
+	"US.IL.167\tSangamon County\tSangamon County\t4250546\n" +
+	"DK.81\tNorth Denmark\tNorth Denmark\t6418538\n"
+
+const testCountryInfo = "# ISO\tISO3\tISO-Numeric\tfips\tCountry\n" +
+	"US\tUSA\t840\tUS\tUnited States\n" +
+	"DK\tDNK\t208\tDA\tDenmark\n"
+
+// testGazetteer returns a gazetteer read from the GeoNames test data.
+func testGazetteer(t *testing.T) *Gazetteer {
+	t.Helper()
+	g := New()
+	if err := g.ReadGeoNames(strings.NewReader(testGeoNames)); err != nil {
+		t.Fatalf("ReadGeoNames() error = %v", err)
+	}
+	if err := g.ReadAdminCodes(strings.NewReader(testAdminCodes)); err != nil {
+		t.Fatalf("ReadAdminCodes() error = %v", err)
+	}
+	if err := g.ReadCountryInfo(strings.NewReader(testCountryInfo)); err != nil {
+		t.Fatalf("ReadCountryInfo() error = %v", err)
+	}
+	return g
+}
+
+func TestReadGeoNames(t *testing.T) {
+	g := testGazetteer(t)
+	if g.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", g.Len())
+	}
+
+	place := g.Get("4407066")
+	if place == nil {
+		t.Fatal("Get(4407066) = nil")
+	}
+	if place.Admin1 != "Missouri" || place.Admin2 != "City of Saint Louis" || place.Country != "United States" {
+		t.Errorf("admin names = %q, %q, %q", place.Admin2, place.Admin1, place.Country)
+	}
+	if place.Latitude != 38.62727 || place.Longitude != -90.19789 || place.Population != 315685 {
+		t.Errorf("place = %+v", place)
+	}
+	if got, want := place.FullName(), "Saint Louis, City of Saint Louis, Missouri, United States"; got != want {
+		t.Errorf("FullName() = %q, want %q", got, want)
+	}
+	if got, want := g.Get("2620214").FullName(), "Hjørring, , North Denmark, Denmark"; got != want {
+		t.Errorf("FullName() = %q, want %q", got, want)
+	}
+
+	if err := New().ReadGeoNames(strings.NewReader("1\tShort\n")); err == nil {
+		t.Error("ReadGeoNames() accepted a short line")
+	}
+}
+
+func TestPlace_FormatForm(t *testing.T) {
+	// Levels with the same name are all kept
+	place := &Place{Name: "Québec", Admin1: "Québec", Country: "Canada"}
+	if got, want := place.FullName(), "Québec, , Québec, Canada"; got != want {
+		t.Errorf("FullName() = %q, want %q", got, want)
+	}
+	if got, want := place.FormatForm([]string{"Town", "Province", "Country"}), "Québec, Québec, Canada"; got != want {
+		t.Errorf("FormatForm() = %q, want %q", got, want)
+	}
+	if got, want := place.FormatForm([]string{"Parish", "City", "Country"}), ", Québec, Canada"; got != want {
+		t.Errorf("FormatForm() = %q, want %q", got, want)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "Name,Alternate Names,County,State,Country,Lat,Lng\n" +
+		"Ballymote,Baile an Mhóta;Ballimote,Sligo,Connacht,Ireland,54.0886,-8.5153\n" +
+		"Tubbercurry,,Sligo,Connacht,Ireland,54.0567,-8.7294\n"
+	g := New()
+	if err := g.ReadCSV(strings.NewReader(input)); err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	if g.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", g.Len())
+	}
+
+	place := g.Get("2")
+	if place == nil || place.Name != "Ballymote" || place.Admin2 != "Sligo" || place.Longitude != -8.5153 {
+		t.Fatalf("Get(2) = %+v", place)
+	}
+	if len(place.AlternateNames) != 2 || place.AlternateNames[1] != "Ballimote" {
+		t.Errorf("AlternateNames = %v", place.AlternateNames)
+	}
+	if resolution := g.ResolveString("Baile an Mhota, Sligo, Ireland"); resolution.Place != place {
+		t.Errorf("ResolveString() = %+v", resolution)
+	}
+
+	if err := New().ReadCSV(strings.NewReader("name,lat\nX,1\n")); err == nil {
+		t.Error("ReadCSV() accepted a header without longitude")
+	}
+	if err := New().ReadCSV(strings.NewReader("name,lat,lon\nX,north,1\n")); err == nil {
+		t.Error("ReadCSV() accepted an invalid latitude")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "places.csv")
+	if err := os.WriteFile(csvPath, []byte("name,latitude,longitude\nHjørring,57.46,9.98\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tsvPath := filepath.Join(dir, "DK.txt")
+	if err := os.WriteFile(tsvPath, []byte(testGeoNames), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := LoadFile(csvPath)
+	if err != nil || g.Len() != 1 {
+		t.Errorf("LoadFile(csv) = %v, %v", g, err)
+	}
+	g, err = LoadFile(tsvPath)
+	if err != nil || g.Len() != 4 {
+		t.Errorf("LoadFile(txt) = %v, %v", g, err)
+	}
+	if _, err := LoadFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("LoadFile() of a missing file succeeded")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"St. Louis", "saint louis"},
+		{"Saint-Louis", "saint louis"},
+		{"  SAINT   LOUIS ", "saint louis"},
+		{"Hjørring", "hjorring"},
+		{"Zürich", "zurich"},
+		{"Mt. Vernon", "mount vernon"},
+		{"Ste. Genevieve", "sainte genevieve"},
+		{"Ft Wayne", "fort wayne"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalize(tt.name); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	g := testGazetteer(t)
+
+	tests := []struct {
+		place string
+		want  string // Place ID, "" if unresolved
+	}{
+		{"St. Louis, MO", "4407066"},
+		{"Saint Louis, Missouri, USA", "4407066"},
+		{"St Louis, City of St. Louis, Missouri, United States", "4407066"},
+		{"Springfield, Greene, Missouri", "4409896"},
+		{"Springfield, Illinois, USA", "4250542"},
+		{"Springfield", "4409896"}, // Ambiguous: the most populous
+		{"Hjorring, Denmark", "2620214"},
+		{"Hjørring, DK", "2620214"},
+		{"Hjørring, Danmark", ""},                   // Endonyms are not in countryInfo.txt
+		{"Old Mill Farm, St. Louis, MO", "4407066"}, // Unknown detail first
+		{"Springfield, Ohio", ""},                   // Known name, wrong state
+		{"Atlantis", ""},
+	}
+	for _, tt := range tests {
+		resolution := g.ResolveString(tt.place)
+		got := ""
+		if resolution.Resolved() {
+			got = resolution.Place.ID
+		}
+		if got != tt.want {
+			t.Errorf("ResolveString(%q) = %q (confidence %.2f), want %q", tt.place, got, resolution.Confidence, tt.want)
+		}
+	}
+
+	exact := g.ResolveString("Saint Louis, Missouri, USA")
+	partial := g.ResolveString("St. Louis")
+	ambiguous := g.ResolveString("Springfield")
+	if !(exact.Confidence > partial.Confidence && partial.Confidence > ambiguous.Confidence) {
+		t.Errorf("confidences = %.2f, %.2f, %.2f, want decreasing", exact.Confidence, partial.Confidence, ambiguous.Confidence)
+	}
+	if ambiguous.Candidates != 2 {
+		t.Errorf("Candidates = %d, want 2", ambiguous.Candidates)
+	}
+
+	g.MinConfidence = 0.9
+	if g.ResolveString("St. Louis").Resolved() {
+		t.Error("ResolveString() resolved below MinConfidence")
+	}
+}
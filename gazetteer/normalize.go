@@ -0,0 +1,28 @@
+package gazetteer
+
+import (
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// abbreviations are the words expanded before names are compared.
+var abbreviations = map[string]string{
+	"st":  "saint",
+	"ste": "sainte",
+	"mt":  "mount",
+	"ft":  "fort",
+}
+
+// normalize returns the key a place name is compared by: folded with
+// types.FoldName, with abbreviations expanded. "St. Louis" and
+// "Saint-Louis" both become "saint louis".
+func normalize(name string) string {
+	words := strings.Fields(types.FoldName(name))
+	for i, word := range words {
+		if expanded, ok := abbreviations[word]; ok {
+			words[i] = expanded
+		}
+	}
+	return strings.Join(words, " ")
+}
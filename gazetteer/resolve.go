@@ -0,0 +1,126 @@
+package gazetteer
+
+import (
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Resolution is the result of matching a place against the gazetteer.
+type Resolution struct {
+	Original   string  // The place as written
+	Place      *Place  // Best match, nil if none reaches MinConfidence
+	Confidence float64 // Confidence of the best match, 0 to 1
+	Candidates int     // Gazetteer places whose name matched
+}
+
+// Resolved reports whether the place was matched to a canonical place.
+func (r *Resolution) Resolved() bool {
+	return r != nil && r.Place != nil
+}
+
+// ResolveString parses value with types.ParsePlace and resolves it.
+func (g *Gazetteer) ResolveString(value string) *Resolution {
+	place, err := types.ParsePlace(value)
+	if err != nil {
+		return &Resolution{Original: value}
+	}
+	return g.Resolve(place)
+}
+
+// Resolve matches place to a canonical place of the gazetteer.
+//
+// The most specific component that is a gazetteer name is taken as the place
+// name; the components after it must then name the enclosing county, state
+// or country (or their codes). The confidence is 0.6 for the name plus up to
+// 0.4 for the share of those components that match, 0.2 when there are none,
+// and is lowered for each more specific component that was skipped and when
+// several places match equally well (the most populous is chosen). A name
+// whose enclosing components all disagree scores 0.4.
+func (g *Gazetteer) Resolve(place *types.GedcomPlace) *Resolution {
+	resolution := &Resolution{}
+	if place == nil {
+		return resolution
+	}
+	resolution.Original = place.Original
+
+	var best *Place
+	bestScore, ties := 0.0, 0
+	for i, component := range place.Components {
+		candidates := g.byName[normalize(component)]
+		resolution.Candidates += len(candidates)
+
+		rest := place.Components[i+1:]
+		for _, candidate := range candidates {
+			score := g.score(candidate, rest) - 0.1*float64(i)
+			switch {
+			case best == nil || score > bestScore+1e-9:
+				best, bestScore, ties = candidate, score, 1
+			case score > bestScore-1e-9:
+				ties++
+				if candidate.Population > best.Population {
+					best = candidate
+				}
+			}
+		}
+	}
+	if best == nil {
+		return resolution
+	}
+
+	if ties > 1 {
+		bestScore *= 0.75
+	}
+	if bestScore < 0 {
+		bestScore = 0
+	}
+	resolution.Confidence = bestScore
+	if bestScore >= g.MinConfidence {
+		resolution.Place = best
+	}
+	return resolution
+}
+
+// score scores a candidate whose name matched from the place components
+// after the name.
+func (g *Gazetteer) score(candidate *Place, rest []string) float64 {
+	if len(rest) == 0 {
+		return 0.8
+	}
+	regions := g.regionNames(candidate)
+	matched := 0
+	for _, component := range rest {
+		if regions[normalize(component)] {
+			matched++
+		}
+	}
+	if matched == 0 {
+		return 0.4
+	}
+	return 0.6 + 0.4*float64(matched)/float64(len(rest))
+}
+
+// regionNames returns the normalized names and codes of the divisions that
+// contain place.
+func (g *Gazetteer) regionNames(place *Place) map[string]bool {
+	names := make(map[string]bool)
+	add := func(values ...string) {
+		for _, value := range values {
+			if key := normalize(value); key != "" {
+				names[key] = true
+			}
+		}
+	}
+	add(place.Admin2, place.Admin2Code, place.Admin1, place.Admin1Code, place.Country, place.CountryCode)
+	if c := g.countries[place.CountryCode]; c != nil {
+		add(c.name, c.iso3)
+	}
+	return names
+}
+
+// PlaceID returns the ID of the canonical place of place, or "" if it does
+// not resolve.
+func (g *Gazetteer) PlaceID(place *types.GedcomPlace) string {
+	if resolution := g.Resolve(place); resolution.Resolved() {
+		return resolution.Place.ID
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+package gazetteer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Options controls how Standardize rewrites places.
+type Options struct {
+	// Format returns the PLAC value written for a canonical place. By
+	// default the place is written in the PLAC line's FORM, or else the
+	// tree's place form or DefaultForm (see Place.FormatForm).
+	Format func(place *Place) string
+
+	// AddCoordinates adds MAP.LATI/LONG with the canonical coordinates to
+	// rewritten places that have no MAP yet.
+	AddCoordinates bool
+}
+
+// DefaultOptions returns the default options: canonical places in the
+// place form, no coordinates added.
+func DefaultOptions() *Options {
+	return &Options{}
+}
+
+// Cluster groups unresolved place values that share the same normalized
+// most specific name, e.g. "Hjørring, Danmark" and "Hjorring, Denmark". The
+// values are likely variants of one place, to be reviewed or added to the
+// gazetteer.
+type Cluster struct {
+	Key      string   // Normalized most specific name
+	Variants []string // Distinct PLAC values, sorted
+	Count    int      // Number of PLAC lines with these values
+}
+
+// Report is the result of Standardize: how each distinct PLAC value of a
+// tree resolved. Rewrite applies it.
+type Report struct {
+	Resolved   map[string]*Resolution // Resolved PLAC values
+	Unresolved []*Cluster             // Unresolved PLAC values, largest clusters first
+
+	lines   map[string][]*types.GedcomLine
+	form    []string // The tree's place form
+	options *Options
+}
+
+// Standardize resolves every distinct PLAC value in the records of tree
+// (events, attributes and their substructures) against g. Places are parsed
+// with the header's place form and the line's own FORM. The tree is not
+// changed until Report.Rewrite is called. options may be nil for
+// DefaultOptions.
+func Standardize(tree *types.GedcomTree, g *Gazetteer, options *Options) *Report {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	report := &Report{
+		Resolved: make(map[string]*Resolution),
+		lines:    make(map[string][]*types.GedcomLine),
+		form:     tree.GetPlaceForm(),
+		options:  options,
+	}
+	for _, record := range tree.GetAllRecords() {
+		if record.Type() == types.RecordTypeHEAD {
+			continue // HEAD.PLAC holds the place form
+		}
+		collectPlaces(record.FirstLine(), report.lines)
+	}
+
+	clusters := make(map[string]*Cluster)
+	for value, lines := range report.lines {
+		place, err := types.ParsePlaceLine(lines[0], report.form)
+		if err != nil {
+			continue
+		}
+		if resolution := g.Resolve(place); resolution.Resolved() {
+			report.Resolved[value] = resolution
+			continue
+		}
+
+		key := normalize(place.Components[0])
+		cluster := clusters[key]
+		if cluster == nil {
+			cluster = &Cluster{Key: key}
+			clusters[key] = cluster
+		}
+		cluster.Variants = append(cluster.Variants, value)
+		cluster.Count += len(lines)
+	}
+
+	for _, cluster := range clusters {
+		sort.Strings(cluster.Variants)
+		report.Unresolved = append(report.Unresolved, cluster)
+	}
+	sort.Slice(report.Unresolved, func(i, j int) bool {
+		a, b := report.Unresolved[i], report.Unresolved[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Key < b.Key
+	})
+	return report
+}
+
+// collectPlaces adds the PLAC lines under line to places, by value.
+func collectPlaces(line *types.GedcomLine, places map[string][]*types.GedcomLine) {
+	for _, child := range line.ChildLines() {
+		if child.Tag == "PLAC" && strings.TrimSpace(child.Value) != "" {
+			places[child.Value] = append(places[child.Value], child)
+		}
+		collectPlaces(child, places)
+	}
+}
+
+// Rewrite sets every resolved PLAC line to the canonical value of its place
+// and, with Options.AddCoordinates, adds its coordinates. With a transaction
+// the edits are journaled and can be undone; tx may be nil to edit the lines
+// directly. It returns the number of PLAC lines changed.
+func (r *Report) Rewrite(tx *types.Transaction) (int, error) {
+	setValue := func(line *types.GedcomLine, selector, value string) error {
+		if tx != nil {
+			return tx.SetValue(line, selector, value)
+		}
+		line.SetValue(selector, value)
+		return nil
+	}
+
+	values := make([]string, 0, len(r.Resolved))
+	for value := range r.Resolved {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	changed := 0
+	for _, value := range values {
+		place := r.Resolved[value].Place
+		for _, line := range r.lines[value] {
+			canonical := r.canonical(place, line)
+			lineChanged := false
+			if line.Value != canonical {
+				if err := setValue(line, "", canonical); err != nil {
+					return changed, err
+				}
+				lineChanged = true
+			}
+			if r.options.AddCoordinates && len(line.GetLines("MAP")) == 0 {
+				if err := setValue(line, "MAP.LATI", types.FormatLatitude(place.Latitude)); err != nil {
+					return changed, err
+				}
+				if err := setValue(line, "MAP.LONG", types.FormatLongitude(place.Longitude)); err != nil {
+					return changed, err
+				}
+				lineChanged = true
+			}
+			if lineChanged {
+				changed++
+			}
+		}
+	}
+	return changed, nil
+}
+
+// canonical returns the value a PLAC line resolved to place is rewritten
+// to: Options.Format, or the place in the line's FORM, the tree's place form
+// or DefaultForm.
+func (r *Report) canonical(place *Place, line *types.GedcomLine) string {
+	if r.options.Format != nil {
+		return r.options.Format(place)
+	}
+	form := types.ParsePlaceForm(line.GetValue("FORM"))
+	if len(form) == 0 {
+		form = r.form
+	}
+	return place.FormatForm(form)
+}
@@ -0,0 +1,178 @@
+package gazetteer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+const standardizeInput = `0 HEAD
+1 PLAC
+2 FORM City, State, Country
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 PLAC St. Louis, MO
+1 DEAT
+2 PLAC Saint Louis, Missouri, USA
+3 MAP
+4 LATI N38.6
+4 LONG W90.2
+0 @I2@ INDI
+1 NAME Jane /Smith/
+1 BIRT
+2 PLAC St. Louis, MO
+1 RESI
+2 PLAC Hjørring, Danmark
+0 @I3@ INDI
+1 NAME Karen /Jensen/
+1 BIRT
+2 PLAC Hjorring, Danmark
+1 CHR
+2 PLAC Atlantis
+0 TRLR
+`
+
+func standardizeTestTree(t *testing.T) *types.GedcomTree {
+	t.Helper()
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(standardizeInput))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	return tree
+}
+
+func TestStandardize(t *testing.T) {
+	tree := standardizeTestTree(t)
+	report := Standardize(tree, testGazetteer(t), nil)
+
+	if len(report.Resolved) != 2 {
+		t.Fatalf("Resolved = %v, want 2 values", report.Resolved)
+	}
+	for _, value := range []string{"St. Louis, MO", "Saint Louis, Missouri, USA"} {
+		if resolution := report.Resolved[value]; !resolution.Resolved() || resolution.Place.ID != "4407066" {
+			t.Errorf("Resolved[%q] = %+v", value, resolution)
+		}
+	}
+
+	if len(report.Unresolved) != 2 {
+		t.Fatalf("Unresolved = %d clusters, want 2", len(report.Unresolved))
+	}
+	cluster := report.Unresolved[0]
+	if cluster.Key != "hjorring" || cluster.Count != 2 || len(cluster.Variants) != 2 {
+		t.Errorf("Unresolved[0] = %+v", cluster)
+	}
+	if cluster := report.Unresolved[1]; cluster.Key != "atlantis" || cluster.Count != 1 {
+		t.Errorf("Unresolved[1] = %+v", cluster)
+	}
+
+	// Nothing is changed before Rewrite
+	if got := tree.GetIndividual("@I1@").(*types.IndividualRecord).GetBirthPlace(); got != "St. Louis, MO" {
+		t.Errorf("birth place before Rewrite = %q", got)
+	}
+}
+
+func TestReportRewrite(t *testing.T) {
+	tree := standardizeTestTree(t)
+	report := Standardize(tree, testGazetteer(t), &Options{AddCoordinates: true})
+
+	changed, err := report.Rewrite(nil)
+	if err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+	if changed != 3 {
+		t.Errorf("Rewrite() = %d, want 3", changed)
+	}
+
+	// Written in the header's place form
+	want := "Saint Louis, Missouri, United States"
+	birth := tree.GetIndividual("@I1@").FirstLine().GetLines("BIRT")[0].GetLines("PLAC")[0]
+	if birth.Value != want {
+		t.Errorf("birth PLAC = %q, want %q", birth.Value, want)
+	}
+	if got := birth.GetValue("MAP.LATI") + " " + birth.GetValue("MAP.LONG"); got != "N38.62727 W90.19789" {
+		t.Errorf("birth MAP = %q", got)
+	}
+
+	// An existing MAP is kept
+	death := tree.GetIndividual("@I1@").FirstLine().GetLines("DEAT")[0].GetLines("PLAC")[0]
+	if death.Value != want || death.GetValue("MAP.LATI") != "N38.6" {
+		t.Errorf("death PLAC = %q, LATI %q", death.Value, death.GetValue("MAP.LATI"))
+	}
+
+	if got := tree.GetIndividual("@I3@").(*types.IndividualRecord).GetBirthPlace(); got != "Hjorring, Danmark" {
+		t.Errorf("unresolved place rewritten to %q", got)
+	}
+}
+
+func TestReportRewrite_PlaceForm(t *testing.T) {
+	input := `0 HEAD
+1 PLAC
+2 FORM City, County, State, Country
+0 @I1@ INDI
+1 BIRT
+2 PLAC Hjorring, , , Denmark
+1 DEAT
+2 PLAC Saint Louis, , Missouri, USA
+0 TRLR
+`
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	if _, err := Standardize(tree, testGazetteer(t), nil).Rewrite(nil); err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+
+	tests := []struct {
+		event                        string
+		city, county, state, country string
+	}{
+		{"BIRT", "Hjørring", "", "North Denmark", "Denmark"},
+		{"DEAT", "Saint Louis", "City of Saint Louis", "Missouri", "United States"},
+	}
+	for _, tt := range tests {
+		line := tree.GetIndividual("@I1@").FirstLine().GetLines(tt.event)[0].GetLines("PLAC")[0]
+		place, err := types.ParsePlaceLine(line, tree.GetPlaceForm())
+		if err != nil {
+			t.Fatalf("%s: ParsePlaceLine(%q) error = %v", tt.event, line.Value, err)
+		}
+		if place.City != tt.city || place.County != tt.county || place.State != tt.state || place.Country != tt.country {
+			t.Errorf("%s: %q parses as %q/%q/%q/%q", tt.event, line.Value, place.City, place.County, place.State, place.Country)
+		}
+	}
+}
+
+func TestReportRewriteTransaction(t *testing.T) {
+	tree := standardizeTestTree(t)
+	report := Standardize(tree, testGazetteer(t), &Options{
+		Format:         func(place *Place) string { return place.Name + ", " + place.Admin1Code },
+		AddCoordinates: true,
+	})
+
+	journal := types.NewJournal(tree)
+	tx, err := journal.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if _, err := report.Rewrite(tx); err != nil {
+		t.Fatalf("Rewrite() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	birth := tree.GetIndividual("@I2@").FirstLine().GetLines("BIRT")[0].GetLines("PLAC")[0]
+	if birth.Value != "Saint Louis, MO" || birth.GetValue("MAP.LATI") == "" {
+		t.Errorf("birth PLAC = %q, LATI %q", birth.Value, birth.GetValue("MAP.LATI"))
+	}
+
+	if err := journal.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if birth.Value != "St. Louis, MO" || len(birth.GetLines("MAP")) != 0 {
+		t.Errorf("after Undo: PLAC = %q, MAP = %v", birth.Value, birth.GetLines("MAP"))
+	}
+}
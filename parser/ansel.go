@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// anselSpacing maps ANSEL (ANSI Z39.47) spacing graphic characters in the
+// 0xA1-0xDF range to their Unicode equivalents. The table includes the
+// GEDCOM 5.5.1 extensions (0xBE, 0xBF, 0xCD-0xCF) on top of the MARC-8 set.
+var anselSpacing = map[byte]rune{
+	0xA1: 'Ł', // Latin capital letter L with stroke
+	0xA2: 'Ø', // Latin capital letter O with stroke
+	0xA3: 'Đ', // Latin capital letter D with stroke
+	0xA4: 'Þ', // Latin capital letter thorn
+	0xA5: 'Æ', // Latin capital letter AE
+	0xA6: 'Œ', // Latin capital ligature OE
+	0xA7: 'ʹ', // modifier letter prime (soft sign)
+	0xA8: '·', // middle dot
+	0xA9: '♭', // music flat sign
+	0xAA: '®', // registered sign
+	0xAB: '±', // plus-minus sign
+	0xAC: 'Ơ', // Latin capital letter O with horn
+	0xAD: 'Ư', // Latin capital letter U with horn
+	0xAE: 'ʼ', // modifier letter apostrophe (alif)
+	0xB0: 'ʻ', // modifier letter turned comma (ayn)
+	0xB1: 'ł', // Latin small letter l with stroke
+	0xB2: 'ø', // Latin small letter o with stroke
+	0xB3: 'đ', // Latin small letter d with stroke
+	0xB4: 'þ', // Latin small letter thorn
+	0xB5: 'æ', // Latin small letter ae
+	0xB6: 'œ', // Latin small ligature oe
+	0xB7: 'ʺ', // modifier letter double prime (hard sign)
+	0xB8: 'ı', // Latin small letter dotless i
+	0xB9: '£', // pound sign
+	0xBA: 'ð', // Latin small letter eth
+	0xBC: 'ơ', // Latin small letter o with horn
+	0xBD: 'ư', // Latin small letter u with horn
+	0xBE: '□', // white square (GEDCOM extension: empty box)
+	0xBF: '■', // black square (GEDCOM extension: black box)
+	0xC0: '°', // degree sign
+	0xC1: 'ℓ', // script small l
+	0xC2: '℗', // sound recording copyright
+	0xC3: '©', // copyright sign
+	0xC4: '♯', // music sharp sign
+	0xC5: '¿', // inverted question mark
+	0xC6: '¡', // inverted exclamation mark
+	0xC7: 'ß', // Latin small letter sharp s (MARC-8)
+	0xC8: '€', // euro sign (MARC-8)
+	0xCD: 'e', // midline e (GEDCOM extension)
+	0xCE: 'o', // midline o (GEDCOM extension)
+	0xCF: 'ß', // Latin small letter sharp s (GEDCOM extension)
+}
+
+// anselCombining maps ANSEL non-spacing (combining) characters in the
+// 0xE0-0xFE range to Unicode combining marks. In ANSEL these precede the
+// base character they modify; in Unicode they follow it.
+var anselCombining = map[byte]rune{
+	0xE0: '̉', // hook above
+	0xE1: '̀', // grave accent
+	0xE2: '́', // acute accent
+	0xE3: '̂', // circumflex accent
+	0xE4: '̃', // tilde
+	0xE5: '̄', // macron
+	0xE6: '̆', // breve
+	0xE7: '̇', // dot above
+	0xE8: '̈', // diaeresis (umlaut)
+	0xE9: '̌', // caron (hacek)
+	0xEA: '̊', // ring above
+	0xEB: '︠', // ligature, left half
+	0xEC: '︡', // ligature, right half
+	0xED: '̕', // comma above right
+	0xEE: '̋', // double acute accent
+	0xEF: '̐', // candrabindu
+	0xF0: '̧', // cedilla
+	0xF1: '̨', // ogonek (right hook)
+	0xF2: '̣', // dot below
+	0xF3: '̤', // diaeresis below
+	0xF4: '̥', // ring below
+	0xF5: '̳', // double low line
+	0xF6: '̲', // low line (underscore)
+	0xF7: '̦', // comma below
+	0xF8: '̜', // left half ring below
+	0xF9: '̮', // breve below
+	0xFA: '︢', // double tilde, left half
+	0xFB: '︣', // double tilde, right half
+	0xFE: '̓', // comma above
+}
+
+// isANSELCombining reports whether b is an ANSEL combining diacritic.
+func isANSELCombining(b byte) bool {
+	_, ok := anselCombining[b]
+	return ok
+}
+
+// decodeANSELByte converts a single non-combining ANSEL byte to a rune.
+// Unassigned code points are mapped to the Unicode replacement character.
+func decodeANSELByte(b byte) rune {
+	if b < 0x80 {
+		return rune(b)
+	}
+	if r, ok := anselSpacing[b]; ok {
+		return r
+	}
+	return utf8.RuneError
+}
+
+// anselDecoder is a transform.Transformer that converts ANSEL bytes to UTF-8.
+// Combining diacritics are moved after their base character so the output
+// is valid (decomposed) Unicode.
+type anselDecoder struct {
+	transform.NopResetter
+}
+
+// Transform implements transform.Transformer.
+func (anselDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		// Collect the run of combining marks preceding the base character
+		end := nSrc
+		for end < len(src) && isANSELCombining(src[end]) {
+			end++
+		}
+
+		// A run of marks cut off by the buffer boundary needs more input
+		if end == len(src) && end > nSrc && !atEOF {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+
+		// Marks that precede a line break (or EOF) have no base character;
+		// emit them after a no-break space so that they neither attach to
+		// the newline nor compose with the character before them
+		consumed := end - nSrc
+		var base rune = -1
+		if end < len(src) && src[end] != '\n' && src[end] != '\r' {
+			base = decodeANSELByte(src[end])
+			consumed++
+		} else if end > nSrc {
+			base = '\u00a0'
+		}
+
+		size := 0
+		if base >= 0 {
+			size += utf8.RuneLen(base)
+		}
+		for i := nSrc; i < end; i++ {
+			size += utf8.RuneLen(anselCombining[src[i]])
+		}
+		if size == 0 {
+			// Bare line break with no pending marks; copy it through below
+			size = 1
+		}
+		if nDst+size > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+
+		if base >= 0 {
+			nDst += utf8.EncodeRune(dst[nDst:], base)
+		}
+		for i := nSrc; i < end; i++ {
+			nDst += utf8.EncodeRune(dst[nDst:], anselCombining[src[i]])
+		}
+		if base < 0 && end == nSrc {
+			// Line break (no marks, no base): pass through unchanged
+			dst[nDst] = src[end]
+			nDst++
+			consumed = 1
+		}
+		nSrc += consumed
+	}
+	return nDst, nSrc, nil
+}
+
+// NewANSELReader wraps r so that ANSEL (ANSI Z39.47) encoded input is
+// decoded to UTF-8. Combining diacritics are reordered after their base
+// character and the result is normalized to NFC, so "\xE2e" (acute + e)
+// becomes the single precomposed rune 'é'.
+func NewANSELReader(r io.Reader) io.Reader {
+	return transform.NewReader(r, transform.Chain(anselDecoder{}, norm.NFC))
+}
+
+// DecodeANSEL decodes an ANSEL byte slice into an NFC-normalized UTF-8 string.
+func DecodeANSEL(data []byte) (string, error) {
+	out, _, err := transform.Bytes(transform.Chain(anselDecoder{}, norm.NFC), data)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+func TestDecodeANSEL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{"plain ASCII", []byte("John /Doe/"), "John /Doe/"},
+		{"acute before base", []byte{'R', 'e', 'n', 0xE2, 'e'}, "René"},
+		{"umlaut", []byte{'M', 0xE8, 'u', 'l', 'l', 'e', 'r'}, "Müller"},
+		{"cedilla", []byte{'F', 'r', 'a', 'n', 0xF0, 'c', 'o', 'i', 's'}, "François"},
+		{"spacing characters", []byte{0xA1, 'o', 'd', 0xB8}, "Łodı"},
+		{"stroke and ligature", []byte{0xB2, 0xB5}, "øæ"},
+		{"stacked diacritics", []byte{0xE3, 0xF2, 'a'}, "ậ"},
+		{"mark before newline", []byte{'a', 0xE2, '\n', 'b'}, "a\u00a0\u0301\nb"},
+		{"mark at EOF", []byte{'a', 0xE8}, "a\u00a0\u0308"},
+		{"unassigned byte", []byte{0x80}, "�"},
+		{"GEDCOM extensions", []byte{0xBE, 0xBF, 0xCF}, "□■ß"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeANSEL(tt.input)
+			if err != nil {
+				t.Fatalf("DecodeANSEL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeANSEL() = %+q, want %+q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewANSELReader_BufferBoundary(t *testing.T) {
+	// Place a combining mark exactly at a small read boundary to make sure
+	// the decoder waits for the base character instead of splitting them.
+	input := append(bytes.Repeat([]byte("x"), 4095), 0xE2, 'e')
+	got, err := io.ReadAll(NewANSELReader(&oneByteReader{data: input}))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !strings.HasSuffix(string(got), "xé") {
+		t.Errorf("expected output to end in %q, got %q", "xé", string(got[len(got)-8:]))
+	}
+}
+
+// oneByteReader returns input one byte at a time.
+type oneByteReader struct {
+	data []byte
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+func TestDeclaredEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Encoding
+	}{
+		{"ANSEL declared", "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR ANSEL\n0 TRLR\n", "ANSEL"},
+		{"CRLF terminators", "0 HEAD\r\n1 CHAR UTF-8\r\n0 TRLR\r\n", "UTF-8"},
+		{"CR terminators", "0 HEAD\r1 CHAR ANSEL\r0 TRLR\r", "ANSEL"},
+		{"no CHAR tag", "0 HEAD\n1 GEDC\n0 TRLR\n", ""},
+		{"CHAR outside header ignored", "0 HEAD\n0 @I1@ INDI\n1 CHAR ANSEL\n", ""},
+		{"nested CHAR ignored", "0 HEAD\n1 SOUR X\n2 CHAR ANSEL\n0 TRLR\n", ""},
+		{"no HEAD", "0 @I1@ INDI\n1 NAME X\n", ""},
+		{"UTF-8 BOM", "\xEF\xBB\xBF0 HEAD\n1 CHAR UTF-8\n", "UTF-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectDeclaredEncoding(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("DetectDeclaredEncoding() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectDeclaredEncoding() = %+q, want %+q", got, tt.want)
+			}
+		})
+	}
+}
+
+// anselTestFile builds a small ANSEL-encoded GEDCOM file and returns its path.
+func anselTestFile(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.WriteString("0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR ANSEL\n")
+	buf.WriteString("0 @I1@ INDI\n1 NAME Ren")
+	buf.Write([]byte{0xE2, 'e'})
+	buf.WriteString(" /M")
+	buf.Write([]byte{0xE8, 'u'})
+	buf.WriteString("ller/\n1 BIRT\n2 PLAC ")
+	buf.Write([]byte{0xA1, 'o', 'd', 0xE2, 'z'})
+	buf.WriteString("\n0 TRLR\n")
+
+	path := filepath.Join(t.TempDir(), "ansel.ged")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	return path
+}
+
+func TestDetectEncoding_ANSELFromHeader(t *testing.T) {
+	got, err := DetectEncoding(anselTestFile(t))
+	if err != nil {
+		t.Fatalf("DetectEncoding() error = %v", err)
+	}
+	if got != EncodingANSEL {
+		t.Errorf("DetectEncoding() = %q, want %q", got, EncodingANSEL)
+	}
+}
+
+func TestHierarchicalParser_ANSEL(t *testing.T) {
+	p := NewHierarchicalParser()
+	tree, err := p.Parse(anselTestFile(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if tree.GetEncoding() != string(EncodingANSEL) {
+		t.Errorf("tree encoding = %q, want %q", tree.GetEncoding(), EncodingANSEL)
+	}
+
+	indi, ok := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	if !ok {
+		t.Fatal("expected @I1@ to be an individual")
+	}
+	if got := indi.GetName(); got != "René /Müller/" {
+		t.Errorf("GetName() = %q, want %q", got, "René /Müller/")
+	}
+	if got := indi.GetBirthPlace(); got != "Łodź" {
+		t.Errorf("GetBirthPlace() = %q, want %q", got, "Łodź")
+	}
+}
+
+func TestStreamingParser_ANSEL(t *testing.T) {
+	iterator, err := NewRecordIterator(anselTestFile(t))
+	if err != nil {
+		t.Fatalf("NewRecordIterator() error = %v", err)
+	}
+	defer iterator.Close()
+
+	var name string
+	for iterator.Next() {
+		if indi, ok := iterator.Record().(*types.IndividualRecord); ok {
+			name = indi.GetName()
+		}
+	}
+	if iterator.Error() != nil {
+		t.Fatalf("iterator error = %v", iterator.Error())
+	}
+	if name != "René /Müller/" {
+		t.Errorf("GetName() = %q, want %q", name, "René /Müller/")
+	}
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// LineError describes why ParseLineFast rejected a line.
+type LineError struct {
+	Code    types.ErrorCode // types.CodeInvalidLevel or types.CodeMissingTag
+	Offset  int             // Byte offset of the problem in the trimmed line
+	Message string
+	Err     error // Underlying error, if any
+}
+
+// Error implements the error interface.
+func (e *LineError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// RecoveryPolicy decides what the parsers do with lines they cannot use as
+// written. Every problem is reported as a diagnostic whatever the policy.
+type RecoveryPolicy int
+
+const (
+	// RecoveryLenient skips lines that cannot be parsed (the default). Lines
+	// that skip levels (e.g. 3 under 1) are kept under the nearest parent with
+	// their level unchanged.
+	RecoveryLenient RecoveryPolicy = iota
+	// RecoveryStrict stops parsing at the first problem line. The parse
+	// returns the diagnostic, a *types.GedcomError, as its error.
+	RecoveryStrict
+	// RecoveryRepair fixes problem lines where the intent is clear and skips
+	// the others:
+	//   - a level jump (e.g. 3 under 1) is treated as a child of the nearest
+	//     valid parent, one level below it
+	//   - text without a level is joined to the previous line as CONT
+	//   - a CONC/CONT nested under another CONC/CONT continues the same value
+	RecoveryRepair
+)
+
+// String returns the policy name.
+func (p RecoveryPolicy) String() string {
+	switch p {
+	case RecoveryStrict:
+		return "strict"
+	case RecoveryRepair:
+		return "repair"
+	default:
+		return "lenient"
+	}
+}
+
+// ParseRecoveryPolicy returns the policy named "strict", "lenient" or "repair".
+func ParseRecoveryPolicy(name string) (RecoveryPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "strict":
+		return RecoveryStrict, nil
+	case "lenient", "":
+		return RecoveryLenient, nil
+	case "repair":
+		return RecoveryRepair, nil
+	}
+	return RecoveryLenient, fmt.Errorf("unknown recovery policy %q (use strict, lenient or repair)", name)
+}
+
+// SetRecoveryPolicy sets what the parser does with lines it cannot use as
+// written (see RecoveryPolicy). The default is RecoveryLenient.
+func (hp *HierarchicalParser) SetRecoveryPolicy(policy RecoveryPolicy) {
+	hp.recovery = policy
+}
+
+// SetRecoveryPolicy sets what the parser does with lines it cannot use as
+// written (see RecoveryPolicy). The default is RecoveryLenient.
+func (shp *StreamingHierarchicalParser) SetRecoveryPolicy(policy RecoveryPolicy) {
+	shp.recovery = policy
+}
+
+// SetRecoveryPolicy sets what the parser does with lines it cannot use as
+// written (see RecoveryPolicy). The default is RecoveryLenient.
+func (pp *ParallelHierarchicalParser) SetRecoveryPolicy(policy RecoveryPolicy) {
+	pp.recovery = policy
+}
+
+// report adds diag to em. In strict mode it returns diag to stop parsing.
+func (p RecoveryPolicy) report(em *types.ErrorManager, diag *types.GedcomError) error {
+	em.AddDiagnostic(diag)
+	if p == RecoveryStrict {
+		return diag
+	}
+	return nil
+}
+
+// lineDiagnostic creates a warning about line lineNumber, whose text as read
+// is raw. offset is the byte offset of the problem in the trimmed line.
+func lineDiagnostic(code types.ErrorCode, message string, lineNumber int, raw string, offset int, suggestion, context string) *types.GedcomError {
+	text := strings.TrimRight(raw, "\r\n")
+	indent := len(text) - len(strings.TrimLeft(text, " \t"))
+	return &types.GedcomError{
+		Severity:   types.SeverityWarning,
+		Message:    message,
+		LineNumber: lineNumber,
+		Context:    context,
+		Type:       code.Type(),
+		Code:       code,
+		Column:     indent + offset + 1,
+		Raw:        text,
+		Suggestion: suggestion,
+	}
+}
+
+// malformedLineDiagnostic reports a line ParseLineFast rejected with err.
+func malformedLineDiagnostic(err error, lineNumber int, raw string) *types.GedcomError {
+	code, offset := types.CodeInvalidLevel, 0
+	var lineErr *LineError
+	if errors.As(err, &lineErr) {
+		code, offset = lineErr.Code, lineErr.Offset
+	}
+
+	suggestion := "Start the line with a level number, or join it to the previous line with CONT"
+	if code == types.CodeMissingTag {
+		suggestion = "Add a tag after the level"
+	}
+	return lineDiagnostic(code, fmt.Sprintf("Malformed line: %v", err), lineNumber, raw, offset, suggestion, "Line Parsing")
+}
+
+// levelJumpDiagnostic reports a line at level under parent that skips levels.
+func levelJumpDiagnostic(level int, parent *types.GedcomLine, lineNumber int, raw string) *types.GedcomError {
+	return lineDiagnostic(types.CodeLevelJump,
+		fmt.Sprintf("Level jump: level %d line under level %d %s", level, parent.Level, parent.Tag),
+		lineNumber, raw, 0, fmt.Sprintf("Use level %d", parent.Level+1), "Hierarchy")
+}
+
+// orphanedLineDiagnostic reports a line that has no parent.
+func orphanedLineDiagnostic(err error, lineNumber int, raw string) *types.GedcomError {
+	return lineDiagnostic(types.CodeOrphanedLine, fmt.Sprintf("Orphaned line: %s", err.Error()),
+		lineNumber, raw, 0, "Start a record with a level 0 line before this line", "Hierarchy")
+}
+
+// continuationDiagnostic reports a CONC/CONT line rejected with err. last is
+// the continuation line before it.
+func continuationDiagnostic(err error, last *TagInfo, lineNumber int, raw string) *types.GedcomError {
+	suggestion := "Use the same level for all CONC and CONT lines continuing a value"
+	if last != nil {
+		suggestion = fmt.Sprintf("Use level %d, like the %s line before it", last.Level, last.Tag)
+	}
+	return lineDiagnostic(types.CodeInvalidContinuation, fmt.Sprintf("Invalid continuation: %v", err),
+		lineNumber, raw, 0, suggestion, "CONC/CONT Handling")
+}
@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+const problemFile = "0 HEAD\n" +
+	"1 GEDC\n" +
+	"2 VERS 5.5.1\n" +
+	"0 @I1@ INDI\n" +
+	"1 NAME John /Doe/\n" +
+	"1 BIRT\n" +
+	"  3 DATE 1 JAN 1900\n" +
+	"1 NOTE first\n" +
+	"2 CONT second\n" +
+	"3 CONC ,third\n" +
+	"continued text\n" +
+	"1 @X1@\n" +
+	"0 TRLR\n"
+
+func TestParseLineFast_LineError(t *testing.T) {
+	tests := []struct {
+		line   string
+		code   types.ErrorCode
+		offset int
+	}{
+		{"text without level", types.CodeInvalidLevel, 0},
+		{"-1 NAME x", types.CodeInvalidLevel, 0},
+		{"word", types.CodeInvalidLevel, 0},
+		{"1", types.CodeMissingTag, 1},
+		{"0 @I1@", types.CodeMissingTag, 6},
+	}
+
+	for _, tt := range tests {
+		_, _, _, _, err := ParseLineFast(tt.line)
+		var lineErr *LineError
+		if !errors.As(err, &lineErr) {
+			t.Errorf("ParseLineFast(%q) error = %v, want a *LineError", tt.line, err)
+			continue
+		}
+		if lineErr.Code != tt.code || lineErr.Offset != tt.offset {
+			t.Errorf("ParseLineFast(%q) = %s at %d, want %s at %d", tt.line, lineErr.Code, lineErr.Offset, tt.code, tt.offset)
+		}
+	}
+}
+
+func TestParseRecoveryPolicy(t *testing.T) {
+	for _, policy := range []RecoveryPolicy{RecoveryLenient, RecoveryStrict, RecoveryRepair} {
+		got, err := ParseRecoveryPolicy(strings.ToUpper(policy.String()))
+		if err != nil || got != policy {
+			t.Errorf("ParseRecoveryPolicy(%q) = %v, %v", policy.String(), got, err)
+		}
+	}
+	if _, err := ParseRecoveryPolicy("forgiving"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+func parseWithPolicy(t *testing.T, policy RecoveryPolicy) (*types.GedcomTree, *HierarchicalParser, error) {
+	t.Helper()
+	p := NewHierarchicalParser()
+	p.SetRecoveryPolicy(policy)
+	tree, err := p.ParseReader(strings.NewReader(problemFile))
+	return tree, p, err
+}
+
+func TestHierarchicalParser_RecoveryLenient(t *testing.T) {
+	tree, p, err := parseWithPolicy(t, RecoveryLenient)
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	var codes []string
+	for _, e := range p.GetErrors() {
+		codes = append(codes, string(e.Code))
+	}
+	want := "level-jump invalid-continuation invalid-level missing-tag"
+	if got := strings.Join(codes, " "); got != want {
+		t.Fatalf("codes = %q, want %q", got, want)
+	}
+
+	jump := p.GetErrorManager().GetErrorsByCode(types.CodeLevelJump)[0]
+	if jump.LineNumber != 7 || jump.Column != 3 || jump.Raw != "  3 DATE 1 JAN 1900" || jump.Suggestion != "Use level 2" {
+		t.Errorf("level jump = %+v", *jump)
+	}
+	missing := p.GetErrorManager().GetErrorsByCode(types.CodeMissingTag)[0]
+	if missing.LineNumber != 12 || missing.Column != 7 {
+		t.Errorf("missing tag at %d:%d, want 12:7", missing.LineNumber, missing.Column)
+	}
+
+	// Lines that skip levels are kept as they are; unparseable ones are skipped
+	indi := tree.GetIndividual("@I1@")
+	if date := indi.GetLines("BIRT.DATE"); len(date) != 1 || date[0].Level != 3 {
+		t.Errorf("BIRT.DATE = %v, want one line at level 3", date)
+	}
+	if got := indi.GetValue("NOTE"); got != "first\nsecond" {
+		t.Errorf("NOTE = %q, want %q", got, "first\nsecond")
+	}
+}
+
+func TestHierarchicalParser_RecoveryRepair(t *testing.T) {
+	tree, p, err := parseWithPolicy(t, RecoveryRepair)
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	if n := len(p.GetErrors()); n != 4 {
+		t.Errorf("got %d diagnostics, want 4", n)
+	}
+
+	indi := tree.GetIndividual("@I1@")
+	if date := indi.GetLines("BIRT.DATE"); len(date) != 1 || date[0].Level != 2 {
+		t.Errorf("BIRT.DATE = %v, want one line at level 2", date)
+	}
+	if got := indi.GetValue("NOTE"); got != "first\nsecond,third\ncontinued text" {
+		t.Errorf("NOTE = %q", got)
+	}
+	if msg := p.GetErrorManager().GetErrorsByCode(types.CodeLevelJump)[0].Message; !strings.HasSuffix(msg, "treated as level 2") {
+		t.Errorf("level jump message = %q", msg)
+	}
+}
+
+func TestHierarchicalParser_RecoveryStrict(t *testing.T) {
+	tree, p, err := parseWithPolicy(t, RecoveryStrict)
+	if tree != nil {
+		t.Error("expected no tree in strict mode")
+	}
+	var diag *types.GedcomError
+	if !errors.As(err, &diag) {
+		t.Fatalf("ParseReader() error = %v, want a *types.GedcomError", err)
+	}
+	if diag.Code != types.CodeLevelJump || diag.LineNumber != 7 {
+		t.Errorf("error = %v, want level-jump on line 7", diag)
+	}
+	if len(p.GetErrors()) != 1 {
+		t.Errorf("got %d diagnostics, want parsing to stop at the first", len(p.GetErrors()))
+	}
+	if got := diag.Error(); got != "warning[level-jump]: Level jump: level 3 line under level 1 BIRT (Line 7, Column 3)" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestStreamingHierarchicalParser_Recovery(t *testing.T) {
+	shp := NewStreamingHierarchicalParser()
+	shp.SetRecoveryPolicy(RecoveryRepair)
+	var indi types.Record
+	err := shp.ParseWithHandlerReader(strings.NewReader(problemFile), func(record types.Record) error {
+		if record.XrefID() == "@I1@" {
+			indi = record
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseWithHandlerReader() error = %v", err)
+	}
+	if got := indi.GetValue("NOTE"); got != "first\nsecond,third\ncontinued text" {
+		t.Errorf("NOTE = %q", got)
+	}
+
+	shp = NewStreamingHierarchicalParser()
+	shp.SetRecoveryPolicy(RecoveryStrict)
+	err = shp.ParseWithHandlerReader(strings.NewReader(problemFile), func(types.Record) error { return nil })
+	var diag *types.GedcomError
+	if !errors.As(err, &diag) || diag.Code != types.CodeLevelJump {
+		t.Errorf("strict error = %v, want level-jump", err)
+	}
+}
+
+func TestParallelHierarchicalParser_RecoveryStrict(t *testing.T) {
+	path := writeIndexedFile(t, largeGedcom7())
+
+	pp := NewParallelHierarchicalParser()
+	pp.SetWorkers(4)
+	pp.SetRecoveryPolicy(RecoveryStrict)
+	_, err := pp.Parse(path)
+
+	var diag *types.GedcomError
+	if !errors.As(err, &diag) {
+		t.Fatalf("Parse() error = %v, want a *types.GedcomError", err)
+	}
+	// The first problem line, counted from the start of the file
+	if diag.Code != types.CodeInvalidLevel || diag.LineNumber != 8006 || diag.Raw != "not a gedcom line" {
+		t.Errorf("error = %+v", *diag)
+	}
+}
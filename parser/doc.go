@@ -17,7 +17,7 @@
 //
 //   - Automatic optimization: Parallel processing auto-enabled for files >= 32KB
 //   - Hierarchical parsing: Builds complete parent-child relationships
-//   - Encoding detection: Supports UTF-8, UTF-16 (with BOM), and ANSEL
+//   - Encoding detection: Supports UTF-8, UTF-16, ANSEL, ANSI (Windows-1252), ISO-8859-1 and MacRoman
 //   - Continuation handling: Processes CONC (concatenate) and CONT (continue) lines
 //   - Error recovery: Continues parsing after non-fatal errors
 //   - Line validation: Validates line format and structure
@@ -90,6 +90,15 @@
 //		}
 //	}
 //
+// # Parsing from a Reader
+//
+// Every parser also accepts an io.Reader, for input that has no file path
+// (stdin, HTTP upload bodies, zip entries, in-memory buffers):
+//
+//	tree, err := parser.NewParser().ParseReader(os.Stdin)
+//	err = sp.ParseWithHandlerReader(resp.Body, handler)
+//	iterator, err := parser.NewRecordIteratorFromReader(bytes.NewReader(data))
+//
 // # Algorithm
 //
 // The parser uses a stack-based algorithm to build hierarchical relationships:
@@ -1,10 +1,16 @@
 package parser
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
 )
 
 // Encoding represents the character encoding of a GEDCOM file
@@ -16,18 +22,35 @@ const (
 	EncodingANSEL Encoding = "ANSEL"
 	EncodingASCII Encoding = "ASCII"
 	EncodingANSI  Encoding = "ANSI"
+
+	// EncodingUTF16LE and EncodingUTF16BE identify BOM-less UTF-16 input
+	// whose byte order was inferred from the data.
+	EncodingUTF16LE Encoding = "UTF-16LE"
+	EncodingUTF16BE Encoding = "UTF-16BE"
+
+	// EncodingLatin1 is ISO 8859-1.
+	EncodingLatin1 Encoding = "ISO-8859-1"
+
+	// EncodingMacRoman is the classic Mac OS Roman character set.
+	EncodingMacRoman Encoding = "MACINTOSH"
 )
 
-// DetectEncoding detects the character encoding of a GEDCOM file by reading the BOM.
+// headerPeekSize is the number of bytes read from the start of a file when
+// looking for the HEAD.CHAR declaration. Headers are normally a few hundred
+// bytes; the limit only guards against scanning a huge file with no HEAD.
+const headerPeekSize = 64 * 1024
+
+// DetectEncoding detects the character encoding of a GEDCOM file from its BOM
+// and, when no BOM is present, from the CHAR tag in the header.
 //
 // Detection order:
 // 1. Check for UTF-8 BOM (EF BB BF)
 // 2. Check for UTF-16 BE BOM (FE FF)
 // 3. Check for UTF-16 LE BOM (FF FE)
-// 4. Default to UTF-8 if no BOM found
-//
-// Note: ANSEL detection requires reading the CHAR tag from the header,
-// which will be done during parsing. This function only detects BOM-based encodings.
+// 4. Check for BOM-less UTF-16 ("0\x00 \x00" or "\x000\x00 ")
+// 5. Check the HEAD.CHAR declaration for an 8-bit encoding
+//    (ANSEL, ANSI/Windows-1252, ISO-8859-1, MacRoman)
+// 6. Default to UTF-8
 func DetectEncoding(filePath string) (Encoding, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -35,36 +58,132 @@ func DetectEncoding(filePath string) (Encoding, error) {
 	}
 	defer file.Close()
 
-	// Read first 4 bytes to check for BOM
-	bom := make([]byte, 4)
-	n, err := file.Read(bom)
-	if err != nil && err != io.EOF {
+	// Read the start of the file to check for a BOM and the header
+	peek := make([]byte, headerPeekSize)
+	n, err := io.ReadFull(file, peek)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return "", fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return DetectEncodingBytes(peek[:n]), nil
+}
+
+// DetectEncodingBytes applies the DetectEncoding rules to the first bytes of
+// a GEDCOM stream. data should hold at least the whole HEAD record for the
+// CHAR declaration to be found; 64KB is plenty in practice.
+func DetectEncodingBytes(data []byte) Encoding {
+	n := len(data)
 	if n < 2 {
-		// File too short, default to UTF-8
-		return EncodingUTF8, nil
+		// Input too short, default to UTF-8
+		return EncodingUTF8
 	}
 
 	// Check for UTF-8 BOM (EF BB BF)
-	if n >= 3 && bom[0] == 0xEF && bom[1] == 0xBB && bom[2] == 0xBF {
-		return EncodingUTF8, nil
+	if n >= 3 && data[0] == 0xEF && data[1] == 0xBB && data[2] == 0xBF {
+		return EncodingUTF8
 	}
 
 	// Check for UTF-16 BE BOM (FE FF)
-	if bom[0] == 0xFE && bom[1] == 0xFF {
-		return EncodingUTF16, nil
+	if data[0] == 0xFE && data[1] == 0xFF {
+		return EncodingUTF16
 	}
 
 	// Check for UTF-16 LE BOM (FF FE)
-	if bom[0] == 0xFF && bom[1] == 0xFE {
-		return EncodingUTF16, nil
+	if data[0] == 0xFF && data[1] == 0xFE {
+		return EncodingUTF16
+	}
+
+	// No BOM: a GEDCOM file starts with "0 HEAD", so UTF-16 shows up as
+	// alternating NUL bytes around the leading "0"
+	if n >= 4 && data[0] == '0' && data[1] == 0x00 && data[3] == 0x00 {
+		return EncodingUTF16LE
+	}
+	if n >= 4 && data[0] == 0x00 && data[1] == '0' && data[2] == 0x00 {
+		return EncodingUTF16BE
+	}
+
+	// 8-bit encodings are ASCII-compatible, so the header can be read
+	// as-is to find the CHAR declaration
+	switch declared := normalizeEncoding(declaredEncoding(data)); declared {
+	case EncodingANSEL, EncodingANSI, EncodingLatin1, EncodingMacRoman:
+		return declared
 	}
 
-	// No BOM detected, default to UTF-8
+	// Default to UTF-8
 	// (GEDCOM 5.5.1 spec says ANSEL is primary, but UTF-8 is most common in practice)
-	return EncodingUTF8, nil
+	return EncodingUTF8
+}
+
+// peekInput buffers r and returns up to the first 64KB without consuming them,
+// for encoding detection on streams that cannot be re-opened. Empty input is
+// an error, matching ValidateFile.
+func peekInput(r io.Reader) (*bufio.Reader, []byte, error) {
+	br := bufio.NewReaderSize(r, headerPeekSize)
+	peek, err := br.Peek(headerPeekSize)
+	if err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if len(peek) == 0 {
+		return nil, nil, fmt.Errorf("input is empty")
+	}
+	return br, peek, nil
+}
+
+// DetectDeclaredEncoding reads the GEDCOM header from r and returns the value
+// of its HEAD.CHAR tag, or an empty Encoding if the header declares none.
+// Only the first 64KB of r are examined.
+func DetectDeclaredEncoding(r io.Reader) (Encoding, error) {
+	data, err := io.ReadAll(io.LimitReader(r, headerPeekSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read header: %w", err)
+	}
+	return declaredEncoding(data), nil
+}
+
+// declaredEncoding scans the HEAD record in data for a level-1 CHAR line.
+// A leading UTF-8 BOM is ignored. Scanning stops at the next level-0 line.
+func declaredEncoding(data []byte) Encoding {
+	if len(data) >= 3 && data[0] == 0xEF && data[1] == 0xBB && data[2] == 0xBF {
+		data = data[3:]
+	}
+
+	inHeader := false
+	for len(data) > 0 {
+		// Split off the next line (GEDCOM allows CR, LF or CRLF terminators)
+		end := 0
+		for end < len(data) && data[end] != '\n' && data[end] != '\r' {
+			end++
+		}
+		line := strings.TrimSpace(string(data[:end]))
+		data = data[end:]
+		for len(data) > 0 && (data[0] == '\n' || data[0] == '\r') {
+			data = data[1:]
+		}
+
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if fields[0] == "0" {
+			if inHeader {
+				// End of HEAD record
+				return ""
+			}
+			if len(fields) < 2 || fields[1] != "HEAD" {
+				// First record is not HEAD; nothing to find
+				return ""
+			}
+			inHeader = true
+			continue
+		}
+
+		if inHeader && fields[0] == "1" && len(fields) >= 3 && fields[1] == "CHAR" {
+			return Encoding(fields[2])
+		}
+	}
+
+	return ""
 }
 
 // GetReader returns an appropriate reader for the given encoding.
@@ -91,25 +210,51 @@ func GetReader(file *os.File, encoding Encoding) (io.Reader, error) {
 		}
 		return file, nil
 
+	default:
+		return NewDecodingReader(file, encoding)
+	}
+}
+
+// NewDecodingReader wraps r so that input in the given encoding is decoded
+// to UTF-8. A leading BOM is consumed. Unlike GetReader, r need not be seekable.
+func NewDecodingReader(r io.Reader, encoding Encoding) (io.Reader, error) {
+	switch encoding {
+	case EncodingUTF8, EncodingASCII:
+		// ASCII is subset of UTF-8; only a UTF-8 BOM needs removing
+		br, ok := r.(*bufio.Reader)
+		if !ok {
+			br = bufio.NewReader(r)
+		}
+		if bom, err := br.Peek(3); err == nil && bom[0] == 0xEF && bom[1] == 0xBB && bom[2] == 0xBF {
+			br.Discard(3)
+		}
+		return br, nil
+
 	case EncodingUTF16:
-		// UTF-16 requires special handling - use binary reader
-		// For now, we'll use a simple approach and let Go's encoding handle it
-		// This is a placeholder - full UTF-16 support would need proper decoder
-		return file, nil
+		// The BOM selects the byte order (and is consumed); little-endian is
+		// assumed if it is missing
+		decoder := unicode.BOMOverride(unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder())
+		return transform.NewReader(r, decoder), nil
 
-	case EncodingANSEL:
-		// ANSEL requires special decoder (not implemented yet)
-		// For now, treat as UTF-8 and warn
-		return file, nil
+	case EncodingUTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder().Reader(r), nil
 
-	case EncodingASCII:
-		// ASCII is subset of UTF-8, can use UTF-8 reader
-		return file, nil
+	case EncodingUTF16BE:
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder().Reader(r), nil
+
+	case EncodingANSEL:
+		// ANSEL has no BOM; decode to NFC UTF-8
+		return NewANSELReader(r), nil
 
 	case EncodingANSI:
-		// ANSI (Windows-1252) requires special handling
-		// For now, treat as UTF-8 and warn
-		return file, nil
+		// GEDCOM "ANSI" is Windows-1252 in practice
+		return charmap.Windows1252.NewDecoder().Reader(r), nil
+
+	case EncodingLatin1:
+		return charmap.ISO8859_1.NewDecoder().Reader(r), nil
+
+	case EncodingMacRoman:
+		return charmap.Macintosh.NewDecoder().Reader(r), nil
 
 	default:
 		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
@@ -128,13 +273,79 @@ func ValidateEncoding(detected Encoding, declared Encoding) error {
 	normalizedDetected := normalizeEncoding(detected)
 	normalizedDeclared := normalizeEncoding(declared)
 
-	if normalizedDetected != normalizedDeclared {
+	if normalizedDetected != normalizedDeclared && !compatibleEncodings(normalizedDetected, normalizedDeclared) {
 		return fmt.Errorf("encoding mismatch: detected %s, declared %s", detected, declared)
 	}
 
 	return nil
 }
 
+// compatibleEncodings reports whether text declared as one encoding decodes
+// identically with the other. ASCII is a strict subset of UTF-8.
+func compatibleEncodings(a, b Encoding) bool {
+	return (a == EncodingASCII && b == EncodingUTF8) || (a == EncodingUTF8 && b == EncodingASCII)
+}
+
+// isUTF16 reports whether enc is any UTF-16 variant.
+func isUTF16(enc Encoding) bool {
+	return normalizeEncoding(enc) == EncodingUTF16
+}
+
+// encodingRestartError signals that the header declared an encoding other
+// than the one the stream was opened with, and the input must be re-read.
+// The diagnostics of the aborted attempt are discarded and notice is reported
+// instead.
+type encodingRestartError struct {
+	encoding Encoding
+	notice   *types.GedcomError
+}
+
+func (e *encodingRestartError) Error() string {
+	return fmt.Sprintf("input must be re-read as %s", e.encoding)
+}
+
+// checkDeclaredEncoding compares the HEAD.CHAR declaration with the encoding
+// the stream is being decoded with and reports any mismatch to em.
+//
+// A BOM is authoritative, so when hasBOM is set only a warning is recorded.
+// Otherwise, if the declared encoding has a decoder and canReopen is set, an
+// *encodingRestartError is returned so the caller can re-open the stream with
+// it; nothing is recorded then, the restart carries its own notice. UTF-16 is never
+// switched to or from: if the CHAR line could be read at all, the byte width
+// was already right.
+func checkDeclaredEncoding(em *types.ErrorManager, active Encoding, hasBOM, canReopen bool, declared Encoding, lineNumber int) *encodingRestartError {
+	if err := ValidateEncoding(active, declared); err == nil {
+		return nil
+	}
+
+	normalized := normalizeEncoding(declared)
+	if hasBOM || isUTF16(active) || isUTF16(normalized) {
+		em.AddCodedError(types.CodeEncodingMismatch, types.SeverityWarning, fmt.Sprintf("Encoding mismatch: file decoded as %s but header declares CHAR %s", active, declared), lineNumber, "Encoding")
+		return nil
+	}
+
+	switch normalized {
+	case EncodingUTF8, EncodingASCII, EncodingANSEL, EncodingANSI, EncodingLatin1, EncodingMacRoman:
+		if !canReopen {
+			em.AddCodedError(types.CodeEncodingMismatch, types.SeverityWarning, fmt.Sprintf("Encoding mismatch: stream decoded as %s but header declares CHAR %s; stream cannot be re-read", active, declared), lineNumber, "Encoding")
+			return nil
+		}
+		return &encodingRestartError{
+			encoding: normalized,
+			notice: &types.GedcomError{
+				Severity:   types.SeverityInfo,
+				Message:    fmt.Sprintf("Encoding mismatch: file decoded as %s but header declares CHAR %s; re-reading as %s", active, declared, normalized),
+				LineNumber: lineNumber,
+				Context:    "Encoding",
+				Code:       types.CodeEncodingMismatch,
+			},
+		}
+	default:
+		em.AddCodedError(types.CodeUnsupportedEncoding, types.SeverityWarning, fmt.Sprintf("Unsupported encoding declared: CHAR %s; decoding as %s", declared, active), lineNumber, "Encoding")
+		return nil
+	}
+}
+
 // normalizeEncoding normalizes encoding names for comparison (case-insensitive)
 func normalizeEncoding(enc Encoding) Encoding {
 	// Convert to uppercase for case-insensitive comparison
@@ -143,14 +354,18 @@ func normalizeEncoding(enc Encoding) Encoding {
 	switch upper {
 	case "UTF-8", "UTF8":
 		return EncodingUTF8
-	case "UTF-16", "UTF16", "UNICODE":
+	case "UTF-16", "UTF16", "UNICODE", "UTF-16LE", "UTF-16BE":
 		return EncodingUTF16
 	case "ANSEL":
 		return EncodingANSEL
 	case "ASCII":
 		return EncodingASCII
-	case "ANSI", "WINDOWS-1252":
+	case "ANSI", "WINDOWS-1252", "CP1252":
 		return EncodingANSI
+	case "ISO-8859-1", "ISO8859-1", "LATIN1", "LATIN-1":
+		return EncodingLatin1
+	case "MACINTOSH", "MACROMAN", "MAC-ROMAN":
+		return EncodingMacRoman
 	default:
 		return enc
 	}
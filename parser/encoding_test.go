@@ -2,10 +2,15 @@ package parser
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"unicode/utf16"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
 func TestDetectEncoding(t *testing.T) {
@@ -414,3 +419,265 @@ func contains(s, substr string) bool {
 		 bytes.Contains([]byte(s), []byte(substr))))
 }
 
+
+// utf16Bytes encodes s as UTF-16 with the given byte order, optionally with a BOM.
+func utf16Bytes(s string, bigEndian, withBOM bool) []byte {
+	var out []byte
+	put := func(u uint16) {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	if withBOM {
+		put(0xFEFF)
+	}
+	for _, u := range utf16.Encode([]rune(s)) {
+		put(u)
+	}
+	return out
+}
+
+func TestDetectEncoding_Transcoded(t *testing.T) {
+	tmpDir := t.TempDir()
+	gedcom := "0 HEAD\n1 CHAR UNICODE\n0 TRLR\n"
+
+	tests := []struct {
+		name     string
+		fileData []byte
+		want     Encoding
+	}{
+		{"UTF-16 LE without BOM", utf16Bytes(gedcom, false, false), EncodingUTF16LE},
+		{"UTF-16 BE without BOM", utf16Bytes(gedcom, true, false), EncodingUTF16BE},
+		{"ANSI declared", []byte("0 HEAD\n1 CHAR ANSI\n0 TRLR\n"), EncodingANSI},
+		{"Windows-1252 declared", []byte("0 HEAD\n1 CHAR windows-1252\n0 TRLR\n"), EncodingANSI},
+		{"Latin-1 declared", []byte("0 HEAD\n1 CHAR ISO-8859-1\n0 TRLR\n"), EncodingLatin1},
+		{"MacRoman declared", []byte("0 HEAD\n1 CHAR MACINTOSH\n0 TRLR\n"), EncodingMacRoman},
+		{"UTF-16 declared without BOM stays UTF-8", []byte("0 HEAD\n1 CHAR UNICODE\n0 TRLR\n"), EncodingUTF8},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(tmpDir, fmt.Sprintf("transcoded_%d.ged", i))
+			if err := os.WriteFile(tmpFile, tt.fileData, 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			got, err := DetectEncoding(tmpFile)
+			if err != nil {
+				t.Fatalf("DetectEncoding() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectEncoding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetReader_Transcoding(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		fileData []byte
+		encoding Encoding
+		want     string
+	}{
+		{"UTF-16 LE with BOM", utf16Bytes("1 NAME Zoë", false, true), EncodingUTF16, "1 NAME Zoë"},
+		{"UTF-16 BE with BOM", utf16Bytes("1 NAME Zoë", true, true), EncodingUTF16, "1 NAME Zoë"},
+		{"UTF-16 LE without BOM", utf16Bytes("1 NAME Zoë", false, false), EncodingUTF16LE, "1 NAME Zoë"},
+		{"UTF-16 BE without BOM", utf16Bytes("1 NAME Zoë", true, false), EncodingUTF16BE, "1 NAME Zoë"},
+		{"Windows-1252", []byte("1 NAME Ren\xe9 \x80 \x9c"), EncodingANSI, "1 NAME René € œ"},
+		{"Latin-1", []byte("1 NAME Ren\xe9 \xdf"), EncodingLatin1, "1 NAME René ß"},
+		{"MacRoman", []byte("1 NAME Ren\x8e \x9a"), EncodingMacRoman, "1 NAME René ö"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(tmpDir, fmt.Sprintf("reader_%d.ged", i))
+			if err := os.WriteFile(tmpFile, tt.fileData, 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			file, err := os.Open(tmpFile)
+			if err != nil {
+				t.Fatalf("failed to open file: %v", err)
+			}
+			defer file.Close()
+
+			reader, err := GetReader(file, tt.encoding)
+			if err != nil {
+				t.Fatalf("GetReader() error = %v", err)
+			}
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("decoded = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEncoding_ASCIICompatible(t *testing.T) {
+	if err := ValidateEncoding(EncodingUTF8, EncodingASCII); err != nil {
+		t.Errorf("ValidateEncoding(UTF-8, ASCII) = %v, want nil", err)
+	}
+	if err := ValidateEncoding(EncodingUTF16LE, "UNICODE"); err != nil {
+		t.Errorf("ValidateEncoding(UTF-16LE, UNICODE) = %v, want nil", err)
+	}
+}
+
+func TestHierarchicalParser_UTF16(t *testing.T) {
+	content := "0 HEAD\n1 CHAR UNICODE\n0 @I1@ INDI\n1 NAME Zoë /Brontë/\n0 TRLR\n"
+	for _, bigEndian := range []bool{false, true} {
+		tmpFile := filepath.Join(t.TempDir(), "utf16.ged")
+		if err := os.WriteFile(tmpFile, utf16Bytes(content, bigEndian, true), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+
+		p := NewHierarchicalParser()
+		tree, err := p.Parse(tmpFile)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		indi, ok := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+		if !ok {
+			t.Fatal("expected @I1@ to be an individual")
+		}
+		if got := indi.GetName(); got != "Zoë /Brontë/" {
+			t.Errorf("bigEndian=%v: GetName() = %q, want %q", bigEndian, got, "Zoë /Brontë/")
+		}
+		if p.HasErrors() {
+			t.Errorf("bigEndian=%v: unexpected errors: %v", bigEndian, p.GetErrors())
+		}
+	}
+}
+
+// largeHeaderANSIFile writes a Windows-1252 file whose CHAR declaration lies
+// beyond the detection window, so only the parser's header check can find it.
+// A level jump before the CHAR line gives one diagnostic in the header.
+func largeHeaderANSIFile(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.WriteString("0 HEAD\n1 SOUR test\n3 _JUMP x\n1 NOTE start\n")
+	for buf.Len() < headerPeekSize+1024 {
+		buf.WriteString("2 CONT ")
+		buf.WriteString(strings.Repeat("x", 100))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("1 CHAR ANSI\n0 @I1@ INDI\n1 NAME Ren\xe9 /Dup\xe9/\n0 TRLR\n")
+
+	tmpFile := filepath.Join(t.TempDir(), "ansi.ged")
+	if err := os.WriteFile(tmpFile, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	return tmpFile
+}
+
+func TestHierarchicalParser_ReopensWithDeclaredEncoding(t *testing.T) {
+	tmpFile := largeHeaderANSIFile(t)
+
+	p := NewHierarchicalParser()
+	tree, err := p.Parse(tmpFile)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if tree.GetEncoding() != string(EncodingANSI) {
+		t.Errorf("tree encoding = %q, want %q", tree.GetEncoding(), EncodingANSI)
+	}
+	indi, ok := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	if !ok {
+		t.Fatal("expected @I1@ to be an individual")
+	}
+	if got := indi.GetName(); got != "René /Dupé/" {
+		t.Errorf("GetName() = %q, want %q", got, "René /Dupé/")
+	}
+	if n := len(tree.GetAllIndividuals()); n != 1 {
+		t.Errorf("expected 1 individual after re-read, got %d", n)
+	}
+
+	// The mismatch is reported once, on the CHAR line
+	var found *types.GedcomError
+	for _, e := range p.GetErrors() {
+		if e.Context == "Encoding" {
+			if found != nil {
+				t.Errorf("expected a single encoding diagnostic, got another: %v", e)
+			}
+			found = e
+		}
+	}
+	if found == nil {
+		t.Fatal("expected an encoding mismatch diagnostic")
+	}
+	if found.LineNumber == 0 || !strings.Contains(found.Message, "ANSI") {
+		t.Errorf("unexpected diagnostic: %v", found)
+	}
+
+	// Diagnostics of the aborted first pass are not reported again
+	if n := len(p.GetErrorManager().GetErrorsByCode(types.CodeLevelJump)); n != 1 {
+		t.Errorf("expected the level jump to be reported once, got %d", n)
+	}
+}
+
+func TestStreamingParser_ReopensWithDeclaredEncoding(t *testing.T) {
+	p := NewStreamingHierarchicalParser()
+	var names []string
+	err := p.ParseWithHandler(largeHeaderANSIFile(t), func(record types.Record) error {
+		if indi, ok := record.(*types.IndividualRecord); ok {
+			names = append(names, indi.GetName())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseWithHandler() error = %v", err)
+	}
+	if len(names) != 1 || names[0] != "René /Dupé/" {
+		t.Errorf("names = %q, want [%q]", names, "René /Dupé/")
+	}
+	if n := len(p.GetErrorManager().GetErrorsByCode(types.CodeLevelJump)); n != 1 {
+		t.Errorf("expected the level jump to be reported once, got %d", n)
+	}
+	if n := len(p.GetErrorManager().GetErrorsByCode(types.CodeEncodingMismatch)); n != 1 {
+		t.Errorf("expected the re-read to be reported once, got %d", n)
+	}
+}
+
+func TestParallelParser_ReopensWithDeclaredEncoding(t *testing.T) {
+	p := NewParallelHierarchicalParser()
+	tree, err := p.Parse(largeHeaderANSIFile(t))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := tree.GetIndividual("@I1@").(*types.IndividualRecord).GetName(); got != "René /Dupé/" {
+		t.Errorf("GetName() = %q, want %q", got, "René /Dupé/")
+	}
+	if n := len(p.GetErrorManager().GetErrorsByCode(types.CodeLevelJump)); n != 1 {
+		t.Errorf("expected the level jump to be reported once, got %d", n)
+	}
+	if n := len(p.GetErrorManager().GetErrorsByCode(types.CodeEncodingMismatch)); n != 1 {
+		t.Errorf("expected the re-read to be reported once, got %d", n)
+	}
+}
+
+func TestHierarchicalParser_BOMOverridesDeclaredEncoding(t *testing.T) {
+	content := "\xEF\xBB\xBF0 HEAD\n1 CHAR ANSEL\n0 @I1@ INDI\n1 NAME Zoë\n0 TRLR\n"
+	tmpFile := filepath.Join(t.TempDir(), "bom.ged")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	p := NewHierarchicalParser()
+	tree, err := p.Parse(tmpFile)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := tree.GetIndividual("@I1@").(*types.IndividualRecord).GetName(); got != "Zoë" {
+		t.Errorf("GetName() = %q, want %q", got, "Zoë")
+	}
+
+	warnings := p.GetErrorManager().GetErrorsBySeverity(types.SeverityWarning)
+	if len(warnings) != 1 || warnings[0].LineNumber != 2 {
+		t.Errorf("expected one warning on line 2, got %v", warnings)
+	}
+}
@@ -2,8 +2,12 @@ package parser
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -11,6 +15,10 @@ import (
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
+// parallelThreshold is the input size (32KB) at which HierarchicalParser
+// switches to parallel record processing.
+const parallelThreshold = 32 * 1024
+
 // HierarchicalParser is a full hierarchical parser that builds complete GEDCOM tree structure.
 // It automatically enables parallel processing for files >= 32KB to improve performance.
 // This parser merges the benefits of sequential and parallel parsing approaches.
@@ -20,6 +28,11 @@ type HierarchicalParser struct {
 	continuationHandler *ContinuationHandler
 	errorManager        *types.ErrorManager
 	factory             *types.RecordFactory // Reused factory to avoid allocations
+	lossless            bool                 // Keep original line text (see SetLossless)
+	recovery            RecoveryPolicy       // What to do with problem lines (see SetRecoveryPolicy)
+	versions            *versionTracker      // Header state for input that starts after HEAD (see ParallelHierarchicalParser)
+	uuidNamespace       string               // Name space of record UUIDs (see SetUUIDNamespace)
+	lineCount           int                  // Lines read by the last parseStream
 
 	// Parallel processing fields (auto-enabled for files >= 32KB)
 	enableParallel bool
@@ -46,79 +59,246 @@ func NewHierarchicalParser() *HierarchicalParser {
 // Returns the tree and any parsing errors (warnings don't stop parsing).
 // Parallel processing is automatically enabled for files >= 32KB.
 func (hp *HierarchicalParser) Parse(filePath string) (*types.GedcomTree, error) {
+	return hp.ParseContext(context.Background(), filePath)
+}
+
+// ParseContext is like Parse but stops with ctx's error once ctx is cancelled
+// or its deadline passes. Progress is reported in bytes read, out of the file
+// size, to the ProgressReporter attached with types.WithProgress.
+func (hp *HierarchicalParser) ParseContext(ctx context.Context, filePath string) (*types.GedcomTree, error) {
 	// Step 1: Validate file
 	if err := ValidateFile(filePath); err != nil {
-		hp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("File validation failed: %v", err), 0, "File Validation")
+		hp.errorManager.AddCodedError(types.CodeInvalidFile, types.SeveritySevere, fmt.Sprintf("File validation failed: %v", err), 0, "File Validation")
 		return nil, fmt.Errorf("file validation failed: %w", err)
 	}
 
 	// Step 2: Check file size and auto-enable parallel processing if beneficial
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
-		hp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Failed to stat file: %v", err), 0, "File I/O")
+		hp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to stat file: %v", err), 0, "File I/O")
 		return nil, fmt.Errorf("failed to stat file: %w", err)
 	}
 
-	const parallelThreshold = 32 * 1024 // 32KB - threshold for parallel processing
 	if fileInfo.Size() >= parallelThreshold {
 		hp.enableParallel = true
-		hp.recordChan = make(chan *types.GedcomLine, 100) // Buffered channel
-		// Start record processor goroutine
-		hp.wg.Add(1)
-		go hp.processRecords()
+		hp.startParallel()
 	}
 
 	// Step 3: Detect encoding
 	encoding, err := DetectEncoding(filePath)
 	if err != nil {
-		hp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Encoding detection failed: %v", err), 0, "Encoding Detection")
+		hp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Encoding detection failed: %v", err), 0, "Encoding Detection")
 		return nil, fmt.Errorf("encoding detection failed: %w", err)
 	}
-	hp.tree.SetEncoding(string(encoding))
 
-	// Step 4: Open file
+	// Step 4: Parse, re-opening the file if HEAD.CHAR calls for another decoder
+	errorCount := hp.errorManager.Count()
+	for {
+		hp.tree.SetEncoding(string(encoding))
+		tracker := types.NewProgressTracker(ctx, progressStage, fileInfo.Size())
+		err = hp.parseFile(filePath, encoding, tracker)
+		if err == nil {
+			tracker.Finish()
+		}
+
+		var restart *encodingRestartError
+		if !errors.As(err, &restart) {
+			break
+		}
+		encoding = restart.encoding
+		hp.reset(errorCount)
+		hp.errorManager.AddDiagnostic(restart.notice)
+	}
+
+	// If parallel processing was enabled, close channel and wait for workers to finish
+	hp.stopParallel()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hp.lossless {
+		markPristine(hp.tree)
+	}
+	assignUUIDs(hp.tree, hp.uuidNamespace, filePath)
+
+	// Resolve media file references relative to the GEDCOM file
+	hp.tree.SetMediaSource(types.NewDirMediaSource(filepath.Dir(filePath)))
+
+	// Return tree (errors are available via GetErrors())
+	return hp.tree, nil
+}
+
+// parseFile opens filePath with the decoder for encoding and parses it into hp.tree.
+// Bytes read are counted with tracker.
+func (hp *HierarchicalParser) parseFile(filePath string, encoding Encoding, tracker *types.ProgressTracker) error {
 	file, err := os.Open(filePath)
 	if err != nil {
-		hp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Failed to open file: %v", err), 0, "File I/O")
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		hp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to open file: %v", err), 0, "File I/O")
+		return fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	// Step 5: Get reader with proper encoding (handles BOM skipping)
-	reader, err := GetReader(file, encoding)
+	bom, err := ReadBOM(file)
 	if err != nil {
-		hp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
+		hp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to read file: %v", err), 0, "File I/O")
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Get reader with proper encoding (handles BOM skipping)
+	reader, err := getTrackedReader(file, encoding, tracker)
+	if err != nil {
+		hp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
+		return fmt.Errorf("failed to create reader: %w", err)
+	}
+
+	return hp.parseStream(reader, encoding, HasBOM(bom), true)
+}
+
+// ParseReader parses GEDCOM data from r and builds the complete hierarchical tree
+// structure. Use it for input that has no file path: stdin, an HTTP request body,
+// a zip entry or an in-memory buffer.
+//
+// The encoding is detected from the first 64KB of r, which are buffered. Since the
+// total size is unknown, parallel processing is enabled when that buffer fills up,
+// i.e. when the input is at least 32KB. A stream cannot be re-read, so a HEAD.CHAR
+// declaration beyond the buffered prefix only produces a warning.
+func (hp *HierarchicalParser) ParseReader(r io.Reader) (*types.GedcomTree, error) {
+	return hp.ParseReaderContext(context.Background(), r)
+}
+
+// ParseReaderContext is like ParseReader but stops with ctx's error once ctx
+// is cancelled or its deadline passes. Progress is reported in bytes read; the
+// total is unknown (0).
+func (hp *HierarchicalParser) ParseReaderContext(ctx context.Context, r io.Reader) (*types.GedcomTree, error) {
+	br, peek, err := peekInput(r)
+	if err != nil {
+		hp.errorManager.AddCodedError(types.CodeInvalidFile, types.SeveritySevere, fmt.Sprintf("Input validation failed: %v", err), 0, "File Validation")
+		return nil, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	if len(peek) >= parallelThreshold {
+		hp.enableParallel = true
+		hp.startParallel()
+	}
+
+	encoding := DetectEncodingBytes(peek)
+	hp.tree.SetEncoding(string(encoding))
+
+	tracker := types.NewProgressTracker(ctx, progressStage, 0)
+	reader, err := NewDecodingReader(&progressReader{r: br, tracker: tracker}, encoding)
+	if err != nil {
+		hp.stopParallel()
+		hp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
 		return nil, fmt.Errorf("failed to create reader: %w", err)
 	}
 
-	// Step 5: Parse file line by line using stack-based algorithm
+	err = hp.parseStream(reader, encoding, HasBOM(peek), false)
+	hp.stopParallel()
+	if err != nil {
+		return nil, err
+	}
+	tracker.Finish()
+
+	if hp.lossless {
+		markPristine(hp.tree)
+	}
+	assignUUIDs(hp.tree, hp.uuidNamespace, "")
+
+	return hp.tree, nil
+}
+
+// parseStream parses decoded GEDCOM lines from reader using the stack-based algorithm.
+// If canReopen is set, it returns an *encodingRestartError when the header declares
+// a different encoding than the one the stream is decoded with.
+func (hp *HierarchicalParser) parseStream(reader io.Reader, encoding Encoding, hasBOM, canReopen bool) error {
 	scanner := bufio.NewScanner(reader)
 	lineNumber := 0
+	var currentRecordLine *types.GedcomLine
+	versions := hp.versions
+	if versions == nil {
+		versions = newVersionTracker(hp.errorManager)
+	}
+	defer func() { hp.tree.SetVersion(versions.Version()) }()
+
+	// Lossless mode: original text not yet attached to a line (blank or
+	// skipped lines), and the line that received text last
+	var pendingRaw string
+	var lastLine *types.GedcomLine
+	if hp.lossless {
+		scanner.Split(scanLinesKeepEOL)
+		hp.tree.SetSourceLayout(&types.SourceLayout{BOM: hasBOM && encoding == EncodingUTF8})
+	}
 
 	for scanner.Scan() {
 		lineNumber++
-		line := scanner.Text()
+		raw := scanner.Text()
+		if hp.lossless && lineNumber == 1 {
+			hp.tree.GetSourceLayout().LineEnding = lineEnding(raw)
+		}
 
 		// Skip empty lines
-		line = strings.TrimSpace(line)
+		line := strings.TrimSpace(raw)
 		if len(line) == 0 {
+			if hp.lossless {
+				pendingRaw += raw
+			}
 			continue
 		}
 
 		// Parse the line using optimized parser (line is already trimmed)
 		level, tag, value, xrefID, err := ParseLineFast(line)
 		if err != nil {
+			diag := malformedLineDiagnostic(err, lineNumber, raw)
+			if hp.recovery == RecoveryRepair && diag.Code == types.CodeInvalidLevel && !hp.parentsStack.IsEmpty() {
+				// Text without a level is usually part of a value that contained a line break
+				topLine := hp.parentsStack.Peek()
+				diag.Message += "; joined to the previous line as CONT"
+				hp.errorManager.AddDiagnostic(diag)
+				hp.continuationHandler.HandleContinuation("CONT", topLine.Level+1, line)
+				if hp.lossless {
+					lastLine = topLine
+					lastLine.AppendRaw(pendingRaw + raw)
+					pendingRaw = ""
+				}
+				continue
+			}
 			// Log warning but continue parsing
-			hp.errorManager.AddError(types.SeverityWarning, fmt.Sprintf("Malformed line: %v", err), lineNumber, "Line Parsing")
+			if err := hp.recovery.report(hp.errorManager, diag); err != nil {
+				return err
+			}
+			if hp.lossless {
+				pendingRaw += raw
+			}
 			continue
 		}
+		versions.observe(level, tag, value, lineNumber)
 
 		// Handle CONC/CONT continuation lines
 		if tag == "CONC" || tag == "CONT" {
 			if err := hp.continuationHandler.HandleContinuation(tag, level, value); err != nil {
-				// Invalid continuation, skip it
-				hp.errorManager.AddError(types.SeverityWarning, fmt.Sprintf("Invalid continuation: %v", err), lineNumber, "CONC/CONT Handling")
-				continue
+				last := hp.continuationHandler.GetLastTag()
+				diag := continuationDiagnostic(err, last, lineNumber, raw)
+				if hp.recovery != RecoveryRepair {
+					// Invalid continuation, skip it
+					if err := hp.recovery.report(hp.errorManager, diag); err != nil {
+						return err
+					}
+					if hp.lossless {
+						pendingRaw += raw
+					}
+					continue
+				}
+				// Continue the same value at the level of the line before
+				diag.Message += fmt.Sprintf("; treated as level %d", last.Level)
+				hp.errorManager.AddDiagnostic(diag)
+				hp.continuationHandler.HandleContinuation(tag, last.Level, value)
+			}
+			// Keep the split point with the line being continued
+			if hp.lossless && !hp.parentsStack.IsEmpty() {
+				lastLine = hp.parentsStack.Peek()
+				lastLine.AppendRaw(pendingRaw + raw)
+				pendingRaw = ""
 			}
 			// Continue to next line (value is accumulated)
 			continue
@@ -143,6 +323,11 @@ func (hp *HierarchicalParser) Parse(filePath string) (*types.GedcomTree, error)
 			// Create GedcomLine
 			gedcomLine := types.NewGedcomLine(level, tag, value, xrefID)
 			gedcomLine.LineNumber = lineNumber
+			if hp.lossless {
+				gedcomLine.AppendRaw(pendingRaw + raw)
+				pendingRaw = ""
+				lastLine = gedcomLine
+			}
 
 			// Process record (parallel if enabled, sequential otherwise)
 			if hp.enableParallel {
@@ -164,6 +349,7 @@ func (hp *HierarchicalParser) Parse(filePath string) (*types.GedcomTree, error)
 			// Reset stack (new top-level record)
 			hp.parentsStack.Clear()
 			hp.parentsStack.Push(gedcomLine)
+			currentRecordLine = gedcomLine
 
 			// Update last tag
 			hp.continuationHandler.SetLastTag(tag, level)
@@ -176,14 +362,37 @@ func (hp *HierarchicalParser) Parse(filePath string) (*types.GedcomTree, error)
 		parent, err := hp.parentsStack.FindParent(level)
 		if err != nil {
 			// Orphaned line - no parent found
-			hp.errorManager.AddError(types.SeverityWarning, fmt.Sprintf("Orphaned line: %s", err.Error()), lineNumber, "Hierarchy")
+			if err := hp.recovery.report(hp.errorManager, orphanedLineDiagnostic(err, lineNumber, raw)); err != nil {
+				return err
+			}
 			// Skip this line
+			if hp.lossless {
+				pendingRaw += raw
+			}
 			continue
 		}
 
+		// A line should be one level below its parent; deeper lines are kept
+		// under the nearest parent
+		if level > parent.Level+1 {
+			diag := levelJumpDiagnostic(level, parent, lineNumber, raw)
+			if hp.recovery == RecoveryRepair {
+				level = parent.Level + 1
+				diag.Message += fmt.Sprintf("; treated as level %d", level)
+			}
+			if err := hp.recovery.report(hp.errorManager, diag); err != nil {
+				return err
+			}
+		}
+
 		// Create child line (no xref for level > 0)
 		childLine := types.NewGedcomLine(level, tag, value, "")
 		childLine.LineNumber = lineNumber
+		if hp.lossless {
+			childLine.AppendRaw(pendingRaw + raw)
+			pendingRaw = ""
+			lastLine = childLine
+		}
 
 		// Add as child to parent
 		parent.AddChild(childLine)
@@ -193,6 +402,13 @@ func (hp *HierarchicalParser) Parse(filePath string) (*types.GedcomTree, error)
 
 		// Update last tag
 		hp.continuationHandler.SetLastTag(tag, level)
+
+		// Verify the HEAD.CHAR declaration against the decoder in use
+		if level == 1 && tag == "CHAR" && currentRecordLine != nil && currentRecordLine.Tag == "HEAD" {
+			if restart := checkDeclaredEncoding(hp.errorManager, encoding, hasBOM, canReopen, Encoding(value), lineNumber); restart != nil {
+				return restart
+			}
+		}
 	}
 
 	// Handle remaining CONC/CONT value (if file ends with continuation)
@@ -208,19 +424,53 @@ func (hp *HierarchicalParser) Parse(filePath string) (*types.GedcomTree, error)
 		}
 	}
 
+	// Trailing blank lines belong to the last line of the file
+	if lastLine != nil && pendingRaw != "" {
+		lastLine.AppendRaw(pendingRaw)
+	}
+	hp.lineCount = lineNumber
+
 	if err := scanner.Err(); err != nil {
-		hp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Error reading file: %v", err), lineNumber, "File I/O")
-		return nil, fmt.Errorf("error reading file: %w", err)
+		if isContextError(err) {
+			return err
+		}
+		hp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Error reading file: %v", err), lineNumber, "File I/O")
+		return fmt.Errorf("error reading file: %w", err)
 	}
 
-	// If parallel processing was enabled, close channel and wait for workers to finish
-	if hp.enableParallel {
-		close(hp.recordChan)
-		hp.wg.Wait()
+	return nil
+}
+
+// startParallel starts the record processor goroutine.
+func (hp *HierarchicalParser) startParallel() {
+	if !hp.enableParallel {
+		return
 	}
+	hp.recordChan = make(chan *types.GedcomLine, 100) // Buffered channel
+	hp.wg.Add(1)
+	go hp.processRecords()
+}
 
-	// Return tree (errors are available via GetErrors())
-	return hp.tree, nil
+// stopParallel closes the record channel and waits for the processor to drain it.
+func (hp *HierarchicalParser) stopParallel() {
+	if !hp.enableParallel || hp.recordChan == nil {
+		return
+	}
+	close(hp.recordChan)
+	hp.wg.Wait()
+	hp.recordChan = nil
+}
+
+// reset discards the partially built tree so the input can be parsed again,
+// and the errors found in it: only the first errorCount errors, collected
+// before the attempt, are kept.
+func (hp *HierarchicalParser) reset(errorCount int) {
+	hp.stopParallel()
+	hp.errorManager.Truncate(errorCount)
+	hp.tree = types.NewGedcomTree()
+	hp.parentsStack = NewLineStack()
+	hp.continuationHandler = NewContinuationHandler()
+	hp.startParallel()
 }
 
 // processRecords processes level 0 records in a separate goroutine.
@@ -279,6 +529,11 @@ func (bp *BasicParser) Parse(filePath string) (*types.GedcomTree, error) {
 	return bp.parser.Parse(filePath)
 }
 
+// ParseReader parses GEDCOM data from r using hierarchical parsing.
+func (bp *BasicParser) ParseReader(r io.Reader) (*types.GedcomTree, error) {
+	return bp.parser.ParseReader(r)
+}
+
 // GetTree returns the parsed tree.
 func (bp *BasicParser) GetTree() *types.GedcomTree {
 	return bp.parser.GetTree()
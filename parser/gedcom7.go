@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// gedcom7OnlyTags are tags introduced by GEDCOM 7.0. Seeing them in a file that
+// declares an older version usually means the file was exported by a 7.0-aware
+// application without updating HEAD.GEDC.VERS.
+var gedcom7OnlyTags = map[string]bool{
+	"SNOTE":  true,
+	"EXID":   true,
+	"SCHMA":  true,
+	"PHRASE": true,
+	"TRAN":   true,
+	"CREA":   true,
+	"INIL":   true,
+	"CROP":   true,
+	"MIME":   true,
+	"SDATE":  true,
+	"NO":     true,
+	"UID":    true,
+}
+
+// gedcom7RemovedTags are 5.5.1 tags that GEDCOM 7.0 no longer defines.
+// CONC is handled separately because it changes line values.
+var gedcom7RemovedTags = map[string]bool{
+	"CHAR": true,
+	"SUBN": true,
+	"RFN":  true,
+	"AFN":  true,
+	"RIN":  true,
+}
+
+// versionTracker follows HEAD.GEDC.VERS while a file is parsed and reports
+// structures that do not belong to the declared GEDCOM version. Nothing is
+// rewritten: a 5.5.1 file keeps its CONC-joined values and a 7.0 file keeps its
+// SNOTE records and @VOID@ pointers, so callers can tell the versions apart.
+type versionTracker struct {
+	errorManager *types.ErrorManager
+
+	version    string            // HEAD.GEDC.VERS value
+	schema     map[string]string // HEAD.SCHMA.TAG declarations (7.0)
+	inHeader   bool
+	headerDone bool
+	level1Tag  string // Most recent level-1 tag inside HEAD
+	charLine   int    // Line number of HEAD.CHAR, if present
+
+	reported map[string]bool // Messages already reported once
+}
+
+// newVersionTracker creates a versionTracker reporting to errorManager.
+func newVersionTracker(errorManager *types.ErrorManager) *versionTracker {
+	return &versionTracker{
+		errorManager: errorManager,
+		schema:       make(map[string]string),
+		reported:     make(map[string]bool),
+	}
+}
+
+// fork returns a tracker with the header state of vt that reports to
+// errorManager, for parsing input that continues after the header.
+func (vt *versionTracker) fork(errorManager *types.ErrorManager) *versionTracker {
+	return &versionTracker{
+		errorManager: errorManager,
+		version:      vt.version,
+		schema:       vt.schema,
+		headerDone:   vt.headerDone,
+		reported:     make(map[string]bool),
+	}
+}
+
+// Version returns the declared GEDCOM version, or "" if none was found.
+func (vt *versionTracker) Version() string {
+	return vt.version
+}
+
+// isGedcom7 reports whether the header declared GEDCOM 7.x.
+func (vt *versionTracker) isGedcom7() bool {
+	return types.IsGedcom7(vt.version)
+}
+
+// observe inspects one parsed line. It must be called for every line,
+// including CONC/CONT, in file order.
+func (vt *versionTracker) observe(level int, tag, value string, lineNumber int) {
+	if level == 0 {
+		if vt.inHeader {
+			vt.finishHeader()
+		}
+		vt.inHeader = tag == "HEAD"
+	}
+
+	if vt.inHeader {
+		vt.observeHeader(level, tag, value, lineNumber)
+		return
+	}
+
+	// Until the header has been read the version is unknown
+	if !vt.headerDone {
+		return
+	}
+
+	if vt.isGedcom7() {
+		vt.checkGedcom7(tag, lineNumber)
+	} else if vt.version != "" {
+		vt.checkGedcom5(tag, value, lineNumber)
+	}
+}
+
+// observeHeader records HEAD.GEDC.VERS, HEAD.CHAR and HEAD.SCHMA.TAG.
+func (vt *versionTracker) observeHeader(level int, tag, value string, lineNumber int) {
+	switch level {
+	case 1:
+		vt.level1Tag = tag
+		if tag == "CHAR" {
+			vt.charLine = lineNumber
+		}
+	case 2:
+		if vt.level1Tag == "GEDC" && tag == "VERS" {
+			vt.version = strings.TrimSpace(value)
+		}
+		if vt.level1Tag == "SCHMA" && tag == "TAG" {
+			fields := strings.Fields(value)
+			if len(fields) > 0 {
+				uri := ""
+				if len(fields) > 1 {
+					uri = fields[1]
+				}
+				vt.schema[fields[0]] = uri
+			}
+		}
+	}
+}
+
+// finishHeader is called when the header record ends and the version is known.
+func (vt *versionTracker) finishHeader() {
+	vt.headerDone = true
+	if vt.isGedcom7() && vt.charLine > 0 {
+		vt.reportOnce("CHAR", types.CodeVersionMismatch, types.SeverityHint,
+			"CHAR is not part of GEDCOM 7.0; files are always UTF-8", vt.charLine)
+	}
+}
+
+// checkGedcom7 reports 5.5.1-only structures and undeclared extension tags
+// in a GEDCOM 7.0 file.
+func (vt *versionTracker) checkGedcom7(tag string, lineNumber int) {
+	switch {
+	case tag == "CONC":
+		vt.reportOnce("CONC", types.CodeVersionMismatch, types.SeverityWarning,
+			"CONC is not part of GEDCOM 7.0; continuation lines are joined as in 5.5.1", lineNumber)
+	case gedcom7RemovedTags[tag]:
+		vt.reportOnce(tag, types.CodeVersionMismatch, types.SeverityHint,
+			fmt.Sprintf("%s is not part of GEDCOM 7.0", tag), lineNumber)
+	case strings.HasPrefix(tag, "_"):
+		if _, declared := vt.schema[tag]; !declared {
+			vt.reportOnce(tag, types.CodeUndeclaredExtension, types.SeverityHint,
+				fmt.Sprintf("Extension tag %s is not declared in HEAD.SCHMA", tag), lineNumber)
+		}
+	}
+}
+
+// checkGedcom5 reports GEDCOM 7.0 structures in a file declaring an older version.
+func (vt *versionTracker) checkGedcom5(tag, value string, lineNumber int) {
+	if gedcom7OnlyTags[tag] {
+		vt.reportOnce(tag, types.CodeVersionMismatch, types.SeverityInfo,
+			fmt.Sprintf("%s is a GEDCOM 7.0 tag but the file declares version %s", tag, vt.version), lineNumber)
+	}
+	if types.IsVoidPointer(value) {
+		vt.reportOnce(types.VoidPointer, types.CodeVersionMismatch, types.SeverityWarning,
+			fmt.Sprintf("%s pointers require GEDCOM 7.0 but the file declares version %s", types.VoidPointer, vt.version), lineNumber)
+	}
+}
+
+// reportOnce adds an error the first time key is seen.
+func (vt *versionTracker) reportOnce(key string, code types.ErrorCode, severity types.ErrorSeverity, message string, lineNumber int) {
+	if vt.reported[key] {
+		return
+	}
+	vt.reported[key] = true
+	vt.errorManager.AddCodedError(code, severity, message, lineNumber, "GEDCOM Version")
+}
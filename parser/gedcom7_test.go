@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+const gedcom7Sample = `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 SCHMA
+2 TAG _SKYPEID http://xmlns.com/foaf/0.1/skypeID
+0 @I1@ INDI
+1 NAME John /Doe/
+1 _SKYPEID john.doe
+1 _UNDECLARED x
+1 EXID 12345
+2 TYPE http://example.com/ids
+1 SNOTE @N1@
+1 BIRT
+2 DATE JULIAN 3 MAR 1700
+1 FAMS @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @VOID@
+1 CHIL @VOID@
+0 @N1@ SNOTE A long shared note
+1 CONC  that uses CONC
+0 TRLR
+`
+
+// findErrors returns the errors whose message contains substr.
+func findErrors(errs []*types.GedcomError, substr string) []*types.GedcomError {
+	var found []*types.GedcomError
+	for _, err := range errs {
+		if strings.Contains(err.Message, substr) {
+			found = append(found, err)
+		}
+	}
+	return found
+}
+
+func TestHierarchicalParser_Gedcom7(t *testing.T) {
+	p := NewHierarchicalParser()
+	tree, err := p.ParseReader(strings.NewReader(gedcom7Sample))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	if got := tree.GetVersion(); got != "7.0" {
+		t.Errorf("GetVersion() = %q, want %q", got, "7.0")
+	}
+	if !tree.IsGedcom7() {
+		t.Error("expected IsGedcom7() to be true")
+	}
+
+	snote, ok := tree.GetSharedNote("@N1@").(*types.SharedNoteRecord)
+	if !ok {
+		t.Fatal("expected @N1@ to be a shared note")
+	}
+	if got := snote.GetText(); got != "A long shared note that uses CONC" {
+		t.Errorf("GetText() = %q", got)
+	}
+
+	indi := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	if ids := indi.GetExternalIDs(); len(ids) != 1 || ids[0].ID != "12345" {
+		t.Errorf("GetExternalIDs() = %+v", ids)
+	}
+	if notes := indi.GetSharedNotes(); len(notes) != 1 || notes[0] != "@N1@" {
+		t.Errorf("GetSharedNotes() = %v", notes)
+	}
+
+	fam := tree.GetFamily("@F1@").(*types.FamilyRecord)
+	if fam.GetWife() != "" || len(fam.GetChildren()) != 0 {
+		t.Errorf("@VOID@ pointers should resolve to nothing, got wife %q children %v", fam.GetWife(), fam.GetChildren())
+	}
+
+	header := tree.GetHeader().(*types.HeaderRecord)
+	if _, ok := header.GetSchemaTags()["_SKYPEID"]; !ok {
+		t.Error("expected _SKYPEID schema declaration")
+	}
+
+	errs := p.GetErrors()
+	if found := findErrors(errs, "CONC is not part of GEDCOM 7.0"); len(found) != 1 {
+		t.Errorf("expected one CONC warning, got %d", len(found))
+	} else if found[0].LineNumber != 21 {
+		t.Errorf("CONC warning on line %d, want 21", found[0].LineNumber)
+	}
+	if found := findErrors(errs, "_UNDECLARED is not declared"); len(found) != 1 {
+		t.Errorf("expected undeclared extension hint, got %d", len(found))
+	}
+	if found := findErrors(errs, "_SKYPEID is not declared"); len(found) != 0 {
+		t.Error("declared extension tag should not be reported")
+	}
+}
+
+func TestHierarchicalParser_Gedcom551WithGedcom7Tags(t *testing.T) {
+	data := strings.Replace(gedcom7Sample, "2 VERS 7.0", "2 VERS 5.5.1", 1)
+
+	p := NewHierarchicalParser()
+	tree, err := p.ParseReader(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	if tree.IsGedcom7() {
+		t.Error("5.5.1 tree should not report IsGedcom7()")
+	}
+
+	errs := p.GetErrors()
+	if found := findErrors(errs, "SNOTE is a GEDCOM 7.0 tag"); len(found) != 1 {
+		t.Errorf("expected one SNOTE notice, got %d", len(found))
+	}
+	if found := findErrors(errs, "@VOID@ pointers require GEDCOM 7.0"); len(found) != 1 {
+		t.Errorf("expected one @VOID@ warning, got %d", len(found))
+	}
+	if found := findErrors(errs, "CONC is not part"); len(found) != 0 {
+		t.Error("CONC is valid in GEDCOM 5.5.1")
+	}
+}
+
+func TestStreamingParser_Gedcom7Version(t *testing.T) {
+	p := NewStreamingHierarchicalParser()
+	var snotes int
+	err := p.ParseWithHandlerReader(strings.NewReader(gedcom7Sample), func(record types.Record) error {
+		if record.Type() == types.RecordTypeSNOTE {
+			snotes++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseWithHandlerReader() error = %v", err)
+	}
+	if p.GetVersion() != "7.0" {
+		t.Errorf("GetVersion() = %q, want %q", p.GetVersion(), "7.0")
+	}
+	if snotes != 1 {
+		t.Errorf("expected 1 SNOTE record, got %d", snotes)
+	}
+}
@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -364,3 +367,91 @@ func TestBasicParser_Parse_InvalidFile(t *testing.T) {
 	}
 }
 
+
+func TestHierarchicalParser_ParseReader(t *testing.T) {
+	testContent := "\xEF\xBB\xBF0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME Zoë /Brontë/\n1 FAMS @F1@\n0 @F1@ FAM\n1 HUSB @I1@\n0 TRLR\n"
+
+	parser := NewHierarchicalParser()
+	tree, err := parser.ParseReader(strings.NewReader(testContent))
+	if err != nil {
+		t.Fatalf("ParseReader failed: %v", err)
+	}
+
+	indi, ok := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	if !ok {
+		t.Fatal("Expected @I1@ to be an individual")
+	}
+	if got := indi.GetName(); got != "Zoë /Brontë/" {
+		t.Errorf("GetName() = %q, want %q", got, "Zoë /Brontë/")
+	}
+	if tree.GetFamily("@F1@") == nil {
+		t.Error("Expected @F1@ family")
+	}
+	if parser.HasErrors() {
+		t.Errorf("Unexpected errors: %v", parser.GetErrors())
+	}
+}
+
+func TestHierarchicalParser_ParseReader_MatchesParse(t *testing.T) {
+	// A file large enough to enable parallel processing must produce the
+	// same tree from a reader as from its path
+	var b strings.Builder
+	b.WriteString("0 HEAD\n1 CHAR ANSEL\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&b, "0 @I%d@ INDI\n1 NAME Person%d /Ren\xE2e/\n1 BIRT\n2 DATE 1 JAN 1900\n", i, i)
+	}
+	b.WriteString("0 TRLR\n")
+	content := b.String()
+
+	testFile := filepath.Join(t.TempDir(), "large.ged")
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	fromFile, err := NewHierarchicalParser().Parse(testFile)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	fromReader, err := NewHierarchicalParser().ParseReader(bytes.NewReader([]byte(content)))
+	if err != nil {
+		t.Fatalf("ParseReader failed: %v", err)
+	}
+
+	if len(fromReader.GetAllIndividuals()) != len(fromFile.GetAllIndividuals()) {
+		t.Errorf("individual count: reader %d, file %d", len(fromReader.GetAllIndividuals()), len(fromFile.GetAllIndividuals()))
+	}
+	if fromReader.GetEncoding() != string(EncodingANSEL) {
+		t.Errorf("encoding = %q, want %q", fromReader.GetEncoding(), EncodingANSEL)
+	}
+	indi, ok := fromReader.GetIndividual("@I1999@").(*types.IndividualRecord)
+	if !ok {
+		t.Fatal("Expected @I1999@ to be an individual")
+	}
+	if got := indi.GetName(); got != "Person1999 /René/" {
+		t.Errorf("GetName() = %q, want %q", got, "Person1999 /René/")
+	}
+}
+
+func TestHierarchicalParser_ParseReader_Empty(t *testing.T) {
+	parser := NewHierarchicalParser()
+	if _, err := parser.ParseReader(strings.NewReader("")); err == nil {
+		t.Error("Expected error for empty input")
+	}
+	if !parser.HasSevereErrors() {
+		t.Error("Expected a severe error for empty input")
+	}
+}
+
+func TestSmartParser_ParseReader(t *testing.T) {
+	p := NewParser()
+	tree, err := p.ParseReader(strings.NewReader("0 HEAD\n0 @I1@ INDI\n1 NAME A /B/\n0 TRLR\n"))
+	if err != nil {
+		t.Fatalf("ParseReader failed: %v", err)
+	}
+	if tree.GetIndividual("@I1@") == nil {
+		t.Error("Expected @I1@")
+	}
+	if p.GetTree() != tree {
+		t.Error("GetTree() should return the parsed tree")
+	}
+}
@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// GedzipDatasetName is the name of the GEDCOM dataset inside a GEDZIP archive.
+const GedzipDatasetName = "gedcom.ged"
+
+// GedzipExtension is the file extension of GEDZIP archives.
+const GedzipExtension = ".gdz"
+
+// GedzipArchive is a GEDCOM 7.0 GEDZIP archive: a zip file holding the dataset
+// (gedcom.ged) and the media files it references. It implements
+// types.MediaSource so that OBJE FILE values can be opened from the archive.
+//
+// The archive file is reopened for each access, so a GedzipArchive needs no
+// closing and stays usable for as long as the file exists.
+type GedzipArchive struct {
+	path    string
+	entries map[string]bool
+}
+
+// OpenGedzip opens the GEDZIP archive at filePath and indexes its entries.
+func OpenGedzip(filePath string) (*GedzipArchive, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GEDZIP archive: %w", err)
+	}
+	defer reader.Close()
+
+	archive := &GedzipArchive{
+		path:    filePath,
+		entries: make(map[string]bool, len(reader.File)),
+	}
+	for _, file := range reader.File {
+		if !file.FileInfo().IsDir() {
+			archive.entries[file.Name] = true
+		}
+	}
+	if !archive.entries[GedzipDatasetName] {
+		return nil, fmt.Errorf("GEDZIP archive has no %s entry: %s", GedzipDatasetName, filePath)
+	}
+	return archive, nil
+}
+
+// Path returns the path of the archive file.
+func (ga *GedzipArchive) Path() string {
+	return ga.path
+}
+
+// Entries returns the names of all file entries in the archive, sorted.
+func (ga *GedzipArchive) Entries() []string {
+	names := make([]string, 0, len(ga.entries))
+	for name := range ga.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasEntry reports whether a FILE reference resolves to an archive entry.
+func (ga *GedzipArchive) HasEntry(ref string) bool {
+	name, ok := types.LocalMediaPath(ref)
+	return ok && ga.entries[name]
+}
+
+// OpenMedia opens the archive entry a FILE reference points to.
+// It implements types.MediaSource.
+func (ga *GedzipArchive) OpenMedia(ref string) (io.ReadCloser, error) {
+	name, ok := types.LocalMediaPath(ref)
+	if !ok {
+		return nil, fmt.Errorf("media file %q is not stored in the archive", ref)
+	}
+	if !ga.entries[name] {
+		return nil, fmt.Errorf("media file %q not found in archive", ref)
+	}
+	return ga.openEntry(name)
+}
+
+// OpenDataset opens the gedcom.ged entry of the archive.
+func (ga *GedzipArchive) OpenDataset() (io.ReadCloser, error) {
+	return ga.openEntry(GedzipDatasetName)
+}
+
+// openEntry opens a single entry; closing the result also closes the archive.
+func (ga *GedzipArchive) openEntry(name string) (io.ReadCloser, error) {
+	reader, err := zip.OpenReader(ga.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GEDZIP archive: %w", err)
+	}
+	entry, err := reader.Open(name)
+	if err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("failed to open archive entry %s: %w", name, err)
+	}
+	return &archiveEntryReader{entry: entry, archive: reader}, nil
+}
+
+// archiveEntryReader reads one zip entry and closes its archive when done.
+type archiveEntryReader struct {
+	entry   io.ReadCloser
+	archive *zip.ReadCloser
+}
+
+// Read implements io.Reader.
+func (r *archiveEntryReader) Read(p []byte) (int, error) {
+	return r.entry.Read(p)
+}
+
+// Close closes the entry and the archive.
+func (r *archiveEntryReader) Close() error {
+	entryErr := r.entry.Close()
+	if err := r.archive.Close(); err != nil {
+		return err
+	}
+	return entryErr
+}
+
+// isExternalMediaRef reports whether a FILE value is an absolute URL
+// (http:, https:, ftp:, ...) that is not expected inside the archive.
+func isExternalMediaRef(ref string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(ref))
+	return err == nil && parsed.Scheme != "" && parsed.Scheme != "file"
+}
+
+// ParseGedzip parses the gedcom.ged dataset of a GEDZIP archive and resolves
+// OBJE FILE references against the archive entries. References that point to
+// no entry are reported as warnings; absolute URLs are left alone. The returned
+// tree's media source is the archive, so tree.OpenMedia(obje.GetFile()) reads
+// media bytes straight from the archive.
+func (hp *HierarchicalParser) ParseGedzip(filePath string) (*types.GedcomTree, error) {
+	return hp.ParseGedzipContext(context.Background(), filePath)
+}
+
+// ParseGedzipContext is like ParseGedzip but stops once ctx is cancelled or
+// its deadline passes. Progress is reported as for ParseReaderContext.
+func (hp *HierarchicalParser) ParseGedzipContext(ctx context.Context, filePath string) (*types.GedcomTree, error) {
+	archive, err := OpenGedzip(filePath)
+	if err != nil {
+		hp.errorManager.AddCodedError(types.CodeInvalidArchive, types.SeveritySevere, err.Error(), 0, "GEDZIP")
+		return nil, err
+	}
+
+	dataset, err := archive.OpenDataset()
+	if err != nil {
+		hp.errorManager.AddCodedError(types.CodeInvalidArchive, types.SeveritySevere, err.Error(), 0, "GEDZIP")
+		return nil, err
+	}
+	defer dataset.Close()
+
+	tree, err := hp.ParseReaderContext(ctx, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Name the records after the archive rather than its dataset entry
+	assignUUIDs(tree, hp.uuidNamespace, filePath)
+	tree.SetMediaSource(archive)
+	hp.resolveArchiveMedia(tree, archive)
+	return tree, nil
+}
+
+// resolveArchiveMedia reports OBJE FILE references missing from the archive.
+func (hp *HierarchicalParser) resolveArchiveMedia(tree *types.GedcomTree, archive *GedzipArchive) {
+	for xrefID, record := range tree.GetAllMultimedia() {
+		for _, fileLine := range record.GetLines("FILE") {
+			ref := fileLine.Value
+			if ref == "" || isExternalMediaRef(ref) || archive.HasEntry(ref) {
+				continue
+			}
+			hp.errorManager.AddCodedError(types.CodeMissingMedia, types.SeverityWarning,
+				fmt.Sprintf("Media file %q of %s not found in GEDZIP archive", ref, xrefID),
+				fileLine.LineNumber, "GEDZIP")
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// writeTestGedzip creates a GEDZIP archive with the given entries and returns its path.
+func writeTestGedzip(t *testing.T, entries map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "family.gdz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	for name, content := range entries {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create entry %s: %v", name, err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatalf("failed to write entry %s: %v", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+	return path
+}
+
+const gedzipDataset = `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Doe/
+1 OBJE @O1@
+0 @O1@ OBJE
+1 FILE photos/John%20Doe.jpg
+2 FORM image/jpeg
+0 @O2@ OBJE
+1 FILE photos/missing.jpg
+2 FORM image/jpeg
+0 @O3@ OBJE
+1 FILE https://example.com/portrait.jpg
+2 FORM image/jpeg
+0 TRLR
+`
+
+func TestHierarchicalParser_ParseGedzip(t *testing.T) {
+	path := writeTestGedzip(t, map[string]string{
+		"gedcom.ged":          gedzipDataset,
+		"photos/John Doe.jpg": "JPEGDATA",
+	})
+
+	p := NewHierarchicalParser()
+	tree, err := p.ParseGedzip(path)
+	if err != nil {
+		t.Fatalf("ParseGedzip() error = %v", err)
+	}
+	if len(tree.GetAllIndividuals()) != 1 {
+		t.Errorf("expected 1 individual, got %d", len(tree.GetAllIndividuals()))
+	}
+
+	obje := tree.GetRecordByXref("@O1@").(*types.MultimediaRecord)
+	media, err := tree.OpenMedia(obje.GetFile())
+	if err != nil {
+		t.Fatalf("OpenMedia() error = %v", err)
+	}
+	data, err := io.ReadAll(media)
+	media.Close()
+	if err != nil || string(data) != "JPEGDATA" {
+		t.Errorf("media bytes = %q, %v; want %q", data, err, "JPEGDATA")
+	}
+
+	// The missing file is reported, the external URL is not
+	var missing []string
+	for _, e := range p.GetErrors() {
+		if e.Context == "GEDZIP" {
+			missing = append(missing, e.Message)
+		}
+	}
+	if len(missing) != 1 || !strings.Contains(missing[0], "photos/missing.jpg") {
+		t.Errorf("expected one missing media warning, got %v", missing)
+	}
+}
+
+func TestOpenGedzip_NoDataset(t *testing.T) {
+	path := writeTestGedzip(t, map[string]string{"other.ged": "0 HEAD\n0 TRLR\n"})
+	if _, err := OpenGedzip(path); err == nil {
+		t.Error("expected error for archive without gedcom.ged")
+	}
+}
+
+func TestSmartParser_Gedzip(t *testing.T) {
+	path := writeTestGedzip(t, map[string]string{
+		"gedcom.ged":          gedzipDataset,
+		"photos/John Doe.jpg": "JPEGDATA",
+	})
+
+	tree, err := NewParser().Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, ok := tree.GetMediaSource().(*GedzipArchive); !ok {
+		t.Errorf("expected media source to be the archive, got %T", tree.GetMediaSource())
+	}
+}
+
+func TestSmartParser_GedzipLossless(t *testing.T) {
+	path := writeTestGedzip(t, map[string]string{"gedcom.ged": losslessSample})
+
+	p := NewParser()
+	p.SetLossless(true)
+	tree, err := p.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if tree.GetSourceLayout() == nil {
+		t.Fatal("expected source layout to be recorded")
+	}
+	if raw := tree.GetIndividual("@I1@").FirstLine().Raw(); raw != "0 @I1@ INDI\r\n" {
+		t.Errorf("INDI Raw() = %q", raw)
+	}
+}
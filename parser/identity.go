@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// SetUUIDNamespace sets the name space from which record UUIDs are derived
+// (see types.StableUUID). Records with a _UID, UID or EXID line take their
+// UUID from it; the others get one derived from the name space and their
+// xref, so the same file gives the same UUIDs in every parse.
+//
+// By default the name space is the file name from HEAD.FILE, or else the base
+// name of the parsed file. Set it to tell apart files that share both, or to
+// give two versions of a file the same UUIDs for the same xrefs.
+func (hp *HierarchicalParser) SetUUIDNamespace(namespace string) {
+	hp.uuidNamespace = namespace
+}
+
+// SetUUIDNamespace sets the name space of record UUIDs (see
+// HierarchicalParser.SetUUIDNamespace).
+func (pp *ParallelHierarchicalParser) SetUUIDNamespace(namespace string) {
+	pp.uuidNamespace = namespace
+}
+
+// SetUUIDNamespace sets the name space of the UUIDs of the records handed to
+// the handler (see HierarchicalParser.SetUUIDNamespace).
+func (shp *StreamingHierarchicalParser) SetUUIDNamespace(namespace string) {
+	shp.uuidNamespace = namespace
+}
+
+// SetUUIDNamespace sets the name space of the UUIDs of the records read (see
+// HierarchicalParser.SetUUIDNamespace).
+func (ir *IndexedReader) SetUUIDNamespace(namespace string) {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	ir.uuidNamespace = namespace
+}
+
+// assignUUIDs gives the records of tree their stable UUIDs. An empty
+// namespace defaults to HEAD.FILE, then to the base name of filePath.
+func assignUUIDs(tree *types.GedcomTree, namespace, filePath string) {
+	tree.AssignStableUUIDs(uuidNamespaceFor(namespace, tree.GetHeader(), filePath))
+}
+
+// uuidNamespaceFor returns namespace, or else the FILE of header, or else the
+// base name of filePath. header may be nil.
+func uuidNamespaceFor(namespace string, header types.Record, filePath string) string {
+	if namespace == "" && header != nil {
+		namespace = strings.TrimSpace(header.GetValue("FILE"))
+	}
+	if namespace == "" && filePath != "" {
+		namespace = filepath.Base(filePath)
+	}
+	return namespace
+}
+
+// withUUIDs returns a handler that gives each record its stable UUID before
+// passing it to handler, as assignUUIDs does for a whole tree. The header,
+// which comes first, settles the default name space.
+func withUUIDs(handler RecordHandler, namespace, filePath string) RecordHandler {
+	var assigner *types.UUIDAssigner
+	return func(record types.Record) error {
+		if assigner == nil {
+			var header types.Record
+			if record.Type() == types.RecordTypeHEAD {
+				header = record
+			}
+			assigner = types.NewUUIDAssigner(uuidNamespaceFor(namespace, header, filePath))
+		}
+		assigner.Assign(record)
+		return handler(record)
+	}
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+func TestHierarchicalParser_StableUUIDs(t *testing.T) {
+	input := "0 HEAD\n1 FILE family.ged\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n1 _UID 0F7D2E1C4A6B4C559E3D2B1A09F8E7D6A1B2\n" +
+		"0 @I2@ INDI\n1 NAME Jane /Doe/\n" +
+		"0 TRLR\n"
+
+	parse := func(namespace string) *types.GedcomTree {
+		p := NewHierarchicalParser()
+		p.SetUUIDNamespace(namespace)
+		tree, err := p.ParseReader(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseReader() error = %v", err)
+		}
+		return tree
+	}
+	first, second := parse(""), parse("")
+
+	for _, record := range first.GetAllRecords() {
+		if other := second.GetRecordByUUID(record.UUID()); other == nil || other.XrefID() != record.XrefID() {
+			t.Errorf("%s %s has another UUID in the second parse", record.Type(), record.XrefID())
+		}
+	}
+	if got := first.GetIndividual("@I1@").UUID(); got != "0f7d2e1c-4a6b-4c55-9e3d-2b1a09f8e7d6" {
+		t.Errorf("@I1@ UUID = %q, want the _UID", got)
+	}
+	if got := first.GetIndividual("@I2@").UUID(); got != types.DeriveUUID("family.ged", "@I2@") {
+		t.Errorf("@I2@ UUID = %q, want one derived from HEAD.FILE", got)
+	}
+	if got := parse("other").GetIndividual("@I2@").UUID(); got != types.DeriveUUID("other", "@I2@") {
+		t.Errorf("@I2@ UUID with SetUUIDNamespace = %q", got)
+	}
+}
+
+func TestParallelHierarchicalParser_StableUUIDs(t *testing.T) {
+	path := writeIndexedFile(t, largeGedcom7())
+
+	sequential, err := NewHierarchicalParser().Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	pp := NewParallelHierarchicalParser()
+	pp.SetWorkers(4)
+	parallel, err := pp.Parse(path)
+	if err != nil {
+		t.Fatalf("parallel Parse() error = %v", err)
+	}
+
+	for _, xref := range []string{"@I1@", "@I6000@", "@I12000@"} {
+		if got, want := parallel.GetIndividual(xref).UUID(), sequential.GetIndividual(xref).UUID(); got != want {
+			t.Errorf("%s UUID = %q, want %q", xref, got, want)
+		}
+	}
+}
+
+func TestStreamingParsers_StableUUIDs(t *testing.T) {
+	input := "0 HEAD\n1 FILE family.ged\n" +
+		"0 @I1@ INDI\n1 NAME John /Doe/\n1 _UID 0F7D2E1C4A6B4C559E3D2B1A09F8E7D6A1B2\n" +
+		"0 @I2@ INDI\n1 NAME Jane /Doe/\n1 _UID 0F7D2E1C4A6B4C559E3D2B1A09F8E7D6A1B2\n" +
+		"0 @I3@ INDI\n1 NAME Jim /Doe/\n" +
+		"0 TRLR\n"
+	path := writeIndexedFile(t, input)
+
+	tree, err := NewHierarchicalParser().Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := func(record types.Record) string {
+		if record.XrefID() == "" {
+			return tree.GetHeader().UUID()
+		}
+		return tree.GetRecordByXref(record.XrefID()).UUID()
+	}
+
+	streamed := 0
+	err = NewStreamingHierarchicalParser().ParseWithHandler(path, func(record types.Record) error {
+		if record.Type() != types.RecordTypeTRLR && record.UUID() != want(record) {
+			t.Errorf("streamed %s %s UUID = %q, want %q", record.Type(), record.XrefID(), record.UUID(), want(record))
+		}
+		streamed++
+		return nil
+	})
+	if err != nil || streamed == 0 {
+		t.Fatalf("ParseWithHandler() error = %v, %d records", err, streamed)
+	}
+
+	iterator, err := NewRecordIterator(path)
+	if err != nil {
+		t.Fatalf("NewRecordIterator() error = %v", err)
+	}
+	defer iterator.Close()
+	for iterator.Next() {
+		if record := iterator.Record(); record.XrefID() != "" && record.UUID() != want(record) {
+			t.Errorf("iterated %s UUID = %q, want %q", record.XrefID(), record.UUID(), want(record))
+		}
+	}
+
+	reader, err := NewIndexedReader(path)
+	if err != nil {
+		t.Fatalf("NewIndexedReader() error = %v", err)
+	}
+	defer reader.Close()
+	for _, xref := range []string{"@I1@", "@I3@"} {
+		record, err := reader.Record(xref)
+		if err != nil {
+			t.Fatalf("Record(%s) error = %v", xref, err)
+		}
+		if record.UUID() != want(record) {
+			t.Errorf("indexed %s UUID = %q, want %q", xref, record.UUID(), want(record))
+		}
+	}
+	reader.SetUUIDNamespace("other")
+	if record, _ := reader.Record("@I3@"); record.UUID() != types.DeriveUUID("other", "@I3@") {
+		t.Errorf("indexed @I3@ UUID with SetUUIDNamespace = %q", record.UUID())
+	}
+}
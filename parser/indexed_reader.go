@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// IndexedReader reads single records from a GEDCOM file by xref, using a
+// RecordIndex to seek straight to them. Only the requested record is read and
+// parsed, so lookups on multi-gigabyte files take about as long as on small
+// ones.
+//
+// When the file changes, the index is rebuilt (and its sidecar rewritten)
+// before the next lookup. IndexedReader is safe for concurrent use.
+//
+// Example:
+//
+//	reader, err := NewIndexedReader("huge.ged")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer reader.Close()
+//
+//	indi, err := reader.Individual("@I123456@")
+type IndexedReader struct {
+	mu           sync.Mutex
+	filePath     string
+	file         *os.File
+	index        *RecordIndex
+	errorManager *types.ErrorManager
+
+	uuidNamespace string       // Name space of record UUIDs (see SetUUIDNamespace)
+	header        types.Record // HEAD record, for the default UUID name space
+}
+
+// NewIndexedReader opens a GEDCOM file for random access, loading its sidecar
+// index or building it if it is missing or out of date (see OpenRecordIndex).
+func NewIndexedReader(filePath string) (*IndexedReader, error) {
+	ir := &IndexedReader{
+		filePath:     filePath,
+		errorManager: types.NewErrorManager(),
+	}
+	if err := ir.open(); err != nil {
+		return nil, err
+	}
+	return ir, nil
+}
+
+// open (re)loads the index and opens the file.
+func (ir *IndexedReader) open() error {
+	index, err := OpenRecordIndex(ir.filePath)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(ir.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+
+	if ir.file != nil {
+		ir.file.Close()
+	}
+	ir.file = file
+	ir.index = index
+	ir.header = readHeader(ir.filePath)
+	return nil
+}
+
+// errHeaderRead stops a parse once the header has been read.
+var errHeaderRead = errors.New("header read")
+
+// readHeader returns the HEAD record at the start of filePath, or nil if the
+// file does not start with one.
+func readHeader(filePath string) types.Record {
+	var header types.Record
+	_ = NewStreamingHierarchicalParser().ParseWithHandler(filePath, func(record types.Record) error {
+		if record.Type() == types.RecordTypeHEAD {
+			header = record
+		}
+		return errHeaderRead
+	})
+	return header
+}
+
+// Index returns the index in use.
+func (ir *IndexedReader) Index() *RecordIndex {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	return ir.index
+}
+
+// Record reads and parses the record with the given xref. Line numbers of the
+// returned lines refer to the whole file. The record has the stable UUID it
+// gets when the whole file is parsed (see SetUUIDNamespace), unless its
+// identifier is also stated by an earlier record.
+func (ir *IndexedReader) Record(xref string) (types.Record, error) {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+
+	if ir.file == nil {
+		return nil, fmt.Errorf("reader is closed")
+	}
+	if ir.index.IsStale() {
+		if err := ir.open(); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, ok := ir.index.Lookup(xref)
+	if !ok {
+		return nil, fmt.Errorf("record %s not found", xref)
+	}
+
+	data := make([]byte, entry.Length)
+	if _, err := ir.file.ReadAt(data, entry.Offset); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read record %s: %w", xref, err)
+	}
+
+	record, err := ir.parseRecord(data, entry)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.FirstLine().XrefID != xref {
+		return nil, fmt.Errorf("record index is out of date: %s not found at offset %d", xref, entry.Offset)
+	}
+	types.NewUUIDAssigner(uuidNamespaceFor(ir.uuidNamespace, ir.header, ir.filePath)).Assign(record)
+	return record, nil
+}
+
+// parseRecord parses the bytes of one record.
+func (ir *IndexedReader) parseRecord(data []byte, entry RecordOffset) (types.Record, error) {
+	reader, err := NewDecodingReader(bytes.NewReader(data), ir.index.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reader: %w", err)
+	}
+
+	shp := NewStreamingHierarchicalParser()
+	shp.errorManager = ir.errorManager
+	var record types.Record
+	err = shp.parseStream(reader, ir.index.Encoding, false, false, func(r types.Record) error {
+		if record == nil {
+			record = r
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if record != nil {
+		shiftLineNumbers(record.FirstLine(), entry.Line-1)
+	}
+	return record, nil
+}
+
+// shiftLineNumbers adds delta to the line numbers of line and its descendants.
+func shiftLineNumbers(line *types.GedcomLine, delta int) {
+	line.LineNumber += delta
+	for _, child := range line.ChildLines() {
+		shiftLineNumbers(child, delta)
+	}
+}
+
+// Individual reads the INDI record with the given xref.
+func (ir *IndexedReader) Individual(xref string) (*types.IndividualRecord, error) {
+	record, err := ir.Record(xref)
+	if err != nil {
+		return nil, err
+	}
+	indi, ok := record.(*types.IndividualRecord)
+	if !ok {
+		return nil, fmt.Errorf("record %s is a %s, not an individual", xref, record.Type())
+	}
+	return indi, nil
+}
+
+// Family reads the FAM record with the given xref.
+func (ir *IndexedReader) Family(xref string) (*types.FamilyRecord, error) {
+	record, err := ir.Record(xref)
+	if err != nil {
+		return nil, err
+	}
+	fam, ok := record.(*types.FamilyRecord)
+	if !ok {
+		return nil, fmt.Errorf("record %s is a %s, not a family", xref, record.Type())
+	}
+	return fam, nil
+}
+
+// GetErrors returns the warnings collected while parsing records.
+func (ir *IndexedReader) GetErrors() []*types.GedcomError {
+	return ir.errorManager.Errors()
+}
+
+// Close closes the file.
+func (ir *IndexedReader) Close() error {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+
+	if ir.file == nil {
+		return nil
+	}
+	err := ir.file.Close()
+	ir.file = nil
+	return err
+}
@@ -3,6 +3,8 @@ package parser
 import (
 	"fmt"
 	"strconv"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
 // ParseLineFast is an optimized version of ParseLine that uses manual byte parsing
@@ -11,10 +13,13 @@ import (
 // Performance improvement: ~2-3x faster than ParseLine for typical GEDCOM files.
 //
 // Note: Assumes input line is already trimmed (no leading/trailing whitespace).
+//
+// Errors are *LineError values carrying a diagnostic code and the position of
+// the problem.
 func ParseLineFast(line string) (level int, tag string, value string, xrefID string, err error) {
 	// Check for empty line
 	if len(line) == 0 {
-		return 0, "", "", "", fmt.Errorf("empty line")
+		return 0, "", "", "", &LineError{Code: types.CodeMissingTag, Message: "empty line"}
 	}
 
 	// Find first space (level ends here)
@@ -26,25 +31,29 @@ func ParseLineFast(line string) (level int, tag string, value string, xrefID str
 		}
 	}
 	if firstSpace == -1 {
-		return 0, "", "", "", fmt.Errorf("line has insufficient parts: %q", line)
+		lineErr := &LineError{Code: types.CodeInvalidLevel, Message: fmt.Sprintf("line has insufficient parts: %q", line)}
+		if _, err := strconv.Atoi(line); err == nil {
+			lineErr.Code, lineErr.Offset = types.CodeMissingTag, len(line)
+		}
+		return 0, "", "", "", lineErr
 	}
 
 	// Parse level (first part, before first space)
 	levelStr := line[:firstSpace]
 	level, err = strconv.Atoi(levelStr)
 	if err != nil {
-		return 0, "", "", "", fmt.Errorf("invalid level %q: %w", levelStr, err)
+		return 0, "", "", "", &LineError{Code: types.CodeInvalidLevel, Message: fmt.Sprintf("invalid level %q: %v", levelStr, err), Err: err}
 	}
 
 	// Level must be non-negative
 	if level < 0 {
-		return 0, "", "", "", fmt.Errorf("level cannot be negative: %d", level)
+		return 0, "", "", "", &LineError{Code: types.CodeInvalidLevel, Message: fmt.Sprintf("level cannot be negative: %d", level)}
 	}
 
 	// Find second space (after level)
 	start := firstSpace + 1
 	if start >= len(line) {
-		return 0, "", "", "", fmt.Errorf("line has insufficient parts: %q", line)
+		return 0, "", "", "", &LineError{Code: types.CodeMissingTag, Offset: len(line), Message: fmt.Sprintf("line has insufficient parts: %q", line)}
 	}
 
 	// Find end of second token (tag or xref)
@@ -72,13 +81,13 @@ func ParseLineFast(line string) (level int, tag string, value string, xrefID str
 		// Format: level xref tag [value]
 		xrefID = secondToken
 		if secondSpace == -1 {
-			return 0, "", "", "", fmt.Errorf("line with xref missing tag: %q", line)
+			return 0, "", "", "", &LineError{Code: types.CodeMissingTag, Offset: len(line), Message: fmt.Sprintf("line with xref missing tag: %q", line)}
 		}
 
 		// Find tag (starts after second space)
 		tagStart := secondSpace + 1
 		if tagStart >= len(line) {
-			return 0, "", "", "", fmt.Errorf("line with xref missing tag: %q", line)
+			return 0, "", "", "", &LineError{Code: types.CodeMissingTag, Offset: len(line), Message: fmt.Sprintf("line with xref missing tag: %q", line)}
 		}
 
 		// Find third space (tag ends here, value starts after)
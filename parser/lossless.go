@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// SetLossless enables lossless parsing. In lossless mode the parser keeps the
+// original text of every line (see types.GedcomLine.Raw), including line
+// terminators, blank lines and CONC/CONT split points, and records the file
+// layout on the tree. This lets GedcomExporter reproduce unmodified records
+// byte-for-byte, at the cost of roughly doubling memory use.
+//
+// The original text is kept after decoding, so files in encodings other than
+// UTF-8 are reproduced in UTF-8.
+func (hp *HierarchicalParser) SetLossless(enabled bool) {
+	hp.lossless = enabled
+}
+
+// scanLinesKeepEOL is a bufio.SplitFunc like bufio.ScanLines that keeps the
+// line terminator ("\n" or "\r\n") in the returned token.
+func scanLinesKeepEOL(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// lineEnding returns the terminator of a token returned by scanLinesKeepEOL.
+func lineEnding(token string) string {
+	switch {
+	case strings.HasSuffix(token, "\r\n"):
+		return "\r\n"
+	case strings.HasSuffix(token, "\n"):
+		return "\n"
+	}
+	return ""
+}
+
+// markPristine snapshots every record of tree so later edits can be detected.
+func markPristine(tree *types.GedcomTree) {
+	for _, record := range tree.GetAllRecords() {
+		record.FirstLine().MarkPristine()
+	}
+}
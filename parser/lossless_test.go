@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+const losslessSample = "\uFEFF0 HEAD\r\n" +
+	"1 CHAR UTF-8\r\n" +
+	"0 @I1@ INDI\r\n" +
+	"1 SEX M\r\n" +
+	"1 NAME John /Doe/\r\n" +
+	"1 NOTE A note that was spl\r\n" +
+	"2 CONC it in the middle\r\n" +
+	"2 CONT of a word\r\n" +
+	"\r\n" +
+	"1 BIRT\r\n" +
+	"2 DATE 1 JAN 1900\r\n" +
+	"0 TRLR"
+
+func TestHierarchicalParser_Lossless(t *testing.T) {
+	p := NewHierarchicalParser()
+	p.SetLossless(true)
+	tree, err := p.ParseReader(strings.NewReader(losslessSample))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+
+	layout := tree.GetSourceLayout()
+	if layout == nil {
+		t.Fatal("expected source layout to be recorded")
+	}
+	if !layout.BOM || layout.LineEnding != "\r\n" {
+		t.Errorf("layout = %+v, want BOM and CRLF", *layout)
+	}
+
+	indi := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	first := indi.FirstLine()
+	if first.Raw() != "0 @I1@ INDI\r\n" {
+		t.Errorf("INDI Raw() = %q", first.Raw())
+	}
+
+	// Children keep file order, not tag order
+	var tags []string
+	for _, child := range first.ChildLines() {
+		tags = append(tags, child.Tag)
+	}
+	if got := strings.Join(tags, " "); got != "SEX NAME NOTE BIRT" {
+		t.Errorf("child order = %q, want %q", got, "SEX NAME NOTE BIRT")
+	}
+
+	note := first.Children["NOTE"][0]
+	wantNote := "1 NOTE A note that was spl\r\n2 CONC it in the middle\r\n2 CONT of a word\r\n"
+	if note.Raw() != wantNote {
+		t.Errorf("NOTE Raw() = %q, want %q", note.Raw(), wantNote)
+	}
+	if note.Value != "A note that was split in the middle\nof a word" {
+		t.Errorf("NOTE value = %q", note.Value)
+	}
+
+	// The blank line is kept with the line that follows it
+	birt := first.Children["BIRT"][0]
+	if birt.Raw() != "\r\n1 BIRT\r\n" {
+		t.Errorf("BIRT Raw() = %q", birt.Raw())
+	}
+	if note.IsModified() || birt.IsModified() {
+		t.Error("expected parsed lines to be unmodified")
+	}
+
+	// Records are listed in file order
+	var order []string
+	for _, record := range tree.GetAllRecords() {
+		order = append(order, record.FirstLine().Tag)
+	}
+	if got := strings.Join(order, " "); got != "HEAD INDI TRLR" {
+		t.Errorf("record order = %q, want %q", got, "HEAD INDI TRLR")
+	}
+}
+
+func TestHierarchicalParser_LosslessDisabled(t *testing.T) {
+	tree, err := NewHierarchicalParser().ParseReader(strings.NewReader(losslessSample))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	if tree.GetSourceLayout() != nil {
+		t.Error("expected no source layout without lossless mode")
+	}
+	indi := tree.GetIndividual("@I1@").(*types.IndividualRecord)
+	if indi.FirstLine().Raw() != "" {
+		t.Errorf("expected no raw text, got %q", indi.FirstLine().Raw())
+	}
+}
@@ -0,0 +1,463 @@
+package parser
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// minChunkSize is the smallest part of a file given to one worker; smaller
+// files are parsed by fewer workers.
+const minChunkSize = 256 * 1024
+
+// ParallelHierarchicalParser parses a GEDCOM file on several cores. The file
+// is split into byte ranges that start at level-0 lines, each range is parsed
+// by its own worker, and the results are merged in file order into one tree.
+//
+// The result is the same as HierarchicalParser's: records, line numbers
+// (which refer to the whole file) and errors, in the same order. Only the
+// time spent differs, which makes it worthwhile for files of many megabytes.
+//
+// UTF-16 files cannot be split at byte offsets and are parsed sequentially.
+//
+// Example:
+//
+//	p := parser.NewParallelHierarchicalParser()
+//	p.SetWorkers(8)
+//	tree, err := p.Parse("huge.ged")
+type ParallelHierarchicalParser struct {
+	tree          *types.GedcomTree
+	errorManager  *types.ErrorManager
+	numWorkers    int
+	lossless      bool
+	recovery      RecoveryPolicy
+	uuidNamespace string
+}
+
+// NewParallelHierarchicalParser creates a parser using one worker per CPU.
+func NewParallelHierarchicalParser() *ParallelHierarchicalParser {
+	return &ParallelHierarchicalParser{
+		tree:         types.NewGedcomTree(),
+		errorManager: types.NewErrorManager(),
+		numWorkers:   runtime.NumCPU(),
+	}
+}
+
+// SetWorkers sets the maximum number of workers. Values below 1 mean one
+// worker per CPU.
+func (pp *ParallelHierarchicalParser) SetWorkers(n int) {
+	if n < 1 {
+		n = runtime.NumCPU()
+	}
+	pp.numWorkers = n
+}
+
+// SetLossless enables or disables lossless mode (see HierarchicalParser.SetLossless).
+func (pp *ParallelHierarchicalParser) SetLossless(enabled bool) {
+	pp.lossless = enabled
+}
+
+// Parse parses a GEDCOM file and builds the complete hierarchical tree structure.
+// Returns the tree and any parsing errors (warnings don't stop parsing).
+func (pp *ParallelHierarchicalParser) Parse(filePath string) (*types.GedcomTree, error) {
+	return pp.ParseContext(context.Background(), filePath)
+}
+
+// ParseContext is like Parse but stops with ctx's error once ctx is cancelled
+// or its deadline passes. Progress is reported in bytes read, out of the file
+// size, to the ProgressReporter attached with types.WithProgress.
+func (pp *ParallelHierarchicalParser) ParseContext(ctx context.Context, filePath string) (*types.GedcomTree, error) {
+	if err := ValidateFile(filePath); err != nil {
+		pp.errorManager.AddCodedError(types.CodeInvalidFile, types.SeveritySevere, fmt.Sprintf("File validation failed: %v", err), 0, "File Validation")
+		return nil, fmt.Errorf("file validation failed: %w", err)
+	}
+
+	encoding, err := DetectEncoding(filePath)
+	if err != nil {
+		pp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Encoding detection failed: %v", err), 0, "Encoding Detection")
+		return nil, fmt.Errorf("encoding detection failed: %w", err)
+	}
+	if isUTF16(encoding) {
+		return pp.parseSequential(ctx, filePath)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		pp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to open file: %v", err), 0, "File I/O")
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		pp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to stat file: %v", err), 0, "File I/O")
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	// Parse, starting over if HEAD.CHAR calls for another decoder
+	var tree *types.GedcomTree
+	for {
+		tracker := types.NewProgressTracker(ctx, progressStage, info.Size())
+		tree, err = pp.parseChunks(file, info.Size(), encoding, &sharedTracker{tracker: tracker})
+		if err == nil {
+			tracker.Finish()
+		}
+
+		var restart *encodingRestartError
+		if !errors.As(err, &restart) {
+			break
+		}
+		encoding = restart.encoding
+		pp.errorManager.AddDiagnostic(restart.notice)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pp.lossless {
+		markPristine(tree)
+	}
+	assignUUIDs(tree, pp.uuidNamespace, filePath)
+	tree.SetMediaSource(types.NewDirMediaSource(filepath.Dir(filePath)))
+
+	pp.tree = tree
+	return tree, nil
+}
+
+// parseSequential parses filePath with a HierarchicalParser.
+func (pp *ParallelHierarchicalParser) parseSequential(ctx context.Context, filePath string) (*types.GedcomTree, error) {
+	hp := NewHierarchicalParser()
+	hp.errorManager = pp.errorManager
+	hp.SetLossless(pp.lossless)
+	hp.SetRecoveryPolicy(pp.recovery)
+	hp.SetUUIDNamespace(pp.uuidNamespace)
+	tree, err := hp.ParseContext(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	pp.tree = tree
+	return tree, nil
+}
+
+// chunkResult is the outcome of parsing one byte range of a file.
+type chunkResult struct {
+	tree         *types.GedcomTree
+	errorManager *types.ErrorManager
+	lines        int
+	err          error
+}
+
+// parseChunks splits file at level-0 lines, parses the parts concurrently and
+// merges them. Errors are added to pp.errorManager in file order.
+func (pp *ParallelHierarchicalParser) parseChunks(file *os.File, size int64, encoding Encoding, tracker *sharedTracker) (*types.GedcomTree, error) {
+	bounds, err := chunkBoundaries(file, size, pp.chunkCount(size))
+	if err != nil {
+		pp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to read file: %v", err), 0, "File I/O")
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Chunks after the first need the version declared in the header
+	var versions *versionTracker
+	if len(bounds) > 2 {
+		if versions, err = readHeaderVersions(file, bounds[1], encoding); err != nil {
+			pp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to read file: %v", err), 0, "File I/O")
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+
+	results := make([]chunkResult, len(bounds)-1)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var seed *versionTracker
+			if i > 0 {
+				seed = versions
+			}
+			results[i] = pp.parseChunk(file, bounds[i], bounds[i+1], encoding, seed, tracker)
+		}(i)
+	}
+	wg.Wait()
+
+	return pp.merge(results, encoding)
+}
+
+// chunkCount returns the number of parts to split a file of size bytes into.
+func (pp *ParallelHierarchicalParser) chunkCount(size int64) int {
+	n := pp.numWorkers
+	if limit := int(size / minChunkSize); n > limit {
+		n = limit
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// parseChunk parses bytes [start, end) of file with its own HierarchicalParser.
+// Line numbers are relative to the start of the chunk. versions carries the
+// header state for chunks after the first.
+func (pp *ParallelHierarchicalParser) parseChunk(file *os.File, start, end int64, encoding Encoding, versions *versionTracker, tracker *sharedTracker) chunkResult {
+	hp := NewHierarchicalParser()
+	hp.lossless = pp.lossless
+	hp.recovery = pp.recovery
+	if versions != nil {
+		hp.versions = versions.fork(hp.errorManager)
+	}
+	result := chunkResult{tree: hp.tree, errorManager: hp.errorManager}
+
+	hasBOM := false
+	if start == 0 {
+		bom := make([]byte, 4)
+		n, _ := file.ReadAt(bom, 0)
+		hasBOM = HasBOM(bom[:n])
+	}
+
+	section := io.NewSectionReader(file, start, end-start)
+	reader, err := NewDecodingReader(&trackedChunkReader{r: section, tracker: tracker}, encoding)
+	if err != nil {
+		hp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
+		result.err = fmt.Errorf("failed to create reader: %w", err)
+		return result
+	}
+
+	// Only the first chunk contains HEAD.CHAR and may restart the parse
+	result.err = hp.parseStream(reader, encoding, hasBOM, start == 0)
+	result.lines = hp.lineCount
+	return result
+}
+
+// merge combines the chunk trees and errors in file order, shifting line
+// numbers to refer to the whole file. Version messages are reported once per
+// file, as by HierarchicalParser.
+func (pp *ParallelHierarchicalParser) merge(results []chunkResult, encoding Encoding) (*types.GedcomTree, error) {
+	// A restart discards the whole attempt, diagnostics included
+	var restart *encodingRestartError
+	if errors.As(results[0].err, &restart) {
+		return nil, restart
+	}
+
+	tree := types.NewGedcomTree()
+	tree.SetEncoding(string(encoding))
+	if pp.lossless {
+		tree.SetSourceLayout(results[0].tree.GetSourceLayout())
+	}
+
+	offset := 0
+	reported := make(map[string]bool)
+	for _, result := range results {
+		// In strict mode the error is the diagnostic, whose line is shifted below
+		var failed *types.GedcomError
+		errors.As(result.err, &failed)
+
+		for _, e := range result.errorManager.Errors() {
+			if e.Context == "GEDCOM Version" {
+				if reported[e.Message] {
+					continue
+				}
+				reported[e.Message] = true
+			}
+			diag := *e
+			if diag.LineNumber > 0 {
+				diag.LineNumber += offset
+			}
+			pp.errorManager.AddDiagnostic(&diag)
+			if e == failed {
+				result.err = &diag
+			}
+		}
+		if result.err != nil {
+			return nil, result.err
+		}
+
+		for _, record := range result.tree.GetAllRecords() {
+			if offset > 0 {
+				shiftLineNumbers(record.FirstLine(), offset)
+			}
+			tree.AddRecord(record)
+		}
+		if tree.GetVersion() == "" {
+			tree.SetVersion(result.tree.GetVersion())
+		}
+		offset += result.lines
+	}
+	return tree, nil
+}
+
+// chunkBoundaries returns the offsets at which the parts of a file start,
+// followed by size. Each part after the first starts at a level-0 line, or at
+// the blank lines before it, so the line that continues a value or record is
+// always in the same part.
+func chunkBoundaries(r io.ReaderAt, size int64, n int) ([]int64, error) {
+	bounds := []int64{0}
+	for i := 1; i < n; i++ {
+		target := size * int64(i) / int64(n)
+		if target <= bounds[len(bounds)-1] {
+			continue
+		}
+		boundary, err := nextRecordStart(r, target, size)
+		if err != nil {
+			return nil, err
+		}
+		if boundary > bounds[len(bounds)-1] && boundary < size {
+			bounds = append(bounds, boundary)
+		}
+	}
+	return append(bounds, size), nil
+}
+
+// nextRecordStart returns the offset of the first level-0 line starting at or
+// after offset, moved back over any blank lines directly before it, or size if
+// there is none.
+func nextRecordStart(r io.ReaderAt, offset, size int64) (int64, error) {
+	// Start one byte early to find out whether offset is at a line start
+	pos := offset - 1
+	reader := bufio.NewReader(io.NewSectionReader(r, pos, size-pos))
+
+	readLine := func() ([]byte, error) {
+		line, err := reader.ReadSlice('\n')
+		pos += int64(len(line))
+		// Lines longer than the buffer are read in pieces; only the first matters
+		for err == bufio.ErrBufferFull {
+			var more []byte
+			more, err = reader.ReadSlice('\n')
+			pos += int64(len(more))
+		}
+		return line, err
+	}
+
+	// Skip the rest of the line containing offset-1
+	if _, err := readLine(); err != nil {
+		if err == io.EOF {
+			return size, nil
+		}
+		return 0, err
+	}
+
+	blankStart := int64(-1)
+	for {
+		start := pos
+		line, err := readLine()
+		if len(line) > 0 {
+			if len(strings.TrimSpace(string(line))) == 0 {
+				if blankStart < 0 {
+					blankStart = start
+				}
+			} else if _, _, ok := levelZeroLine(line, false); ok {
+				if blankStart >= 0 {
+					return blankStart, nil
+				}
+				return start, nil
+			} else {
+				blankStart = -1
+			}
+		}
+		if err == io.EOF {
+			return size, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// readHeaderVersions reads the first record of file, normally HEAD, and
+// returns a versionTracker that has seen it. Reading stops at end.
+func readHeaderVersions(file *os.File, end int64, encoding Encoding) (*versionTracker, error) {
+	reader, err := NewDecodingReader(io.NewSectionReader(file, 0, end), encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	// Messages about the header are reported by the first chunk
+	versions := newVersionTracker(types.NewErrorManager())
+	scanner := bufio.NewScanner(reader)
+	records := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		level, tag, value, _, err := ParseLineFast(line)
+		if err != nil {
+			continue
+		}
+		if level == 0 {
+			if records++; records > 1 {
+				break
+			}
+		}
+		versions.observe(level, tag, value, 0)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if versions.inHeader {
+		versions.finishHeader()
+		versions.inHeader = false
+	}
+	return versions, nil
+}
+
+// sharedTracker lets several chunk readers report to one ProgressTracker.
+type sharedTracker struct {
+	mu      sync.Mutex
+	tracker *types.ProgressTracker
+}
+
+// Add records n more bytes read (see types.ProgressTracker.Add).
+func (st *sharedTracker) Add(n int64) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.tracker.Add(n)
+}
+
+// trackedChunkReader counts the bytes read from one chunk with a sharedTracker.
+type trackedChunkReader struct {
+	r       io.Reader
+	tracker *sharedTracker
+}
+
+// Read implements io.Reader.
+func (tr *trackedChunkReader) Read(p []byte) (int, error) {
+	n, err := tr.r.Read(p)
+	if trackErr := tr.tracker.Add(int64(n)); trackErr != nil {
+		return n, trackErr
+	}
+	return n, err
+}
+
+// GetErrors returns all errors collected during parsing.
+func (pp *ParallelHierarchicalParser) GetErrors() []*types.GedcomError {
+	return pp.errorManager.Errors()
+}
+
+// HasErrors returns true if any errors were encountered.
+func (pp *ParallelHierarchicalParser) HasErrors() bool {
+	return pp.errorManager.HasErrors()
+}
+
+// HasSevereErrors returns true if any severe errors were encountered.
+func (pp *ParallelHierarchicalParser) HasSevereErrors() bool {
+	return pp.errorManager.HasSevereErrors()
+}
+
+// GetErrorManager returns the error manager (for advanced usage).
+func (pp *ParallelHierarchicalParser) GetErrorManager() *types.ErrorManager {
+	return pp.errorManager
+}
+
+// GetTree returns the parsed tree.
+func (pp *ParallelHierarchicalParser) GetTree() *types.GedcomTree {
+	return pp.tree
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// largeGedcom7 returns a GEDCOM 7.0 file of about 1.5MB with blank lines,
+// malformed lines and 5.5.1 structures spread through it.
+func largeGedcom7() string {
+	var b strings.Builder
+	b.WriteString("\xEF\xBB\xBF0 HEAD\r\n1 GEDC\r\n2 VERS 7.0\r\n1 CHAR UTF-8\r\n")
+	for i := 1; i <= 12000; i++ {
+		fmt.Fprintf(&b, "0 @I%d@ INDI\r\n1 NAME Person%d /Family%d/\r\n", i, i, i%97)
+		fmt.Fprintf(&b, "1 BIRT\r\n2 DATE %d JAN 18%02d\r\n2 PLAC Town %d\r\n", i%28+1, i%100, i%31)
+		fmt.Fprintf(&b, "1 NOTE A note that was spl\r\n2 CONC it for person %d\r\n2 CONT second line\r\n", i)
+		if i%1000 == 0 {
+			b.WriteString("\r\nnot a gedcom line\r\n3 ORPHAN line\r\n1 RIN 12\r\n\r\n")
+		}
+	}
+	b.WriteString("0 TRLR\r\n")
+	return b.String()
+}
+
+// dumpTree lists every line of a tree with its number, value and raw text.
+func dumpTree(tree *types.GedcomTree) []string {
+	var out []string
+	var walk func(line *types.GedcomLine)
+	walk = func(line *types.GedcomLine) {
+		out = append(out, fmt.Sprintf("%d|%d|%s|%s|%s|%q", line.LineNumber, line.Level, line.XrefID, line.Tag, line.Value, line.Raw()))
+		for _, child := range line.ChildLines() {
+			walk(child)
+		}
+	}
+	for _, record := range tree.GetAllRecords() {
+		walk(record.FirstLine())
+	}
+	return out
+}
+
+func dumpErrors(errs []*types.GedcomError) []string {
+	out := make([]string, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, fmt.Sprintf("%v|%d|%s|%s", e.Severity, e.LineNumber, e.Context, e.Message))
+	}
+	return out
+}
+
+func TestParallelHierarchicalParser_MatchesSequential(t *testing.T) {
+	path := writeIndexedFile(t, largeGedcom7())
+
+	hp := NewHierarchicalParser()
+	hp.SetLossless(true)
+	want, err := hp.Parse(path)
+	if err != nil {
+		t.Fatalf("HierarchicalParser.Parse() error = %v", err)
+	}
+
+	pp := NewParallelHierarchicalParser()
+	pp.SetWorkers(4)
+	pp.SetLossless(true)
+	got, err := pp.Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if n := len(dumpTree(want)); n < 12000*6 {
+		t.Fatalf("expected a large tree, got %d lines", n)
+	}
+	wantLines, gotLines := dumpTree(want), dumpTree(got)
+	if len(gotLines) != len(wantLines) {
+		t.Fatalf("got %d lines, want %d", len(gotLines), len(wantLines))
+	}
+	for i := range wantLines {
+		if gotLines[i] != wantLines[i] {
+			t.Fatalf("line %d = %s, want %s", i, gotLines[i], wantLines[i])
+		}
+	}
+
+	wantErrs, gotErrs := dumpErrors(hp.GetErrors()), dumpErrors(pp.GetErrors())
+	if strings.Join(gotErrs, "\n") != strings.Join(wantErrs, "\n") {
+		t.Errorf("errors differ:\ngot:\n%s\nwant:\n%s", strings.Join(gotErrs, "\n"), strings.Join(wantErrs, "\n"))
+	}
+
+	if got.GetVersion() != "7.0" || got.GetEncoding() != want.GetEncoding() {
+		t.Errorf("version %q encoding %q, want 7.0 and %q", got.GetVersion(), got.GetEncoding(), want.GetEncoding())
+	}
+	layout := got.GetSourceLayout()
+	if layout == nil || !layout.BOM || layout.LineEnding != "\r\n" {
+		t.Errorf("layout = %+v, want BOM and CRLF", layout)
+	}
+	if got.GetIndividual("@I12000@") == nil || got.GetHeader() == nil {
+		t.Error("expected all records in the merged tree")
+	}
+}
+
+func TestParallelHierarchicalParser_Deterministic(t *testing.T) {
+	path := writeIndexedFile(t, largeGedcom7())
+
+	var first []string
+	for run := 0; run < 3; run++ {
+		pp := NewParallelHierarchicalParser()
+		pp.SetWorkers(6)
+		if _, err := pp.Parse(path); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		errs := dumpErrors(pp.GetErrors())
+		if run == 0 {
+			first = errs
+			continue
+		}
+		if strings.Join(errs, "\n") != strings.Join(first, "\n") {
+			t.Fatalf("run %d reported different errors", run)
+		}
+	}
+}
+
+func TestParallelHierarchicalParser_Cancelled(t *testing.T) {
+	path := writeIndexedFile(t, largeGedcom7())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pp := NewParallelHierarchicalParser()
+	pp.SetWorkers(4)
+	if _, err := pp.ParseContext(ctx, path); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestChunkBoundaries(t *testing.T) {
+	data := "0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME A /B/\n\n\n0 @I2@ INDI\n1 NOTE x\n2 CONT 0 y\n0 TRLR\n"
+	r := strings.NewReader(data)
+
+	bounds, err := chunkBoundaries(r, int64(len(data)), 4)
+	if err != nil {
+		t.Fatalf("chunkBoundaries() error = %v", err)
+	}
+	for i, b := range bounds[1 : len(bounds)-1] {
+		rest := data[b:]
+		if !strings.HasPrefix(strings.TrimLeft(rest, "\n"), "0 ") {
+			t.Errorf("chunk %d starts with %q", i+1, rest)
+		}
+		if strings.HasPrefix(rest, "\n") && data[b-1] != '\n' {
+			t.Errorf("chunk %d starts mid-line", i+1)
+		}
+	}
+	if bounds[0] != 0 || bounds[len(bounds)-1] != int64(len(data)) {
+		t.Errorf("bounds = %v", bounds)
+	}
+
+	// Blank lines before a record stay with it
+	if got, _ := nextRecordStart(r, int64(strings.Index(data, "1 NAME")), int64(len(data))); data[got:got+2] != "\n\n" {
+		t.Errorf("nextRecordStart() = %d (%q), want the blank lines before @I2@", got, data[got:])
+	}
+	if got, _ := nextRecordStart(r, int64(strings.Index(data, "0 TRLR"))+1, int64(len(data))); got != int64(len(data)) {
+		t.Errorf("nextRecordStart() past the last record = %d, want %d", got, len(data))
+	}
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// progressStage is the stage name parsers report progress under.
+const progressStage = "Parsing"
+
+// progressReader counts the bytes read from r with a ProgressTracker and
+// fails with the context's error once the context is done, which stops the
+// line scanner reading from it.
+type progressReader struct {
+	r       io.Reader
+	tracker *types.ProgressTracker
+}
+
+// Read implements io.Reader.
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	if trackErr := pr.tracker.Add(int64(n)); trackErr != nil {
+		return n, trackErr
+	}
+	return n, err
+}
+
+// getTrackedReader is like GetReader but counts the bytes read from file,
+// before decoding, so progress matches the file size.
+func getTrackedReader(file *os.File, encoding Encoding, tracker *types.ProgressTracker) (io.Reader, error) {
+	if encoding != EncodingUTF8 {
+		return NewDecodingReader(&progressReader{r: file, tracker: tracker}, encoding)
+	}
+	reader, err := GetReader(file, encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &progressReader{r: reader, tracker: tracker}, nil
+}
+
+// isContextError reports whether err comes from a cancelled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+func TestHierarchicalParser_ParseContext_Progress(t *testing.T) {
+	path := findTestDataFile("royal92.ged")
+	if path == "" {
+		t.Skip("royal92.ged not found")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	var last, total int64
+	reports := 0
+	ctx := types.WithProgress(context.Background(), types.ProgressFunc(func(stage string, done, n int64) {
+		reports++
+		last, total = done, n
+	}))
+
+	tree, err := NewHierarchicalParser().ParseContext(ctx, path)
+	if err != nil {
+		t.Fatalf("ParseContext() error = %v", err)
+	}
+	if len(tree.GetAllIndividuals()) == 0 {
+		t.Error("expected individuals in parsed tree")
+	}
+	if total != info.Size() || last != info.Size() {
+		t.Errorf("final progress %d/%d, want %d/%d", last, total, info.Size(), info.Size())
+	}
+	if reports < 10 {
+		t.Errorf("got %d progress reports, want at least 10", reports)
+	}
+}
+
+func TestHierarchicalParser_ParseContext_Cancelled(t *testing.T) {
+	path := findTestDataFile("royal92.ged")
+	if path == "" {
+		t.Skip("royal92.ged not found")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := NewHierarchicalParser()
+	tree, err := p.ParseContext(ctx, path)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ParseContext() error = %v, want context.Canceled", err)
+	}
+	if tree != nil {
+		t.Error("expected no tree from a cancelled parse")
+	}
+	if p.HasSevereErrors() {
+		t.Errorf("cancellation should not be reported as a parse error: %v", p.GetErrors())
+	}
+}
+
+func TestSmartParser_ParseReaderContext_Cancelled(t *testing.T) {
+	data := "0 HEAD\n1 CHAR UTF-8\n" + strings.Repeat("0 @I1@ INDI\n1 NAME John /Doe/\n", 5000) + "0 TRLR\n"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewParser().ParseReaderContext(ctx, strings.NewReader(data))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ParseReaderContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestStreamingParser_ParseWithHandlerContext_Cancelled(t *testing.T) {
+	path := findTestDataFile("royal92.ged")
+	if path == "" {
+		t.Skip("royal92.ged not found")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	records := 0
+	err := NewStreamingHierarchicalParser().ParseWithHandlerContext(ctx, path, func(record types.Record) error {
+		records++
+		if records == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ParseWithHandlerContext() error = %v, want context.Canceled", err)
+	}
+	if records < 10 {
+		t.Errorf("handled %d records before cancelling, want at least 10", records)
+	}
+}
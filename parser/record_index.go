@@ -0,0 +1,304 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recordIndexVersion is written on the first line of sidecar index files.
+const recordIndexVersion = "GEDCOM-RECORD-INDEX 1"
+
+// RecordOffset locates one level-0 record in a GEDCOM file.
+type RecordOffset struct {
+	Xref   string // Cross-reference ID, e.g. "@I1@"
+	Tag    string // Record tag, e.g. "INDI"
+	Offset int64  // Byte offset of the record's first line
+	Length int64  // Length in bytes, up to the next level-0 line
+	Line   int    // Line number of the record's first line
+}
+
+// RecordIndex maps the xrefs of a GEDCOM file to the byte ranges of their
+// records, so single records can be read without parsing the whole file.
+// Indexes are stored in a sidecar file next to the GEDCOM file (see
+// RecordIndexPath) and are out of date once the file's size or modification
+// time changes.
+//
+// Only byte-oriented encodings (UTF-8, ASCII, ANSEL, ANSI, ISO-8859-1,
+// MacRoman) can be indexed; UTF-16 files cannot.
+type RecordIndex struct {
+	FilePath string    // GEDCOM file the index describes
+	Encoding Encoding  // Encoding of the file
+	Size     int64     // Size of the file when it was indexed
+	ModTime  time.Time // Modification time of the file when it was indexed
+
+	records map[string]RecordOffset
+}
+
+// RecordIndexPath returns the path of the sidecar index for a GEDCOM file:
+// the file path with ".idx" appended.
+func RecordIndexPath(filePath string) string {
+	return filePath + ".idx"
+}
+
+// BuildRecordIndex scans a GEDCOM file and records the offset and length of
+// every level-0 record that has an xref. It reads the file once without
+// parsing records. If an xref is used by several records, the first is indexed.
+func BuildRecordIndex(filePath string) (*RecordIndex, error) {
+	if err := ValidateFile(filePath); err != nil {
+		return nil, fmt.Errorf("file validation failed: %w", err)
+	}
+
+	encoding, err := DetectEncoding(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("encoding detection failed: %w", err)
+	}
+	if isUTF16(encoding) {
+		return nil, fmt.Errorf("cannot index %s files", encoding)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	index := &RecordIndex{
+		FilePath: filePath,
+		Encoding: encoding,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		records:  make(map[string]RecordOffset),
+	}
+
+	var current *RecordOffset
+	closeRecord := func(end int64) {
+		if current == nil {
+			return
+		}
+		current.Length = end - current.Offset
+		if _, exists := index.records[current.Xref]; !exists && current.Xref != "" {
+			index.records[current.Xref] = *current
+		}
+		current = nil
+	}
+
+	reader := bufio.NewReaderSize(file, 1<<20)
+	var offset int64
+	lineNumber := 0
+	for {
+		line, err := reader.ReadSlice('\n')
+		start := offset
+		offset += int64(len(line))
+		if len(line) > 0 {
+			lineNumber++
+			if xref, tag, ok := levelZeroLine(line, start == 0); ok {
+				closeRecord(start)
+				current = &RecordOffset{Xref: xref, Tag: tag, Offset: start, Line: lineNumber}
+			}
+		}
+
+		// Lines longer than the buffer are read in pieces; only the first matters
+		for err == bufio.ErrBufferFull {
+			line, err = reader.ReadSlice('\n')
+			offset += int64(len(line))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+	closeRecord(offset)
+
+	return index, nil
+}
+
+// levelZeroLine reports whether line starts a level-0 record and returns its
+// xref ("" if none) and tag. first is set for the first line of the file,
+// which may start with a UTF-8 BOM.
+func levelZeroLine(line []byte, first bool) (xref, tag string, ok bool) {
+	if first {
+		line = bytes.TrimPrefix(line, []byte("\xEF\xBB\xBF"))
+	}
+	line = bytes.TrimLeft(line, " \t")
+	if len(line) < 2 || line[0] != '0' || (line[1] != ' ' && line[1] != '\t') {
+		return "", "", false
+	}
+
+	// Only the first fields are needed; records start with short lines
+	if len(line) > 256 {
+		line = line[:256]
+	}
+	fields := strings.Fields(string(line))
+	if len(fields) < 2 {
+		return "", "", false
+	}
+	if strings.HasPrefix(fields[1], "@") {
+		if len(fields) < 3 {
+			return "", "", false
+		}
+		return fields[1], fields[2], true
+	}
+	return "", fields[1], true
+}
+
+// LoadRecordIndex reads the sidecar index of a GEDCOM file. It fails if there
+// is no sidecar or the file changed since it was indexed.
+func LoadRecordIndex(filePath string) (*RecordIndex, error) {
+	file, err := os.Open(RecordIndexPath(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open record index: %w", err)
+	}
+	defer file.Close()
+
+	index, err := readRecordIndex(file, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if index.IsStale() {
+		return nil, fmt.Errorf("record index is out of date: %s changed", filePath)
+	}
+	return index, nil
+}
+
+// readRecordIndex reads an index in sidecar format from r.
+func readRecordIndex(r io.Reader, filePath string) (*RecordIndex, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || scanner.Text() != recordIndexVersion {
+		return nil, fmt.Errorf("not a record index (expected %q)", recordIndexVersion)
+	}
+
+	index := &RecordIndex{FilePath: filePath}
+	header := make(map[string]string)
+	for len(header) < 4 && scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		header[key] = value
+	}
+	size, err1 := strconv.ParseInt(header["size"], 10, 64)
+	modTime, err2 := strconv.ParseInt(header["modtime"], 10, 64)
+	count, err3 := strconv.Atoi(header["records"])
+	if err1 != nil || err2 != nil || err3 != nil || header["encoding"] == "" {
+		return nil, fmt.Errorf("invalid record index header")
+	}
+	index.Size = size
+	index.ModTime = time.Unix(0, modTime)
+	index.Encoding = Encoding(header["encoding"])
+	index.records = make(map[string]RecordOffset, count)
+
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid record index entry: %q", scanner.Text())
+		}
+		offset, err1 := strconv.ParseInt(fields[2], 10, 64)
+		length, err2 := strconv.ParseInt(fields[3], 10, 64)
+		line, err3 := strconv.Atoi(fields[4])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("invalid record index entry: %q", scanner.Text())
+		}
+		index.records[fields[0]] = RecordOffset{Xref: fields[0], Tag: fields[1], Offset: offset, Length: length, Line: line}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read record index: %w", err)
+	}
+	if len(index.records) != count {
+		return nil, fmt.Errorf("record index is truncated: %d of %d entries", len(index.records), count)
+	}
+	return index, nil
+}
+
+// OpenRecordIndex returns the index of a GEDCOM file, loading its sidecar if
+// it is up to date and otherwise building the index and saving the sidecar.
+// A sidecar that cannot be written (e.g. in a read-only directory) is skipped.
+func OpenRecordIndex(filePath string) (*RecordIndex, error) {
+	if index, err := LoadRecordIndex(filePath); err == nil {
+		return index, nil
+	}
+
+	index, err := BuildRecordIndex(filePath)
+	if err != nil {
+		return nil, err
+	}
+	_ = index.Save()
+	return index, nil
+}
+
+// Save writes the index to the sidecar file of its GEDCOM file. The sidecar is
+// written to a temporary file first and renamed, so readers never see a
+// partial index.
+func (ri *RecordIndex) Save() error {
+	path := RecordIndexPath(ri.FilePath)
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create record index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	fmt.Fprintln(w, recordIndexVersion)
+	fmt.Fprintf(w, "size %d\n", ri.Size)
+	fmt.Fprintf(w, "modtime %d\n", ri.ModTime.UnixNano())
+	fmt.Fprintf(w, "encoding %s\n", ri.Encoding)
+	fmt.Fprintf(w, "records %d\n", len(ri.records))
+	for _, entry := range ri.entries() {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", entry.Xref, entry.Tag, entry.Offset, entry.Length, entry.Line)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write record index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write record index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write record index: %w", err)
+	}
+	return nil
+}
+
+// IsStale reports whether the GEDCOM file changed (or disappeared) since it
+// was indexed.
+func (ri *RecordIndex) IsStale() bool {
+	info, err := os.Stat(ri.FilePath)
+	if err != nil {
+		return true
+	}
+	return info.Size() != ri.Size || !info.ModTime().Equal(ri.ModTime)
+}
+
+// Lookup returns the location of the record with the given xref.
+func (ri *RecordIndex) Lookup(xref string) (RecordOffset, bool) {
+	entry, ok := ri.records[xref]
+	return entry, ok
+}
+
+// entries returns the indexed records in file order.
+func (ri *RecordIndex) entries() []RecordOffset {
+	entries := make([]RecordOffset, 0, len(ri.records))
+	for _, entry := range ri.records {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Offset < entries[j].Offset
+	})
+	return entries
+}
+
+// Len returns the number of indexed records.
+func (ri *RecordIndex) Len() int {
+	return len(ri.records)
+}
@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+const indexedFile = "\xEF\xBB\xBF0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME John /Doe/\n1 NOTE first\n2 CONT line\n\n0 @I2@ INDI\n1 NAME Jane /Doe/\n0 @F1@ FAM\n1 HUSB @I1@\n1 WIFE @I2@\n0 TRLR"
+
+func writeIndexedFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tree.ged")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestBuildRecordIndex(t *testing.T) {
+	path := writeIndexedFile(t, indexedFile)
+	index, err := BuildRecordIndex(path)
+	if err != nil {
+		t.Fatalf("BuildRecordIndex() error = %v", err)
+	}
+
+	if index.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", index.Len())
+	}
+
+	tests := []struct {
+		xref   string
+		tag    string
+		record string
+		line   int
+	}{
+		{"@I1@", "INDI", "0 @I1@ INDI\n1 NAME John /Doe/\n1 NOTE first\n2 CONT line\n\n", 3},
+		{"@I2@", "INDI", "0 @I2@ INDI\n1 NAME Jane /Doe/\n", 8},
+		{"@F1@", "FAM", "0 @F1@ FAM\n1 HUSB @I1@\n1 WIFE @I2@\n", 10},
+	}
+	for _, tt := range tests {
+		entry, ok := index.Lookup(tt.xref)
+		if !ok {
+			t.Errorf("Lookup(%s) not found", tt.xref)
+			continue
+		}
+		if got := indexedFile[entry.Offset : entry.Offset+entry.Length]; got != tt.record {
+			t.Errorf("%s covers %q, want %q", tt.xref, got, tt.record)
+		}
+		if entry.Tag != tt.tag || entry.Line != tt.line {
+			t.Errorf("%s: tag %s line %d, want %s line %d", tt.xref, entry.Tag, entry.Line, tt.tag, tt.line)
+		}
+	}
+}
+
+func TestRecordIndex_SaveLoad(t *testing.T) {
+	path := writeIndexedFile(t, indexedFile)
+	if _, err := LoadRecordIndex(path); err == nil {
+		t.Error("expected LoadRecordIndex() to fail without a sidecar")
+	}
+
+	index, err := OpenRecordIndex(path)
+	if err != nil {
+		t.Fatalf("OpenRecordIndex() error = %v", err)
+	}
+	if _, err := os.Stat(RecordIndexPath(path)); err != nil {
+		t.Fatalf("expected sidecar to be written: %v", err)
+	}
+
+	loaded, err := LoadRecordIndex(path)
+	if err != nil {
+		t.Fatalf("LoadRecordIndex() error = %v", err)
+	}
+	if loaded.Len() != index.Len() || loaded.Encoding != index.Encoding {
+		t.Errorf("loaded index = %d records (%s), want %d (%s)", loaded.Len(), loaded.Encoding, index.Len(), index.Encoding)
+	}
+	want, _ := index.Lookup("@F1@")
+	if got, _ := loaded.Lookup("@F1@"); got != want {
+		t.Errorf("Lookup(@F1@) = %+v, want %+v", got, want)
+	}
+
+	// Changing the file invalidates the sidecar
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if !loaded.IsStale() {
+		t.Error("expected IsStale() after the file changed")
+	}
+	if _, err := LoadRecordIndex(path); err == nil {
+		t.Error("expected LoadRecordIndex() to reject a stale sidecar")
+	}
+}
+
+func TestIndexedReader(t *testing.T) {
+	path := writeIndexedFile(t, indexedFile)
+	reader, err := NewIndexedReader(path)
+	if err != nil {
+		t.Fatalf("NewIndexedReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	indi, err := reader.Individual("@I1@")
+	if err != nil {
+		t.Fatalf("Individual() error = %v", err)
+	}
+	if indi.GetName() != "John /Doe/" || indi.GetValue("NOTE") != "first\nline" {
+		t.Errorf("Individual(@I1@) = %q, note %q", indi.GetName(), indi.GetValue("NOTE"))
+	}
+	if indi.FirstLine().LineNumber != 3 || indi.GetLines("NAME")[0].LineNumber != 4 {
+		t.Errorf("line numbers = %d, %d; want 3, 4", indi.FirstLine().LineNumber, indi.GetLines("NAME")[0].LineNumber)
+	}
+
+	fam, err := reader.Family("@F1@")
+	if err != nil {
+		t.Fatalf("Family() error = %v", err)
+	}
+	if fam.GetHusband() != "@I1@" {
+		t.Errorf("GetHusband() = %q", fam.GetHusband())
+	}
+
+	if _, err := reader.Family("@I1@"); err == nil {
+		t.Error("expected an error for an INDI read as a family")
+	}
+	if _, err := reader.Record("@X9@"); err == nil {
+		t.Error("expected an error for an unknown xref")
+	}
+
+	// The index is rebuilt when the file changes
+	changed := "0 HEAD\n0 @I0@ INDI\n1 NAME Zed /New/\n" + indexedFile[len("\xEF\xBB\xBF0 HEAD\n"):]
+	if err := os.WriteFile(path, []byte(changed), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(path, later, later)
+
+	indi, err = reader.Individual("@I2@")
+	if err != nil {
+		t.Fatalf("Individual() after change error = %v", err)
+	}
+	if indi.GetName() != "Jane /Doe/" {
+		t.Errorf("Individual(@I2@) = %q", indi.GetName())
+	}
+	if _, err := reader.Individual("@I0@"); err != nil {
+		t.Errorf("expected new record to be found: %v", err)
+	}
+
+	reader.Close()
+	if _, err := reader.Record("@I1@"); err == nil {
+		t.Error("expected an error after Close")
+	}
+}
+
+func TestBuildRecordIndex_Royal92(t *testing.T) {
+	path := findTestDataFile("royal92.ged")
+	if path == "" {
+		t.Skip("royal92.ged not found")
+	}
+
+	index, err := BuildRecordIndex(path)
+	if err != nil {
+		t.Fatalf("BuildRecordIndex() error = %v", err)
+	}
+
+	tree, err := NewHierarchicalParser().Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := len(tree.GetAllIndividuals()) + len(tree.GetAllFamilies())
+	if index.Len() < want {
+		t.Errorf("Len() = %d, want at least %d", index.Len(), want)
+	}
+
+	// Write the sidecar to a temporary directory
+	index.FilePath = filepath.Join(t.TempDir(), "royal92.ged")
+	if err := index.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(RecordIndexPath(index.FilePath))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	info, _ := os.Stat(path)
+	if int64(len(data)) > info.Size()/3 {
+		t.Errorf("sidecar is %d bytes for a %d byte file", len(data), info.Size())
+	}
+
+	var expected types.Record
+	for _, record := range tree.GetAllIndividuals() {
+		expected = record
+		break
+	}
+	entry, _ := index.Lookup(expected.XrefID())
+	if entry.Line != expected.FirstLine().LineNumber {
+		t.Errorf("Lookup(%s).Line = %d, want %d", expected.XrefID(), entry.Line, expected.FirstLine().LineNumber)
+	}
+}
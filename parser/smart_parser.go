@@ -1,6 +1,11 @@
 package parser
 
 import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
@@ -12,7 +17,8 @@ import (
 // For very large files (>100MB) requiring streaming, users should
 // explicitly use StreamingHierarchicalParser.
 type SmartParser struct {
-	parser ParserInterface
+	parser   ParserInterface
+	lossless bool
 }
 
 // NewParser is the recommended entry point for parsing GEDCOM files.
@@ -30,20 +36,82 @@ type ParserInterface interface {
 	GetTree() *types.GedcomTree
 }
 
+// ReaderParser is implemented by parsers that can also parse GEDCOM data
+// from an io.Reader, such as HierarchicalParser and SmartParser. Code taking
+// a ParserInterface can type-assert for it:
+//
+//	if rp, ok := p.(parser.ReaderParser); ok {
+//		tree, err = rp.ParseReader(os.Stdin)
+//	}
+type ReaderParser interface {
+	ParserInterface
+	ParseReader(r io.Reader) (*types.GedcomTree, error)
+}
+
+// ContextParser is implemented by parsers that can be cancelled and report
+// progress through a context, such as HierarchicalParser and SmartParser.
+// Like ReaderParser, type-assert a ParserInterface for it.
+type ContextParser interface {
+	ParserInterface
+	ParseContext(ctx context.Context, filePath string) (*types.GedcomTree, error)
+	ParseReaderContext(ctx context.Context, r io.Reader) (*types.GedcomTree, error)
+}
+
+var (
+	_ ReaderParser  = (*HierarchicalParser)(nil)
+	_ ReaderParser  = (*SmartParser)(nil)
+	_ ContextParser = (*HierarchicalParser)(nil)
+	_ ContextParser = (*SmartParser)(nil)
+)
+
 // NewSmartParser creates a parser that automatically selects the best implementation
 // based on file size. This optimizes for both small and large files.
 func NewSmartParser() *SmartParser {
 	return &SmartParser{}
 }
 
+// SetLossless enables or disables lossless mode on the parser selected for
+// each file (see HierarchicalParser.SetLossless).
+func (sp *SmartParser) SetLossless(enabled bool) {
+	sp.lossless = enabled
+}
+
 // Parse automatically selects and uses the best parser for the file size.
 // HierarchicalParser automatically enables parallel processing for files >= 32KB,
 // so we always use it for full-tree parsing.
+// GEDZIP archives (.gdz) are recognized by extension and parsed with ParseGedzip.
 func (sp *SmartParser) Parse(filePath string) (*types.GedcomTree, error) {
+	return sp.ParseContext(context.Background(), filePath)
+}
+
+// ParseContext is like Parse but stops once ctx is cancelled or its deadline
+// passes, and reports progress to the ProgressReporter attached to ctx.
+func (sp *SmartParser) ParseContext(ctx context.Context, filePath string) (*types.GedcomTree, error) {
 	// Always use HierarchicalParser which automatically enables parallel processing
 	// for files >= 32KB. This provides optimal performance without user configuration.
-	sp.parser = NewHierarchicalParser()
-	return sp.parser.Parse(filePath)
+	hp := NewHierarchicalParser()
+	hp.SetLossless(sp.lossless)
+	sp.parser = hp
+	if strings.EqualFold(filepath.Ext(filePath), GedzipExtension) {
+		return hp.ParseGedzipContext(ctx, filePath)
+	}
+	return hp.ParseContext(ctx, filePath)
+}
+
+// ParseReader parses GEDCOM data from r (stdin, an upload body, a zip entry,
+// an in-memory buffer). Parallel processing is enabled for inputs >= 32KB.
+func (sp *SmartParser) ParseReader(r io.Reader) (*types.GedcomTree, error) {
+	return sp.ParseReaderContext(context.Background(), r)
+}
+
+// ParseReaderContext is like ParseReader but stops once ctx is cancelled or
+// its deadline passes, and reports progress to the ProgressReporter attached
+// to ctx.
+func (sp *SmartParser) ParseReaderContext(ctx context.Context, r io.Reader) (*types.GedcomTree, error) {
+	hp := NewHierarchicalParser()
+	hp.SetLossless(sp.lossless)
+	sp.parser = hp
+	return hp.ParseReaderContext(ctx, r)
 }
 
 // GetErrors returns all errors collected during parsing
@@ -2,6 +2,8 @@ package parser
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -22,6 +24,9 @@ type RecordHandler func(record types.Record) error
 type StreamingHierarchicalParser struct {
 	continuationHandler *ContinuationHandler
 	errorManager        *types.ErrorManager
+	version             string         // HEAD.GEDC.VERS of the last parsed input
+	recovery            RecoveryPolicy // What to do with problem lines (see SetRecoveryPolicy)
+	uuidNamespace       string         // Name space of record UUIDs (see SetUUIDNamespace)
 }
 
 // NewStreamingHierarchicalParser creates a new StreamingHierarchicalParser.
@@ -38,6 +43,8 @@ func NewStreamingHierarchicalParser() *StreamingHierarchicalParser {
 //
 // The handler is called for each complete record (INDI, FAM, NOTE, etc.) as soon
 // as it's fully parsed. If the handler returns an error, parsing stops.
+// Records have the same stable UUIDs as when the file is parsed into a tree
+// (see SetUUIDNamespace).
 //
 // Example:
 //
@@ -48,40 +55,116 @@ func NewStreamingHierarchicalParser() *StreamingHierarchicalParser {
 //		return nil // Continue parsing
 //	})
 func (shp *StreamingHierarchicalParser) ParseWithHandler(filePath string, handler RecordHandler) error {
+	return shp.ParseWithHandlerContext(context.Background(), filePath, handler)
+}
+
+// ParseWithHandlerContext is like ParseWithHandler but stops with ctx's error
+// once ctx is cancelled or its deadline passes. Progress is reported in bytes
+// read, out of the file size, to the ProgressReporter attached to ctx.
+func (shp *StreamingHierarchicalParser) ParseWithHandlerContext(ctx context.Context, filePath string, handler RecordHandler) error {
 	// Step 1: Validate file
 	if err := ValidateFile(filePath); err != nil {
-		shp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("File validation failed: %v", err), 0, "File Validation")
+		shp.errorManager.AddCodedError(types.CodeInvalidFile, types.SeveritySevere, fmt.Sprintf("File validation failed: %v", err), 0, "File Validation")
 		return fmt.Errorf("file validation failed: %w", err)
 	}
 
 	// Step 2: Detect encoding
 	encoding, err := DetectEncoding(filePath)
 	if err != nil {
-		shp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Encoding detection failed: %v", err), 0, "Encoding Detection")
+		shp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Encoding detection failed: %v", err), 0, "Encoding Detection")
 		return fmt.Errorf("encoding detection failed: %w", err)
 	}
 
-	// Step 3: Open file
+	size, err := FileSize(filePath)
+	if err != nil {
+		shp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to stat file: %v", err), 0, "File I/O")
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	// Step 3: Parse, re-opening the file if HEAD.CHAR calls for another decoder
+	errorCount := shp.errorManager.Count()
+	for {
+		tracker := types.NewProgressTracker(ctx, progressStage, size)
+		err = shp.parseFile(filePath, encoding, tracker, withUUIDs(handler, shp.uuidNamespace, filePath))
+
+		var restart *encodingRestartError
+		if !errors.As(err, &restart) {
+			if err == nil {
+				tracker.Finish()
+			}
+			return err
+		}
+		encoding = restart.encoding
+		shp.continuationHandler = NewContinuationHandler()
+		shp.errorManager.Truncate(errorCount)
+		shp.errorManager.AddDiagnostic(restart.notice)
+	}
+}
+
+// parseFile opens filePath with the decoder for encoding and streams its records to handler.
+func (shp *StreamingHierarchicalParser) parseFile(filePath string, encoding Encoding, tracker *types.ProgressTracker, handler RecordHandler) error {
 	file, err := os.Open(filePath)
 	if err != nil {
-		shp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Failed to open file: %v", err), 0, "File I/O")
+		shp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to open file: %v", err), 0, "File I/O")
 		return fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	// Step 4: Get reader with proper encoding
-	reader, err := GetReader(file, encoding)
+	bom, err := ReadBOM(file)
 	if err != nil {
-		shp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
+		shp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Failed to read file: %v", err), 0, "File I/O")
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Get reader with proper encoding
+	reader, err := getTrackedReader(file, encoding, tracker)
+	if err != nil {
+		shp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
 		return fmt.Errorf("failed to create reader: %w", err)
 	}
 
-	// Step 5: Parse file line by line
-	return shp.parseStream(reader, handler)
+	// Parse file line by line
+	return shp.parseStream(reader, encoding, HasBOM(bom), true, handler)
+}
+
+// ParseWithHandlerReader is like ParseWithHandler but reads GEDCOM data from r
+// instead of a file path. The encoding is detected from the first 64KB of r,
+// which are buffered; a HEAD.CHAR declaration beyond that only produces a warning
+// because a stream cannot be re-read.
+func (shp *StreamingHierarchicalParser) ParseWithHandlerReader(r io.Reader, handler RecordHandler) error {
+	return shp.ParseWithHandlerReaderContext(context.Background(), r, handler)
+}
+
+// ParseWithHandlerReaderContext is like ParseWithHandlerReader but stops with
+// ctx's error once ctx is cancelled or its deadline passes. Progress is
+// reported in bytes read; the total is unknown (0).
+func (shp *StreamingHierarchicalParser) ParseWithHandlerReaderContext(ctx context.Context, r io.Reader, handler RecordHandler) error {
+	br, peek, err := peekInput(r)
+	if err != nil {
+		shp.errorManager.AddCodedError(types.CodeInvalidFile, types.SeveritySevere, fmt.Sprintf("Input validation failed: %v", err), 0, "File Validation")
+		return fmt.Errorf("input validation failed: %w", err)
+	}
+
+	encoding := DetectEncodingBytes(peek)
+	tracker := types.NewProgressTracker(ctx, progressStage, 0)
+	reader, err := NewDecodingReader(&progressReader{r: br, tracker: tracker}, encoding)
+	if err != nil {
+		shp.errorManager.AddCodedError(types.CodeEncodingError, types.SeveritySevere, fmt.Sprintf("Failed to create reader: %v", err), 0, "Encoding")
+		return fmt.Errorf("failed to create reader: %w", err)
+	}
+
+	if err := shp.parseStream(reader, encoding, HasBOM(peek), false, withUUIDs(handler, shp.uuidNamespace, "")); err != nil {
+		return err
+	}
+	tracker.Finish()
+	return nil
 }
 
 // parseStream performs the actual streaming parsing.
-func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler RecordHandler) error {
+// If canReopen is set, it returns an *encodingRestartError when the header declares
+// a different encoding than the one the stream is decoded with and no record has
+// been handed out yet.
+func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, encoding Encoding, hasBOM, canReopen bool, handler RecordHandler) error {
 	scanner := bufio.NewScanner(reader)
 	lineNumber := 0
 	parentsStack := NewLineStack()
@@ -89,13 +172,16 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 
 	// Track current record being built
 	var currentRecordLine *types.GedcomLine
+	recordsHandled := 0
+	versions := newVersionTracker(shp.errorManager)
+	defer func() { shp.version = versions.Version() }()
 
 	for scanner.Scan() {
 		lineNumber++
-		line := scanner.Text()
+		raw := scanner.Text()
 
 		// Skip empty lines
-		line = strings.TrimSpace(line)
+		line := strings.TrimSpace(raw)
 		if len(line) == 0 {
 			continue
 		}
@@ -103,15 +189,36 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 		// Parse the line using optimized parser (line is already trimmed)
 		level, tag, value, xrefID, err := ParseLineFast(line)
 		if err != nil {
-			shp.errorManager.AddError(types.SeverityWarning, fmt.Sprintf("Malformed line: %v", err), lineNumber, "Line Parsing")
+			diag := malformedLineDiagnostic(err, lineNumber, raw)
+			if shp.recovery == RecoveryRepair && diag.Code == types.CodeInvalidLevel && !parentsStack.IsEmpty() {
+				// Text without a level is usually part of a value that contained a line break
+				diag.Message += "; joined to the previous line as CONT"
+				shp.errorManager.AddDiagnostic(diag)
+				shp.continuationHandler.HandleContinuation("CONT", parentsStack.Peek().Level+1, line)
+				continue
+			}
+			if err := shp.recovery.report(shp.errorManager, diag); err != nil {
+				return err
+			}
 			continue
 		}
+		versions.observe(level, tag, value, lineNumber)
 
 		// Handle CONC/CONT continuation lines
 		if tag == "CONC" || tag == "CONT" {
 			if err := shp.continuationHandler.HandleContinuation(tag, level, value); err != nil {
-				shp.errorManager.AddError(types.SeverityWarning, fmt.Sprintf("Invalid continuation: %v", err), lineNumber, "CONC/CONT Handling")
-				continue
+				last := shp.continuationHandler.GetLastTag()
+				diag := continuationDiagnostic(err, last, lineNumber, raw)
+				if shp.recovery != RecoveryRepair {
+					if err := shp.recovery.report(shp.errorManager, diag); err != nil {
+						return err
+					}
+					continue
+				}
+				// Continue the same value at the level of the line before
+				diag.Message += fmt.Sprintf("; treated as level %d", last.Level)
+				shp.errorManager.AddDiagnostic(diag)
+				shp.continuationHandler.HandleContinuation(tag, last.Level, value)
 			}
 			// Continue accumulating - don't apply yet
 			continue
@@ -149,6 +256,7 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 				if err := handler(record); err != nil {
 					return fmt.Errorf("handler error: %w", err)
 				}
+				recordsHandled++
 			}
 
 			// Reset continuation handler for new record
@@ -176,10 +284,25 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 		parent, err := parentsStack.FindParent(level)
 		if err != nil {
 			// Orphaned line - no parent found
-			shp.errorManager.AddError(types.SeverityWarning, fmt.Sprintf("Orphaned line: %s", err.Error()), lineNumber, "Hierarchy")
+			if err := shp.recovery.report(shp.errorManager, orphanedLineDiagnostic(err, lineNumber, raw)); err != nil {
+				return err
+			}
 			continue
 		}
 
+		// A line should be one level below its parent; deeper lines are kept
+		// under the nearest parent
+		if level > parent.Level+1 {
+			diag := levelJumpDiagnostic(level, parent, lineNumber, raw)
+			if shp.recovery == RecoveryRepair {
+				level = parent.Level + 1
+				diag.Message += fmt.Sprintf("; treated as level %d", level)
+			}
+			if err := shp.recovery.report(shp.errorManager, diag); err != nil {
+				return err
+			}
+		}
+
 		// Create child line
 		childLine := types.NewGedcomLine(level, tag, value, "")
 		childLine.LineNumber = lineNumber
@@ -192,6 +315,13 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 
 		// Update continuation handler
 		shp.continuationHandler.SetLastTag(tag, level)
+
+		// Verify the HEAD.CHAR declaration against the decoder in use
+		if level == 1 && tag == "CHAR" && currentRecordLine != nil && currentRecordLine.Tag == "HEAD" {
+			if restart := checkDeclaredEncoding(shp.errorManager, encoding, hasBOM, canReopen && recordsHandled == 0, Encoding(value), lineNumber); restart != nil {
+				return restart
+			}
+		}
 	}
 
 	// Handle remaining CONC/CONT value (if file ends with continuation)
@@ -216,6 +346,11 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 		}
 	}
 
+	// A cancelled context cuts the input short; don't hand out a partial record
+	if err := scanner.Err(); err != nil && isContextError(err) {
+		return err
+	}
+
 	// Yield the last record if any
 	if currentRecordLine != nil {
 		record := factory.CreateRecord(currentRecordLine)
@@ -225,13 +360,20 @@ func (shp *StreamingHierarchicalParser) parseStream(reader io.Reader, handler Re
 	}
 
 	if err := scanner.Err(); err != nil {
-		shp.errorManager.AddError(types.SeveritySevere, fmt.Sprintf("Error reading file: %v", err), lineNumber, "File I/O")
+		shp.errorManager.AddCodedError(types.CodeIOError, types.SeveritySevere, fmt.Sprintf("Error reading file: %v", err), lineNumber, "File I/O")
 		return fmt.Errorf("error reading file: %w", err)
 	}
 
 	return nil
 }
 
+// GetVersion returns the GEDCOM version declared in HEAD.GEDC.VERS of the
+// last parsed input, or "" if none was declared. It is set when parsing
+// completes; handlers can read the header record directly instead.
+func (shp *StreamingHierarchicalParser) GetVersion() string {
+	return shp.version
+}
+
 // GetErrors returns all errors collected during parsing.
 func (shp *StreamingHierarchicalParser) GetErrors() []*types.GedcomError {
 	return shp.errorManager.Errors()
@@ -266,6 +408,23 @@ type RecordIterator struct {
 // NewRecordIterator creates a new RecordIterator for the given file.
 // The iterator starts parsing in a background goroutine.
 func NewRecordIterator(filePath string) (*RecordIterator, error) {
+	return newRecordIterator(func(parser *StreamingHierarchicalParser, handler RecordHandler) error {
+		return parser.ParseWithHandler(filePath, handler)
+	}), nil
+}
+
+// NewRecordIteratorFromReader creates a new RecordIterator that reads GEDCOM
+// data from r. The iterator starts parsing in a background goroutine; r must
+// not be used by the caller until the iterator is exhausted or closed.
+func NewRecordIteratorFromReader(r io.Reader) (*RecordIterator, error) {
+	return newRecordIterator(func(parser *StreamingHierarchicalParser, handler RecordHandler) error {
+		return parser.ParseWithHandlerReader(r, handler)
+	}), nil
+}
+
+// newRecordIterator starts parse in a background goroutine, feeding its
+// records to the returned iterator.
+func newRecordIterator(parse func(*StreamingHierarchicalParser, RecordHandler) error) *RecordIterator {
 	parser := NewStreamingHierarchicalParser()
 	iterator := &RecordIterator{
 		parser:     parser,
@@ -279,7 +438,7 @@ func NewRecordIterator(filePath string) (*RecordIterator, error) {
 		defer close(iterator.recordChan)
 		defer close(iterator.errorChan)
 
-		err := parser.ParseWithHandler(filePath, func(record types.Record) error {
+		err := parse(parser, func(record types.Record) error {
 			select {
 			case iterator.recordChan <- record:
 				return nil
@@ -296,7 +455,7 @@ func NewRecordIterator(filePath string) (*RecordIterator, error) {
 		}
 	}()
 
-	return iterator, nil
+	return iterator
 }
 
 // Next advances the iterator to the next record and returns true if a record is available.
@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -362,3 +364,65 @@ func TestStreamingHierarchicalParser_GetErrors(t *testing.T) {
 	// The parser will successfully parse them, but validators will flag them
 	_ = errors // Errors might be empty, which is fine
 }
+
+func TestStreamingHierarchicalParser_ParseWithHandlerReader(t *testing.T) {
+	content := "0 HEAD\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME John /Doe/\n1 NOTE First\n2 CONC  part\n0 @I2@ INDI\n1 NAME Jane /Smith/\n0 TRLR\n"
+
+	parser := NewStreamingHierarchicalParser()
+	var records []types.Record
+	err := parser.ParseWithHandlerReader(strings.NewReader(content), func(record types.Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseWithHandlerReader failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Expected 4 records, got %d", len(records))
+	}
+	if got := records[1].GetValue("NOTE"); got != "First part" {
+		t.Errorf("NOTE = %q, want %q", got, "First part")
+	}
+}
+
+func TestStreamingHierarchicalParser_ParseWithHandlerReader_UTF16(t *testing.T) {
+	content := utf16Bytes("0 HEAD\n1 CHAR UNICODE\n0 @I1@ INDI\n1 NAME Zoë\n0 TRLR\n", true, true)
+
+	parser := NewStreamingHierarchicalParser()
+	var name string
+	err := parser.ParseWithHandlerReader(bytes.NewReader(content), func(record types.Record) error {
+		if indi, ok := record.(*types.IndividualRecord); ok {
+			name = indi.GetName()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParseWithHandlerReader failed: %v", err)
+	}
+	if name != "Zoë" {
+		t.Errorf("GetName() = %q, want %q", name, "Zoë")
+	}
+}
+
+func TestNewRecordIteratorFromReader(t *testing.T) {
+	content := "0 HEAD\n0 @I1@ INDI\n0 @I2@ INDI\n0 @F1@ FAM\n0 TRLR\n"
+
+	iterator, err := NewRecordIteratorFromReader(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("NewRecordIteratorFromReader failed: %v", err)
+	}
+	defer iterator.Close()
+
+	var xrefs []string
+	for iterator.Next() {
+		if xref := iterator.Record().XrefID(); xref != "" {
+			xrefs = append(xrefs, xref)
+		}
+	}
+	if iterator.Error() != nil {
+		t.Fatalf("Iterator error: %v", iterator.Error())
+	}
+	if len(xrefs) != 3 || xrefs[0] != "@I1@" || xrefs[2] != "@F1@" {
+		t.Errorf("xrefs = %v, want [@I1@ @I2@ @F1@]", xrefs)
+	}
+}
@@ -12,6 +12,7 @@ type AncestorOptions struct {
 	IncludeSelf    bool                                // Include starting individual
 	Filter         func(*types.IndividualRecord) bool // Custom filter function
 	Order          Order                               // BFS or DFS order
+	Lineage        Lineage                             // Parent-child links to follow
 }
 
 // Order represents the traversal order.
@@ -29,6 +30,7 @@ func NewAncestorOptions() *AncestorOptions {
 		IncludeSelf:    false,
 		Filter:         nil,
 		Order:          OrderBFS,
+		Lineage:        LineageAll,
 	}
 }
 
@@ -57,6 +59,13 @@ func (aq *AncestorQuery) Filter(fn func(*types.IndividualRecord) bool) *Ancestor
 	return aq
 }
 
+// Lineage limits the search to the parent-child links lineage follows, e.g.
+// LineageBiological to leave out adoptive and foster parents.
+func (aq *AncestorQuery) Lineage(lineage Lineage) *AncestorQuery {
+	aq.options.Lineage = lineage
+	return aq
+}
+
 // Execute runs the query and returns ancestor records.
 func (aq *AncestorQuery) Execute() ([]*types.IndividualRecord, error) {
 	// Record metrics if available
@@ -120,48 +129,21 @@ func (aq *AncestorQuery) findAncestors(node *IndividualNode, nodeID uint32, ance
 
 	visited[nodeID] = true
 
-	// Phase 2: Use cached parents for O(1) access (fastest path)
-	if len(node.parents) > 0 {
-		for _, parent := range node.parents {
-			// Phase 3: Use cached nodeID directly - no lock acquisition!
-			parentID := parent.BaseNode.nodeID
-			if parentID != 0 {
-				ancestors[parentID] = parent
-				// Phase 3: Pass parentID through recursion to avoid repeated lookups
-				aq.findAncestors(parent, parentID, ancestors, visited, depth+1)
-			}
-		}
-		return
+	// Phase 2: Use cached parents for O(1) access (fastest path). The cache
+	// does not know the pedigree, so filtering and the fallback walk the
+	// indexed FAMC edges (Phase 1) instead.
+	parents := node.parents
+	if len(parents) == 0 || aq.options.Lineage.filters() {
+		parents = node.lineageParents(aq.options.Lineage)
 	}
 
-	// Fallback: Use indexed FAMC edges (Phase 1 optimization)
-	// This path is used if parent cache is not populated (shouldn't happen in normal flow)
-	for _, edge := range node.famcEdges {
-		if edge.Family != nil {
-			famNode := edge.Family
-			// Phase 1: Use indexed edges for O(1) access
-			if famNode.husbandEdge != nil {
-				if husband, ok := famNode.husbandEdge.To.(*IndividualNode); ok {
-					// Phase 3: Use cached nodeID directly - no lock acquisition!
-					husbandID := husband.BaseNode.nodeID
-					if husbandID != 0 {
-						ancestors[husbandID] = husband
-						// Phase 3: Pass husbandID through recursion
-						aq.findAncestors(husband, husbandID, ancestors, visited, depth+1)
-					}
-				}
-			}
-			if famNode.wifeEdge != nil {
-				if wife, ok := famNode.wifeEdge.To.(*IndividualNode); ok {
-					// Phase 3: Use cached nodeID directly - no lock acquisition!
-					wifeID := wife.BaseNode.nodeID
-					if wifeID != 0 {
-						ancestors[wifeID] = wife
-						// Phase 3: Pass wifeID through recursion
-						aq.findAncestors(wife, wifeID, ancestors, visited, depth+1)
-					}
-				}
-			}
+	for _, parent := range parents {
+		// Phase 3: Use cached nodeID directly - no lock acquisition!
+		parentID := parent.BaseNode.nodeID
+		if parentID != 0 {
+			ancestors[parentID] = parent
+			// Phase 3: Pass parentID through recursion to avoid repeated lookups
+			aq.findAncestors(parent, parentID, ancestors, visited, depth+1)
 		}
 	}
 }
@@ -259,50 +241,20 @@ func (aq *AncestorQuery) findAncestorsWithDepth(node *IndividualNode, nodeID uin
 
 	visited[nodeID] = true
 
-	// Phase 2: Use cached parents for O(1) access (fastest path)
-	if len(node.parents) > 0 {
-		for _, parent := range node.parents {
-			// Phase 3: Use cached nodeID directly - no lock acquisition!
-			parentID := parent.BaseNode.nodeID
-			if parentID != 0 {
-				ancestors[parentID] = parent
-				depths[parentID] = depth + 1
-				// Phase 3: Pass parentID through recursion to avoid repeated lookups
-				aq.findAncestorsWithDepth(parent, parentID, ancestors, visited, depths, depth+1)
-			}
-		}
-		return
+	// Phase 2: Use cached parents, see findAncestors
+	parents := node.parents
+	if len(parents) == 0 || aq.options.Lineage.filters() {
+		parents = node.lineageParents(aq.options.Lineage)
 	}
 
-	// Fallback: Use indexed FAMC edges (Phase 1 optimization)
-	for _, edge := range node.famcEdges {
-		if edge.Family != nil {
-			famNode := edge.Family
-			// Phase 1: Use indexed edges for O(1) access
-			if famNode.husbandEdge != nil {
-				if husband, ok := famNode.husbandEdge.To.(*IndividualNode); ok {
-					// Phase 3: Use cached nodeID directly - no lock acquisition!
-					husbandID := husband.BaseNode.nodeID
-					if husbandID != 0 {
-						ancestors[husbandID] = husband
-						depths[husbandID] = depth + 1
-						// Phase 3: Pass husbandID through recursion
-						aq.findAncestorsWithDepth(husband, husbandID, ancestors, visited, depths, depth+1)
-					}
-				}
-			}
-			if famNode.wifeEdge != nil {
-				if wife, ok := famNode.wifeEdge.To.(*IndividualNode); ok {
-					// Phase 3: Use cached nodeID directly - no lock acquisition!
-					wifeID := wife.BaseNode.nodeID
-					if wifeID != 0 {
-						ancestors[wifeID] = wife
-						depths[wifeID] = depth + 1
-						// Phase 3: Pass wifeID through recursion
-						aq.findAncestorsWithDepth(wife, wifeID, ancestors, visited, depths, depth+1)
-					}
-				}
-			}
+	for _, parent := range parents {
+		// Phase 3: Use cached nodeID directly - no lock acquisition!
+		parentID := parent.BaseNode.nodeID
+		if parentID != 0 {
+			ancestors[parentID] = parent
+			depths[parentID] = depth + 1
+			// Phase 3: Pass parentID through recursion to avoid repeated lookups
+			aq.findAncestorsWithDepth(parent, parentID, ancestors, visited, depths, depth+1)
 		}
 	}
 }
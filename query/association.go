@@ -0,0 +1,211 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Edge properties set on ASSO edges.
+const (
+	PropertyRole   = "role"   // types.AssociationRole, as a string
+	PropertyPhrase = "phrase" // RELA value or ROLE/ASSO PHRASE
+	PropertyEvent  = "event"  // Tag of the event the ASSO is under, if any
+)
+
+// Role returns the role of the associate of an ASSO edge, or RoleUnknown.
+func (e *Edge) Role() types.AssociationRole {
+	if value, ok := e.Properties[PropertyRole].(string); ok {
+		return types.AssociationRole(value)
+	}
+	return types.RoleUnknown
+}
+
+// isAssociation reports whether the edge is an ASSO or ALIA edge. Path
+// finding leaves these out, so that paths and the relationships computed
+// from them only follow family links.
+func (e *Edge) isAssociation() bool {
+	return e.EdgeType == EdgeTypeASSO || e.EdgeType == EdgeTypeALIA
+}
+
+// associationProperties returns the edge properties of an ASSO link.
+func associationProperties(association types.Association) map[string]interface{} {
+	properties := map[string]interface{}{PropertyRole: string(association.Role)}
+	if association.Phrase != "" {
+		properties[PropertyPhrase] = association.Phrase
+	}
+	if association.Event != "" {
+		properties[PropertyEvent] = string(association.Event)
+	}
+	return properties
+}
+
+// associationEdges returns the ASSO edges of an individual or family node
+// and, for individuals, its ALIA edges. Links to individuals that are not in
+// the graph are skipped.
+func (g *Graph) associationEdges(from GraphNode, associations []types.Association, aliases []string) []*Edge {
+	var edges []*Edge
+	for i, association := range associations {
+		to := g.GetIndividual(association.Xref)
+		if to == nil {
+			continue
+		}
+		edge := NewEdge(fmt.Sprintf("%s_ASSO_%s_%d", from.ID(), association.Xref, i), from, to, EdgeTypeASSO)
+		edge.Properties = associationProperties(association)
+		edges = append(edges, edge)
+	}
+	for i, alias := range aliases {
+		if to := g.GetIndividual(alias); to != nil {
+			edges = append(edges, NewEdge(fmt.Sprintf("%s_ALIA_%s_%d", from.ID(), alias, i), from, to, EdgeTypeALIA))
+		}
+	}
+	return edges
+}
+
+// anyRole matches associations whatever their role.
+func anyRole(types.AssociationRole) bool { return true }
+
+// roleIs returns a matcher for associations with the given role.
+func roleIs(role types.AssociationRole) func(types.AssociationRole) bool {
+	return func(r types.AssociationRole) bool { return r == role }
+}
+
+// associates returns the individuals node's own ASSO lines point at whose
+// role matches.
+func (node *IndividualNode) associates(match func(types.AssociationRole) bool) []*IndividualNode {
+	var result []*IndividualNode
+	seen := make(map[string]bool)
+	for _, edge := range node.OutEdges() {
+		if edge.EdgeType != EdgeTypeASSO || !match(edge.Role()) {
+			continue
+		}
+		if to, ok := edge.To.(*IndividualNode); ok && !seen[to.ID()] {
+			seen[to.ID()] = true
+			result = append(result, to)
+		}
+	}
+	return result
+}
+
+// associatedWith returns the individuals whose ASSO lines point at node with
+// a matching role. An ASSO of a family counts for both spouses.
+func (node *IndividualNode) associatedWith(match func(types.AssociationRole) bool) []*IndividualNode {
+	var result []*IndividualNode
+	seen := make(map[string]bool)
+	add := func(indi *IndividualNode) {
+		if indi != nil && indi.ID() != node.ID() && !seen[indi.ID()] {
+			seen[indi.ID()] = true
+			result = append(result, indi)
+		}
+	}
+	for _, edge := range node.InEdges() {
+		if edge.EdgeType != EdgeTypeASSO || !match(edge.Role()) {
+			continue
+		}
+		switch from := edge.From.(type) {
+		case *IndividualNode:
+			add(from)
+		case *FamilyNode:
+			add(from.getHusbandFromEdges())
+			add(from.getWifeFromEdges())
+		}
+	}
+	return result
+}
+
+// aliases returns the individuals linked to node by ALIA, in either
+// direction.
+func (node *IndividualNode) aliases() []*IndividualNode {
+	var result []*IndividualNode
+	seen := make(map[string]bool)
+	add := func(other GraphNode) {
+		if indi, ok := other.(*IndividualNode); ok && indi.ID() != node.ID() && !seen[indi.ID()] {
+			seen[indi.ID()] = true
+			result = append(result, indi)
+		}
+	}
+	for _, edge := range node.OutEdges() {
+		if edge.EdgeType == EdgeTypeALIA {
+			add(edge.To)
+		}
+	}
+	for _, edge := range node.InEdges() {
+		if edge.EdgeType == EdgeTypeALIA {
+			add(edge.From)
+		}
+	}
+	return result
+}
+
+// associatedIndividuals returns everyone linked to node by ASSO or ALIA in
+// either direction, including the associates of the families it is a
+// spouse in. Used by component queries that include associations.
+func (node *IndividualNode) associatedIndividuals() []*IndividualNode {
+	result := node.associates(anyRole)
+	result = append(result, node.associatedWith(anyRole)...)
+	result = append(result, node.aliases()...)
+	for _, edge := range node.famsEdges {
+		if edge.Family == nil {
+			continue
+		}
+		for _, famEdge := range edge.Family.OutEdges() {
+			if to, ok := famEdge.To.(*IndividualNode); ok && famEdge.EdgeType == EdgeTypeASSO {
+				result = append(result, to)
+			}
+		}
+	}
+	return result
+}
+
+// recordsOf returns the records of nodes.
+func recordsOf(nodes []*IndividualNode) []*types.IndividualRecord {
+	records := make([]*types.IndividualRecord, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Individual != nil {
+			records = append(records, node.Individual)
+		}
+	}
+	return records
+}
+
+// Associates returns the individuals this individual's ASSO lines point at
+// whose role is role, e.g. types.RoleGodparent for the godparents. Use
+// types.RoleUnknown for associates without a RELA or ROLE.
+func (iq *IndividualQuery) Associates(role types.AssociationRole) ([]*types.IndividualRecord, error) {
+	node := iq.graph.GetIndividual(iq.xrefID)
+	if node == nil {
+		return nil, fmt.Errorf("individual %s not found", iq.xrefID)
+	}
+	return recordsOf(node.associates(roleIs(role))), nil
+}
+
+// AllAssociates returns the individuals this individual's ASSO lines point
+// at, whatever their role.
+func (iq *IndividualQuery) AllAssociates() ([]*types.IndividualRecord, error) {
+	node := iq.graph.GetIndividual(iq.xrefID)
+	if node == nil {
+		return nil, fmt.Errorf("individual %s not found", iq.xrefID)
+	}
+	return recordsOf(node.associates(anyRole)), nil
+}
+
+// AssociatedWith returns the individuals for whom this individual played
+// role, e.g. types.RoleWitness for everyone they were a witness for. An ASSO
+// under a family event, such as a marriage witness, counts for both spouses.
+func (iq *IndividualQuery) AssociatedWith(role types.AssociationRole) ([]*types.IndividualRecord, error) {
+	node := iq.graph.GetIndividual(iq.xrefID)
+	if node == nil {
+		return nil, fmt.Errorf("individual %s not found", iq.xrefID)
+	}
+	return recordsOf(node.associatedWith(roleIs(role))), nil
+}
+
+// Aliases returns the individuals linked to this one by ALIA, in either
+// direction: other records that describe the same person.
+func (iq *IndividualQuery) Aliases() ([]*types.IndividualRecord, error) {
+	node := iq.graph.GetIndividual(iq.xrefID)
+	if node == nil {
+		return nil, fmt.Errorf("individual %s not found", iq.xrefID)
+	}
+	return recordsOf(node.aliases()), nil
+}
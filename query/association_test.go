@@ -0,0 +1,151 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// associationTestGraph builds a family whose child has godparents and whose
+// marriage has a witness, none of them related by blood or marriage:
+//
+//	@I1@ + @I2@ (@F1@): @I3@, godparents @I4@ and @I5@
+//	@F1@ MARR witness @I4@
+//	@I4@ also witnessed the birth of @I6@
+//	@I7@ is an alias record of @I5@
+func associationTestGraph(t *testing.T) *QueryBuilder {
+	t.Helper()
+	input := `0 HEAD
+0 @I1@ INDI
+1 FAMS @F1@
+0 @I2@ INDI
+1 FAMS @F1@
+0 @I3@ INDI
+1 FAMC @F1@
+1 ASSO @I4@
+2 RELA Godfather
+1 BAPM
+2 ASSO @I5@
+3 ROLE GODP
+0 @I4@ INDI
+0 @I5@ INDI
+1 ALIA @I7@
+0 @I6@ INDI
+1 BIRT
+2 ASSO @I4@
+3 ROLE WITN
+0 @I7@ INDI
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+1 MARR
+2 ASSO @I4@
+3 ROLE WITN
+0 TRLR
+`
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	qb, err := NewQuery(tree)
+	if err != nil {
+		t.Fatalf("NewQuery() error = %v", err)
+	}
+	return qb
+}
+
+func TestAssociationEdges(t *testing.T) {
+	qb := associationTestGraph(t)
+
+	var asso *Edge
+	for _, edge := range qb.Graph().GetIndividual("@I3@").OutEdges() {
+		if edge.EdgeType == EdgeTypeASSO && edge.To.ID() == "@I5@" {
+			asso = edge
+		}
+	}
+	if asso == nil {
+		t.Fatal("no ASSO edge from @I3@ to @I5@")
+	}
+	if asso.Role() != types.RoleGodparent || asso.Properties[PropertyEvent] != "BAPM" {
+		t.Errorf("ASSO edge properties = %v", asso.Properties)
+	}
+}
+
+func TestIndividualQuery_Associations(t *testing.T) {
+	qb := associationTestGraph(t)
+
+	godparents, err := qb.Individual("@I3@").Associates(types.RoleGodparent)
+	if err != nil || xrefs(godparents) != "@I4@ @I5@" {
+		t.Errorf("Associates(godparent) = %q, %v", xrefs(godparents), err)
+	}
+	all, _ := qb.Individual("@I3@").AllAssociates()
+	if xrefs(all) != "@I4@ @I5@" {
+		t.Errorf("AllAssociates() = %q", xrefs(all))
+	}
+
+	// The marriage witness counts for both spouses
+	witnessed, err := qb.Individual("@I4@").AssociatedWith(types.RoleWitness)
+	if err != nil || xrefs(witnessed) != "@I1@ @I2@ @I6@" {
+		t.Errorf("AssociatedWith(witness) = %q, %v", xrefs(witnessed), err)
+	}
+	godchildren, _ := qb.Individual("@I4@").AssociatedWith(types.RoleGodparent)
+	if xrefs(godchildren) != "@I3@" {
+		t.Errorf("AssociatedWith(godparent) = %q", xrefs(godchildren))
+	}
+
+	// ALIA is followed in both directions
+	for xref, want := range map[string]string{"@I5@": "@I7@", "@I7@": "@I5@"} {
+		aliases, _ := qb.Individual(xref).Aliases()
+		if xrefs(aliases) != want {
+			t.Errorf("%s Aliases() = %q, want %q", xref, xrefs(aliases), want)
+		}
+	}
+
+	if _, err := qb.Individual("@I99@").Associates(types.RoleGodparent); err == nil {
+		t.Error("Associates() of a missing individual: expected error")
+	}
+}
+
+func TestComponent_IncludeAssociations(t *testing.T) {
+	qb := associationTestGraph(t)
+	graph := qb.Graph()
+
+	component, err := graph.GetComponentForPerson("@I3@")
+	if err != nil || xrefs(component) != "@I1@ @I2@ @I3@" {
+		t.Errorf("GetComponentForPerson() = %q, %v", xrefs(component), err)
+	}
+
+	options := NewComponentOptions()
+	options.IncludeAssociations = true
+	component, err = graph.GetComponentForPersonWithOptions("@I3@", options)
+	if err != nil || xrefs(component) != "@I1@ @I2@ @I3@ @I4@ @I5@ @I6@ @I7@" {
+		t.Errorf("GetComponentForPersonWithOptions(IncludeAssociations) = %q, %v", xrefs(component), err)
+	}
+}
+
+func TestPathFinding_SkipsAssociations(t *testing.T) {
+	graph := associationTestGraph(t).Graph()
+
+	// @I4@ is only linked to the family by ASSO
+	if path, err := graph.ShortestPath("@I3@", "@I4@"); err == nil && path != nil {
+		t.Errorf("ShortestPath() followed an ASSO edge: %d edges", path.Length)
+	}
+
+	path, err := graph.ShortestPath("@I3@", "@I1@")
+	if err != nil {
+		t.Fatalf("ShortestPath() error = %v", err)
+	}
+	for _, edge := range path.Edges {
+		if edge.isAssociation() {
+			t.Errorf("path contains %s edge", edge.EdgeType)
+		}
+	}
+
+	result, err := graph.CalculateRelationship("@I3@", "@I1@")
+	if err != nil || result.RelationshipType != "ancestor" {
+		t.Errorf("CalculateRelationship() = %+v, %v", result, err)
+	}
+}
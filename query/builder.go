@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -9,18 +10,42 @@ import (
 // BuildGraph constructs a graph from a GEDCOM tree.
 // This includes Phase 1 (nodes) and Phase 2 (edges).
 func BuildGraph(tree *types.GedcomTree) (*Graph, error) {
-	graph := NewGraph(tree)
+	return buildGraph(context.Background(), NewGraph(tree), tree)
+}
+
+// BuildGraphContext is like BuildGraph but stops with ctx's error once ctx is
+// cancelled or config.Timeout.BuildTimeout has elapsed (a zero timeout means no
+// limit). If config is nil, DefaultConfig() is used. Progress is reported per
+// record to the ProgressReporter attached with types.WithProgress, in two
+// stages: "Building graph: nodes" and "Building graph: edges".
+func BuildGraphContext(ctx context.Context, tree *types.GedcomTree, config *Config) (*Graph, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.Timeout.BuildTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout.BuildTimeout)
+		defer cancel()
+	}
+	return buildGraph(ctx, NewGraphWithConfig(tree, config), tree)
+}
 
+// buildGraph runs the build phases for graph, checking ctx between records.
+func buildGraph(ctx context.Context, graph *Graph, tree *types.GedcomTree) (*Graph, error) {
 	// Phase 1: Create all nodes
-	if err := createNodes(graph, tree); err != nil {
+	if err := createNodes(ctx, graph, tree); err != nil {
 		return nil, fmt.Errorf("failed to create nodes: %w", err)
 	}
 
 	// Phase 2: Create all edges
-	if err := createEdges(graph, tree); err != nil {
+	if err := createEdges(ctx, graph, tree); err != nil {
 		return nil, fmt.Errorf("failed to create edges: %w", err)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Phase 2: Build cached relationships
 	// Note: Relationships are now computed on-demand from edges to save memory.
 	// This phase is no longer needed, but kept for potential future optimizations.
@@ -45,10 +70,20 @@ func BuildGraph(tree *types.GedcomTree) (*Graph, error) {
 }
 
 // createNodes creates all nodes from the GEDCOM tree.
-func createNodes(graph *Graph, tree *types.GedcomTree) error {
-	// Create IndividualNodes
+func createNodes(ctx context.Context, graph *Graph, tree *types.GedcomTree) error {
 	individuals := tree.GetAllIndividuals()
+	families := tree.GetAllFamilies()
+	notes := tree.GetAllNotes()
+	sources := tree.GetAllSources()
+	repositories := tree.GetAllRepositories()
+	total := len(individuals) + len(families) + len(notes) + len(sources) + len(repositories)
+	tracker := types.NewProgressTracker(ctx, "Building graph: nodes", int64(total))
+
+	// Create IndividualNodes
 	for xrefID, record := range individuals {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
 		indi, ok := record.(*types.IndividualRecord)
 		if !ok {
 			continue
@@ -60,8 +95,10 @@ func createNodes(graph *Graph, tree *types.GedcomTree) error {
 	}
 
 	// Create FamilyNodes
-	families := tree.GetAllFamilies()
 	for xrefID, record := range families {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
 		fam, ok := record.(*types.FamilyRecord)
 		if !ok {
 			continue
@@ -73,8 +110,10 @@ func createNodes(graph *Graph, tree *types.GedcomTree) error {
 	}
 
 	// Create NoteNodes
-	notes := tree.GetAllNotes()
 	for xrefID, record := range notes {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
 		note, ok := record.(*types.NoteRecord)
 		if !ok {
 			continue
@@ -86,8 +125,10 @@ func createNodes(graph *Graph, tree *types.GedcomTree) error {
 	}
 
 	// Create SourceNodes
-	sources := tree.GetAllSources()
 	for xrefID, record := range sources {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
 		source, ok := record.(*types.SourceRecord)
 		if !ok {
 			continue
@@ -99,8 +140,10 @@ func createNodes(graph *Graph, tree *types.GedcomTree) error {
 	}
 
 	// Create RepositoryNodes
-	repositories := tree.GetAllRepositories()
 	for xrefID, record := range repositories {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
 		repo, ok := record.(*types.RepositoryRecord)
 		if !ok {
 			continue
@@ -114,14 +157,22 @@ func createNodes(graph *Graph, tree *types.GedcomTree) error {
 	// EventNodes will be created in Phase 2 when we process edges
 	// (they're embedded in Individual/Family records)
 
+	tracker.Finish()
 	return nil
 }
 
 // createEdges creates all edges in the graph.
 // This includes Individual ↔ Family edges, reference edges, and event edges.
-func createEdges(graph *Graph, tree *types.GedcomTree) error {
+func createEdges(ctx context.Context, graph *Graph, tree *types.GedcomTree) error {
+	// Family edges visit families, reference edges all records but
+	// repositories, and event edges individuals and families
+	individuals := len(tree.GetAllIndividuals())
+	families := len(tree.GetAllFamilies())
+	total := 3*families + 2*individuals + len(tree.GetAllNotes()) + len(tree.GetAllSources())
+	tracker := types.NewProgressTracker(ctx, "Building graph: edges", int64(total))
+
 	// Phase 2.1: Create Individual ↔ Family edges
-	if err := createFamilyEdges(graph, tree); err != nil {
+	if err := createFamilyEdges(graph, tree, tracker); err != nil {
 		return fmt.Errorf("failed to create family edges: %w", err)
 	}
 
@@ -129,15 +180,16 @@ func createEdges(graph *Graph, tree *types.GedcomTree) error {
 	populateParentCache(graph)
 
 	// Phase 2.2: Create reference edges (NOTE, SOUR, REPO)
-	if err := createReferenceEdges(graph, tree); err != nil {
+	if err := createReferenceEdges(graph, tree, tracker); err != nil {
 		return fmt.Errorf("failed to create reference edges: %w", err)
 	}
 
 	// Phase 2.3: Create event nodes and edges
-	if err := createEventNodesAndEdges(graph, tree); err != nil {
+	if err := createEventNodesAndEdges(graph, tree, tracker); err != nil {
 		return fmt.Errorf("failed to create event nodes and edges: %w", err)
 	}
 
+	tracker.Finish()
 	return nil
 }
 
@@ -174,10 +226,14 @@ func populateParentCache(graph *Graph) {
 }
 
 // createFamilyEdges creates edges between Individual and Family nodes.
-func createFamilyEdges(graph *Graph, tree *types.GedcomTree) error {
+func createFamilyEdges(graph *Graph, tree *types.GedcomTree, tracker *types.ProgressTracker) error {
 	families := tree.GetAllFamilies()
 
 	for famXref, famRecord := range families {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		fam, ok := famRecord.(*types.FamilyRecord)
 		if !ok {
 			continue
@@ -269,6 +325,9 @@ func createFamilyEdges(graph *Graph, tree *types.GedcomTree) error {
 
 			// Phase 1: Index the FAMC edge for fast access
 			childNode.famcEdges = append(childNode.famcEdges, edge2)
+
+			// Both edges carry the pedigree of the link (PEDI, _FREL, _MREL)
+			setLinkPedigree(fam, childNode.Individual, edge, edge2)
 		}
 	}
 
@@ -276,10 +335,14 @@ func createFamilyEdges(graph *Graph, tree *types.GedcomTree) error {
 }
 
 // createReferenceEdges creates NOTE, SOUR, and REPO reference edges.
-func createReferenceEdges(graph *Graph, tree *types.GedcomTree) error {
+func createReferenceEdges(graph *Graph, tree *types.GedcomTree, tracker *types.ProgressTracker) error {
 	// Individual -> NOTE edges
 	individuals := tree.GetAllIndividuals()
 	for xrefID, record := range individuals {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		indi, ok := record.(*types.IndividualRecord)
 		if !ok {
 			continue
@@ -315,11 +378,22 @@ func createReferenceEdges(graph *Graph, tree *types.GedcomTree) error {
 				}
 			}
 		}
+
+		// ASSO and ALIA links to other individuals
+		for _, edge := range graph.associationEdges(indiNode, indi.GetAssociations(), indi.GetAliases()) {
+			if err := graph.AddEdge(edge); err != nil {
+				return fmt.Errorf("failed to add %s edge: %w", edge.EdgeType, err)
+			}
+		}
 	}
 
 	// Family -> NOTE and SOUR edges
 	families := tree.GetAllFamilies()
 	for xrefID, record := range families {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		fam, ok := record.(*types.FamilyRecord)
 		if !ok {
 			continue
@@ -355,11 +429,22 @@ func createReferenceEdges(graph *Graph, tree *types.GedcomTree) error {
 				}
 			}
 		}
+
+		// ASSO links under family events
+		for _, edge := range graph.associationEdges(famNode, fam.GetAssociations(), nil) {
+			if err := graph.AddEdge(edge); err != nil {
+				return fmt.Errorf("failed to add ASSO edge: %w", err)
+			}
+		}
 	}
 
 	// Note -> SOUR edges
 	notes := tree.GetAllNotes()
 	for xrefID, record := range notes {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		note, ok := record.(*types.NoteRecord)
 		if !ok {
 			continue
@@ -386,6 +471,10 @@ func createReferenceEdges(graph *Graph, tree *types.GedcomTree) error {
 	// Source -> REPO edges
 	sources := tree.GetAllSources()
 	for xrefID, record := range sources {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		source, ok := record.(*types.SourceRecord)
 		if !ok {
 			continue
@@ -413,10 +502,14 @@ func createReferenceEdges(graph *Graph, tree *types.GedcomTree) error {
 }
 
 // createEventNodesAndEdges creates EventNodes from embedded events and their edges.
-func createEventNodesAndEdges(graph *Graph, tree *types.GedcomTree) error {
+func createEventNodesAndEdges(graph *Graph, tree *types.GedcomTree, tracker *types.ProgressTracker) error {
 	// Process Individual events
 	individuals := tree.GetAllIndividuals()
 	for xrefID, record := range individuals {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		indi, ok := record.(*types.IndividualRecord)
 		if !ok {
 			continue
@@ -499,6 +592,10 @@ func createEventNodesAndEdges(graph *Graph, tree *types.GedcomTree) error {
 	// Process Family events
 	families := tree.GetAllFamilies()
 	for xrefID, record := range families {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		fam, ok := record.(*types.FamilyRecord)
 		if !ok {
 			continue
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -373,7 +374,7 @@ func TestBuilder_createNodes_EdgeCases(t *testing.T) {
 	// But we can test the error path in AddNode
 	
 	// Test createNodes with empty tree
-	err := createNodes(graph, tree)
+	err := createNodes(context.Background(), graph, tree)
 	if err != nil {
 		t.Fatalf("createNodes should succeed with empty tree: %v", err)
 	}
@@ -385,12 +386,12 @@ func TestBuilder_createEdges_EdgeCases(t *testing.T) {
 	graph := NewGraph(tree)
 
 	// Create nodes first
-	if err := createNodes(graph, tree); err != nil {
+	if err := createNodes(context.Background(), graph, tree); err != nil {
 		t.Fatalf("Failed to create nodes: %v", err)
 	}
 
 	// Test createEdges with empty tree
-	err := createEdges(graph, tree)
+	err := createEdges(context.Background(), graph, tree)
 	if err != nil {
 		t.Fatalf("createEdges should succeed with empty tree: %v", err)
 	}
@@ -405,12 +406,12 @@ func TestBuilder_createEdges_EdgeCases(t *testing.T) {
 	tree2.AddRecord(types.NewIndividualRecord(indiLine))
 
 	graph2 := NewGraph(tree2)
-	if err := createNodes(graph2, tree2); err != nil {
+	if err := createNodes(context.Background(), graph2, tree2); err != nil {
 		t.Fatalf("Failed to create nodes: %v", err)
 	}
 
 	// createEdges should handle missing references gracefully
-	err2 := createEdges(graph2, tree2)
+	err2 := createEdges(context.Background(), graph2, tree2)
 	if err2 != nil {
 		t.Logf("createEdges returned error (may be expected): %v", err2)
 	}
@@ -1,8 +1,11 @@
 package query
 
 import (
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/gazetteer"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
@@ -294,6 +297,68 @@ func TestPlaceCollectionQuery_AllUniquenessCriteria(t *testing.T) {
 	}
 }
 
+// TestPlaceCollectionQuery_ByCanonical tests uniqueness by canonical gazetteer place
+func TestPlaceCollectionQuery_ByCanonical(t *testing.T) {
+	tree := CreateTestTree()
+	tree.AddRecord(CreateTestIndividualWithBirth("@I1@", "John /Doe/", "1 JAN 1900", "St. Louis, MO"))
+	tree.AddRecord(CreateTestIndividualWithBirth("@I2@", "Jane /Doe/", "1 JAN 1900", "Saint Louis, Missouri, USA"))
+	tree.AddRecord(CreateTestIndividualWithBirth("@I3@", "Bob /Smith/", "1 JAN 1900", "Atlantis"))
+
+	q, err := CreateTestQuery(tree)
+	if err != nil {
+		t.Fatalf("Failed to create query: %v", err)
+	}
+
+	g := gazetteer.New()
+	err = g.ReadCSV(strings.NewReader("id,name,state,state_code,country,country_code,lat,lon\n" +
+		"4407066,Saint Louis,Missouri,MO,United States,USA,38.62727,-90.19789\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+
+	results, err := q.Places().By(PlaceUniqueByCanonical).WithGazetteer(g).Execute()
+	if err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	sort.Strings(results)
+	want := []string{"Atlantis", "Saint Louis, , Missouri, United States"}
+	if len(results) != len(want) || results[0] != want[0] || results[1] != want[1] {
+		t.Errorf("Execute() = %v, want %v", results, want)
+	}
+
+	// Different places with the same name are not merged
+	tree = CreateTestTree()
+	tree.AddRecord(CreateTestIndividualWithBirth("@I1@", "John /Doe/", "1 JAN 1900", "Newport Village, RI"))
+	tree.AddRecord(CreateTestIndividualWithBirth("@I2@", "Jane /Doe/", "1 JAN 1900", "Newport Town, RI"))
+	twins, err := CreateTestQuery(tree)
+	if err != nil {
+		t.Fatalf("Failed to create query: %v", err)
+	}
+	g = gazetteer.New()
+	err = g.ReadCSV(strings.NewReader("id,name,alternate_names,state,state_code,country,lat,lon\n" +
+		"1,Newport,Newport Village,Rhode Island,RI,United States,41.49,-71.31\n" +
+		"2,Newport,Newport Town,Rhode Island,RI,United States,41.52,-71.29\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	results, err = twins.Places().By(PlaceUniqueByCanonical).WithGazetteer(g).Execute()
+	if err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Execute() with two places named Newport = %v, want 2 places", results)
+	}
+
+	// Without a gazetteer places are compared by full string
+	results, err = q.Places().By(PlaceUniqueByCanonical).Execute()
+	if err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("Execute() without gazetteer = %v, want 3 places", results)
+	}
+}
+
 // TestCollectionQuery_ErrorHandling tests error handling in collection queries
 func TestCollectionQuery_ErrorHandling(t *testing.T) {
 	// Test with empty tree
@@ -6,8 +6,9 @@ import (
 
 // ComponentOptions holds configuration for component queries.
 type ComponentOptions struct {
-	MaxDepth int // Limit traversal depth (0 = unlimited)
-	MaxSize  int // Limit result size (0 = unlimited)
+	MaxDepth            int  // Limit traversal depth (0 = unlimited)
+	MaxSize             int  // Limit result size (0 = unlimited)
+	IncludeAssociations bool // Also follow ASSO and ALIA links (godparents, witnesses, aliases)
 }
 
 // NewComponentOptions creates new ComponentOptions with defaults.
@@ -105,6 +106,15 @@ func (g *Graph) GetComponentForPersonWithOptions(personID string, options *Compo
 			}
 		}
 
+		// Through ASSO and ALIA edges, if requested
+		if options.IncludeAssociations {
+			for _, associate := range current.associatedIndividuals() {
+				if associate.ID() != current.ID() {
+					connectedIndividuals[associate.ID()] = associate
+				}
+			}
+		}
+
 		// Add connected individuals to queue
 		for neighborID, neighborNode := range connectedIndividuals {
 			if !visited[neighborID] {
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// buildChainTree returns a tree of n generations, each a child of the previous
+// one, so @I1@ and @In@ are connected through n-1 families.
+func buildChainTree(n int) *types.GedcomTree {
+	tree := CreateTestTree()
+	for i := 1; i <= n; i++ {
+		AddTestIndividual(tree, fmt.Sprintf("@I%d@", i), fmt.Sprintf("Person%d /Chain/", i))
+	}
+	for i := 1; i < n; i++ {
+		parent := fmt.Sprintf("@I%d@", i)
+		child := fmt.Sprintf("@I%d@", i+1)
+		tree.AddRecord(CreateTestFamily(fmt.Sprintf("@F%d@", i), parent, "", []string{child}))
+	}
+	return tree
+}
+
+func TestBuildGraphContext_Progress(t *testing.T) {
+	tree := buildChainTree(50)
+
+	final := make(map[string][2]int64)
+	ctx := types.WithProgress(context.Background(), types.ProgressFunc(func(stage string, done, total int64) {
+		final[stage] = [2]int64{done, total}
+	}))
+
+	graph, err := BuildGraphContext(ctx, tree, nil)
+	if err != nil {
+		t.Fatalf("BuildGraphContext() error = %v", err)
+	}
+	if graph.GetIndividual("@I50@") == nil {
+		t.Error("expected @I50@ in graph")
+	}
+
+	// 50 individuals and 49 families
+	if got := final["Building graph: nodes"]; got != [2]int64{99, 99} {
+		t.Errorf("nodes progress = %v, want [99 99]", got)
+	}
+	if got := final["Building graph: edges"]; got[0] != got[1] || got[1] == 0 {
+		t.Errorf("edges progress = %v, want complete", got)
+	}
+}
+
+func TestBuildGraphContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := BuildGraphContext(ctx, buildChainTree(10), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("BuildGraphContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestBuildGraphContext_BuildTimeout(t *testing.T) {
+	config := DefaultConfig()
+	config.Timeout.BuildTimeout = time.Nanosecond
+
+	_, err := BuildGraphContext(context.Background(), buildChainTree(10), config)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BuildGraphContext() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFilterQuery_ExecuteContext(t *testing.T) {
+	graph, err := BuildGraph(buildChainTree(20))
+	if err != nil {
+		t.Fatalf("BuildGraph() error = %v", err)
+	}
+
+	results, err := NewFilterQuery(graph).ByName("Chain").ExecuteContext(context.Background())
+	if err != nil {
+		t.Fatalf("ExecuteContext() error = %v", err)
+	}
+	if len(results) != 20 {
+		t.Errorf("got %d results, want 20", len(results))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewFilterQuery(graph).ExecuteContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ExecuteContext() error = %v, want context.Canceled", err)
+	}
+
+	// The graph's query timeout applies to ExecuteContext but not Execute
+	graph.config.Timeout.QueryTimeout = time.Nanosecond
+	if _, err := NewFilterQuery(graph).ExecuteContext(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ExecuteContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if _, err := NewFilterQuery(graph).Execute(); err != nil {
+		t.Errorf("Execute() error = %v", err)
+	}
+}
+
+func TestGraph_PathFindingContext(t *testing.T) {
+	graph, err := BuildGraph(buildChainTree(20))
+	if err != nil {
+		t.Fatalf("BuildGraph() error = %v", err)
+	}
+
+	path, err := graph.ShortestPathContext(context.Background(), "@I1@", "@I20@")
+	if err != nil {
+		t.Fatalf("ShortestPathContext() error = %v", err)
+	}
+	if path.Length != 38 {
+		t.Errorf("path length = %d, want 38", path.Length)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := graph.ShortestPathContext(ctx, "@I1@", "@I20@"); !errors.Is(err, context.Canceled) {
+		t.Errorf("ShortestPathContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := graph.AllPathsContext(ctx, "@I1@", "@I20@", 40); !errors.Is(err, context.Canceled) {
+		t.Errorf("AllPathsContext() error = %v, want context.Canceled", err)
+	}
+
+	paths, err := graph.AllPathsContext(context.Background(), "@I1@", "@I3@", 4)
+	if err != nil {
+		t.Fatalf("AllPathsContext() error = %v", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("got %d paths, want 1", len(paths))
+	}
+}
@@ -0,0 +1,437 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/validator"
+)
+
+// estimateIterations bounds how often constraints are propagated, so
+// estimates reach relatives up to this many links from a dated individual.
+const estimateIterations = 10
+
+// DateEstimator estimates the birth dates of individuals without one. Each
+// individual's possible birth dates start unbounded and are narrowed by:
+//   - their own dated events, which come after the birth, and ages stated on them
+//   - their death date and ValidationConfig.MaxDeathAge
+//   - their marriage dates and MinMarriageAge/MaxMarriageAge, and spouse ages
+//     stated on family events
+//   - parents' and children's birth dates and MinParentAge/MaxParentAge
+//     (birth links only, see LineageBiological), and the parents' deaths
+//   - siblings' birth dates: siblings are at most MaxParentAge-MinParentAge
+//     years apart and, where the dated children of a family are listed in
+//     birth order, the undated ones are taken to be in order too
+//
+// Bounds are propagated between relatives until nothing changes, so an
+// estimate can rest on other estimates. A constraint that would leave no
+// possible date is skipped.
+type DateEstimator struct {
+	graph  *Graph
+	config *validator.ValidationConfig
+
+	// Time Deceased is judged at; the zero time is the time of the run
+	referenceTime time.Time
+}
+
+// NewDateEstimator creates a DateEstimator over the graph's tree. A nil
+// config uses validator.NewValidationConfig.
+func NewDateEstimator(graph *Graph, config *validator.ValidationConfig) *DateEstimator {
+	if config == nil {
+		config = validator.NewValidationConfig()
+	}
+	return &DateEstimator{graph: graph, config: config}
+}
+
+// WithReferenceTime sets the time estimates are made at: an individual is
+// Deceased when their latest possible birth is more than MaxDeathAge years
+// before it. By default it is the time Estimate is called.
+func (de *DateEstimator) WithReferenceTime(t time.Time) *DateEstimator {
+	de.referenceTime = t
+	return de
+}
+
+// Estimate returns the estimates of individuals without a birth date, keyed
+// by xref. Individuals nothing is known about are left out.
+func (de *DateEstimator) Estimate() types.DateEstimates {
+	estimates, _ := de.EstimateContext(context.Background())
+	return estimates
+}
+
+// EstimateContext is like Estimate but stops with ctx's error once ctx is
+// cancelled or the graph's Config.Timeout.QueryTimeout has elapsed. Progress
+// is reported per propagation pass under the stage "Estimating dates".
+func (de *DateEstimator) EstimateContext(ctx context.Context) (types.DateEstimates, error) {
+	ctx, cancel := de.graph.queryContext(ctx)
+	defer cancel()
+
+	tree := de.graph.tree
+	if tree == nil {
+		return types.DateEstimates{}, nil
+	}
+
+	e := &estimation{
+		config:        de.config,
+		referenceTime: de.referenceTime,
+		bounds:        make(map[string]*birthBounds),
+	}
+	if e.referenceTime.IsZero() {
+		e.referenceTime = time.Now()
+	}
+	e.collect(tree)
+
+	tracker := types.NewProgressTracker(ctx, "Estimating dates", estimateIterations)
+	for i := 0; i < estimateIterations; i++ {
+		if err := tracker.Add(1); err != nil {
+			return nil, err
+		}
+		if !e.propagate() {
+			break
+		}
+	}
+	tracker.Finish()
+
+	return e.estimates(), nil
+}
+
+// birthBounds are the possible birth dates of one individual. A zero time
+// is an open bound. Recorded birth dates are never changed.
+type birthBounds struct {
+	earliest, latest             time.Time
+	earliestReason, latestReason string
+	recorded                     bool
+}
+
+// notBefore raises the earliest bound to t. It reports whether the bound
+// changed; it does not if t is no later than the bound or after the latest.
+func (b *birthBounds) notBefore(t time.Time, reason string) bool {
+	if b.recorded || t.IsZero() {
+		return false
+	}
+	if !b.earliest.IsZero() && !t.After(b.earliest) {
+		return false
+	}
+	if !b.latest.IsZero() && t.After(b.latest) {
+		return false
+	}
+	b.earliest, b.earliestReason = t, reason
+	return true
+}
+
+// notAfter lowers the latest bound to t, like notBefore.
+func (b *birthBounds) notAfter(t time.Time, reason string) bool {
+	if b.recorded || t.IsZero() {
+		return false
+	}
+	if !b.latest.IsZero() && !t.Before(b.latest) {
+		return false
+	}
+	if !b.earliest.IsZero() && t.Before(b.earliest) {
+		return false
+	}
+	b.latest, b.latestReason = t, reason
+	return true
+}
+
+// parentLink is a birth link between a parent and a child.
+type parentLink struct {
+	parent, child string
+	role          string // "father", "mother" or "parent"
+}
+
+// siblingLink is a pair of children of one family, earlier first in CHIL
+// order. ordered is set when the family's children are in birth order.
+type siblingLink struct {
+	earlier, later string
+	family         string
+	ordered        bool
+}
+
+// estimation holds the state of one DateEstimator run.
+type estimation struct {
+	config        *validator.ValidationConfig
+	referenceTime time.Time
+	bounds        map[string]*birthBounds
+	xrefs         []string
+	parents       []parentLink
+	siblings      []siblingLink
+}
+
+// collect sets the bounds that follow from each individual's own records
+// and gathers the links between relatives.
+func (e *estimation) collect(tree *types.GedcomTree) {
+	for xrefID := range tree.GetAllIndividuals() {
+		e.xrefs = append(e.xrefs, xrefID)
+	}
+	sort.Strings(e.xrefs)
+
+	for _, xrefID := range e.xrefs {
+		indi, ok := tree.GetIndividual(xrefID).(*types.IndividualRecord)
+		if !ok {
+			continue
+		}
+		b := &birthBounds{}
+		e.bounds[xrefID] = b
+		if birth, err := indi.GetBirthDateParsed(); err == nil && birth != nil && birth.IsValid() {
+			b.earliest, b.latest, b.recorded = birth.Earliest(), birth.Latest(), true
+			continue
+		}
+		e.collectEvents(indi, b)
+	}
+
+	var famXrefs []string
+	for xrefID := range tree.GetAllFamilies() {
+		famXrefs = append(famXrefs, xrefID)
+	}
+	sort.Strings(famXrefs)
+	for _, famXref := range famXrefs {
+		if fam, ok := tree.GetFamily(famXref).(*types.FamilyRecord); ok {
+			e.collectFamily(tree, fam)
+		}
+	}
+}
+
+// collectEvents bounds an undated individual's birth by their own events.
+func (e *estimation) collectEvents(indi *types.IndividualRecord, b *birthBounds) {
+	for _, event := range types.ExtractEvents(indi) {
+		if event.Type == types.EventTypeBirth || !event.HasDate() {
+			continue
+		}
+		date := event.Date.Date
+		if latest, ok := boundLatest(date); ok {
+			b.notAfter(latest, fmt.Sprintf("%s dated %s", event.EffectiveType(), date))
+		}
+		if event.Type == types.EventTypeDeath {
+			if earliest, ok := boundEarliest(date); ok {
+				b.notBefore(earliest.AddDate(-e.config.MaxDeathAge, 0, 0),
+					fmt.Sprintf("died %s, at most %d years old", date, e.config.MaxDeathAge))
+			}
+		}
+		e.collectAge(b, event, event.Age, "stated age")
+	}
+}
+
+// collectAge bounds a birth by an age stated at an event.
+func (e *estimation) collectAge(b *birthBounds, event *types.Event, age *types.Age, what string) {
+	if !event.HasDate() {
+		return
+	}
+	birth, ok := types.BirthRangeFromAge(event.Date.Date, age)
+	if !ok {
+		return
+	}
+	reason := fmt.Sprintf("%s %s at %s (%s)", what, age, event.EffectiveType(), event.Date.Date)
+	if earliest, ok := boundEarliest(birth.StartDate()); ok {
+		b.notBefore(earliest, reason)
+	}
+	if latest, ok := boundLatest(birth.EndDate()); ok {
+		b.notAfter(latest, reason)
+	}
+}
+
+// collectFamily bounds the spouses by the family's marriages and stated
+// ages, bounds the children by their parents' deaths, and links parents,
+// children and siblings.
+func (e *estimation) collectFamily(tree *types.GedcomTree, fam *types.FamilyRecord) {
+	husband, wife := fam.GetHusband(), fam.GetWife()
+
+	for _, event := range types.ExtractEvents(fam) {
+		if !event.HasDate() {
+			continue
+		}
+		for _, spouse := range []struct {
+			xref, role string
+			age        *types.Age
+		}{{husband, "husband", event.HusbandAge}, {wife, "wife", event.WifeAge}} {
+			b := e.bounds[spouse.xref]
+			if b == nil {
+				continue
+			}
+			if event.Type == types.EventTypeMarriage {
+				date := event.Date.Date
+				if latest, ok := boundLatest(date); ok {
+					b.notAfter(latest.AddDate(-e.config.MinMarriageAge, 0, 0),
+						fmt.Sprintf("married %s in %s, at least %d years old", date, fam.XrefID(), e.config.MinMarriageAge))
+				}
+				if earliest, ok := boundEarliest(date); ok {
+					b.notBefore(earliest.AddDate(-e.config.MaxMarriageAge, 0, 0),
+						fmt.Sprintf("married %s in %s, at most %d years old", date, fam.XrefID(), e.config.MaxMarriageAge))
+				}
+			}
+			e.collectAge(b, event, spouse.age, "stated "+spouse.role+" age")
+		}
+	}
+
+	children := fam.GetChildren()
+	for _, childXref := range children {
+		child, ok := tree.GetIndividual(childXref).(*types.IndividualRecord)
+		if !ok {
+			continue
+		}
+		link := &Edge{Properties: linkPedigree(fam, child)}
+		for _, parent := range []struct {
+			xref, role string
+			edgeType   EdgeType
+			deathSlack int // Years a child can be born after this parent's death
+		}{{husband, "father", EdgeTypeHUSB, 1}, {wife, "mother", EdgeTypeWIFE, 0}} {
+			if parent.xref == "" || !LineageBiological.Allows(link.ParentPedigree(parent.edgeType)) {
+				continue
+			}
+			e.parents = append(e.parents, parentLink{parent: parent.xref, child: childXref, role: parent.role})
+			if parentRecord, ok := tree.GetIndividual(parent.xref).(*types.IndividualRecord); ok {
+				death, err := parentRecord.GetDeathDateParsed()
+				if latest, ok := boundLatest(death); err == nil && ok {
+					e.bounds[childXref].notAfter(latest.AddDate(parent.deathSlack, 0, 0),
+						fmt.Sprintf("%s %s died %s", parent.role, parent.xref, death))
+				}
+			}
+		}
+	}
+
+	ordered := e.childrenInBirthOrder(children)
+	for i := range children {
+		for j := i + 1; j < len(children); j++ {
+			e.siblings = append(e.siblings, siblingLink{
+				earlier: children[i],
+				later:   children[j],
+				family:  fam.XrefID(),
+				ordered: ordered,
+			})
+		}
+	}
+}
+
+// childrenInBirthOrder reports whether the children with a recorded birth
+// date are listed in birth order.
+func (e *estimation) childrenInBirthOrder(children []string) bool {
+	var previous time.Time
+	for _, childXref := range children {
+		b := e.bounds[childXref]
+		if b == nil || !b.recorded {
+			continue
+		}
+		if b.earliest.Before(previous) {
+			return false
+		}
+		previous = b.earliest
+	}
+	return true
+}
+
+// propagate applies the links between relatives once and reports whether
+// any bound changed.
+func (e *estimation) propagate() bool {
+	changed := false
+	minAge, maxAge := e.config.MinParentAge, e.config.MaxParentAge
+
+	for _, link := range e.parents {
+		parent, child := e.bounds[link.parent], e.bounds[link.child]
+		if parent == nil || child == nil {
+			continue
+		}
+		if !parent.earliest.IsZero() && child.notBefore(parent.earliest.AddDate(minAge, 0, 0),
+			fmt.Sprintf("%s %s born no earlier than %s, at least %d at a child's birth", link.role, link.parent, formatBound(parent.earliest), minAge)) {
+			changed = true
+		}
+		if !parent.latest.IsZero() && child.notAfter(parent.latest.AddDate(maxAge, 0, 0),
+			fmt.Sprintf("%s %s born no later than %s, at most %d at a child's birth", link.role, link.parent, formatBound(parent.latest), maxAge)) {
+			changed = true
+		}
+		if !child.earliest.IsZero() && parent.notBefore(child.earliest.AddDate(-maxAge, 0, 0),
+			fmt.Sprintf("%s of %s (born no earlier than %s), at most %d at a child's birth", link.role, link.child, formatBound(child.earliest), maxAge)) {
+			changed = true
+		}
+		if !child.latest.IsZero() && parent.notAfter(child.latest.AddDate(-minAge, 0, 0),
+			fmt.Sprintf("%s of %s (born no later than %s), at least %d at a child's birth", link.role, link.child, formatBound(child.latest), minAge)) {
+			changed = true
+		}
+	}
+
+	span := maxAge - minAge
+	for _, link := range e.siblings {
+		earlier, later := e.bounds[link.earlier], e.bounds[link.later]
+		if earlier == nil || later == nil {
+			continue
+		}
+		pairs := []struct {
+			b, other      *birthBounds
+			xref, sibling string
+		}{{earlier, later, link.earlier, link.later}, {later, earlier, link.later, link.earlier}}
+		for _, pair := range pairs {
+			if !pair.other.earliest.IsZero() && pair.b.notBefore(pair.other.earliest.AddDate(-span, 0, 0),
+				fmt.Sprintf("sibling %s born no earlier than %s, at most %d years apart", pair.sibling, formatBound(pair.other.earliest), span)) {
+				changed = true
+			}
+			if !pair.other.latest.IsZero() && pair.b.notAfter(pair.other.latest.AddDate(span, 0, 0),
+				fmt.Sprintf("sibling %s born no later than %s, at most %d years apart", pair.sibling, formatBound(pair.other.latest), span)) {
+				changed = true
+			}
+		}
+		if !link.ordered {
+			continue
+		}
+		if !earlier.earliest.IsZero() && later.notBefore(earlier.earliest,
+			fmt.Sprintf("listed after sibling %s (born no earlier than %s) in %s", link.earlier, formatBound(earlier.earliest), link.family)) {
+			changed = true
+		}
+		if !later.latest.IsZero() && earlier.notAfter(later.latest,
+			fmt.Sprintf("listed before sibling %s (born no later than %s) in %s", link.later, formatBound(later.latest), link.family)) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// estimates builds the estimates of the individuals without a recorded birth.
+func (e *estimation) estimates() types.DateEstimates {
+	estimates := make(types.DateEstimates)
+	for _, xrefID := range e.xrefs {
+		b := e.bounds[xrefID]
+		if b == nil || b.recorded {
+			continue
+		}
+
+		var explanation []string
+		if !b.earliest.IsZero() {
+			explanation = append(explanation, fmt.Sprintf("Earliest %s: %s", formatBound(b.earliest), b.earliestReason))
+		}
+		if !b.latest.IsZero() {
+			explanation = append(explanation, fmt.Sprintf("Latest %s: %s", formatBound(b.latest), b.latestReason))
+		}
+		estimate := types.NewDateEstimate(b.earliest, b.latest, explanation)
+		if estimate == nil {
+			continue
+		}
+		estimate.Deceased = !b.latest.IsZero() && b.latest.AddDate(e.config.MaxDeathAge, 0, 0).Before(e.referenceTime)
+		estimates[xrefID] = estimate
+	}
+	return estimates
+}
+
+// boundEarliest returns the earliest time of a date, unless the date is
+// missing or a BEFORE date, which has no earliest time.
+func boundEarliest(date *types.GedcomDate) (time.Time, bool) {
+	if date == nil || !date.IsValid() || date.Type == types.DateTypeBefore {
+		return time.Time{}, false
+	}
+	return date.Earliest(), true
+}
+
+// boundLatest returns the latest time of a date, unless the date is missing
+// or an AFTER date, which has no latest time.
+func boundLatest(date *types.GedcomDate) (time.Time, bool) {
+	if date == nil || !date.IsValid() || date.Type == types.DateTypeAfter {
+		return time.Time{}, false
+	}
+	return date.Latest(), true
+}
+
+// formatBound formats a bound as a GEDCOM day, such as "2 JAN 1857".
+func formatBound(t time.Time) string {
+	return strings.ToUpper(t.Format("2 Jan 2006"))
+}
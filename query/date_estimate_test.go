@@ -0,0 +1,192 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// dateEstimateTestGraph builds a family where only some births are dated:
+//
+//	@I1@ (b. 1 JAN 1800) + @I2@ (@F1@, married 10 JUN 1825):
+//	    @I3@ b. 1826, @I4@ undated, @I5@ b. 1830, @I6@ adopted, undated
+//	@I7@ died 5 MAR 1890 aged 60y
+//	@I8@ nothing known
+func dateEstimateTestGraph(t *testing.T) *Graph {
+	t.Helper()
+	input := `0 HEAD
+0 @I1@ INDI
+1 BIRT
+2 DATE 1 JAN 1800
+1 FAMS @F1@
+0 @I2@ INDI
+1 FAMS @F1@
+0 @I3@ INDI
+1 BIRT
+2 DATE 1826
+1 FAMC @F1@
+0 @I4@ INDI
+1 FAMC @F1@
+0 @I5@ INDI
+1 BIRT
+2 DATE 1830
+1 FAMC @F1@
+0 @I6@ INDI
+1 FAMC @F1@
+2 PEDI adopted
+0 @I7@ INDI
+1 DEAT
+2 DATE 5 MAR 1890
+2 AGE 60y
+0 @I8@ INDI
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE 10 JUN 1825
+1 CHIL @I3@
+1 CHIL @I4@
+1 CHIL @I5@
+1 CHIL @I6@
+0 TRLR
+`
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	graph, err := BuildGraph(tree)
+	if err != nil {
+		t.Fatalf("BuildGraph() error = %v", err)
+	}
+	return graph
+}
+
+func TestDateEstimator_Estimate(t *testing.T) {
+	estimates := NewDateEstimator(dateEstimateTestGraph(t), nil).WithReferenceTime(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).Estimate()
+
+	for _, xref := range []string{"@I1@", "@I3@", "@I5@", "@I8@"} {
+		if estimate, ok := estimates[xref]; ok {
+			t.Errorf("%s: expected no estimate, got %s", xref, estimate)
+		}
+	}
+
+	tests := []struct {
+		xref     string
+		want     string
+		reason   string
+		deceased bool
+	}{
+		// Listed between two dated siblings
+		{"@I4@", "EST BET 1 JAN 1826 AND 31 DEC 1830", "listed after sibling @I3@", true},
+		// Married at 12 or older; a child at 80 or younger
+		{"@I2@", "EST BET 1 JAN 1750 AND 10 JUN 1813", "married 10 JUN 1825 in @F1@", true},
+		// Stated age at death
+		{"@I7@", "EST BET 6 MAR 1829 AND 5 MAR 1830", "stated age 60y at DEAT", true},
+	}
+	for _, tt := range tests {
+		estimate, ok := estimates[tt.xref]
+		if !ok {
+			t.Errorf("%s: expected an estimate", tt.xref)
+			continue
+		}
+		if got := estimate.String(); got != tt.want {
+			t.Errorf("%s: estimate = %s, want %s", tt.xref, got, tt.want)
+		}
+		if explanation := strings.Join(estimate.Explanation, "\n"); !strings.Contains(explanation, tt.reason) {
+			t.Errorf("%s: explanation %q does not mention %q", tt.xref, explanation, tt.reason)
+		}
+		if estimate.Deceased != tt.deceased {
+			t.Errorf("%s: Deceased = %v, want %v", tt.xref, estimate.Deceased, tt.deceased)
+		}
+	}
+
+	// An adopted child is not bounded by the father's birth
+	if estimate, ok := estimates["@I6@"]; ok {
+		for _, line := range estimate.Explanation {
+			if strings.Contains(line, "father") {
+				t.Errorf("@I6@: adopted child bounded by a parent: %s", line)
+			}
+		}
+	}
+}
+
+func TestDateEstimator_WithReferenceTime(t *testing.T) {
+	graph := dateEstimateTestGraph(t)
+
+	// @I4@ was born by 1830, so could still have been alive in 1900
+	estimates := NewDateEstimator(graph, nil).WithReferenceTime(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)).Estimate()
+	if estimates["@I4@"].Deceased {
+		t.Error("@I4@: Deceased in 1900, want not")
+	}
+	estimates = NewDateEstimator(graph, nil).WithReferenceTime(time.Date(1951, 1, 1, 0, 0, 0, 0, time.UTC)).Estimate()
+	if !estimates["@I4@"].Deceased {
+		t.Error("@I4@: not Deceased in 1951")
+	}
+}
+
+func TestDateEstimate_String(t *testing.T) {
+	day := time.Date(1813, 6, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		earliest, latest time.Time
+		want             string
+	}{
+		{day.AddDate(-5, 0, 0), day, "EST BET 10 JUN 1808 AND 10 JUN 1813"},
+		{time.Time{}, day, "BEF 10 JUN 1813 (estimated)"},
+		{day, time.Time{}, "AFT 10 JUN 1813 (estimated)"},
+	}
+	for _, tt := range tests {
+		if got := types.NewDateEstimate(tt.earliest, tt.latest, nil).String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestDateEstimator_EstimateContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewDateEstimator(dateEstimateTestGraph(t), nil).EstimateContext(ctx); err == nil {
+		t.Error("EstimateContext() with a cancelled context should return an error")
+	}
+}
+
+func TestFilterQuery_WithEstimates(t *testing.T) {
+	graph := dateEstimateTestGraph(t)
+	estimates := NewDateEstimator(graph, nil).Estimate()
+
+	results, err := NewFilterQuery(graph).ByBirthYear(1828).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := xrefs(results); got != "" {
+		t.Errorf("ByBirthYear(1828) without estimates = %q, want none", got)
+	}
+
+	// @I4@ is estimated between 1826 and 1830
+	results, err = NewFilterQuery(graph).ByBirthYear(1828).WithEstimates(estimates).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := xrefs(results); got != "@I4@" {
+		t.Errorf("ByBirthYear(1828) with estimates = %q, want @I4@", got)
+	}
+
+	// Recorded births still match
+	results, _ = NewFilterQuery(graph).WithEstimates(estimates).ByBirthYear(1830).Execute()
+	if got := xrefs(results); got != "@I5@" {
+		t.Errorf("ByBirthYear(1830) with estimates = %q, want @I5@", got)
+	}
+
+	results, _ = NewFilterQuery(graph).Living().WithEstimates(estimates).Execute()
+	if got := xrefs(results); strings.Contains(got, "@I4@") || !strings.Contains(got, "@I8@") {
+		t.Errorf("Living() with estimates = %q, want @I8@ but not @I4@", got)
+	}
+
+	results, _ = NewFilterQuery(graph).Deceased().WithEstimates(estimates).Execute()
+	if got := xrefs(results); !strings.Contains(got, "@I4@") || !strings.Contains(got, "@I7@") {
+		t.Errorf("Deceased() with estimates = %q, want @I4@ and @I7@", got)
+	}
+}
@@ -12,6 +12,7 @@ type DescendantOptions struct {
 	IncludeSelf    bool                                // Include starting individual
 	Filter         func(*types.IndividualRecord) bool // Custom filter function
 	Order          Order                               // BFS or DFS order
+	Lineage        Lineage                             // Parent-child links to follow
 }
 
 // NewDescendantOptions creates new DescendantOptions with defaults.
@@ -21,6 +22,7 @@ func NewDescendantOptions() *DescendantOptions {
 		IncludeSelf:    false,
 		Filter:         nil,
 		Order:          OrderBFS,
+		Lineage:        LineageAll,
 	}
 }
 
@@ -49,6 +51,13 @@ func (dq *DescendantQuery) Filter(fn func(*types.IndividualRecord) bool) *Descen
 	return dq
 }
 
+// Lineage limits the search to the parent-child links lineage follows, e.g.
+// LineageBiological to leave out adopted and foster children.
+func (dq *DescendantQuery) Lineage(lineage Lineage) *DescendantQuery {
+	dq.options.Lineage = lineage
+	return dq
+}
+
 // Execute runs the query and returns descendant records.
 func (dq *DescendantQuery) Execute() ([]*types.IndividualRecord, error) {
 	// Record metrics if available
@@ -104,6 +113,13 @@ func (dq *DescendantQuery) findDescendants(node *IndividualNode, descendants map
 	visited[node.ID()] = true
 
 	// Find children via FAMS -> Family -> CHIL edges
+	if dq.options.Lineage.filters() {
+		for _, childNode := range node.lineageChildren(dq.options.Lineage) {
+			descendants[childNode.ID()] = childNode
+			dq.findDescendants(childNode, descendants, visited, depth+1)
+		}
+		return
+	}
 	for _, edge := range node.OutEdges() {
 		if edge.EdgeType == EdgeTypeFAMS && edge.Family != nil {
 			famNode := edge.Family
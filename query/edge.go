@@ -17,6 +17,10 @@ const (
 	EdgeTypeREPO     EdgeType = "REPO"      // References a repository
 	EdgeTypeHasEvent EdgeType = "has_event" // Individual/Family has event
 
+	// Individual <-> Individual relationships outside the family
+	EdgeTypeASSO EdgeType = "ASSO" // Individual/Family has an associate (godparent, witness, ...)
+	EdgeTypeALIA EdgeType = "ALIA" // Individual is also described by another record
+
 	// Derived/computed edges (for convenience)
 	EdgeTypeParent  EdgeType = "parent"  // Computed: parent relationship
 	EdgeTypeChild   EdgeType = "child"   // Computed: child relationship
@@ -257,8 +257,8 @@ func matchesDate(dateStr string, year, month, day int) bool {
 		return false
 	}
 	
-	// For exact dates, check components
-	if parsedDate.Type == types.DateTypeExact {
+	// For exact (and interpreted) dates, check components
+	if parsedDate.Type == types.DateTypeExact || parsedDate.Type == types.DateTypeInterpreted {
 		if year > 0 && parsedDate.Year != year {
 			return false
 		}
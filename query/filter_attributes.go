@@ -66,19 +66,28 @@ func (fq *FilterQuery) NoSpouse() *FilterQuery {
 }
 
 // Living filters living individuals (no death date).
-// Uses index for fast lookup.
+// Uses index for fast lookup. With WithEstimates, individuals whose estimate
+// is Deceased are left out.
 func (fq *FilterQuery) Living() *FilterQuery {
 	living := true
 	fq.livingFilter = &living
 	return fq.Where(func(indi *types.IndividualRecord) bool {
+		if estimate := fq.estimates[indi.XrefID()]; estimate != nil && estimate.Deceased {
+			return false
+		}
 		return fq.graph.indexes.isLiving(indi.XrefID())
 	})
 }
 
-// Deceased filters deceased individuals (has death date).
+// Deceased filters deceased individuals (has death date). With
+// WithEstimates, individuals whose estimate is Deceased are included.
 func (fq *FilterQuery) Deceased() *FilterQuery {
 	return fq.Where(func(indi *types.IndividualRecord) bool {
 		deathDate := indi.GetDeathDate()
-		return deathDate != ""
+		if deathDate == "" {
+			estimate := fq.estimates[indi.XrefID()]
+			return estimate != nil && estimate.Deceased
+		}
+		return true
 	})
 }
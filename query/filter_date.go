@@ -7,14 +7,19 @@ import (
 )
 
 // ByBirthDate filters by birth date range.
-// Uses index for fast lookup.
+// Uses index for fast lookup, unless WithEstimates is set.
 func (fq *FilterQuery) ByBirthDate(start, end time.Time) *FilterQuery {
 	fq.birthDateStart = &start
 	fq.birthDateEnd = &end
 	return fq.Where(func(indi *types.IndividualRecord) bool {
 		birthDate, err := indi.GetBirthDateParsed()
 		if err != nil || birthDate == nil || !birthDate.IsValid() {
-			return false
+			estimate := fq.estimates[indi.XrefID()]
+			if estimate == nil {
+				return false
+			}
+			midpoint, ok := estimate.Midpoint()
+			return ok && !midpoint.Before(start) && !midpoint.After(end)
 		}
 
 		birthTime := birthDate.Earliest()
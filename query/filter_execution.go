@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,6 +12,22 @@ import (
 // Uses indexes for fast filtering when possible.
 // If hybrid mode is enabled, uses SQLite for lookups.
 func (fq *FilterQuery) Execute() ([]*types.IndividualRecord, error) {
+	return fq.execute(context.Background())
+}
+
+// ExecuteContext is like Execute but stops with ctx's error once ctx is
+// cancelled or the graph's Config.Timeout.QueryTimeout has elapsed (a zero
+// timeout means no limit). Progress is reported per candidate individual to
+// the ProgressReporter attached with types.WithProgress, under the stage
+// "Filtering".
+func (fq *FilterQuery) ExecuteContext(ctx context.Context) ([]*types.IndividualRecord, error) {
+	ctx, cancel := fq.graph.queryContext(ctx)
+	defer cancel()
+	return fq.execute(ctx)
+}
+
+// execute runs the filter, checking ctx while candidates are filtered.
+func (fq *FilterQuery) execute(ctx context.Context) ([]*types.IndividualRecord, error) {
 	// Record metrics if available
 	start := time.Now()
 	defer func() {
@@ -22,14 +39,14 @@ func (fq *FilterQuery) Execute() ([]*types.IndividualRecord, error) {
 
 	// If hybrid mode, use database queries (SQLite or PostgreSQL)
 	if fq.graph.hybridMode && (fq.graph.queryHelpers != nil || fq.graph.queryHelpersPostgres != nil) {
-		return fq.executeHybrid()
+		return fq.executeHybrid(ctx)
 	}
 
-	return fq.executeEager()
+	return fq.executeEager(ctx)
 }
 
 // executeEager executes the filter query using in-memory indexes
-func (fq *FilterQuery) executeEager() ([]*types.IndividualRecord, error) {
+func (fq *FilterQuery) executeEager(ctx context.Context) ([]*types.IndividualRecord, error) {
 	// Build candidate set using indexes
 	candidateSet := make(map[string]bool)
 	indexes := fq.graph.indexes
@@ -85,7 +102,9 @@ func (fq *FilterQuery) executeEager() ([]*types.IndividualRecord, error) {
 	}
 	// Note: nameEndsFilter is not indexed efficiently, will use Where() filter
 
-	if fq.birthDateStart != nil && fq.birthDateEnd != nil {
+	// Estimated dates are not indexed, so with estimates the birth date and
+	// living filters are left to Where()
+	if fq.birthDateStart != nil && fq.birthDateEnd != nil && fq.estimates == nil {
 		indexed := indexes.findByBirthDate(*fq.birthDateStart, *fq.birthDateEnd)
 		if len(indexed) == 0 {
 			return []*types.IndividualRecord{}, nil // No matches
@@ -160,7 +179,7 @@ func (fq *FilterQuery) executeEager() ([]*types.IndividualRecord, error) {
 		candidateSet = make(map[string]bool)
 	}
 
-	if fq.livingFilter != nil {
+	if fq.livingFilter != nil && fq.estimates == nil {
 		for xrefID := range initialSet {
 			if indexes.isLiving(xrefID) == *fq.livingFilter {
 				candidateSet[xrefID] = true
@@ -181,7 +200,12 @@ func (fq *FilterQuery) executeEager() ([]*types.IndividualRecord, error) {
 
 	// Apply remaining custom filters
 	results := make([]*types.IndividualRecord, 0)
+	tracker := types.NewProgressTracker(ctx, "Filtering", int64(len(initialSet)))
 	for xrefID := range initialSet {
+		if err := tracker.Add(1); err != nil {
+			return nil, err
+		}
+
 		node := fq.graph.GetIndividual(xrefID)
 		if node == nil || node.Individual == nil {
 			continue
@@ -200,6 +224,7 @@ func (fq *FilterQuery) executeEager() ([]*types.IndividualRecord, error) {
 			results = append(results, node.Individual)
 		}
 	}
+	tracker.Finish()
 
 	return results, nil
 }
@@ -221,7 +246,7 @@ type HybridQueryHelper interface {
 }
 
 // executeHybrid executes the filter query using hybrid storage (SQLite + BadgerDB or PostgreSQL + BadgerDB)
-func (fq *FilterQuery) executeHybrid() ([]*types.IndividualRecord, error) {
+func (fq *FilterQuery) executeHybrid(ctx context.Context) ([]*types.IndividualRecord, error) {
 	// Get the appropriate query helpers (SQLite or PostgreSQL)
 	var helpers HybridQueryHelper
 	if fq.graph.queryHelpersPostgres != nil {
@@ -268,8 +293,8 @@ func (fq *FilterQuery) executeHybrid() ([]*types.IndividualRecord, error) {
 		}
 	}
 
-	// Apply date filter
-	if fq.birthDateStart != nil && fq.birthDateEnd != nil {
+	// Apply date filter (with estimates, left to Where())
+	if fq.birthDateStart != nil && fq.birthDateEnd != nil && fq.estimates == nil {
 		dateIDs, err := helpers.FindByBirthDate(*fq.birthDateStart, *fq.birthDateEnd)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query by birth date: %w", err)
@@ -295,6 +320,10 @@ func (fq *FilterQuery) executeHybrid() ([]*types.IndividualRecord, error) {
 		candidateIDs = intersectIDs(candidateIDs, sexIDs)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Apply boolean filters
 	if fq.hasChildrenFilter != nil {
 		candidateIDs = filterByBool(candidateIDs, helpers.HasChildren, *fq.hasChildrenFilter)
@@ -304,13 +333,18 @@ func (fq *FilterQuery) executeHybrid() ([]*types.IndividualRecord, error) {
 		candidateIDs = filterByBool(candidateIDs, helpers.HasSpouse, *fq.hasSpouseFilter)
 	}
 
-	if fq.livingFilter != nil {
+	if fq.livingFilter != nil && fq.estimates == nil {
 		candidateIDs = filterByBool(candidateIDs, helpers.IsLiving, *fq.livingFilter)
 	}
 
 	// Convert node IDs to XREFs and load nodes
 	results := make([]*types.IndividualRecord, 0)
+	tracker := types.NewProgressTracker(ctx, "Filtering", int64(len(candidateIDs)))
 	for _, nodeID := range candidateIDs {
+		if err := tracker.Add(1); err != nil {
+			return nil, err
+		}
+
 		xref, err := helpers.FindXrefByID(nodeID)
 		if err != nil || xref == "" {
 			continue
@@ -334,6 +368,7 @@ func (fq *FilterQuery) executeHybrid() ([]*types.IndividualRecord, error) {
 			results = append(results, node.Individual)
 		}
 	}
+	tracker.Finish()
 
 	// Cache final result if different from initial
 	if fq.graph.hybridCache != nil && len(candidateIDs) != len(results) {
@@ -7,12 +7,18 @@ import (
 )
 
 // ByName filters by name (case-insensitive substring match).
+// The primary name also matches by its search keys, ignoring diacritics and
+// the naming convention's word order, and by its ROMN/FONE variants (see
+// types.GedcomName.SearchKeys), which are worked out once per individual.
 // Uses index for fast lookup.
 func (fq *FilterQuery) ByName(pattern string) *FilterQuery {
 	fq.nameFilter = pattern
 	return fq.Where(func(indi *types.IndividualRecord) bool {
 		name := strings.ToLower(indi.GetName())
-		return strings.Contains(name, strings.ToLower(pattern))
+		if strings.Contains(name, strings.ToLower(pattern)) {
+			return true
+		}
+		return fq.graph.indexes.matchesSearchKeys(indi, pattern)
 	})
 }
 
@@ -26,6 +26,9 @@ type FilterQuery struct {
 	hasChildrenFilter *bool
 	hasSpouseFilter   *bool
 	livingFilter      *bool
+
+	// Estimated birth dates used by date and living filters, if any
+	estimates types.DateEstimates
 }
 
 // NewFilterQuery creates a new FilterQuery.
@@ -36,6 +39,16 @@ func NewFilterQuery(graph *Graph) *FilterQuery {
 	}
 }
 
+// WithEstimates makes ByBirthDate (and the filters built on it), Living and
+// Deceased use estimated birth dates, such as those from DateEstimator, for
+// individuals without a recorded birth date. An estimate matches a date range
+// when the middle of the estimate falls within it; estimates with only one
+// bound never match. Recorded dates always take precedence.
+func (fq *FilterQuery) WithEstimates(estimates types.DateEstimates) *FilterQuery {
+	fq.estimates = estimates
+	return fq
+}
+
 // Where adds a filter condition.
 func (fq *FilterQuery) Where(filter Filter) *FilterQuery {
 	fq.filters = append(fq.filters, filter)
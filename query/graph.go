@@ -45,6 +45,7 @@ type Graph struct {
 
 	// Metadata
 	properties map[string]interface{}
+	config     *Config // Configuration the graph was built with (timeouts, cache sizes)
 
 	// Performance optimizations
 	cache   *queryCache
@@ -129,6 +130,7 @@ func NewGraphWithConfig(tree *types.GedcomTree, config *Config) *Graph {
 		components:     make(map[uint32][]uint32),
 		componentCount: 0,
 		properties:     make(map[string]interface{}),
+		config:         config,
 		cache:          newQueryCache(config.Cache.QueryCacheSize),
 		indexes:        newFilterIndexes(),
 		metrics:        NewMetrics(), // Initialize metrics collection
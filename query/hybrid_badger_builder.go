@@ -14,11 +14,16 @@ type BadgerDBStorage interface {
 
 // buildGraphInBadgerDB stores graph structure in BadgerDB
 // Works with both SQLite and PostgreSQL hybrid storage
-func buildGraphInBadgerDB(storage BadgerDBStorage, tree *types.GedcomTree, graph *Graph) error {
+// Individuals are counted with tracker, which also stops the build once its
+// context is done.
+func buildGraphInBadgerDB(storage BadgerDBStorage, tree *types.GedcomTree, graph *Graph, tracker *types.ProgressTracker) error {
 	db := storage.BadgerDB()
 
 	// Process all node types
-	if err := processNodesForBadgerDB(db, tree, graph); err != nil {
+	if err := processNodesForBadgerDB(db, tree, graph, tracker); err != nil {
+		return err
+	}
+	if err := tracker.Err(); err != nil {
 		return err
 	}
 
@@ -27,6 +32,10 @@ func buildGraphInBadgerDB(storage BadgerDBStorage, tree *types.GedcomTree, graph
 		return fmt.Errorf("failed to build edges: %w", err)
 	}
 
+	if err := tracker.Err(); err != nil {
+		return err
+	}
+
 	// Update database indexes with relationship flags (has_children, has_spouse)
 	// This needs to be done after edges are processed
 	// Check which storage type we're using
@@ -44,13 +53,13 @@ func buildGraphInBadgerDB(storage BadgerDBStorage, tree *types.GedcomTree, graph
 }
 
 // processNodesForBadgerDB processes all node types and stores them in BadgerDB
-func processNodesForBadgerDB(db *badger.DB, tree *types.GedcomTree, graph *Graph) error {
+func processNodesForBadgerDB(db *badger.DB, tree *types.GedcomTree, graph *Graph, tracker *types.ProgressTracker) error {
 	// Use batch write for better performance
 	writeBatch := db.NewWriteBatch()
 	defer writeBatch.Cancel()
 
 	// Process individuals
-	if err := processIndividualsForBadgerDB(writeBatch, tree, graph); err != nil {
+	if err := processIndividualsForBadgerDB(writeBatch, tree, graph, tracker); err != nil {
 		return err
 	}
 
@@ -88,10 +97,14 @@ func processNodesForBadgerDB(db *badger.DB, tree *types.GedcomTree, graph *Graph
 }
 
 // processIndividualsForBadgerDB processes individual records for BadgerDB
-func processIndividualsForBadgerDB(writeBatch *badger.WriteBatch, tree *types.GedcomTree, graph *Graph) error {
+func processIndividualsForBadgerDB(writeBatch *badger.WriteBatch, tree *types.GedcomTree, graph *Graph, tracker *types.ProgressTracker) error {
 	individuals := tree.GetAllIndividuals()
 
 	for xrefID, record := range individuals {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		indiRecord, ok := record.(*types.IndividualRecord)
 		if !ok {
 			continue
@@ -386,7 +399,7 @@ func buildEdgesInBadgerDB(db *badger.DB, tree *types.GedcomTree, graph *Graph) e
 	nodeEdges := make(map[uint32][]EdgeData)
 
 	// Process family relationships
-	if err := processFamilyEdges(families, graph, nodeEdges); err != nil {
+	if err := processFamilyEdges(tree, families, graph, nodeEdges); err != nil {
 		return err
 	}
 
@@ -439,7 +452,7 @@ func buildEdgesInBadgerDB(db *badger.DB, tree *types.GedcomTree, graph *Graph) e
 }
 
 // processFamilyEdges processes family relationship edges
-func processFamilyEdges(families map[string]types.Record, graph *Graph, nodeEdges map[uint32][]EdgeData) error {
+func processFamilyEdges(tree *types.GedcomTree, families map[string]types.Record, graph *Graph, nodeEdges map[uint32][]EdgeData) error {
 	for xrefID, record := range families {
 		famRecord, ok := record.(*types.FamilyRecord)
 		if !ok {
@@ -522,6 +535,8 @@ func processFamilyEdges(families map[string]types.Record, graph *Graph, nodeEdge
 			childID := graph.xrefToID[childXref]
 			graph.mu.RUnlock()
 			if childID != 0 {
+				childRecord, _ := tree.GetIndividual(childXref).(*types.IndividualRecord)
+
 				// CHIL edge: Family -> Individual
 				edgeData := EdgeData{
 					FromID:     famNodeID,
@@ -529,7 +544,7 @@ func processFamilyEdges(families map[string]types.Record, graph *Graph, nodeEdge
 					EdgeType:   EdgeTypeCHIL,
 					FamilyID:   famNodeID,
 					Direction:  DirectionForward,
-					Properties: make(map[string]interface{}),
+					Properties: linkPedigree(famRecord, childRecord),
 				}
 				nodeEdges[famNodeID] = append(nodeEdges[famNodeID], edgeData)
 
@@ -540,7 +555,7 @@ func processFamilyEdges(families map[string]types.Record, graph *Graph, nodeEdge
 					EdgeType:   EdgeTypeFAMC,
 					FamilyID:   famNodeID,
 					Direction:  DirectionBackward,
-					Properties: make(map[string]interface{}),
+					Properties: linkPedigree(famRecord, childRecord),
 				}
 				nodeEdges[childID] = append(nodeEdges[childID], edgeData2)
 			}
@@ -550,7 +565,7 @@ func processFamilyEdges(families map[string]types.Record, graph *Graph, nodeEdge
 	return nil
 }
 
-// processIndividualReferenceEdges processes NOTE and SOUR edges for individuals
+// processIndividualReferenceEdges processes NOTE, SOUR, ASSO and ALIA edges for individuals
 func processIndividualReferenceEdges(tree *types.GedcomTree, graph *Graph, nodeEdges map[uint32][]EdgeData) error {
 	individuals := tree.GetAllIndividuals()
 	for xrefID, record := range individuals {
@@ -601,12 +616,44 @@ func processIndividualReferenceEdges(tree *types.GedcomTree, graph *Graph, nodeE
 				nodeEdges[indiNodeID] = append(nodeEdges[indiNodeID], edgeData)
 			}
 		}
+
+		// ASSO and ALIA references
+		for _, association := range indi.GetAssociations() {
+			graph.mu.RLock()
+			assoNodeID := graph.xrefToID[association.Xref]
+			graph.mu.RUnlock()
+			if assoNodeID != 0 {
+				edgeData := EdgeData{
+					FromID:     indiNodeID,
+					ToID:       assoNodeID,
+					EdgeType:   EdgeTypeASSO,
+					Direction:  DirectionForward,
+					Properties: associationProperties(association),
+				}
+				nodeEdges[indiNodeID] = append(nodeEdges[indiNodeID], edgeData)
+			}
+		}
+		for _, aliasXref := range indi.GetAliases() {
+			graph.mu.RLock()
+			aliasNodeID := graph.xrefToID[aliasXref]
+			graph.mu.RUnlock()
+			if aliasNodeID != 0 {
+				edgeData := EdgeData{
+					FromID:     indiNodeID,
+					ToID:       aliasNodeID,
+					EdgeType:   EdgeTypeALIA,
+					Direction:  DirectionForward,
+					Properties: make(map[string]interface{}),
+				}
+				nodeEdges[indiNodeID] = append(nodeEdges[indiNodeID], edgeData)
+			}
+		}
 	}
 
 	return nil
 }
 
-// processFamilyReferenceEdges processes NOTE and SOUR edges for families
+// processFamilyReferenceEdges processes NOTE, SOUR and ASSO edges for families
 func processFamilyReferenceEdges(families map[string]types.Record, graph *Graph, nodeEdges map[uint32][]EdgeData) error {
 	for xrefID, record := range families {
 		fam, ok := record.(*types.FamilyRecord)
@@ -656,6 +703,23 @@ func processFamilyReferenceEdges(families map[string]types.Record, graph *Graph,
 				nodeEdges[famNodeID] = append(nodeEdges[famNodeID], edgeData)
 			}
 		}
+
+		// ASSO references under family events
+		for _, association := range fam.GetAssociations() {
+			graph.mu.RLock()
+			assoNodeID := graph.xrefToID[association.Xref]
+			graph.mu.RUnlock()
+			if assoNodeID != 0 {
+				edgeData := EdgeData{
+					FromID:     famNodeID,
+					ToID:       assoNodeID,
+					EdgeType:   EdgeTypeASSO,
+					Direction:  DirectionForward,
+					Properties: associationProperties(association),
+				}
+				nodeEdges[famNodeID] = append(nodeEdges[famNodeID], edgeData)
+			}
+		}
 	}
 
 	return nil
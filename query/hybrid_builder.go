@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dgraph-io/badger/v4"
@@ -16,6 +17,13 @@ func BuildGraphHybrid(tree *types.GedcomTree, sqlitePath, badgerPath string, con
 	return BuildGraphHybridWithStorage(tree, sqlitePath, badgerPath, "", "", config, false)
 }
 
+// BuildGraphHybridContext is like BuildGraphHybrid but stops with ctx's error
+// once ctx is cancelled or config.Timeout.BuildTimeout has elapsed. See
+// BuildGraphHybridWithStorageContext.
+func BuildGraphHybridContext(ctx context.Context, tree *types.GedcomTree, sqlitePath, badgerPath string, config *Config) (*Graph, error) {
+	return BuildGraphHybridWithStorageContext(ctx, tree, sqlitePath, badgerPath, "", "", config, false)
+}
+
 // BuildGraphHybridPostgres builds a graph using hybrid storage (PostgreSQL + BadgerDB)
 // This function coordinates the building process by delegating to:
 // - buildGraphInPostgreSQL: Builds indexes in PostgreSQL (see hybrid_postgres_builder.go)
@@ -27,8 +35,38 @@ func BuildGraphHybridPostgres(tree *types.GedcomTree, fileID, badgerPath, databa
 	return BuildGraphHybridWithStorage(tree, "", badgerPath, fileID, databaseURL, config, true)
 }
 
+// BuildGraphHybridPostgresContext is like BuildGraphHybridPostgres but stops
+// with ctx's error once ctx is cancelled or config.Timeout.BuildTimeout has
+// elapsed. See BuildGraphHybridWithStorageContext.
+func BuildGraphHybridPostgresContext(ctx context.Context, tree *types.GedcomTree, fileID, badgerPath, databaseURL string, config *Config) (*Graph, error) {
+	return BuildGraphHybridWithStorageContext(ctx, tree, "", badgerPath, fileID, databaseURL, config, true)
+}
+
 // BuildGraphHybridWithStorage is an internal function that supports both SQLite and PostgreSQL
 func BuildGraphHybridWithStorage(tree *types.GedcomTree, sqlitePath, badgerPath, fileID, databaseURL string, config *Config, usePostgres bool) (*Graph, error) {
+	return buildGraphHybrid(context.Background(), tree, sqlitePath, badgerPath, fileID, databaseURL, config, usePostgres)
+}
+
+// BuildGraphHybridWithStorageContext is like BuildGraphHybridWithStorage but
+// stops with ctx's error once ctx is cancelled or config.Timeout.BuildTimeout
+// has elapsed (a zero timeout means no limit); the storage opened so far is
+// closed. Progress is reported per individual to the ProgressReporter attached
+// with types.WithProgress, in two stages: "Building graph: indexes" (SQLite or
+// PostgreSQL) and "Building graph: storage" (BadgerDB).
+func BuildGraphHybridWithStorageContext(ctx context.Context, tree *types.GedcomTree, sqlitePath, badgerPath, fileID, databaseURL string, config *Config, usePostgres bool) (*Graph, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.Timeout.BuildTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout.BuildTimeout)
+		defer cancel()
+	}
+	return buildGraphHybrid(ctx, tree, sqlitePath, badgerPath, fileID, databaseURL, config, usePostgres)
+}
+
+// buildGraphHybrid builds the hybrid graph, checking ctx between individuals.
+func buildGraphHybrid(ctx context.Context, tree *types.GedcomTree, sqlitePath, badgerPath, fileID, databaseURL string, config *Config, usePostgres bool) (*Graph, error) {
 	// Use default config if none provided
 	if config == nil {
 		config = DefaultConfig()
@@ -100,24 +138,29 @@ func BuildGraphHybridWithStorage(tree *types.GedcomTree, sqlitePath, badgerPath,
 	graph.hybridCache = hybridCache
 
 	// Build graph in both databases
+	individuals := int64(len(tree.GetAllIndividuals()))
+	tracker := types.NewProgressTracker(ctx, "Building graph: indexes", individuals)
 	if usePostgres {
 		postgresStorage := graph.hybridStoragePostgres
-		if err := buildGraphInPostgreSQL(postgresStorage, tree, graph); err != nil {
+		if err := buildGraphInPostgreSQL(postgresStorage, tree, graph, tracker); err != nil {
 			storage.Close()
 			return nil, fmt.Errorf("failed to build PostgreSQL indexes: %w", err)
 		}
 	} else {
 		sqliteStorage := graph.hybridStorage
-		if err := buildGraphInSQLite(sqliteStorage, tree, graph); err != nil {
+		if err := buildGraphInSQLite(sqliteStorage, tree, graph, tracker); err != nil {
 			storage.Close()
 			return nil, fmt.Errorf("failed to build SQLite indexes: %w", err)
 		}
 	}
+	tracker.Finish()
 
-	if err := buildGraphInBadgerDB(storage, tree, graph); err != nil {
+	tracker = types.NewProgressTracker(ctx, "Building graph: storage", individuals)
+	if err := buildGraphInBadgerDB(storage, tree, graph, tracker); err != nil {
 		storage.Close()
 		return nil, fmt.Errorf("failed to build BadgerDB graph: %w", err)
 	}
+	tracker.Finish()
 
 	return graph, nil
 }
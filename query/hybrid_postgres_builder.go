@@ -9,7 +9,9 @@ import (
 )
 
 // buildGraphInPostgreSQL builds indexes in PostgreSQL
-func buildGraphInPostgreSQL(storage *HybridStoragePostgres, tree *types.GedcomTree, graph *Graph) error {
+// Individuals are counted with tracker, which also stops the build once its
+// context is done.
+func buildGraphInPostgreSQL(storage *HybridStoragePostgres, tree *types.GedcomTree, graph *Graph, tracker *types.ProgressTracker) error {
 	db := storage.PostgreSQL()
 	fileID := storage.FileID()
 
@@ -42,7 +44,10 @@ func buildGraphInPostgreSQL(storage *HybridStoragePostgres, tree *types.GedcomTr
 	now := time.Now().Unix()
 
 	// Process all record types
-	if err := processIndividualsForPostgreSQL(tree, graph, stmtNode, stmtXref, fileID, now); err != nil {
+	if err := processIndividualsForPostgreSQL(tree, graph, stmtNode, stmtXref, fileID, now, tracker); err != nil {
+		return err
+	}
+	if err := tracker.Err(); err != nil {
 		return err
 	}
 
@@ -75,10 +80,14 @@ func buildGraphInPostgreSQL(storage *HybridStoragePostgres, tree *types.GedcomTr
 }
 
 // processIndividualsForPostgreSQL processes individual records for PostgreSQL
-func processIndividualsForPostgreSQL(tree *types.GedcomTree, graph *Graph, stmtNode, stmtXref *sql.Stmt, fileID string, now int64) error {
+func processIndividualsForPostgreSQL(tree *types.GedcomTree, graph *Graph, stmtNode, stmtXref *sql.Stmt, fileID string, now int64, tracker *types.ProgressTracker) error {
 	individuals := tree.GetAllIndividuals()
 
 	for xrefID, record := range individuals {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		indiRecord, ok := record.(*types.IndividualRecord)
 		if !ok {
 			continue
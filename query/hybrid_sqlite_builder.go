@@ -9,7 +9,9 @@ import (
 )
 
 // buildGraphInSQLite builds indexes in SQLite
-func buildGraphInSQLite(storage *HybridStorage, tree *types.GedcomTree, graph *Graph) error {
+// Individuals are counted with tracker, which also stops the build once its
+// context is done.
+func buildGraphInSQLite(storage *HybridStorage, tree *types.GedcomTree, graph *Graph, tracker *types.ProgressTracker) error {
 	db := storage.SQLite()
 
 	// Start transaction for batch inserts
@@ -41,7 +43,10 @@ func buildGraphInSQLite(storage *HybridStorage, tree *types.GedcomTree, graph *G
 	now := time.Now().Unix()
 
 	// Process all record types
-	if err := processIndividualsForSQLite(tree, graph, stmtNode, stmtXref, now); err != nil {
+	if err := processIndividualsForSQLite(tree, graph, stmtNode, stmtXref, now, tracker); err != nil {
+		return err
+	}
+	if err := tracker.Err(); err != nil {
 		return err
 	}
 
@@ -74,10 +79,14 @@ func buildGraphInSQLite(storage *HybridStorage, tree *types.GedcomTree, graph *G
 }
 
 // processIndividualsForSQLite processes individual records for SQLite
-func processIndividualsForSQLite(tree *types.GedcomTree, graph *Graph, stmtNode, stmtXref *sql.Stmt, now int64) error {
+func processIndividualsForSQLite(tree *types.GedcomTree, graph *Graph, stmtNode, stmtXref *sql.Stmt, now int64, tracker *types.ProgressTracker) error {
 	individuals := tree.GetAllIndividuals()
 
 	for xrefID, record := range individuals {
+		if err := tracker.Add(1); err != nil {
+			return err
+		}
+
 		indiRecord, ok := record.(*types.IndividualRecord)
 		if !ok {
 			continue
@@ -144,6 +144,7 @@ func (g *Graph) AddEdgeIncremental(edge *Edge) error {
 				
 				edgeID2 := fmt.Sprintf("%s_FAMC_%s", indiNode.ID(), famNode.ID())
 				edge2 := NewEdgeWithFamily(edgeID2, indiNode, famNode, EdgeTypeFAMC, famNode)
+				copyPedigree(edge, edge2)
 				if err := g.addEdgeInternal(edge2); err != nil {
 					// If reverse edge already exists, that's okay
 				} else {
@@ -551,15 +552,9 @@ func (g *Graph) updateIndexesForIndividual(indiNode *IndividualNode) {
 	xrefID := indiNode.ID()
 
 	// Update name index
-	name := strings.ToLower(indi.GetName())
-	if name != "" {
-		g.indexes.nameIndex[name] = append(g.indexes.nameIndex[name], xrefID)
-		words := strings.Fields(name)
-		for _, word := range words {
-			if len(word) > 2 {
-				g.indexes.nameIndex[word] = append(g.indexes.nameIndex[word], xrefID)
-			}
-		}
+	g.indexes.searchKeys[xrefID] = primarySearchKeys(indi)
+	for _, key := range nameIndexKeys(indi, g.indexes.searchKeys[xrefID]) {
+		g.indexes.nameIndex[key] = append(g.indexes.nameIndex[key], xrefID)
 	}
 
 	// Update birth date index
@@ -612,6 +607,7 @@ func (g *Graph) removeFromIndexes(xrefID string) {
 			delete(g.indexes.nameIndex, key)
 		}
 	}
+	delete(g.indexes.searchKeys, xrefID)
 
 	// Remove from birth date index
 	newDateIndex := make([]*dateIndexEntry, 0)
@@ -16,6 +16,9 @@ type FilterIndexes struct {
 	// Name index: lowercase name -> []xrefID
 	nameIndex map[string][]string
 
+	// Search keys of the primary name: xrefID -> keys
+	searchKeys map[string][]string
+
 	// Date index: sorted by birth date
 	birthDateIndex []*dateIndexEntry
 
@@ -45,6 +48,7 @@ type dateIndexEntry struct {
 func newFilterIndexes() *FilterIndexes {
 	return &FilterIndexes{
 		nameIndex:        make(map[string][]string),
+		searchKeys:       make(map[string][]string),
 		birthDateIndex:   make([]*dateIndexEntry, 0),
 		placeIndex:       make(map[string][]string),
 		sexIndex:         make(map[string][]string),
@@ -61,6 +65,7 @@ func (fi *FilterIndexes) buildIndexes(graph *Graph) {
 
 	// Clear existing indexes
 	fi.nameIndex = make(map[string][]string)
+	fi.searchKeys = make(map[string][]string)
 	fi.birthDateIndex = make([]*dateIndexEntry, 0)
 	fi.placeIndex = make(map[string][]string)
 	fi.sexIndex = make(map[string][]string)
@@ -79,16 +84,9 @@ func (fi *FilterIndexes) buildIndexes(graph *Graph) {
 		indi := node.Individual
 
 		// Name index
-		name := strings.ToLower(indi.GetName())
-		if name != "" {
-			fi.nameIndex[name] = append(fi.nameIndex[name], xrefID)
-			// Also index by individual words
-			words := strings.Fields(name)
-			for _, word := range words {
-				if len(word) > 2 { // Only index words longer than 2 chars
-					fi.nameIndex[word] = append(fi.nameIndex[word], xrefID)
-				}
-			}
+		fi.searchKeys[xrefID] = primarySearchKeys(indi)
+		for _, key := range nameIndexKeys(indi, fi.searchKeys[xrefID]) {
+			fi.nameIndex[key] = append(fi.nameIndex[key], xrefID)
 		}
 
 		// Birth date index
@@ -140,17 +138,85 @@ func (fi *FilterIndexes) buildIndexes(graph *Graph) {
 	})
 }
 
-// findByName finds individuals by name pattern (case-insensitive substring).
+// primarySearchKeys returns the search keys of an individual's primary
+// name (see types.GedcomName.SearchKeys), or nil if it has none.
+func primarySearchKeys(indi *types.IndividualRecord) []string {
+	primary, err := indi.GetPrimaryName()
+	if err != nil {
+		return nil
+	}
+	return primary.SearchKeys()
+}
+
+// nameIndexKeys returns the name index keys of an individual: the
+// lowercase name, the search keys of its primary name, and their words
+// longer than 2 characters.
+func nameIndexKeys(indi *types.IndividualRecord, searchKeys []string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	add := func(key string) {
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		keys = append(keys, key)
+		// Also index by individual words
+		for _, word := range strings.Fields(key) {
+			if len(word) > 2 && !seen[word] { // Only index words longer than 2 chars
+				seen[word] = true
+				keys = append(keys, word)
+			}
+		}
+	}
+
+	add(strings.ToLower(indi.GetName()))
+	for _, key := range searchKeys {
+		add(key)
+	}
+	return keys
+}
+
+// matchesSearchKeys reports whether the folded pattern is a substring of
+// one of the search keys of indi's primary name, like
+// types.GedcomName.Matches. The keys are taken from the index, or worked
+// out and kept on first use for individuals not indexed (hybrid storage).
+func (fi *FilterIndexes) matchesSearchKeys(indi *types.IndividualRecord, pattern string) bool {
+	pattern = types.FoldName(pattern)
+	if pattern == "" {
+		return false
+	}
+
+	fi.mu.RLock()
+	keys, ok := fi.searchKeys[indi.XrefID()]
+	fi.mu.RUnlock()
+	if !ok {
+		keys = primarySearchKeys(indi)
+		fi.mu.Lock()
+		fi.searchKeys[indi.XrefID()] = keys
+		fi.mu.Unlock()
+	}
+
+	for _, key := range keys {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// findByName finds individuals by name pattern (case-insensitive substring,
+// or substring of a folded search key).
 func (fi *FilterIndexes) findByName(pattern string) []string {
 	fi.mu.RLock()
 	defer fi.mu.RUnlock()
 
 	patternLower := strings.ToLower(pattern)
+	patternFolded := types.FoldName(pattern)
 	resultSet := make(map[string]bool)
 
 	// Check exact matches and word matches
 	for key, xrefIDs := range fi.nameIndex {
-		if strings.Contains(key, patternLower) {
+		if strings.Contains(key, patternLower) || (patternFolded != "" && strings.Contains(key, patternFolded)) {
 			for _, xrefID := range xrefIDs {
 				resultSet[xrefID] = true
 			}
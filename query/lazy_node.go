@@ -217,6 +217,7 @@ func (g *Graph) loadIndividualEdgesUnlocked(node *IndividualNode) error {
 			if famNode != nil {
 				edgeID := fmt.Sprintf("%s_FAMC_%s_%d", xrefID, famcXref, i)
 				edge := NewEdgeWithFamily(edgeID, node, famNode, EdgeTypeFAMC, famNode)
+				setLinkPedigree(famNode.Family, indi, edge)
 				if err := g.addEdgeInternal(edge); err != nil {
 					// Continue on error (edge might already exist)
 				}
@@ -261,6 +262,13 @@ func (g *Graph) loadIndividualEdgesUnlocked(node *IndividualNode) error {
 				}
 			}
 		}
+
+		// Load ASSO and ALIA edges
+		for _, edge := range g.associationEdges(node, indi.GetAssociations(), indi.GetAliases()) {
+			if err := g.addEdgeInternal(edge); err != nil {
+				// Continue on error
+			}
+		}
 	}
 
 	return nil
@@ -327,6 +335,7 @@ func (g *Graph) loadFamilyEdgesUnlocked(node *FamilyNode) error {
 			// Also create reverse FAMC edge
 			edgeID2 := fmt.Sprintf("%s_FAMC_%s_%d", childXref, xrefID, i)
 			edge2 := NewEdgeWithFamily(edgeID2, childNode, node, EdgeTypeFAMC, node)
+			setLinkPedigree(fam, childNode.Individual, edge, edge2)
 			if err := g.AddEdge(edge2); err != nil {
 				// Continue on error
 			}
@@ -358,6 +367,13 @@ func (g *Graph) loadFamilyEdgesUnlocked(node *FamilyNode) error {
 		}
 	}
 
+	// Load ASSO edges
+	for _, edge := range g.associationEdges(node, fam.GetAssociations(), nil) {
+		if err := g.AddEdge(edge); err != nil {
+			// Continue on error
+		}
+	}
+
 	return nil
 }
 
@@ -1,11 +1,29 @@
 package query
 
 import (
+	"context"
 	"fmt"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
 // ShortestPath finds the shortest path between two nodes using bidirectional BFS.
 func (g *Graph) ShortestPath(fromID, toID string) (*Path, error) {
+	return g.shortestPath(context.Background(), fromID, toID)
+}
+
+// ShortestPathContext is like ShortestPath but stops with ctx's error once ctx
+// is cancelled or the graph's Config.Timeout.QueryTimeout has elapsed.
+// Progress is reported in expanded nodes, out of the node count, under the
+// stage "Finding shortest path".
+func (g *Graph) ShortestPathContext(ctx context.Context, fromID, toID string) (*Path, error) {
+	ctx, cancel := g.queryContext(ctx)
+	defer cancel()
+	return g.shortestPath(ctx, fromID, toID)
+}
+
+// shortestPath runs the bidirectional BFS, checking ctx as nodes are expanded.
+func (g *Graph) shortestPath(ctx context.Context, fromID, toID string) (*Path, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
@@ -63,7 +81,7 @@ func (g *Graph) ShortestPath(fromID, toID string) (*Path, error) {
 			edge *Edge
 		}, 0)
 		for _, edge := range node.OutEdges() {
-			if edge.To != nil {
+			if edge.To != nil && !edge.isAssociation() {
 				neighbors = append(neighbors, struct {
 					node GraphNode
 					edge *Edge
@@ -71,7 +89,7 @@ func (g *Graph) ShortestPath(fromID, toID string) (*Path, error) {
 			}
 		}
 		for _, edge := range node.InEdges() {
-			if edge.IsBidirectional() && edge.From != nil {
+			if edge.IsBidirectional() && edge.From != nil && !edge.isAssociation() {
 				neighbors = append(neighbors, struct {
 					node GraphNode
 					edge *Edge
@@ -82,7 +100,12 @@ func (g *Graph) ShortestPath(fromID, toID string) (*Path, error) {
 	}
 
 	// Bidirectional BFS
+	tracker := types.NewProgressTracker(ctx, "Finding shortest path", int64(len(g.nodes)))
 	for len(queueFrom) > 0 || len(queueTo) > 0 {
+		if err := tracker.Add(1); err != nil {
+			return nil, err
+		}
+
 		// Expand from forward direction
 		if len(queueFrom) > 0 {
 			current := queueFrom[0]
@@ -230,6 +253,22 @@ func reconstructBidirectionalPath(
 // AllPaths finds all paths between two nodes using DFS.
 // maxLength limits the maximum path length to avoid infinite loops.
 func (g *Graph) AllPaths(fromID, toID string, maxLength int) ([]*Path, error) {
+	return g.allPaths(context.Background(), fromID, toID, maxLength)
+}
+
+// AllPathsContext is like AllPaths but stops with ctx's error once ctx is
+// cancelled or the graph's Config.Timeout.QueryTimeout has elapsed. The
+// number of paths grows quickly with maxLength, so this is the variant to use
+// on large graphs. Progress is reported in visited nodes under the stage
+// "Finding paths"; the total is unknown (0).
+func (g *Graph) AllPathsContext(ctx context.Context, fromID, toID string, maxLength int) ([]*Path, error) {
+	ctx, cancel := g.queryContext(ctx)
+	defer cancel()
+	return g.allPaths(ctx, fromID, toID, maxLength)
+}
+
+// allPaths runs the DFS, checking ctx as nodes are visited.
+func (g *Graph) allPaths(ctx context.Context, fromID, toID string, maxLength int) ([]*Path, error) {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 
@@ -254,15 +293,23 @@ func (g *Graph) AllPaths(fromID, toID string, maxLength int) ([]*Path, error) {
 	currentEdges := make([]*Edge, 0)
 	visited := make(map[string]bool)
 
-	g.allPathsDFS(fromNode, toNode, currentPath, currentEdges, visited, &paths, maxLength)
+	tracker := types.NewProgressTracker(ctx, "Finding paths", 0)
+	if err := g.allPathsDFS(fromNode, toNode, currentPath, currentEdges, visited, &paths, maxLength, tracker); err != nil {
+		return nil, err
+	}
+	tracker.Finish()
 
 	return paths, nil
 }
 
-// allPathsDFS is the recursive helper for AllPaths.
-func (g *Graph) allPathsDFS(current, target GraphNode, currentPath []GraphNode, currentEdges []*Edge, visited map[string]bool, paths *[]*Path, maxLength int) {
+// allPathsDFS is the recursive helper for AllPaths. It stops with the
+// tracker's context error once that context is done.
+func (g *Graph) allPathsDFS(current, target GraphNode, currentPath []GraphNode, currentEdges []*Edge, visited map[string]bool, paths *[]*Path, maxLength int, tracker *types.ProgressTracker) error {
 	if len(currentPath) > maxLength {
-		return
+		return nil
+	}
+	if err := tracker.Add(1); err != nil {
+		return err
 	}
 
 	currentPath = append(currentPath, current)
@@ -283,18 +330,25 @@ func (g *Graph) allPathsDFS(current, target GraphNode, currentPath []GraphNode,
 	} else {
 		// Continue searching
 		for _, edge := range current.OutEdges() {
+			if edge.isAssociation() {
+				continue
+			}
 			neighbor := edge.To
 			if neighbor != nil && !visited[neighbor.ID()] {
-				g.allPathsDFS(neighbor, target, currentPath, append(currentEdges, edge), visited, paths, maxLength)
+				if err := g.allPathsDFS(neighbor, target, currentPath, append(currentEdges, edge), visited, paths, maxLength, tracker); err != nil {
+					return err
+				}
 			}
 		}
 
 		// Check bidirectional edges
 		for _, edge := range current.InEdges() {
-			if edge.IsBidirectional() {
+			if edge.IsBidirectional() && !edge.isAssociation() {
 				neighbor := edge.From
 				if neighbor != nil && !visited[neighbor.ID()] {
-					g.allPathsDFS(neighbor, target, currentPath, append(currentEdges, edge), visited, paths, maxLength)
+					if err := g.allPathsDFS(neighbor, target, currentPath, append(currentEdges, edge), visited, paths, maxLength, tracker); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -302,6 +356,15 @@ func (g *Graph) allPathsDFS(current, target GraphNode, currentPath []GraphNode,
 
 	// Backtrack
 	visited[current.ID()] = false
+	return nil
+}
+
+// queryContext applies the graph's Config.Timeout.QueryTimeout to ctx.
+func (g *Graph) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.config != nil && g.config.Timeout.QueryTimeout > 0 {
+		return context.WithTimeout(ctx, g.config.Timeout.QueryTimeout)
+	}
+	return context.WithCancel(ctx)
 }
 
 // determinePathType determines the type of path based on edge types.
@@ -1,5 +1,7 @@
 package query
 
+import "context"
+
 // PathOptions holds configuration for path queries.
 type PathOptions struct {
 	MaxLength      int  // Maximum path length (0 = unlimited, but defaults to 10)
@@ -55,30 +57,51 @@ func (pq *PathQuery) Shortest() (*Path, error) {
 	return pq.graph.ShortestPath(pq.fromXrefID, pq.toXrefID)
 }
 
+// ShortestContext is like Shortest but stops once ctx is cancelled or the
+// query timeout elapses. See Graph.ShortestPathContext.
+func (pq *PathQuery) ShortestContext(ctx context.Context) (*Path, error) {
+	return pq.graph.ShortestPathContext(ctx, pq.fromXrefID, pq.toXrefID)
+}
+
 // All returns all paths between the two individuals.
 func (pq *PathQuery) All() ([]*Path, error) {
-	maxLength := pq.options.MaxLength
-	if maxLength <= 0 {
-		maxLength = 10
+	paths, err := pq.graph.AllPaths(pq.fromXrefID, pq.toXrefID, pq.maxLength())
+	if err != nil {
+		return nil, err
 	}
+	return pq.filterPaths(paths), nil
+}
 
-	paths, err := pq.graph.AllPaths(pq.fromXrefID, pq.toXrefID, maxLength)
+// AllContext is like All but stops once ctx is cancelled or the query timeout
+// elapses. See Graph.AllPathsContext.
+func (pq *PathQuery) AllContext(ctx context.Context) ([]*Path, error) {
+	paths, err := pq.graph.AllPathsContext(ctx, pq.fromXrefID, pq.toXrefID, pq.maxLength())
 	if err != nil {
 		return nil, err
 	}
+	return pq.filterPaths(paths), nil
+}
 
-	// Filter by path type if needed
-	if !pq.options.IncludeMarital || !pq.options.IncludeBlood {
-		filtered := make([]*Path, 0)
-		for _, path := range paths {
-			if pq.shouldIncludePath(path) {
-				filtered = append(filtered, path)
-			}
-		}
-		return filtered, nil
+// maxLength returns the configured maximum path length, 10 if unset.
+func (pq *PathQuery) maxLength() int {
+	if pq.options.MaxLength <= 0 {
+		return 10
 	}
+	return pq.options.MaxLength
+}
 
-	return paths, nil
+// filterPaths drops paths whose type is excluded by the options.
+func (pq *PathQuery) filterPaths(paths []*Path) []*Path {
+	if pq.options.IncludeMarital && pq.options.IncludeBlood {
+		return paths
+	}
+	filtered := make([]*Path, 0)
+	for _, path := range paths {
+		if pq.shouldIncludePath(path) {
+			filtered = append(filtered, path)
+		}
+	}
+	return filtered
 }
 
 // shouldIncludePath checks if a path should be included based on options.
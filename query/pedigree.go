@@ -0,0 +1,264 @@
+package query
+
+import (
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// Edge properties set on FAMC and CHIL edges. Values are types.PedigreeType
+// values stored as strings.
+const (
+	PropertyPedigree       = "pedigree"        // From INDI.FAMC.PEDI
+	PropertyFatherPedigree = "father_pedigree" // From _FREL, relationship to the husband
+	PropertyMotherPedigree = "mother_pedigree" // From _MREL, relationship to the wife
+)
+
+// Lineage selects the parent-child links that ancestor, descendant and
+// relationship queries follow.
+type Lineage string
+
+const (
+	LineageAll        Lineage = "all"        // Every link (default)
+	LineageBiological Lineage = "biological" // Birth links and links without a pedigree
+	LineageLegal      Lineage = "legal"      // Birth, adoption and links without a pedigree
+)
+
+// Allows reports whether the lineage follows a link with the given pedigree.
+// Links without a pedigree are taken to be birth links, as in GEDCOM.
+func (l Lineage) Allows(pedigree types.PedigreeType) bool {
+	switch l {
+	case LineageBiological:
+		return pedigree == types.PedigreeBirth || pedigree == types.PedigreeUnknown
+	case LineageLegal:
+		return pedigree == types.PedigreeBirth || pedigree == types.PedigreeUnknown || pedigree == types.PedigreeAdopted
+	default:
+		return true
+	}
+}
+
+// filters reports whether the lineage leaves out any links.
+func (l Lineage) filters() bool {
+	return l == LineageBiological || l == LineageLegal
+}
+
+// Pedigree returns the pedigree of a FAMC or CHIL edge, or PedigreeUnknown
+// if the link has none.
+func (e *Edge) Pedigree() types.PedigreeType {
+	return e.pedigreeProperty(PropertyPedigree)
+}
+
+// ParentPedigree returns the pedigree of a FAMC or CHIL edge towards the
+// husband (EdgeTypeHUSB) or the wife (EdgeTypeWIFE) of the family. _FREL and
+// _MREL take precedence over PEDI.
+func (e *Edge) ParentPedigree(role EdgeType) types.PedigreeType {
+	key := PropertyFatherPedigree
+	if role == EdgeTypeWIFE {
+		key = PropertyMotherPedigree
+	}
+	if pedigree := e.pedigreeProperty(key); pedigree != types.PedigreeUnknown {
+		return pedigree
+	}
+	return e.Pedigree()
+}
+
+// pedigreeProperty returns the pedigree stored under key.
+func (e *Edge) pedigreeProperty(key string) types.PedigreeType {
+	if value, ok := e.Properties[key].(string); ok {
+		return types.PedigreeType(value)
+	}
+	return types.PedigreeUnknown
+}
+
+// linkPedigree returns the pedigree properties of the link between child and
+// fam. They are read from the child's FAMC line (PEDI, _FREL, _MREL) and
+// then from the family's CHIL line (_FREL, _MREL).
+func linkPedigree(fam *types.FamilyRecord, child *types.IndividualRecord) map[string]interface{} {
+	properties := make(map[string]interface{})
+	if fam == nil || child == nil {
+		return properties
+	}
+
+	set := func(key, value string) {
+		if _, ok := properties[key]; ok {
+			return
+		}
+		if pedigree := types.ParsePedigree(value); pedigree != types.PedigreeUnknown {
+			properties[key] = string(pedigree)
+		}
+	}
+	setRelations := func(link *types.GedcomLine) {
+		set(PropertyFatherPedigree, link.GetValue("_FREL"))
+		set(PropertyMotherPedigree, link.GetValue("_MREL"))
+	}
+
+	for _, famc := range child.GetLines("FAMC") {
+		if famc.Value == fam.XrefID() {
+			set(PropertyPedigree, famc.GetValue("PEDI"))
+			setRelations(famc)
+			break
+		}
+	}
+	for _, chil := range fam.GetLines("CHIL") {
+		if chil.Value == child.XrefID() {
+			setRelations(chil)
+			break
+		}
+	}
+	return properties
+}
+
+// setLinkPedigree stores the pedigree of the link between child and fam on
+// the given FAMC and CHIL edges.
+func setLinkPedigree(fam *types.FamilyRecord, child *types.IndividualRecord, edges ...*Edge) {
+	for key, value := range linkPedigree(fam, child) {
+		for _, edge := range edges {
+			edge.Properties[key] = value
+		}
+	}
+}
+
+// copyPedigree copies the pedigree properties of a CHIL or FAMC edge to its
+// reverse edge.
+func copyPedigree(from, to *Edge) {
+	for _, key := range []string{PropertyPedigree, PropertyFatherPedigree, PropertyMotherPedigree} {
+		if value, ok := from.Properties[key]; ok {
+			to.Properties[key] = value
+		}
+	}
+}
+
+// lineageParents returns the parents of node whose link to it is followed by
+// lineage.
+func (node *IndividualNode) lineageParents(lineage Lineage) []*IndividualNode {
+	parents := make([]*IndividualNode, 0, 2)
+	seen := make(map[string]bool)
+
+	for _, edge := range node.famcEdges {
+		if edge.Family == nil {
+			continue
+		}
+		for _, parentEdge := range []*Edge{edge.Family.husbandEdge, edge.Family.wifeEdge} {
+			if parentEdge == nil {
+				continue
+			}
+			parent, ok := parentEdge.To.(*IndividualNode)
+			if !ok || seen[parent.ID()] || !lineage.Allows(edge.ParentPedigree(parentEdge.EdgeType)) {
+				continue
+			}
+			seen[parent.ID()] = true
+			parents = append(parents, parent)
+		}
+	}
+
+	return parents
+}
+
+// lineageChildren returns the children of node whose link to it is followed
+// by lineage.
+func (node *IndividualNode) lineageChildren(lineage Lineage) []*IndividualNode {
+	children := make([]*IndividualNode, 0)
+	seen := make(map[string]bool)
+
+	for _, edge := range node.famsEdges {
+		famNode := edge.Family
+		if famNode == nil {
+			continue
+		}
+		role := EdgeTypeHUSB
+		if famNode.wifeEdge != nil && famNode.wifeEdge.To != nil && famNode.wifeEdge.To.ID() == node.ID() {
+			role = EdgeTypeWIFE
+		}
+		for _, chilEdge := range famNode.chilEdges {
+			child, ok := chilEdge.To.(*IndividualNode)
+			if !ok || seen[child.ID()] || !lineage.Allows(chilEdge.ParentPedigree(role)) {
+				continue
+			}
+			seen[child.ID()] = true
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// ancestorDepths returns the ancestors of node that lineage reaches, with the
+// number of generations to the nearest path to each.
+func ancestorDepths(node *IndividualNode, lineage Lineage) map[string]int {
+	depths := make(map[string]int)
+	current := []*IndividualNode{node}
+	for depth := 1; len(current) > 0; depth++ {
+		var next []*IndividualNode
+		for _, n := range current {
+			for _, parent := range n.lineageParents(lineage) {
+				if _, ok := depths[parent.ID()]; ok || parent.ID() == node.ID() {
+					continue
+				}
+				depths[parent.ID()] = depth
+				next = append(next, parent)
+			}
+		}
+		current = next
+	}
+	return depths
+}
+
+// classifyByLineage fills in the relationship of result from the ancestors
+// of from and to that lineage reaches, see CalculateRelationshipWithLineage.
+func (g *Graph) classifyByLineage(from, to *IndividualNode, lineage Lineage, result *RelationshipResult) {
+	fromAncestors := ancestorDepths(from, lineage)
+	toAncestors := ancestorDepths(to, lineage)
+
+	toDepth, isAncestral := fromAncestors[to.ID()]
+	fromDepth, isDescendant := toAncestors[from.ID()]
+	result.IsAncestral = isAncestral
+	result.IsDescendant = isDescendant
+
+	// Lowest common ancestor: the one that minimizes the maximum distance
+	// from both, then the total distance
+	lcaFrom, lcaTo := 0, 0
+	for id, depth1 := range fromAncestors {
+		depth2, ok := toAncestors[id]
+		if !ok {
+			continue
+		}
+		if lcaFrom == 0 || max(depth1, depth2) < max(lcaFrom, lcaTo) ||
+			(max(depth1, depth2) == max(lcaFrom, lcaTo) && depth1+depth2 < lcaFrom+lcaTo) {
+			lcaFrom, lcaTo = depth1, depth2
+		}
+	}
+
+	// Direct relationships, in the order CalculateRelationship checks them
+	switch {
+	case isDescendant && fromDepth == 1:
+		result.RelationshipType = "parent"
+	case !isAncestral && !isDescendant && lcaFrom == 1 && lcaTo == 1:
+		result.RelationshipType = "sibling"
+	case isSpouse(from, to):
+		result.RelationshipType = "spouse"
+	}
+	result.IsDirect = result.RelationshipType != ""
+	result.IsCollateral = !result.IsDirect && !isAncestral && !isDescendant
+
+	switch {
+	case result.IsDirect:
+	case isAncestral:
+		result.RelationshipType = g.getAncestralRelationshipType(true)
+		result.Degree = toDepth
+	case isDescendant:
+		result.RelationshipType = g.getAncestralRelationshipType(false)
+		result.Degree = fromDepth
+	case lcaFrom > 0:
+		result.Degree = min(lcaFrom, lcaTo) - 1
+		result.Removal = abs(lcaFrom - lcaTo)
+		result.RelationshipType = g.getCollateralRelationshipType(result.Degree, result.Removal)
+	}
+}
+
+// isSpouse reports whether a and b are spouses in a family.
+func isSpouse(a, b *IndividualNode) bool {
+	for _, spouse := range a.getSpousesFromEdges() {
+		if spouse.ID() == b.ID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/parser"
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
+)
+
+// pedigreeTestGraph builds a family with birth, adopted, foster and step
+// children:
+//
+//	@I1@ + @I2@ (@F1@): @I3@ birth, @I4@ adopted, @I7@ foster,
+//	                    @I8@ natural child of @I1@ and stepchild of @I2@
+//	@I3@ (@F2@): @I5@
+//	@I4@ (@F3@): @I6@
+func pedigreeTestGraph(t *testing.T) *QueryBuilder {
+	t.Helper()
+	input := `0 HEAD
+0 @I1@ INDI
+1 FAMS @F1@
+0 @I2@ INDI
+1 FAMS @F1@
+0 @I3@ INDI
+1 FAMC @F1@
+2 PEDI birth
+1 FAMS @F2@
+0 @I4@ INDI
+1 FAMC @F1@
+2 PEDI adopted
+1 FAMS @F3@
+0 @I5@ INDI
+1 FAMC @F2@
+0 @I6@ INDI
+1 FAMC @F3@
+0 @I7@ INDI
+1 FAMC @F1@
+2 PEDI foster
+0 @I8@ INDI
+1 FAMC @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+1 CHIL @I4@
+1 CHIL @I7@
+1 CHIL @I8@
+2 _FREL Natural
+2 _MREL Step
+0 @F2@ FAM
+1 HUSB @I3@
+1 CHIL @I5@
+0 @F3@ FAM
+1 HUSB @I4@
+1 CHIL @I6@
+0 TRLR
+`
+	tree, err := parser.NewHierarchicalParser().ParseReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseReader() error = %v", err)
+	}
+	qb, err := NewQuery(tree)
+	if err != nil {
+		t.Fatalf("NewQuery() error = %v", err)
+	}
+	return qb
+}
+
+// xrefs returns the sorted xrefs of records.
+func xrefs(records []*types.IndividualRecord) string {
+	list := make([]string, 0, len(records))
+	for _, record := range records {
+		list = append(list, record.XrefID())
+	}
+	sort.Strings(list)
+	return strings.Join(list, " ")
+}
+
+func TestEdge_Pedigree(t *testing.T) {
+	qb := pedigreeTestGraph(t)
+
+	famc := func(xref string) *Edge {
+		return qb.Graph().GetIndividual(xref).famcEdges[0]
+	}
+	if got := famc("@I4@").Pedigree(); got != types.PedigreeAdopted {
+		t.Errorf("@I4@ Pedigree() = %q", got)
+	}
+	if got := famc("@I5@").Pedigree(); got != types.PedigreeUnknown {
+		t.Errorf("@I5@ Pedigree() = %q", got)
+	}
+
+	step := famc("@I8@")
+	if got := step.ParentPedigree(EdgeTypeHUSB); got != types.PedigreeBirth {
+		t.Errorf("@I8@ ParentPedigree(HUSB) = %q", got)
+	}
+	if got := step.ParentPedigree(EdgeTypeWIFE); got != types.PedigreeOther {
+		t.Errorf("@I8@ ParentPedigree(WIFE) = %q", got)
+	}
+
+	// The CHIL edge carries the same properties
+	for _, chil := range qb.Graph().GetFamily("@F1@").chilEdges {
+		if chil.To.ID() == "@I4@" && chil.Pedigree() != types.PedigreeAdopted {
+			t.Errorf("CHIL edge Pedigree() = %q", chil.Pedigree())
+		}
+	}
+}
+
+func TestLineage_AncestorsAndDescendants(t *testing.T) {
+	qb := pedigreeTestGraph(t)
+
+	ancestors := func(xref string, lineage Lineage) string {
+		records, err := qb.Individual(xref).Ancestors().Lineage(lineage).Execute()
+		if err != nil {
+			t.Fatalf("Ancestors() error = %v", err)
+		}
+		return xrefs(records)
+	}
+	descendants := func(xref string, lineage Lineage) string {
+		records, err := qb.Individual(xref).Descendants().Lineage(lineage).Execute()
+		if err != nil {
+			t.Fatalf("Descendants() error = %v", err)
+		}
+		return xrefs(records)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ancestors of @I6@", ancestors("@I6@", LineageAll), "@I1@ @I2@ @I4@"},
+		{"biological ancestors of @I6@", ancestors("@I6@", LineageBiological), "@I4@"},
+		{"legal ancestors of @I6@", ancestors("@I6@", LineageLegal), "@I1@ @I2@ @I4@"},
+		{"biological ancestors of @I8@", ancestors("@I8@", LineageBiological), "@I1@"},
+		{"descendants of @I1@", descendants("@I1@", LineageAll), "@I3@ @I4@ @I5@ @I6@ @I7@ @I8@"},
+		{"biological descendants of @I1@", descendants("@I1@", LineageBiological), "@I3@ @I5@ @I8@"},
+		{"legal descendants of @I1@", descendants("@I1@", LineageLegal), "@I3@ @I4@ @I5@ @I6@ @I8@"},
+		{"biological descendants of @I2@", descendants("@I2@", LineageBiological), "@I3@ @I5@"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// IndividualQuery.Lineage applies to the queries it starts
+	records, _ := qb.Individual("@I6@").Lineage(LineageBiological).Ancestors().Execute()
+	if got := xrefs(records); got != "@I4@" {
+		t.Errorf("Individual().Lineage().Ancestors() = %q", got)
+	}
+}
+
+func TestLineage_Relationships(t *testing.T) {
+	qb := pedigreeTestGraph(t)
+	graph := qb.Graph()
+
+	result, err := graph.CalculateRelationshipWithLineage("@I5@", "@I6@", LineageAll)
+	if err != nil || result.RelationshipType != "cousin" {
+		t.Errorf("all: %+v, %v", result, err)
+	}
+	result, err = graph.CalculateRelationshipWithLineage("@I5@", "@I6@", LineageLegal)
+	if err != nil || result.RelationshipType != "cousin" || result.Degree != 1 {
+		t.Errorf("legal: %+v, %v", result, err)
+	}
+	result, err = graph.CalculateRelationshipWithLineage("@I5@", "@I6@", LineageBiological)
+	if err != nil || result.RelationshipType != "" || !result.IsCollateral {
+		t.Errorf("biological: %+v, %v", result, err)
+	}
+
+	result, _ = graph.CalculateRelationshipWithLineage("@I3@", "@I4@", LineageBiological)
+	if result.RelationshipType == "sibling" {
+		t.Error("adopted child is a biological sibling")
+	}
+	result, _ = graph.CalculateRelationshipWithLineage("@I3@", "@I8@", LineageBiological)
+	if result.RelationshipType != "sibling" || !result.IsDirect {
+		t.Errorf("half sibling: %+v", result)
+	}
+	result, _ = graph.CalculateRelationshipWithLineage("@I1@", "@I3@", LineageBiological)
+	if result.RelationshipType != "parent" || !result.IsDirect {
+		t.Errorf("parent: %+v", result)
+	}
+	result, _ = graph.CalculateRelationshipWithLineage("@I5@", "@I1@", LineageBiological)
+	if result.RelationshipType != "ancestor" || result.Degree != 2 {
+		t.Errorf("grandparent: %+v", result)
+	}
+
+	cousins, err := qb.Individual("@I5@").Cousins(1)
+	if err != nil || xrefs(cousins) != "@I6@" {
+		t.Errorf("Cousins(1) = %q, %v", xrefs(cousins), err)
+	}
+	cousins, _ = qb.Individual("@I5@").Lineage(LineageBiological).Cousins(1)
+	if len(cousins) != 0 {
+		t.Errorf("biological Cousins(1) = %q", xrefs(cousins))
+	}
+	relationship, _ := qb.Individual("@I5@").RelationshipTo("@I6@").Lineage(LineageLegal).Execute()
+	if relationship == nil || relationship.RelationshipType != "cousin" {
+		t.Errorf("RelationshipTo().Lineage() = %+v", relationship)
+	}
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"fmt"
 
+	"github.com/lesfleursdelanuitdev/ligneous-gedcom/gazetteer"
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
 )
 
@@ -15,6 +16,7 @@ const (
 	PlaceUniqueByState      PlaceUniqueBy = "state"       // By state component
 	PlaceUniqueByCountry    PlaceUniqueBy = "country"     // By country component
 	PlaceUniqueByCityState  PlaceUniqueBy = "city_state" // By city + state
+	PlaceUniqueByCanonical  PlaceUniqueBy = "canonical"  // By canonical gazetteer place (see WithGazetteer)
 )
 
 // PlaceCollectionQuery provides collection operations on places.
@@ -25,6 +27,7 @@ type PlaceCollectionQuery struct {
 	fromDeath   bool
 	fromMarriage bool
 	fromEvents  bool
+	gazetteer   *gazetteer.Gazetteer
 }
 
 // NewPlaceCollectionQuery creates a new PlaceCollectionQuery.
@@ -122,6 +125,15 @@ func (pcq *PlaceCollectionQuery) By(criteria PlaceUniqueBy) *PlaceCollectionQuer
 	return pcq
 }
 
+// WithGazetteer sets the gazetteer used by PlaceUniqueByCanonical. Places
+// that resolve to the same canonical place (by gazetteer ID) count once,
+// under its name in the tree's place form; places that do not resolve are
+// compared by full string.
+func (pcq *PlaceCollectionQuery) WithGazetteer(g *gazetteer.Gazetteer) *PlaceCollectionQuery {
+	pcq.gazetteer = g
+	return pcq
+}
+
 // FromBirth only includes birth places.
 func (pcq *PlaceCollectionQuery) FromBirth() *PlaceCollectionQuery {
 	pcq.fromBirth = true
@@ -166,19 +178,26 @@ func (pcq *PlaceCollectionQuery) Execute() ([]string, error) {
 		return nil, err
 	}
 
+	// Components are mapped with the header's place form, if any
+	var form []string
+	if pcq.graph.tree != nil {
+		form = pcq.graph.tree.GetPlaceForm()
+	}
+
 	// Apply uniqueness logic
 	seen := make(map[string]bool)
 	result := make([]string, 0)
 
 	for _, placeStr := range allPlaces {
 		var key string
+		value := "" // Value listed for key, if not key itself
 
 		switch pcq.uniqueBy {
 		case PlaceUniqueByFullString:
 			key = placeStr
 		case PlaceUniqueByCity, PlaceUniqueByState, PlaceUniqueByCountry, PlaceUniqueByCityState:
 			// Parse place to extract components
-			place, err := types.ParsePlace(placeStr)
+			place, err := types.ParsePlaceWithForm(placeStr, form)
 			if err != nil || place == nil {
 				// If parsing fails, use full string
 				key = placeStr
@@ -194,13 +213,27 @@ func (pcq *PlaceCollectionQuery) Execute() ([]string, error) {
 					key = fmt.Sprintf("%s|%s", place.City, place.State)
 				}
 			}
+		case PlaceUniqueByCanonical:
+			key = placeStr
+			if pcq.gazetteer != nil {
+				place, err := types.ParsePlaceWithForm(placeStr, form)
+				if err == nil {
+					if resolution := pcq.gazetteer.Resolve(place); resolution.Resolved() {
+						key = "gazetteer:" + resolution.Place.ID
+						value = resolution.Place.FormatForm(form)
+					}
+				}
+			}
 		default:
 			key = placeStr
 		}
 
 		if key != "" && !seen[key] {
 			seen[key] = true
-			result = append(result, key)
+			if value == "" {
+				value = key
+			}
+			result = append(result, value)
 		}
 	}
 
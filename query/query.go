@@ -189,25 +189,42 @@ func (qb *QueryBuilder) UniqueNames() (map[string][]string, error) {
 
 // IndividualQuery represents a query starting from a specific individual.
 type IndividualQuery struct {
-	xrefID string
-	graph  *Graph
+	xrefID  string
+	graph   *Graph
+	lineage Lineage
+}
+
+// Lineage sets the parent-child links that Ancestors, Descendants,
+// RelationshipTo, RelationshipToResult and Cousins follow. The default is
+// LineageAll.
+func (iq *IndividualQuery) Lineage(lineage Lineage) *IndividualQuery {
+	iq.lineage = lineage
+	return iq
 }
 
 // Ancestors finds all ancestors of this individual.
 func (iq *IndividualQuery) Ancestors() *AncestorQuery {
+	options := NewAncestorOptions()
+	if iq.lineage != "" {
+		options.Lineage = iq.lineage
+	}
 	return &AncestorQuery{
 		startXrefID: iq.xrefID,
 		graph:       iq.graph,
-		options:     NewAncestorOptions(),
+		options:     options,
 	}
 }
 
 // Descendants finds all descendants of this individual.
 func (iq *IndividualQuery) Descendants() *DescendantQuery {
+	options := NewDescendantOptions()
+	if iq.lineage != "" {
+		options.Lineage = iq.lineage
+	}
 	return &DescendantQuery{
 		startXrefID: iq.xrefID,
 		graph:       iq.graph,
-		options:     NewDescendantOptions(),
+		options:     options,
 	}
 }
 
@@ -348,12 +365,13 @@ func (iq *IndividualQuery) RelationshipTo(otherXrefID string) *RelationshipQuery
 		fromXrefID: iq.xrefID,
 		toXrefID:   otherXrefID,
 		graph:      iq.graph,
+		lineage:    iq.lineage,
 	}
 }
 
 // RelationshipTo returns the relationship result directly (convenience method).
 func (iq *IndividualQuery) RelationshipToResult(otherXrefID string) (*RelationshipResult, error) {
-	return iq.graph.CalculateRelationship(iq.xrefID, otherXrefID)
+	return iq.graph.CalculateRelationshipWithLineage(iq.xrefID, otherXrefID, iq.lineage)
 }
 
 // PathTo finds path(s) to another individual.
@@ -383,7 +401,8 @@ func (iq *IndividualQuery) CommonAncestors(otherXrefID string) ([]*types.Individ
 	return records, nil
 }
 
-// Cousins finds cousins (configurable degree).
+// Cousins finds cousins (configurable degree), following the links set with
+// Lineage.
 func (iq *IndividualQuery) Cousins(degree int) ([]*types.IndividualRecord, error) {
 	// Get all individuals
 	allIndividuals := iq.graph.GetAllIndividuals()
@@ -394,7 +413,7 @@ func (iq *IndividualQuery) Cousins(degree int) ([]*types.IndividualRecord, error
 			continue
 		}
 
-		result, err := iq.graph.CalculateRelationship(iq.xrefID, otherNode.ID())
+		result, err := iq.graph.CalculateRelationshipWithLineage(iq.xrefID, otherNode.ID(), iq.lineage)
 		if err != nil {
 			continue
 		}
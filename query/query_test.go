@@ -359,6 +359,53 @@ func TestFilterQuery_ByName(t *testing.T) {
 	}
 }
 
+func TestFilterQuery_ByName_Conventions(t *testing.T) {
+	tree := types.NewGedcomTree()
+
+	// The header's LANG selects the surname-first convention
+	headerLine := types.NewGedcomLine(0, "HEAD", "", "")
+	headerLine.AddChild(types.NewGedcomLine(1, "LANG", "Japanese", ""))
+	tree.AddRecord(types.NewHeaderRecord(headerLine))
+
+	indi1Line := types.NewGedcomLine(0, "INDI", "", "@I1@")
+	indi1Line.AddChild(types.NewGedcomLine(1, "NAME", "Gabriel /García Márquez/", ""))
+	indi1 := types.NewIndividualRecord(indi1Line)
+	tree.AddRecord(indi1)
+
+	indi2Line := types.NewGedcomLine(0, "INDI", "", "@I2@")
+	nameLine := types.NewGedcomLine(1, "NAME", "/山田/ 太郎", "")
+	romnLine := types.NewGedcomLine(2, "ROMN", "/Yamada/ Taro", "")
+	romnLine.AddChild(types.NewGedcomLine(3, "TYPE", "romaji", ""))
+	nameLine.AddChild(romnLine)
+	indi2Line.AddChild(nameLine)
+	indi2 := types.NewIndividualRecord(indi2Line)
+	tree.AddRecord(indi2)
+
+	query, err := NewQuery(tree)
+	if err != nil {
+		t.Fatalf("Failed to create query: %v", err)
+	}
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"Garcia", "@I1@"},
+		{"marquez gabriel", "@I1@"},
+		{"Yamada", "@I2@"},
+		{"Taro Yamada", "@I2@"},
+	}
+	for _, tt := range tests {
+		results, err := query.Filter().ByName(tt.pattern).Execute()
+		if err != nil {
+			t.Fatalf("Failed to execute filter: %v", err)
+		}
+		if len(results) != 1 || results[0].XrefID() != tt.want {
+			t.Errorf("ByName(%q): expected only %s, got %d results", tt.pattern, tt.want, len(results))
+		}
+	}
+}
+
 func TestFilterQuery_BySex(t *testing.T) {
 	tree := types.NewGedcomTree()
 
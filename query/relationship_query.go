@@ -9,6 +9,13 @@ type RelationshipQuery struct {
 	fromXrefID string
 	toXrefID   string
 	graph      *Graph
+	lineage    Lineage
+}
+
+// Lineage sets the parent-child links the relationship is traced through.
+func (rq *RelationshipQuery) Lineage(lineage Lineage) *RelationshipQuery {
+	rq.lineage = lineage
+	return rq
 }
 
 // Execute calculates and returns the relationship result.
@@ -22,7 +29,7 @@ func (rq *RelationshipQuery) Execute() (*RelationshipResult, error) {
 		}
 	}()
 
-	return rq.graph.CalculateRelationship(rq.fromXrefID, rq.toXrefID)
+	return rq.graph.CalculateRelationshipWithLineage(rq.fromXrefID, rq.toXrefID, rq.lineage)
 }
 
 // GetRelationshipType returns the human-readable relationship type.
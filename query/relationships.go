@@ -19,6 +19,14 @@ type RelationshipResult struct {
 
 // CalculateRelationship calculates the relationship between two individuals.
 func (g *Graph) CalculateRelationship(fromXref, toXref string) (*RelationshipResult, error) {
+	return g.CalculateRelationshipWithLineage(fromXref, toXref, LineageAll)
+}
+
+// CalculateRelationshipWithLineage calculates the relationship between two
+// individuals through the parent-child links lineage follows, so that with
+// LineageBiological an adopted child is not a blood relative of the adoptive
+// family. Path and AllPaths are not filtered.
+func (g *Graph) CalculateRelationshipWithLineage(fromXref, toXref string, lineage Lineage) (*RelationshipResult, error) {
 	fromID := g.GetNodeID(fromXref)
 	toID := g.GetNodeID(toXref)
 	fromNode := g.individuals[fromID]
@@ -44,6 +52,11 @@ func (g *Graph) CalculateRelationship(fromXref, toXref string) (*RelationshipRes
 	allPaths, _ := g.AllPaths(fromXref, toXref, 10)
 	result.AllPaths = allPaths
 
+	if lineage.filters() {
+		g.classifyByLineage(fromNode, toNode, lineage, result)
+		return result, nil
+	}
+
 	// Check if direct relationship (parent, child, sibling, spouse)
 	result.IsDirect = g.isDirectRelationship(fromNode, toNode)
 
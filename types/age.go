@@ -0,0 +1,376 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AgeBound qualifies an age value: the real age is less or greater than the
+// age written.
+type AgeBound string
+
+const (
+	AgeBoundNone        AgeBound = ""
+	AgeBoundLessThan    AgeBound = "<"
+	AgeBoundGreaterThan AgeBound = ">"
+)
+
+// AgeKeyword is a GEDCOM 5.5.1 age keyword used instead of a duration.
+type AgeKeyword string
+
+const (
+	AgeKeywordChild     AgeKeyword = "CHILD"     // Younger than 8 years
+	AgeKeywordInfant    AgeKeyword = "INFANT"    // Younger than 1 year
+	AgeKeywordStillborn AgeKeyword = "STILLBORN" // Died just before, at or near birth
+)
+
+// Age represents a parsed GEDCOM AGE value, the age of a person at an event.
+//
+// Ages are counted in completed units, as on a gravestone: "42y" is anything
+// from the 42nd birthday up to the day before the 43rd, and "42y 3m" from 42
+// years and 3 months up to the day before 42 years and 4 months.
+type Age struct {
+	Original string   // Original GEDCOM age string
+	Bound    AgeBound // "<", ">" or none
+
+	// Duration components ("42y 3m 2w 1d"); unset components are 0
+	Years  int
+	Months int
+	Weeks  int
+	Days   int
+
+	// Keyword is set for CHILD, INFANT and STILLBORN, which have no components.
+	Keyword AgeKeyword
+
+	// Phrase is the GEDCOM 7.0 AGE.PHRASE, if any.
+	Phrase string
+
+	// Parsed status
+	IsParsed   bool
+	ParseError error
+}
+
+// agePattern matches the duration of an age value: any of years, months,
+// weeks and days, in that order, with or without spaces.
+var agePattern = regexp.MustCompile(`(?i)^(?:(\d+)\s*y)?\s*(?:(\d+)\s*m)?\s*(?:(\d+)\s*w)?\s*(?:(\d+)\s*d)?$`)
+
+// ParseAge parses a GEDCOM AGE value and returns an Age.
+// Supports:
+//   - "42y", "42y 3m", "42y 3m 2d", "3w", "10d" (duration)
+//   - "<1y", "> 80y" (bounded duration)
+//   - "CHILD", "INFANT", "STILLBORN" (GEDCOM 5.5.1 keywords)
+//   - "42" (a bare number of years, as written by some programs)
+func ParseAge(ageStr string) (*Age, error) {
+	original := strings.TrimSpace(ageStr)
+	if original == "" {
+		return nil, fmt.Errorf("empty age string")
+	}
+
+	age := &Age{Original: original}
+
+	keyword := AgeKeyword(strings.ToUpper(original))
+	switch keyword {
+	case AgeKeywordChild, AgeKeywordInfant, AgeKeywordStillborn:
+		age.Keyword = keyword
+		age.IsParsed = true
+		return age, nil
+	}
+
+	value := original
+	if bound := AgeBound(value[:1]); bound == AgeBoundLessThan || bound == AgeBoundGreaterThan {
+		age.Bound = bound
+		value = strings.TrimSpace(value[1:])
+	}
+
+	if years, err := strconv.Atoi(value); err == nil && years >= 0 {
+		age.Years = years
+		age.IsParsed = true
+		return age, nil
+	}
+
+	matches := agePattern.FindStringSubmatch(value)
+	if matches == nil || value == "" {
+		age.ParseError = fmt.Errorf("invalid age format: %s", original)
+		return age, age.ParseError
+	}
+	components := []*int{&age.Years, &age.Months, &age.Weeks, &age.Days}
+	for i, component := range components {
+		if matches[i+1] != "" {
+			*component, _ = strconv.Atoi(matches[i+1])
+		}
+	}
+
+	age.IsParsed = true
+	return age, nil
+}
+
+// ParseAgeWithPhrase parses a GEDCOM 7.0 AGE value together with its PHRASE
+// substructure. A phrase with an empty age value gives an age with only a
+// phrase, which is parsed but not valid.
+func ParseAgeWithPhrase(ageStr, phrase string) (*Age, error) {
+	phrase = strings.TrimSpace(phrase)
+	if strings.TrimSpace(ageStr) == "" && phrase != "" {
+		return &Age{Phrase: phrase, IsParsed: true}, nil
+	}
+
+	age, err := ParseAge(ageStr)
+	if age != nil {
+		age.Phrase = phrase
+	}
+	return age, err
+}
+
+// NewAgeFromLine parses an AGE line with its PHRASE. It returns nil if the
+// line is not an AGE line or holds no age.
+func NewAgeFromLine(line *GedcomLine) *Age {
+	if line == nil || line.Tag != "AGE" {
+		return nil
+	}
+	age, _ := ParseAgeWithPhrase(line.Value, line.GetValue("PHRASE"))
+	return age
+}
+
+// IsValid returns true if the age was parsed and has a value. Ages with
+// only a phrase are not valid.
+func (a *Age) IsValid() bool {
+	return a != nil && a.IsParsed && a.ParseError == nil && (a.Keyword != "" || a.Original != "")
+}
+
+// IsExact returns true if the age is a number of days with no bound, so
+// that it names a single birth date for an exact event date.
+func (a *Age) IsExact() bool {
+	return a.IsValid() && a.Keyword == "" && a.Bound == AgeBoundNone && a.smallestUnit() == ageUnitDays
+}
+
+// String returns the age in GEDCOM 7.0 form, such as "> 42y 3m".
+func (a *Age) String() string {
+	if !a.IsValid() {
+		return a.Original
+	}
+	return a.format()
+}
+
+// format writes the keyword or the bound and components of the age.
+func (a *Age) format() string {
+	if a.Keyword != "" {
+		return string(a.Keyword)
+	}
+
+	var parts []string
+	if a.Bound != AgeBoundNone {
+		parts = append(parts, string(a.Bound))
+	}
+	for _, part := range []struct {
+		value int
+		unit  string
+	}{{a.Years, "y"}, {a.Months, "m"}, {a.Weeks, "w"}, {a.Days, "d"}} {
+		if part.value != 0 {
+			parts = append(parts, strconv.Itoa(part.value)+part.unit)
+		}
+	}
+	if a.Years == 0 && a.Months == 0 && a.Weeks == 0 && a.Days == 0 {
+		parts = append(parts, "0y")
+	}
+	return strings.Join(parts, " ")
+}
+
+// ageSpan is a calendar duration. Days may be negative, so that "one year
+// less a day" can be added to a date with time.AddDate.
+type ageSpan struct {
+	years, months, days int
+}
+
+// ageUnit is the smallest unit written in an age, which sets its precision.
+type ageUnit int
+
+const (
+	ageUnitYears ageUnit = iota
+	ageUnitMonths
+	ageUnitWeeks
+	ageUnitDays
+)
+
+// smallestUnit returns the smallest unit written in the age. "0y" and ages
+// with no component count in years.
+func (a *Age) smallestUnit() ageUnit {
+	switch {
+	case a.Days != 0:
+		return ageUnitDays
+	case a.Weeks != 0:
+		return ageUnitWeeks
+	case a.Months != 0:
+		return ageUnitMonths
+	default:
+		return ageUnitYears
+	}
+}
+
+// value returns the age as written as a calendar duration.
+func (a *Age) value() ageSpan {
+	return ageSpan{years: a.Years, months: a.Months, days: a.Weeks*7 + a.Days}
+}
+
+// span returns the youngest and oldest the person can have been. open is
+// set when there is no oldest age (a ">" bound).
+func (a *Age) span() (min, max ageSpan, open bool) {
+	switch a.Keyword {
+	case AgeKeywordChild:
+		return ageSpan{}, ageSpan{years: 8, days: -1}, false
+	case AgeKeywordInfant:
+		return ageSpan{}, ageSpan{years: 1, days: -1}, false
+	case AgeKeywordStillborn:
+		return ageSpan{}, ageSpan{}, false
+	}
+
+	value := a.value()
+	switch a.Bound {
+	case AgeBoundLessThan:
+		if value == (ageSpan{}) {
+			return ageSpan{}, ageSpan{}, false
+		}
+		max = value
+		max.days--
+		return ageSpan{}, max, false
+	case AgeBoundGreaterThan:
+		min = value
+		min.days++
+		return min, ageSpan{}, true
+	}
+
+	// Completed units: the age lasts until the smallest unit ticks over
+	max = value
+	switch a.smallestUnit() {
+	case ageUnitYears:
+		max.years++
+		max.days--
+	case ageUnitMonths:
+		max.months++
+		max.days--
+	case ageUnitWeeks:
+		max.days += 6
+	}
+	return value, max, false
+}
+
+// Approximate lengths used to convert calendar units to a Duration, the
+// same as Duration.String uses.
+const (
+	ageDay   = 24 * time.Hour
+	ageMonth = time.Duration(30.4166 * float64(ageDay))
+	ageYear  = 365 * ageDay
+)
+
+// duration converts the span to a time.Duration.
+func (s ageSpan) duration() time.Duration {
+	return time.Duration(s.years)*ageYear + time.Duration(s.months)*ageMonth + time.Duration(s.days)*ageDay
+}
+
+// Duration returns the age as written, or for a keyword the oldest age it
+// allows. It is an estimate unless the age is exact (see IsExact). Months
+// and years are converted with the lengths Duration.String uses.
+func (a *Age) Duration() Duration {
+	if !a.IsValid() {
+		return NewDuration(0, false, true)
+	}
+	if a.Keyword != "" {
+		_, max, _ := a.span()
+		return NewDuration(max.duration(), true, a.Keyword != AgeKeywordStillborn)
+	}
+	return NewDuration(a.value().duration(), true, !a.IsExact())
+}
+
+// Bounds returns the youngest and oldest the person can have been. For a ">"
+// age there is no oldest age and max.IsKnown is false.
+func (a *Age) Bounds() (min, max Duration) {
+	if !a.IsValid() {
+		unknown := NewDuration(0, false, true)
+		return unknown, unknown
+	}
+
+	minSpan, maxSpan, open := a.span()
+	estimate := open || minSpan != maxSpan
+	min = NewDuration(minSpan.duration(), true, estimate)
+	if open {
+		return min, NewDuration(0, false, true)
+	}
+	return min, NewDuration(maxSpan.duration(), true, estimate)
+}
+
+// BirthRangeFromAge returns the range of birth dates of a person who was
+// the given age on the event date. The event date may itself be partial or a
+// range; the birth range then covers every combination. ok is false if the
+// date or age is not valid. For a ">" age the range is a single BEFORE date.
+func BirthRangeFromAge(eventDate *GedcomDate, age *Age) (birth DateRange, ok bool) {
+	if eventDate == nil || !eventDate.IsValid() || !age.IsValid() {
+		return NewZeroDateRange(), false
+	}
+
+	minSpan, maxSpan, open := age.span()
+	latest := dateFromTime(subtractAge(eventDate.Latest(), minSpan), DateTypeExact)
+	if open {
+		latest.Type = DateTypeBefore
+		latest.Original = latest.String()
+		return NewDateRange(latest, latest), true
+	}
+
+	earliest := dateFromTime(subtractAge(eventDate.Earliest(), maxSpan), DateTypeExact)
+	return NewDateRange(earliest, latest), true
+}
+
+// subtractAge returns t less the span.
+func subtractAge(t time.Time, span ageSpan) time.Time {
+	return t.AddDate(-span.years, -span.months, -span.days)
+}
+
+// dateFromTime returns the Gregorian day of t as a parsed GedcomDate.
+func dateFromTime(t time.Time, dateType DateType) *GedcomDate {
+	year := t.Year()
+	if year <= 0 {
+		year-- // back to BCE numbering
+	}
+	date := &GedcomDate{
+		Type:     dateType,
+		Calendar: CalendarGregorian,
+		Year:     year,
+		Month:    int(t.Month()),
+		Day:      t.Day(),
+		IsParsed: true,
+	}
+	date.Original = date.String()
+	return date
+}
+
+// AgeAt returns the age in completed years, months and days of a person
+// born on birth at the event date, as an exact Age. Partial dates use their
+// earliest day. ok is false if either date is not valid or the event is
+// before the birth.
+func AgeAt(birth, event *GedcomDate) (age *Age, ok bool) {
+	if birth == nil || event == nil || !birth.IsValid() || !event.IsValid() {
+		return nil, false
+	}
+
+	from, to := birth.Earliest(), event.Earliest()
+	if to.Before(from) {
+		return nil, false
+	}
+
+	years := to.Year() - from.Year()
+	months := int(to.Month()) - int(from.Month())
+	days := to.Day() - from.Day()
+	if days < 0 {
+		months--
+		// Days left in the month before the event's month
+		days += time.Date(to.Year(), to.Month(), 0, 0, 0, 0, 0, time.UTC).Day()
+	}
+	if months < 0 {
+		years--
+		months += 12
+	}
+
+	age = &Age{Years: years, Months: months, Days: days, IsParsed: true}
+	age.Original = age.format()
+	return age, true
+}
@@ -0,0 +1,188 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Age
+		str     string
+		wantErr bool
+	}{
+		{input: "42y", want: Age{Years: 42}, str: "42y"},
+		{input: "42y 3m 2d", want: Age{Years: 42, Months: 3, Days: 2}, str: "42y 3m 2d"},
+		{input: "3w", want: Age{Weeks: 3}, str: "3w"},
+		{input: "10D", want: Age{Days: 10}, str: "10d"},
+		{input: "<1y", want: Age{Bound: AgeBoundLessThan, Years: 1}, str: "< 1y"},
+		{input: "> 80y", want: Age{Bound: AgeBoundGreaterThan, Years: 80}, str: "> 80y"},
+		{input: "42", want: Age{Years: 42}, str: "42y"},
+		{input: "child", want: Age{Keyword: AgeKeywordChild}, str: "CHILD"},
+		{input: "INFANT", want: Age{Keyword: AgeKeywordInfant}, str: "INFANT"},
+		{input: "STILLBORN", want: Age{Keyword: AgeKeywordStillborn}, str: "STILLBORN"},
+		{input: "about forty", wantErr: true},
+		{input: "<", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		age, err := ParseAge(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAge(%q) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseAge(%q) returned error: %v", tt.input, err)
+		}
+		if age.Bound != tt.want.Bound || age.Years != tt.want.Years || age.Months != tt.want.Months ||
+			age.Weeks != tt.want.Weeks || age.Days != tt.want.Days || age.Keyword != tt.want.Keyword {
+			t.Errorf("ParseAge(%q) = %+v, want %+v", tt.input, *age, tt.want)
+		}
+		if got := age.String(); got != tt.str {
+			t.Errorf("ParseAge(%q).String() = %q, want %q", tt.input, got, tt.str)
+		}
+	}
+}
+
+func TestParseAgeWithPhrase(t *testing.T) {
+	age, err := ParseAgeWithPhrase("", "in her seventies")
+	if err != nil {
+		t.Fatalf("ParseAgeWithPhrase returned error: %v", err)
+	}
+	if age.Phrase != "in her seventies" || age.IsValid() {
+		t.Errorf("phrase-only age: got %+v, want a phrase and not valid", *age)
+	}
+
+	age, _ = ParseAgeWithPhrase("> 70y", "in her seventies")
+	if !age.IsValid() || age.Years != 70 || age.Phrase != "in her seventies" {
+		t.Errorf("age with phrase: got %+v", *age)
+	}
+}
+
+func TestAge_Bounds(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		input      string
+		min, max   time.Duration
+		maxUnknown bool
+	}{
+		{input: "42y", min: 42 * ageYear, max: 43*ageYear - day},
+		{input: "42y 3m", min: 42*ageYear + 3*ageMonth, max: 42*ageYear + 4*ageMonth - day},
+		{input: "2w", min: 14 * day, max: 20 * day},
+		{input: "10d", min: 10 * day, max: 10 * day},
+		{input: "<1y", min: 0, max: ageYear - day},
+		{input: ">80y", min: 80*ageYear + day, maxUnknown: true},
+		{input: "CHILD", min: 0, max: 8*ageYear - day},
+		{input: "STILLBORN", min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		age, _ := ParseAge(tt.input)
+		min, max := age.Bounds()
+		if min.Duration != tt.min {
+			t.Errorf("%s: min = %v, want %v", tt.input, min.Duration, tt.min)
+		}
+		if tt.maxUnknown {
+			if max.IsKnown {
+				t.Errorf("%s: max should be unknown", tt.input)
+			}
+		} else if max.Duration != tt.max || !max.IsKnown {
+			t.Errorf("%s: max = %v, want %v", tt.input, max.Duration, tt.max)
+		}
+	}
+
+	age, _ := ParseAge("42y")
+	if d := age.Duration(); d.String() != "42 years" || !d.IsEstimate {
+		t.Errorf("Duration() = %q (estimate %v), want an estimate of 42 years", d.String(), d.IsEstimate)
+	}
+	age, _ = ParseAge("42y 3m 2d")
+	if d := age.Duration(); d.IsEstimate {
+		t.Error("Duration() of a day-precise age should not be an estimate")
+	}
+}
+
+func TestBirthRangeFromAge(t *testing.T) {
+	tests := []struct {
+		date, age  string
+		start, end string
+	}{
+		{"1 JAN 1900", "42y", "2 JAN 1857", "1 JAN 1858"},
+		{"15 MAR 1900", "42y 3m 2d", "13 DEC 1857", "13 DEC 1857"},
+		{"1900", "42y", "2 JAN 1857", "31 DEC 1858"},
+		{"10 JUN 1850", "INFANT", "11 JUN 1849", "10 JUN 1850"},
+		{"10 JUN 1850", "< 2m", "11 APR 1850", "10 JUN 1850"},
+		{"10 JUN 1850", "> 80y", "BEFORE 9 JUN 1770", "BEFORE 9 JUN 1770"},
+	}
+
+	for _, tt := range tests {
+		date, _ := ParseDate(tt.date)
+		age, _ := ParseAge(tt.age)
+		birth, ok := BirthRangeFromAge(date, age)
+		if !ok {
+			t.Fatalf("BirthRangeFromAge(%s, %s) not ok", tt.date, tt.age)
+		}
+		if got := birth.StartDate().String(); got != tt.start {
+			t.Errorf("BirthRangeFromAge(%s, %s) start = %s, want %s", tt.date, tt.age, got, tt.start)
+		}
+		if got := birth.EndDate().String(); got != tt.end {
+			t.Errorf("BirthRangeFromAge(%s, %s) end = %s, want %s", tt.date, tt.age, got, tt.end)
+		}
+	}
+
+	date, _ := ParseDate("1900")
+	if _, ok := BirthRangeFromAge(date, nil); ok {
+		t.Error("BirthRangeFromAge without an age should not be ok")
+	}
+}
+
+func TestAgeAt(t *testing.T) {
+	birth, _ := ParseDate("20 MAY 1820")
+	event, _ := ParseDate("3 MAR 1861")
+	age, ok := AgeAt(birth, event)
+	if !ok {
+		t.Fatal("AgeAt not ok")
+	}
+	if age.String() != "40y 9m 11d" {
+		t.Errorf("AgeAt = %s, want 40y 9m 11d", age)
+	}
+	if _, ok := AgeAt(event, birth); ok {
+		t.Error("AgeAt with the event before the birth should not be ok")
+	}
+}
+
+func TestParseEvent_Ages(t *testing.T) {
+	marr := NewGedcomLine(1, "MARR", "", "")
+	marr.AddChild(NewGedcomLine(2, "DATE", "12 OCT 1880", ""))
+	husb := NewGedcomLine(2, "HUSB", "", "")
+	husb.AddChild(NewGedcomLine(3, "AGE", "25y", ""))
+	marr.AddChild(husb)
+	wife := NewGedcomLine(2, "WIFE", "", "")
+	wife.AddChild(NewGedcomLine(3, "AGE", "<21y", ""))
+	marr.AddChild(wife)
+
+	event, err := ParseEvent(marr)
+	if err != nil {
+		t.Fatalf("ParseEvent returned error: %v", err)
+	}
+	if event.Age != nil {
+		t.Errorf("Age = %v, want nil", event.Age)
+	}
+	if event.HusbandAge == nil || event.HusbandAge.Years != 25 {
+		t.Errorf("HusbandAge = %v, want 25y", event.HusbandAge)
+	}
+	if event.WifeAge == nil || event.WifeAge.Bound != AgeBoundLessThan {
+		t.Errorf("WifeAge = %v, want < 21y", event.WifeAge)
+	}
+
+	birth, ok := event.HusbandBirthRange()
+	if !ok || birth.StartDate().String() != "13 OCT 1854" || birth.EndDate().String() != "12 OCT 1855" {
+		t.Errorf("HusbandBirthRange() = %s, %v", birth, ok)
+	}
+	if _, ok := event.BirthRange(); ok {
+		t.Error("BirthRange() without AGE should not be ok")
+	}
+}
@@ -0,0 +1,144 @@
+package types
+
+import "strings"
+
+// AssociationRole is the role an associated individual played for a record,
+// from ASSO.RELA (GEDCOM 5.5.1) or ASSO.ROLE (GEDCOM 7.0).
+type AssociationRole string
+
+const (
+	RoleChild      AssociationRole = "child"
+	RoleClergy     AssociationRole = "clergy"
+	RoleFather     AssociationRole = "father"
+	RoleFriend     AssociationRole = "friend"
+	RoleGodparent  AssociationRole = "godparent"
+	RoleHusband    AssociationRole = "husband"
+	RoleMother     AssociationRole = "mother"
+	RoleMultiple   AssociationRole = "multiple"
+	RoleNeighbor   AssociationRole = "neighbor"
+	RoleOfficiator AssociationRole = "officiator"
+	RoleParent     AssociationRole = "parent"
+	RoleSpouse     AssociationRole = "spouse"
+	RoleWife       AssociationRole = "wife"
+	RoleWitness    AssociationRole = "witness"
+	RoleOther      AssociationRole = "other"
+	RoleUnknown    AssociationRole = "" // No RELA or ROLE given
+)
+
+// ParseAssociationRole returns the AssociationRole for a ROLE or RELA value.
+// Besides the GEDCOM 7.0 enumeration values it accepts the free-text words
+// 5.5.1 files use ("Godfather", "Witness", "Neighbour", ...). Values it does
+// not recognize return RoleOther; an empty value returns RoleUnknown.
+func ParseAssociationRole(value string) AssociationRole {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return RoleUnknown
+	case "chil", "child":
+		return RoleChild
+	case "clergy", "priest", "minister", "pastor", "rabbi":
+		return RoleClergy
+	case "fath", "father":
+		return RoleFather
+	case "friend":
+		return RoleFriend
+	case "godp", "godparent", "godfather", "godmother", "sponsor":
+		return RoleGodparent
+	case "husb", "husband":
+		return RoleHusband
+	case "moth", "mother":
+		return RoleMother
+	case "multiple":
+		return RoleMultiple
+	case "nghbr", "neighbor", "neighbour":
+		return RoleNeighbor
+	case "officiator", "officiant":
+		return RoleOfficiator
+	case "parent":
+		return RoleParent
+	case "spou", "spouse":
+		return RoleSpouse
+	case "wife":
+		return RoleWife
+	case "witn", "witness":
+		return RoleWitness
+	default:
+		return RoleOther
+	}
+}
+
+// Association is an ASSO link to another individual, who played Role for the
+// record, e.g. "1 ASSO @I2@ / 2 ROLE GODP" in the record of @I1@ means @I2@
+// is a godparent of @I1@.
+type Association struct {
+	Xref   string          // Associated individual
+	Role   AssociationRole // Role of the associated individual
+	Phrase string          // RELA value, or the PHRASE of ROLE or ASSO
+	Event  EventType       // Event the ASSO is under, "" if under the record
+	Line   *GedcomLine     // The ASSO line
+}
+
+// associations returns the ASSO links under a record line and under its
+// events (GEDCOM 7.0). @VOID@ pointers are skipped.
+func associations(record *GedcomLine) []Association {
+	var result []Association
+	add := func(asso *GedcomLine, event EventType) {
+		if IsVoidPointer(asso.Value) || strings.TrimSpace(asso.Value) == "" {
+			return
+		}
+		association := Association{Xref: asso.Value, Event: event, Line: asso}
+		if rela := asso.GetValue("RELA"); rela != "" {
+			association.Role = ParseAssociationRole(rela)
+			association.Phrase = rela
+		} else {
+			association.Role = ParseAssociationRole(asso.GetValue("ROLE"))
+			association.Phrase = asso.GetValue("ROLE.PHRASE")
+		}
+		if association.Phrase == "" {
+			association.Phrase = asso.GetValue("PHRASE")
+		}
+		result = append(result, association)
+	}
+
+	for _, line := range record.ChildLines() {
+		if line.Tag == "ASSO" {
+			add(line, "")
+			continue
+		}
+		for _, child := range line.ChildLines() {
+			if child.Tag == "ASSO" {
+				add(child, EventType(line.Tag))
+			}
+		}
+	}
+	return result
+}
+
+// GetAssociations returns the individual's ASSO links, both those directly
+// under the record and those under its events.
+func (ir *IndividualRecord) GetAssociations() []Association {
+	return associations(ir.FirstLine())
+}
+
+// GetAssociationsByRole returns the ASSO links whose associate played role,
+// e.g. RoleGodparent for the godparents of the individual.
+func (ir *IndividualRecord) GetAssociationsByRole(role AssociationRole) []Association {
+	var result []Association
+	for _, association := range ir.GetAssociations() {
+		if association.Role == role {
+			result = append(result, association)
+		}
+	}
+	return result
+}
+
+// GetAliases returns the xrefs of the ALIA lines: other records that
+// describe the same person. GEDCOM 7.0 @VOID@ pointers are skipped.
+func (ir *IndividualRecord) GetAliases() []string {
+	return pointerValues(ir.GetValues("ALIA"))
+}
+
+// GetAssociations returns the ASSO links under the family's events, such as
+// the witnesses of a marriage (GEDCOM 7.0).
+func (fr *FamilyRecord) GetAssociations() []Association {
+	return associations(fr.FirstLine())
+}
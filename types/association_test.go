@@ -0,0 +1,104 @@
+package types
+
+import "testing"
+
+func TestParseAssociationRole(t *testing.T) {
+	tests := []struct {
+		value string
+		want  AssociationRole
+	}{
+		{"GODP", RoleGodparent},
+		{"Godfather", RoleGodparent},
+		{"godmother", RoleGodparent},
+		{"WITN", RoleWitness},
+		{"Witness", RoleWitness},
+		{"NGHBR", RoleNeighbor},
+		{"Neighbour", RoleNeighbor},
+		{"OFFICIATOR", RoleOfficiator},
+		{"CHIL", RoleChild},
+		{"Best man", RoleOther},
+		{"", RoleUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ParseAssociationRole(tt.value); got != tt.want {
+			t.Errorf("ParseAssociationRole(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIndividualRecord_GetAssociations(t *testing.T) {
+	line := NewGedcomLine(0, "INDI", "", "@I1@")
+
+	// GEDCOM 5.5.1: RELA
+	rela := NewGedcomLine(1, "ASSO", "@I2@", "")
+	rela.AddChild(NewGedcomLine(2, "RELA", "Godfather", ""))
+	line.AddChild(rela)
+
+	// GEDCOM 7.0: ROLE with a PHRASE, under an event
+	bapm := NewGedcomLine(1, "BAPM", "", "")
+	role := NewGedcomLine(2, "ASSO", "@I3@", "")
+	roleLine := NewGedcomLine(3, "ROLE", "OTHER", "")
+	roleLine.AddChild(NewGedcomLine(4, "PHRASE", "Sponsor's proxy", ""))
+	role.AddChild(roleLine)
+	bapm.AddChild(role)
+	witness := NewGedcomLine(2, "ASSO", "@I4@", "")
+	witness.AddChild(NewGedcomLine(3, "ROLE", "WITN", ""))
+	bapm.AddChild(witness)
+	line.AddChild(bapm)
+
+	// Void pointers are skipped
+	void := NewGedcomLine(1, "ASSO", "@VOID@", "")
+	void.AddChild(NewGedcomLine(2, "ROLE", "FRIEND", ""))
+	line.AddChild(void)
+
+	line.AddChild(NewGedcomLine(1, "ALIA", "@I5@", ""))
+	line.AddChild(NewGedcomLine(1, "ALIA", "@VOID@", ""))
+	indi := NewIndividualRecord(line)
+
+	associations := indi.GetAssociations()
+	if len(associations) != 3 {
+		t.Fatalf("GetAssociations() returned %d associations, want 3", len(associations))
+	}
+
+	want := []Association{
+		{Xref: "@I2@", Role: RoleGodparent, Phrase: "Godfather"},
+		{Xref: "@I3@", Role: RoleOther, Phrase: "Sponsor's proxy", Event: EventTypeBaptism},
+		{Xref: "@I4@", Role: RoleWitness, Event: EventTypeBaptism},
+	}
+	for i, w := range want {
+		got := associations[i]
+		if got.Xref != w.Xref || got.Role != w.Role || got.Phrase != w.Phrase || got.Event != w.Event {
+			t.Errorf("association %d = %+v, want %+v", i, got, w)
+		}
+		if got.Line == nil || got.Line.Tag != "ASSO" {
+			t.Errorf("association %d Line = %v", i, got.Line)
+		}
+	}
+
+	if godparents := indi.GetAssociationsByRole(RoleGodparent); len(godparents) != 1 || godparents[0].Xref != "@I2@" {
+		t.Errorf("GetAssociationsByRole(godparent) = %+v", godparents)
+	}
+
+	if aliases := indi.GetAliases(); len(aliases) != 1 || aliases[0] != "@I5@" {
+		t.Errorf("GetAliases() = %v, want [@I5@]", aliases)
+	}
+}
+
+func TestFamilyRecord_GetAssociations(t *testing.T) {
+	line := NewGedcomLine(0, "FAM", "", "@F1@")
+	marr := NewGedcomLine(1, "MARR", "", "")
+	asso := NewGedcomLine(2, "ASSO", "@I9@", "")
+	asso.AddChild(NewGedcomLine(3, "ROLE", "WITN", ""))
+	marr.AddChild(asso)
+	line.AddChild(marr)
+	fam := NewFamilyRecord(line)
+
+	associations := fam.GetAssociations()
+	if len(associations) != 1 {
+		t.Fatalf("GetAssociations() returned %d associations, want 1", len(associations))
+	}
+	if got := associations[0]; got.Xref != "@I9@" || got.Role != RoleWitness || got.Event != EventTypeMarriage {
+		t.Errorf("GetAssociations()[0] = %+v", got)
+	}
+}
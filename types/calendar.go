@@ -0,0 +1,336 @@
+package types
+
+import "time"
+
+// Calendar conversion works on Julian Day Numbers (JDN): the number of days
+// since 1 January 4713 BC in the proleptic Julian calendar. Every calendar
+// date maps to a JDN, so dates in different calendars can be compared and
+// subtracted directly.
+
+// unixEpochJDN is the Julian Day Number of 1 January 1970 (Gregorian).
+const unixEpochJDN = 2440588
+
+// frenchEpochJDN is the Julian Day Number of 1 Vendémiaire I, which is
+// 22 September 1792 (Gregorian).
+const frenchEpochJDN = 2375840
+
+// hebrewEpochJDN is the Julian Day Number of 1 Tishrei 1 (7 October 3761 BC,
+// Julian). Days elapsed since the epoch are added to it.
+const hebrewEpochJDN = 347998
+
+// astronomicalYear converts a year where negative values are BCE ("44 BCE" is
+// -44) to astronomical numbering, which has a year 0 (44 BCE is -43).
+func astronomicalYear(year int) int {
+	if year < 0 {
+		return year + 1
+	}
+	return year
+}
+
+// floorDiv divides rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// gregorianToJDN returns the Julian Day Number of a proleptic Gregorian date.
+// The year uses astronomical numbering.
+func gregorianToJDN(year, month, day int) int {
+	a := floorDiv(14-month, 12)
+	y := year + 4800 - a
+	m := month + 12*a - 3
+	return day + floorDiv(153*m+2, 5) + 365*y + floorDiv(y, 4) - floorDiv(y, 100) + floorDiv(y, 400) - 32045
+}
+
+// julianToJDN returns the Julian Day Number of a proleptic Julian date.
+// The year uses astronomical numbering.
+func julianToJDN(year, month, day int) int {
+	a := floorDiv(14-month, 12)
+	y := year + 4800 - a
+	m := month + 12*a - 3
+	return day + floorDiv(153*m+2, 5) + 365*y + floorDiv(y, 4) - 32083
+}
+
+// isGregorianLeapYear reports whether an astronomical year is a Gregorian leap year.
+func isGregorianLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// isJulianLeapYear reports whether an astronomical year is a Julian leap year.
+func isJulianLeapYear(year int) bool {
+	return year%4 == 0
+}
+
+// solarMonthDays returns the length of a month in the Gregorian or Julian calendar.
+func solarMonthDays(month int, leap bool) int {
+	switch month {
+	case 2:
+		if leap {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// isHebrewLeapYear reports whether a Hebrew year has 13 months.
+func isHebrewLeapYear(year int) bool {
+	return mod(7*year+1, 19) < 7
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
+
+// hebrewElapsedDays returns the number of days from the Hebrew epoch to the
+// molad of Tishrei of year, adjusted by the first postponement rule.
+func hebrewElapsedDays(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	day := months*29 + floorDiv(parts, 25920)
+	if mod(3*(day+1), 7) < 3 {
+		day++
+	}
+	return day
+}
+
+// hebrewYearDelay applies the postponement rules that keep adjacent years
+// within the allowed lengths.
+func hebrewYearDelay(year int) int {
+	last := hebrewElapsedDays(year - 1)
+	present := hebrewElapsedDays(year)
+	next := hebrewElapsedDays(year + 1)
+	switch {
+	case next-present == 356:
+		return 2
+	case present-last == 382:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// hebrewNewYear returns the Julian Day Number of 1 Tishrei of year.
+func hebrewNewYear(year int) int {
+	return hebrewEpochJDN + hebrewElapsedDays(year) + hebrewYearDelay(year)
+}
+
+// hebrewMonthDays returns the length of a Hebrew month. Months are numbered
+// from Tishrei (1) as in GEDCOM; month 7 (ADS, Adar II) only exists in leap
+// years and has length 0 otherwise.
+func hebrewMonthDays(year, month int) int {
+	yearDays := hebrewNewYear(year+1) - hebrewNewYear(year)
+	switch month {
+	case 2: // Cheshvan is long in complete years
+		if yearDays%10 == 5 {
+			return 30
+		}
+		return 29
+	case 3: // Kislev is short in deficient years
+		if yearDays%10 == 3 {
+			return 29
+		}
+		return 30
+	case 6: // Adar I in leap years, Adar otherwise
+		if isHebrewLeapYear(year) {
+			return 30
+		}
+		return 29
+	case 7:
+		if isHebrewLeapYear(year) {
+			return 29
+		}
+		return 0
+	case 1, 5, 8, 10, 12:
+		return 30
+	default:
+		return 29
+	}
+}
+
+// hebrewToJDN returns the Julian Day Number of a Hebrew date.
+func hebrewToJDN(year, month, day int) int {
+	jdn := hebrewNewYear(year)
+	for m := 1; m < month; m++ {
+		jdn += hebrewMonthDays(year, m)
+	}
+	return jdn + day - 1
+}
+
+// isFrenchLeapYear reports whether a French Republican year has six
+// complementary days. Years III, VII and XI were leap years while the
+// calendar was in use; later years follow the rule proposed by Romme.
+func isFrenchLeapYear(year int) bool {
+	if year < 15 {
+		return year == 3 || year == 7 || year == 11
+	}
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// frenchMonthDays returns the length of a French Republican month. Month 13
+// holds the complementary days.
+func frenchMonthDays(year, month int) int {
+	if month != 13 {
+		return 30
+	}
+	if isFrenchLeapYear(year) {
+		return 6
+	}
+	return 5
+}
+
+// frenchToJDN returns the Julian Day Number of a French Republican date.
+func frenchToJDN(year, month, day int) int {
+	jdn := frenchEpochJDN + 365*(year-1)
+	for y := 1; y < year; y++ {
+		if isFrenchLeapYear(y) {
+			jdn++
+		}
+	}
+	return jdn + 30*(month-1) + day - 1
+}
+
+// monthsInYear returns the highest month number of calendar.
+func monthsInYear(calendar Calendar) int {
+	switch calendar {
+	case CalendarHebrew, CalendarFrench:
+		return 13
+	default:
+		return 12
+	}
+}
+
+// daysInMonth returns the number of days of month in year for calendar, or 0
+// if the month does not exist in that year. Negative years are BCE.
+func daysInMonth(calendar Calendar, year, month int) int {
+	if month < 1 || month > monthsInYear(calendar) {
+		return 0
+	}
+	switch calendar {
+	case CalendarHebrew:
+		return hebrewMonthDays(year, month)
+	case CalendarFrench:
+		return frenchMonthDays(year, month)
+	case CalendarJulian, CalendarRoman:
+		return solarMonthDays(month, isJulianLeapYear(astronomicalYear(year)))
+	default:
+		return solarMonthDays(month, isGregorianLeapYear(astronomicalYear(year)))
+	}
+}
+
+// toJDN returns the Julian Day Number of a complete date in calendar.
+// Negative years are BCE.
+func toJDN(calendar Calendar, year, month, day int) int {
+	switch calendar {
+	case CalendarHebrew:
+		return hebrewToJDN(year, month, day)
+	case CalendarFrench:
+		return frenchToJDN(year, month, day)
+	case CalendarJulian, CalendarRoman:
+		return julianToJDN(astronomicalYear(year), month, day)
+	default:
+		return gregorianToJDN(astronomicalYear(year), month, day)
+	}
+}
+
+// jdnBounds returns the first and last Julian Day Number covered by a
+// possibly partial date: a year-only date covers the whole year and a
+// month-year date the whole month.
+func jdnBounds(calendar Calendar, year, month, day int) (first, last int) {
+	switch {
+	case month == 0:
+		first = toJDN(calendar, year, 1, 1)
+		next := year + 1
+		if next == 0 {
+			next = 1 // 1 BCE is followed by 1 CE
+		}
+		last = toJDN(calendar, next, 1, 1) - 1
+	case day == 0:
+		first = toJDN(calendar, year, month, 1)
+		last = first + daysInMonth(calendar, year, month) - 1
+		if last < first {
+			last = first
+		}
+	default:
+		first = toJDN(calendar, year, month, day)
+		last = first
+	}
+	return first, last
+}
+
+// jdnToTime returns midnight UTC of a Julian Day Number as a (proleptic
+// Gregorian) time.Time.
+func jdnToTime(jdn int) time.Time {
+	return time.Unix(int64(jdn-unixEpochJDN)*86400, 0).UTC()
+}
+
+// timeToJDN returns the Julian Day Number of the day containing t (UTC).
+func timeToJDN(t time.Time) int {
+	return floorDiv(int(t.UTC().Unix()), 86400) + unixEpochJDN
+}
+
+// JDN returns the Julian Day Number of the earliest day covered by the date
+// (the start day for ranges), or 0 if the date is invalid. Dates in any
+// calendar can be compared by their JDN.
+func (gd *GedcomDate) JDN() int {
+	if !gd.IsValid() {
+		return 0
+	}
+	if gd.IsRange() {
+		first, _ := jdnBounds(gd.calendar(), gd.StartYear, gd.StartMonth, gd.StartDay)
+		return first
+	}
+	first, _ := jdnBounds(gd.calendar(), gd.Year, gd.Month, gd.Day)
+	return first
+}
+
+// ToGregorian returns the date converted to the Gregorian calendar. Only
+// complete dates (day, month and year) can be converted exactly; partial
+// dates are returned unchanged. Range start and end are converted separately.
+func (gd *GedcomDate) ToGregorian() *GedcomDate {
+	converted := *gd
+	if !gd.IsValid() || gd.calendar() == CalendarGregorian {
+		return &converted
+	}
+
+	convert := func(calendar Calendar, year, month, day int) (int, int, int, bool) {
+		if year == 0 || month == 0 || day == 0 {
+			return year, month, day, false
+		}
+		t := jdnToTime(toJDN(calendar, year, month, day))
+		y := t.Year()
+		if y <= 0 {
+			y-- // back to BCE numbering
+		}
+		return y, int(t.Month()), t.Day(), true
+	}
+
+	if gd.IsRange() {
+		y1, m1, d1, ok1 := convert(gd.calendar(), gd.StartYear, gd.StartMonth, gd.StartDay)
+		y2, m2, d2, ok2 := convert(gd.endCalendar(), gd.EndYear, gd.EndMonth, gd.EndDay)
+		if !ok1 || !ok2 {
+			return &converted
+		}
+		converted.StartYear, converted.StartMonth, converted.StartDay = y1, m1, d1
+		converted.EndYear, converted.EndMonth, converted.EndDay = y2, m2, d2
+	} else {
+		y, m, d, ok := convert(gd.calendar(), gd.Year, gd.Month, gd.Day)
+		if !ok {
+			return &converted
+		}
+		converted.Year, converted.Month, converted.Day = y, m, d
+	}
+	converted.Calendar = CalendarGregorian
+	converted.EndCalendar = ""
+	converted.DualYear = false
+	converted.EndDualYear = false
+	converted.gedcom5 = false
+	return &converted
+}
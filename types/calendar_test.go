@@ -0,0 +1,199 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate_CalendarEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		calendar Calendar
+		year     int
+		month    int
+		day      int
+		str      string
+	}{
+		{"@#DJULIAN@ 11 FEB 1731/32", CalendarJulian, 1732, 2, 11, "@#DJULIAN@ 11 FEB 1731/32"},
+		{"@#DHEBREW@ 15 NSN 5784", CalendarHebrew, 5784, 8, 15, "@#DHEBREW@ 15 NSN 5784"},
+		{"@#dhebrew@ ell 5600", CalendarHebrew, 5600, 13, 0, "@#DHEBREW@ ELL 5600"},
+		{"@#DFRENCH R@ 18 BRUM 8", CalendarFrench, 8, 2, 18, "@#DFRENCH R@ 18 BRUM 8"},
+		{"@#DFRENCH R@ 6 COMP 3", CalendarFrench, 3, 13, 6, "@#DFRENCH R@ 6 COMP 3"},
+		{"@#DGREGORIAN@ 1 JAN 1800", CalendarGregorian, 1800, 1, 1, "1 JAN 1800"},
+		{"@#DROMAN@ 1 MAR 700", CalendarRoman, 700, 3, 1, "@#DROMAN@ 1 MAR 700"},
+		{"@#DJULIAN@ 15 MAR 44 B.C.", CalendarJulian, -44, 3, 15, "@#DJULIAN@ 15 MAR 44 B.C."},
+		{"100 BC", CalendarGregorian, -100, 0, 0, "100 B.C."},
+		{"MAR 1750/51", CalendarGregorian, 1751, 3, 0, "MAR 1750/51"},
+		{"1699/00", CalendarGregorian, 1700, 0, 0, "1699/00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+			}
+			if date.Calendar != tt.calendar {
+				t.Errorf("Calendar = %q, want %q", date.Calendar, tt.calendar)
+			}
+			if date.Year != tt.year || date.Month != tt.month || date.Day != tt.day {
+				t.Errorf("got %d-%d-%d, want %d-%d-%d", date.Year, date.Month, date.Day, tt.year, tt.month, tt.day)
+			}
+			if got := date.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+
+	invalid := []string{
+		"@#DJULIAN@ 11 FEB 1731/33", // dual year digits must follow the first year
+		"@#DHEBREW@ 1 ADS 5785",     // Adar II only exists in leap years
+		"@#DHEBREW@ 30 TVT 5785",    // Tevet has 29 days
+		"@#DFRENCH R@ 6 COMP 4",     // year IV has five complementary days
+		"@#DHEBREW@ 1 TSH 5600/01",  // no dual years outside Gregorian/Julian
+		"@#DJULIAN@ 29 FEB 1701",
+	}
+	for _, input := range invalid {
+		if _, err := ParseDate(input); err == nil {
+			t.Errorf("ParseDate(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseDate_CalendarEscapeWithModifiers(t *testing.T) {
+	date, err := ParseDate("ABT @#DJULIAN@ 1700")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Type != DateTypeAbout || date.Calendar != CalendarJulian || date.Year != 1700 {
+		t.Errorf("unexpected date %+v", date)
+	}
+
+	// Calendar before the modifier, as some programs write it
+	date, err = ParseDate("@#DJULIAN@ BEF 1700")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Type != DateTypeBefore || date.Calendar != CalendarJulian {
+		t.Errorf("unexpected date %+v", date)
+	}
+
+	// The range end uses the start calendar unless it names its own
+	date, err = ParseDate("BET @#DJULIAN@ 1 JAN 1700 AND 10 JAN 1700")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Calendar != CalendarJulian || date.EndCalendar != "" {
+		t.Errorf("Calendar = %q, EndCalendar = %q", date.Calendar, date.EndCalendar)
+	}
+	if got := date.String(); got != "BET @#DJULIAN@ 1 JAN 1700 AND @#DJULIAN@ 10 JAN 1700" {
+		t.Errorf("String() = %q", got)
+	}
+
+	date, err = ParseDate("FROM @#DJULIAN@ 1 SEP 1752 TO @#DGREGORIAN@ 20 SEP 1752")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.EndCalendar != CalendarGregorian {
+		t.Errorf("EndCalendar = %q, want %q", date.EndCalendar, CalendarGregorian)
+	}
+	want := time.Date(1752, 9, 20, 23, 59, 59, 0, time.UTC)
+	if !date.Latest().Equal(want) {
+		t.Errorf("Latest() = %v, want %v", date.Latest(), want)
+	}
+
+	// A Gregorian end keeps its calendar when written back
+	for _, input := range []string{"BET @#DJULIAN@ 1700 AND @#DGREGORIAN@ 1710", "BET JULIAN 1700 AND GREGORIAN 1710"} {
+		date, err = ParseDate(input)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) error = %v", input, err)
+		}
+		if got := date.String(); got != input {
+			t.Errorf("String() = %q, want %q", got, input)
+		}
+		reparsed, err := ParseDate(date.String())
+		if err != nil || reparsed.EndCalendar != CalendarGregorian {
+			t.Errorf("%q reparsed with EndCalendar %q (%v)", date.String(), reparsed.EndCalendar, err)
+		}
+	}
+}
+
+func TestGedcomDate_JDN(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // Gregorian equivalent
+	}{
+		{"@#DJULIAN@ 2 SEP 1752", "13 SEP 1752"},
+		{"@#DJULIAN@ 25 DEC 1642", "4 JAN 1643"},
+		{"@#DJULIAN@ 11 FEB 1731/32", "22 FEB 1732"},
+		{"@#DHEBREW@ 1 TSH 5785", "3 OCT 2024"},
+		{"@#DHEBREW@ 1 TSH 5784", "16 SEP 2023"},
+		{"@#DHEBREW@ 15 NSN 5784", "23 APR 2024"},
+		{"@#DHEBREW@ 14 ADS 5784", "24 MAR 2024"},
+		{"@#DHEBREW@ 14 ADR 5785", "14 MAR 2025"},
+		{"@#DFRENCH R@ 1 VEND 1", "22 SEP 1792"},
+		{"@#DFRENCH R@ 18 BRUM 8", "9 NOV 1799"},
+		{"@#DFRENCH R@ 1 VEND 4", "23 SEP 1795"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+			}
+			gregorian, err := ParseDate(tt.want)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.want, err)
+			}
+			if date.JDN() != gregorian.JDN() {
+				t.Errorf("JDN() = %d, want %d (%s)", date.JDN(), gregorian.JDN(), tt.want)
+			}
+			if got := date.ToGregorian().String(); got != tt.want {
+				t.Errorf("ToGregorian() = %q, want %q", got, tt.want)
+			}
+			if date.Compare(gregorian) != 0 {
+				t.Errorf("Compare() = %d, want 0", date.Compare(gregorian))
+			}
+			if !date.Equals(gregorian) {
+				t.Error("Equals() = false, want true")
+			}
+		})
+	}
+
+	// Julius Caesar's death, 15 March 44 BC (Julian)
+	date, _ := ParseDate("@#DJULIAN@ 15 MAR 44 B.C.")
+	if date.JDN() != 1705426 {
+		t.Errorf("JDN() = %d, want %d", date.JDN(), 1705426)
+	}
+}
+
+func TestGedcomDate_CrossCalendarComparison(t *testing.T) {
+	julian, _ := ParseDate("@#DJULIAN@ 10 FEB 1700")
+	gregorian, _ := ParseDate("15 FEB 1700")
+
+	// Julian 10 FEB 1700 is Gregorian 20 FEB 1700, after 15 FEB
+	if julian.Compare(gregorian) != 1 {
+		t.Errorf("Compare() = %d, want 1", julian.Compare(gregorian))
+	}
+	if !julian.IsAfter(gregorian) {
+		t.Error("IsAfter() = false, want true")
+	}
+
+	days := julian.Sub(gregorian).Duration / (24 * time.Hour)
+	if days != 5 {
+		t.Errorf("Sub() = %d days, want 5", days)
+	}
+
+	// Partial dates cover their whole month or year
+	hebrewYear, _ := ParseDate("@#DHEBREW@ 5785")
+	if got := hebrewYear.Earliest(); !got.Equal(time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Earliest() = %v", got)
+	}
+	if got := hebrewYear.Latest(); !got.Equal(time.Date(2025, 9, 22, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("Latest() = %v", got)
+	}
+
+	feb, _ := ParseDate("FEB 1900")
+	if got := feb.Latest(); !got.Equal(time.Date(1900, 2, 28, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("Latest() = %v", got)
+	}
+}
@@ -22,6 +22,13 @@ const (
 	DateTypeTo      DateType = "TO"
 	DateTypeFromTo  DateType = "FROM_TO"
 	DateTypeUnknown DateType = "UNKNOWN"
+
+	// DateTypeInterpreted is an "INT <date> (<phrase>)" date: a date value
+	// interpreted from the phrase, which is kept in GedcomDate.Phrase.
+	DateTypeInterpreted DateType = "INTERPRETED"
+
+	// DateTypePhrase is a "(<phrase>)" date: free text with no date value.
+	DateTypePhrase DateType = "PHRASE"
 )
 
 // DateConstraint describes if a date is constrained by a particular range.
@@ -64,20 +71,90 @@ const (
 	CalendarJulian    Calendar = "JULIAN"
 	CalendarHebrew    Calendar = "HEBREW"
 	CalendarFrench    Calendar = "FRENCH"
+	CalendarRoman     Calendar = "ROMAN"
 	CalendarUnknown   Calendar = "UNKNOWN"
 )
 
+// calendarEscapes maps GEDCOM 5.5.1 calendar escapes, as normalized by
+// normalizeCalendarEscapes, to calendars. An escape may precede each date,
+// e.g. "@#DJULIAN@ 15 MAR 1700". GEDCOM leaves the Roman calendar undefined;
+// its dates are read with Julian month names and reckoning.
+var calendarEscapes = map[string]Calendar{
+	"@#dgregorian@": CalendarGregorian,
+	"@#djulian@":    CalendarJulian,
+	"@#dhebrew@":    CalendarHebrew,
+	"@#dfrench_r@":  CalendarFrench,
+	"@#droman@":     CalendarRoman,
+	"@#dunknown@":   CalendarUnknown,
+}
+
+// calendarEscapePattern matches a calendar escape, including the space in "@#DFRENCH R@".
+var calendarEscapePattern = regexp.MustCompile(`(?i)@#d[a-z]+(?: r)?@`)
+
+// normalizeCalendarEscapes lowercases the calendar escapes in dateStr and
+// replaces their inner space, so each escape is a single field.
+func normalizeCalendarEscapes(dateStr string) string {
+	return calendarEscapePattern.ReplaceAllStringFunc(dateStr, func(escape string) string {
+		return strings.ReplaceAll(strings.ToLower(escape), " ", "_")
+	})
+}
+
+// calendarEscape returns the GEDCOM 5.5.1 escape for a calendar.
+func calendarEscape(calendar Calendar) string {
+	if calendar == CalendarFrench {
+		return "@#DFRENCH R@"
+	}
+	return "@#D" + string(calendar) + "@"
+}
+
+// calendarKeywords maps GEDCOM 7.0 calendar keywords to calendars. A 7.0 date
+// may start with one of these, e.g. "JULIAN 15 MAR 1700".
+var calendarKeywords = map[string]Calendar{
+	"gregorian": CalendarGregorian,
+	"julian":    CalendarJulian,
+	"hebrew":    CalendarHebrew,
+	"french_r":  CalendarFrench,
+}
+
+// calendarKeyword returns the GEDCOM 7.0 keyword for a calendar.
+func calendarKeyword(calendar Calendar) string {
+	if calendar == CalendarFrench {
+		return "FRENCH_R"
+	}
+	return string(calendar)
+}
+
+// epochBCE is the GEDCOM 7.0 epoch marker for years before the common era.
+// BCE years are stored as negative Year values ("44 BCE" has Year -44).
+const epochBCE = "bce"
+
+// epochBC is the GEDCOM 5.5.1 epoch marker. "BC" and "B.C" are accepted too.
+const epochBC = "b.c."
+
+// bceEpochs lists the accepted (lowercased) epoch markers for BCE years.
+var bceEpochs = map[string]bool{epochBCE: true, epochBC: true, "bc": true, "b.c": true}
+
 // GedcomDate represents a parsed GEDCOM date with structured components.
 type GedcomDate struct {
 	Original string   // Original GEDCOM date string
 	Type     DateType // EXACT, ABOUT, BEFORE, AFTER, BETWEEN, etc.
 	Calendar Calendar // GREGORIAN, JULIAN, HEBREW, etc.
 
+	// EndCalendar is the calendar of a range end that names a calendar
+	// different from the start. Empty means the end uses Calendar.
+	EndCalendar Calendar
+
 	// Exact date components
 	Year  int
 	Month int
 	Day   int
 
+	// DualYear is set for dual-dated years such as "1750/51" (Old Style /
+	// New Style). Year (or StartYear) then holds the later year, 1751.
+	// EndDualYear is the same for EndYear.
+	DualYear    bool
+	EndDualYear bool
+
 	// Range date components
 	StartYear  int
 	StartMonth int
@@ -86,9 +163,18 @@ type GedcomDate struct {
 	EndMonth   int
 	EndDay     int
 
+	// Phrase is the text of an interpreted or phrase-only date, without the
+	// parentheses. On other dates it holds a GEDCOM 7.0 DATE.PHRASE, which
+	// String leaves out (see ParseDateWithPhrase).
+	Phrase string
+
 	// Parsed status
 	IsParsed   bool
 	ParseError error
+
+	// gedcom5 is set when the date used GEDCOM 5.5.1 calendar escapes or
+	// B.C., so String writes it back the same way.
+	gedcom5 bool
 }
 
 var (
@@ -102,6 +188,23 @@ var (
 		"nov": 11, "november": 11, "dec": 12, "december": 12,
 	}
 
+	// Hebrew calendar month codes (Tishrei = 1 ... Elul = 13; ADS is Adar II)
+	hebrewMonthMap = map[string]int{
+		"tsh": 1, "csh": 2, "ksl": 3, "tvt": 4, "shv": 5, "adr": 6,
+		"ads": 7, "nsn": 8, "iyr": 9, "svn": 10, "tmz": 11, "aav": 12, "ell": 13,
+	}
+
+	// French Republican calendar month codes (COMP are the complementary days)
+	frenchMonthMap = map[string]int{
+		"vend": 1, "brum": 2, "frim": 3, "nivo": 4, "pluv": 5, "vent": 6,
+		"germ": 7, "flor": 8, "prai": 9, "mess": 10, "ther": 11, "fruc": 12, "comp": 13,
+	}
+
+	// Month codes used when formatting, indexed by month number
+	gregorianMonthNames = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	hebrewMonthNames    = []string{"", "TSH", "CSH", "KSL", "TVT", "SHV", "ADR", "ADS", "NSN", "IYR", "SVN", "TMZ", "AAV", "ELL"}
+	frenchMonthNames    = []string{"", "VEND", "BRUM", "FRIM", "NIVO", "PLUV", "VENT", "GERM", "FLOR", "PRAI", "MESS", "THER", "FRUC", "COMP"}
+
 	// Date type prefixes (case-insensitive, with variations)
 	dateTypePrefixes = map[string]DateType{
 		"abt": DateTypeAbout, "abt.": DateTypeAbout, "about": DateTypeAbout,
@@ -114,15 +217,19 @@ var (
 		"to": DateTypeTo,
 	}
 
-	// Patterns for date parsing (case-insensitive)
-	exactDatePattern = regexp.MustCompile(`(?i)^(\d{1,2})\s+(\w+)\s+(\d{1,4})$`)
-	monthYearPattern = regexp.MustCompile(`(?i)^(\w+)\s+(\d{1,4})$`)
-	yearOnlyPattern  = regexp.MustCompile(`^\d{1,4}$`)
+	// Patterns for date parsing (case-insensitive); years may be dual ("1750/51")
+	exactDatePattern = regexp.MustCompile(`(?i)^(\d{1,2})\s+(\w+)\s+(\d{1,4}(?:/\d{2})?)$`)
+	monthYearPattern = regexp.MustCompile(`(?i)^(\w+)\s+(\d{1,4}(?:/\d{2})?)$`)
+	yearOnlyPattern  = regexp.MustCompile(`^\d{1,4}(?:/\d{2})?$`)
 	betweenPattern   = regexp.MustCompile(`(?i)^(bet|bet\.|between|from)\s+(.+?)\s+(and|to|-)\s+(.+)$`)
 	fromToPattern    = regexp.MustCompile(`(?i)^from\s+(.+?)\s+to\s+(.+)$`)
 	
 	// Enhanced between pattern that handles "BET X AND Y" format
 	betweenPatternEnhanced = regexp.MustCompile(`(?i)^(?:bet|bet\.|between|from)\s+(.+?)\s+(?:and|to|-)\s+(.+)$`)
+
+	// Interpreted dates ("INT 1850 (phrase)") and phrases ("(phrase)")
+	interpretedPattern = regexp.MustCompile(`(?is)^int\s+([^(]+?)\s*(?:\((.*)\))?$`)
+	phrasePattern      = regexp.MustCompile(`(?s)^\((.*)\)$`)
 )
 
 // ParseDate parses a GEDCOM date string and returns a GedcomDate.
@@ -135,7 +242,26 @@ var (
 //   - "AFT 1900" (after)
 //   - "BET 1800 AND 1850" (between)
 //   - "FROM 1800 TO 1850" (range)
+//   - "JULIAN 1 MAR 1700", "HEBREW 1 TSH 5600" (GEDCOM 7.0 calendar keyword)
+//   - "@#DJULIAN@ 1 MAR 1700", "@#DFRENCH R@ 18 BRUM 8" (GEDCOM 5.5.1 calendar escape)
+//   - "44 BCE", "44 B.C." (epoch; stored as a negative year)
+//   - "11 FEB 1750/51" (dual year; stored as the later year)
+//   - "INT 1850 (about the time of the war)" (interpreted date with its phrase)
+//   - "(about the time of the war)" (phrase only; parsed, but has no date value)
+//   - "12 juin 1820", "um 3. Mai 1799", "omstreeks 1800" (registered locales, see DateLocale)
+//
+// Calendars other than Gregorian use their own month names (TSH..ELL for
+// Hebrew, VEND..COMP for French Republican). In a range, an end date without
+// its own calendar uses the calendar of the start date.
 func ParseDate(dateStr string) (*GedcomDate, error) {
+	return ParseDateWithLocale(dateStr, nil)
+}
+
+// ParseDateWithLocale is like ParseDate with locale selected, so that its
+// FROM/TO words and filler words are understood too (see
+// NormalizeDateWithLocale). Record accessors such as
+// IndividualRecord.GetBirthDateParsed use the tree's DateLocale.
+func ParseDateWithLocale(dateStr string, locale *DateLocale) (*GedcomDate, error) {
 	if dateStr == "" {
 		return nil, fmt.Errorf("empty date string")
 	}
@@ -147,11 +273,37 @@ func ParseDate(dateStr string) (*GedcomDate, error) {
 		ParseError: nil,
 	}
 
-	// Normalize to lowercase for case-insensitive matching
-	normalizedDate := strings.ToLower(strings.TrimSpace(dateStr))
+	// Phrase-only and interpreted dates keep the phrase text as written
+	if matches := phrasePattern.FindStringSubmatch(date.Original); matches != nil {
+		date.Type = DateTypePhrase
+		date.Phrase = strings.TrimSpace(matches[1])
+		date.IsParsed = true
+		return date, nil
+	}
+	if matches := interpretedPattern.FindStringSubmatch(date.Original); matches != nil {
+		date.Type = DateTypeInterpreted
+		date.Phrase = strings.TrimSpace(matches[2])
+		if err := parseSingleDate(date, NormalizeDateWithLocale(matches[1], locale)); err != nil {
+			date.ParseError = err
+			return date, err
+		}
+		date.IsParsed = true
+		return date, nil
+	}
+
+	// Rewrite month names and qualifiers of other languages (see NormalizeDate)
+	// and normalize to lowercase for case-insensitive matching
+	normalizedDate := strings.ToLower(normalizeCalendarEscapes(NormalizeDateWithLocale(dateStr, locale)))
 
 	// Check for date type prefixes (case-insensitive)
 	parts := strings.Fields(normalizedDate)
+	if len(parts) > 2 && isCalendarMarker(parts[0]) {
+		// Some programs write the calendar before the prefix ("@#DJULIAN@ ABT 1700")
+		if _, ok := dateTypePrefixes[parts[1]]; ok {
+			parts[0], parts[1] = parts[1], parts[0]
+			normalizedDate = strings.Join(parts, " ")
+		}
+	}
 	if len(parts) > 0 {
 		// Try exact match first
 		if dateType, ok := dateTypePrefixes[parts[0]]; ok {
@@ -191,61 +343,165 @@ func ParseDate(dateStr string) (*GedcomDate, error) {
 }
 
 // parseSingleDate parses a single date (exact, about, before, after, or year-only).
+// A leading GEDCOM 7.0 calendar keyword sets date.Calendar and a trailing BCE
+// epoch makes the year negative.
 func parseSingleDate(date *GedcomDate, dateStr string) error {
 	// Normalize to lowercase for case-insensitive matching
 	dateStr = strings.ToLower(strings.TrimSpace(dateStr))
 
+	calendar, dateStr, bce, gedcom5 := splitCalendarAndEpoch(normalizeCalendarEscapes(dateStr))
+	if calendar != "" {
+		date.Calendar = calendar
+	}
+	if date.Calendar == "" {
+		date.Calendar = CalendarGregorian
+	}
+	date.gedcom5 = date.gedcom5 || gedcom5
+	sign := 1
+	if bce {
+		sign = -1
+	}
+
 	// Try exact date: "15 jan 1800" or "15 JAN 1800"
 	if matches := exactDatePattern.FindStringSubmatch(dateStr); matches != nil {
 		day, _ := strconv.Atoi(matches[1])
 		monthStr := strings.ToLower(matches[2])
-		year, _ := strconv.Atoi(matches[3])
+		year, dual, err := parseYear(date.Calendar, matches[3])
+		if err != nil {
+			return err
+		}
 
-		month, ok := monthMap[monthStr]
+		month, ok := lookupMonth(date.Calendar, monthStr)
 		if !ok {
 			return fmt.Errorf("invalid month: %s", monthStr)
 		}
 
 		// Validate the date
-		_, err := time.Parse("2006-01-02", fmt.Sprintf("%04d-%02d-%02d", year, month, day))
-		if err != nil {
-			return fmt.Errorf("invalid date: %d %s %d", day, monthStr, year)
+		if !validDay(date.Calendar, sign*year, month, day) {
+			return fmt.Errorf("invalid date: %d %s %s", day, monthStr, matches[3])
 		}
 
 		date.Day = day
 		date.Month = month
-		date.Year = year
+		date.Year = sign * year
+		date.DualYear = dual
 		return nil
 	}
 
 	// Try month-year: "JAN 1800" or "january 1800"
 	if matches := monthYearPattern.FindStringSubmatch(dateStr); matches != nil {
 		monthStr := strings.ToLower(matches[1])
-		year, _ := strconv.Atoi(matches[2])
+		year, dual, err := parseYear(date.Calendar, matches[2])
+		if err != nil {
+			return err
+		}
 
-		month, ok := monthMap[monthStr]
-		if !ok {
+		month, ok := lookupMonth(date.Calendar, monthStr)
+		if !ok || daysInMonth(date.Calendar, sign*year, month) == 0 {
 			return fmt.Errorf("invalid month: %s", monthStr)
 		}
 
 		date.Month = month
-		date.Year = year
+		date.Year = sign * year
+		date.DualYear = dual
 		return nil
 	}
 
 	// Try year only: "1800"
 	if yearOnlyPattern.MatchString(dateStr) {
-		year, _ := strconv.Atoi(dateStr)
+		year, dual, err := parseYear(date.Calendar, dateStr)
+		if err != nil {
+			return err
+		}
 		if year < 0 || year > 9999 {
 			return fmt.Errorf("year out of range: %d", year)
 		}
-		date.Year = year
+		date.Year = sign * year
+		date.DualYear = dual
 		return nil
 	}
 
 	return fmt.Errorf("unable to parse date: %s", dateStr)
 }
 
+// isCalendarMarker reports whether a lowercased field is a calendar escape or
+// a GEDCOM 7.0 calendar keyword.
+func isCalendarMarker(field string) bool {
+	if _, ok := calendarEscapes[field]; ok {
+		return true
+	}
+	_, ok := calendarKeywords[field]
+	return ok
+}
+
+// splitCalendarAndEpoch strips a leading calendar escape or GEDCOM 7.0
+// calendar keyword and a trailing BCE epoch from a lowercased date string.
+// gedcom5 reports whether GEDCOM 5.5.1 syntax (an escape or B.C.) was used.
+func splitCalendarAndEpoch(dateStr string) (calendar Calendar, rest string, bce bool, gedcom5 bool) {
+	parts := strings.Fields(dateStr)
+	if len(parts) > 1 {
+		if c, ok := calendarEscapes[parts[0]]; ok {
+			calendar = c
+			gedcom5 = true
+			parts = parts[1:]
+		} else if c, ok := calendarKeywords[parts[0]]; ok {
+			calendar = c
+			parts = parts[1:]
+		}
+	}
+
+	if len(parts) > 1 && bceEpochs[parts[len(parts)-1]] {
+		bce = true
+		gedcom5 = gedcom5 || parts[len(parts)-1] != epochBCE
+		parts = parts[:len(parts)-1]
+	}
+	return calendar, strings.Join(parts, " "), bce, gedcom5
+}
+
+// parseYear parses a year, which may be dual-dated ("1750/51"). A dual year
+// returns the later year; its two digits must follow the first year. Only
+// the Gregorian and Julian calendars have dual years.
+func parseYear(calendar Calendar, yearStr string) (int, bool, error) {
+	first, second, dual := strings.Cut(yearStr, "/")
+	year, err := strconv.Atoi(first)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid year: %s", yearStr)
+	}
+	if !dual {
+		return year, false, nil
+	}
+
+	if calendar == CalendarHebrew || calendar == CalendarFrench {
+		return 0, false, fmt.Errorf("dual year not allowed in %s calendar: %s", calendar, yearStr)
+	}
+	next, err := strconv.Atoi(second)
+	if err != nil || next != (year+1)%100 {
+		return 0, false, fmt.Errorf("invalid dual year: %s", yearStr)
+	}
+	return year + 1, true, nil
+}
+
+// lookupMonth resolves a lowercased month code in the given calendar.
+func lookupMonth(calendar Calendar, monthStr string) (int, bool) {
+	var month int
+	var ok bool
+	switch calendar {
+	case CalendarHebrew:
+		month, ok = hebrewMonthMap[monthStr]
+	case CalendarFrench:
+		month, ok = frenchMonthMap[monthStr]
+	default:
+		month, ok = monthMap[monthStr]
+	}
+	return month, ok
+}
+
+// validDay reports whether day exists in month of year for the calendar,
+// taking leap years and Hebrew year lengths into account.
+func validDay(calendar Calendar, year, month, day int) bool {
+	return day >= 1 && day <= daysInMonth(calendar, year, month)
+}
+
 // parseBetweenDate parses a "BET X AND Y" date (case-insensitive).
 func parseBetweenDate(date *GedcomDate, dateStr string) error {
 	// Use enhanced pattern that captures only the dates, not the keywords
@@ -278,20 +534,34 @@ func parseBetweenDates(date *GedcomDate, startStr, endStr string) error {
 	if err := parseSingleDate(startDate, startStr); err != nil {
 		return fmt.Errorf("invalid start date in BETWEEN: %w", err)
 	}
+
+	// Parse end date, in the start calendar unless it names its own
+	endDate := &GedcomDate{Calendar: startDate.Calendar}
+	if err := parseSingleDate(endDate, endStr); err != nil {
+		return fmt.Errorf("invalid end date in BETWEEN: %w", err)
+	}
+
+	setRangeBounds(date, startDate, endDate)
+	return nil
+}
+
+// setRangeBounds copies parsed start and end dates into the range date.
+func setRangeBounds(date, startDate, endDate *GedcomDate) {
+	date.Calendar = startDate.Calendar
 	date.StartYear = startDate.Year
 	date.StartMonth = startDate.Month
 	date.StartDay = startDate.Day
+	date.DualYear = startDate.DualYear
 
-	// Parse end date
-	endDate := &GedcomDate{}
-	if err := parseSingleDate(endDate, endStr); err != nil {
-		return fmt.Errorf("invalid end date in BETWEEN: %w", err)
+	if endDate.Calendar != startDate.Calendar {
+		date.EndCalendar = endDate.Calendar
 	}
 	date.EndYear = endDate.Year
 	date.EndMonth = endDate.Month
 	date.EndDay = endDate.Day
+	date.EndDualYear = endDate.DualYear
 
-	return nil
+	date.gedcom5 = startDate.gedcom5 || endDate.gedcom5
 }
 
 // parseFromToDate parses a "FROM X TO Y" date (case-insensitive).
@@ -311,25 +581,63 @@ func parseFromToDate(date *GedcomDate, dateStr string) error {
 	if err := parseSingleDate(startDate, startStr); err != nil {
 		return fmt.Errorf("invalid start date in FROM-TO: %w", err)
 	}
-	date.StartYear = startDate.Year
-	date.StartMonth = startDate.Month
-	date.StartDay = startDate.Day
 
-	// Parse end date
-	endDate := &GedcomDate{}
+	// Parse end date, in the start calendar unless it names its own
+	endDate := &GedcomDate{Calendar: startDate.Calendar}
 	if err := parseSingleDate(endDate, endStr); err != nil {
 		return fmt.Errorf("invalid end date in FROM-TO: %w", err)
 	}
-	date.EndYear = endDate.Year
-	date.EndMonth = endDate.Month
-	date.EndDay = endDate.Day
 
+	setRangeBounds(date, startDate, endDate)
 	return nil
 }
 
-// IsValid returns true if the date was successfully parsed.
+// ParseDateWithPhrase parses a GEDCOM 7.0 DATE value together with its
+// PHRASE substructure. The date keeps its own type and carries the phrase in
+// Phrase, so String writes the DATE value alone and the phrase stays on its
+// PHRASE line. A phrase with an empty date value gives a phrase-only date.
+func ParseDateWithPhrase(dateStr, phrase string) (*GedcomDate, error) {
+	return parseDateWithPhrase(dateStr, phrase, nil)
+}
+
+// parseDateWithPhrase is ParseDateWithPhrase with a selected locale.
+func parseDateWithPhrase(dateStr, phrase string, locale *DateLocale) (*GedcomDate, error) {
+	phrase = strings.TrimSpace(phrase)
+	if phrase == "" {
+		return ParseDateWithLocale(dateStr, locale)
+	}
+	if strings.TrimSpace(dateStr) == "" {
+		return &GedcomDate{
+			Original: "(" + phrase + ")",
+			Type:     DateTypePhrase,
+			Calendar: CalendarGregorian,
+			Phrase:   phrase,
+			IsParsed: true,
+		}, nil
+	}
+
+	date, err := ParseDateWithLocale(dateStr, locale)
+	if date != nil {
+		date.Phrase = phrase
+	}
+	return date, err
+}
+
+// IsValid returns true if the date was successfully parsed and has a date
+// value. Phrase-only dates are parsed but have no value, so they are not
+// valid for comparisons; use IsPhrase to detect them.
 func (gd *GedcomDate) IsValid() bool {
-	return gd.IsParsed && gd.ParseError == nil
+	return gd.IsParsed && gd.ParseError == nil && gd.Type != DateTypePhrase
+}
+
+// IsPhrase returns true if the date is a phrase with no date value.
+func (gd *GedcomDate) IsPhrase() bool {
+	return gd.IsParsed && gd.Type == DateTypePhrase
+}
+
+// IsInterpreted returns true if the date is a value interpreted from a phrase.
+func (gd *GedcomDate) IsInterpreted() bool {
+	return gd.IsValid() && gd.Type == DateTypeInterpreted
 }
 
 // IsRange returns true if this is a range date (BETWEEN or FROM-TO).
@@ -337,158 +645,154 @@ func (gd *GedcomDate) IsRange() bool {
 	return gd.Type == DateTypeBetween || gd.Type == DateTypeFromTo
 }
 
-// ToTime converts the date to a time.Time.
-// For range dates, returns the start date.
+// ToTime converts the date to a time.Time in the (proleptic) Gregorian calendar.
+// Dates in other calendars are converted through their Julian Day Number.
+// For range dates, returns the start date; partial dates return their first day.
 // Returns error if date is invalid or cannot be converted.
 func (gd *GedcomDate) ToTime() (time.Time, error) {
 	if !gd.IsValid() {
 		return time.Time{}, fmt.Errorf("invalid date: %v", gd.ParseError)
 	}
 
-	if gd.IsRange() {
-		// Default to January 1st if month/day not specified
-		month := gd.StartMonth
-		if month == 0 {
-			month = 1
-		}
-		day := gd.StartDay
-		if day == 0 {
-			day = 1
-		}
-		return time.Date(gd.StartYear, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
-	}
-
-	// Default to January 1st if month/day not specified
-	month := gd.Month
-	if month == 0 {
-		month = 1
-	}
-	day := gd.Day
-	if day == 0 {
-		day = 1
-	}
-
-	return time.Date(gd.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return jdnToTime(gd.JDN()), nil
 }
 
 // ToISO8601 converts the date to ISO 8601 format (YYYY-MM-DD).
 // For range dates, returns the start date.
+// BCE years use astronomical numbering, which has a year 0: "44 BCE" is
+// "-0043".
+// Dates in other calendars are converted to Gregorian; partial dates use
+// their first day, at the same precision.
 // Returns empty string if date is invalid.
 func (gd *GedcomDate) ToISO8601() string {
 	if !gd.IsValid() {
 		return ""
 	}
 
+	year, month, day := gd.Year, gd.Month, gd.Day
 	if gd.IsRange() {
-		return formatISO8601(gd.StartYear, gd.StartMonth, gd.StartDay)
+		year, month, day = gd.StartYear, gd.StartMonth, gd.StartDay
 	}
 
-	return formatISO8601(gd.Year, gd.Month, gd.Day)
-}
-
-// formatISO8601 formats year, month, day as ISO 8601.
-func formatISO8601(year, month, day int) string {
 	if year == 0 {
 		return ""
 	}
+	if gd.calendar() == CalendarGregorian {
+		year = astronomicalYear(year)
+	} else {
+		// time.Time years are astronomical already
+		t := jdnToTime(gd.JDN())
+		year = t.Year()
+		if month != 0 {
+			month = int(t.Month())
+		}
+		if day != 0 {
+			day = t.Day()
+		}
+	}
 
+	return formatISO8601(year, month, day)
+}
+
+// formatISO8601 formats year, month, day as ISO 8601. The year is
+// astronomical and has at least four digits, after the sign if negative.
+func formatISO8601(year, month, day int) string {
+	yearStr := fmt.Sprintf("%04d", year)
+	if year < 0 {
+		yearStr = fmt.Sprintf("%05d", year)
+	}
 	monthStr := fmt.Sprintf("%02d", month)
 	dayStr := fmt.Sprintf("%02d", day)
 
 	if month == 0 {
-		return fmt.Sprintf("%04d", year)
+		return yearStr
 	}
 	if day == 0 {
-		return fmt.Sprintf("%04d-%s", year, monthStr)
+		return fmt.Sprintf("%s-%s", yearStr, monthStr)
 	}
 
-	return fmt.Sprintf("%04d-%s-%s", year, monthStr, dayStr)
+	return fmt.Sprintf("%s-%s-%s", yearStr, monthStr, dayStr)
 }
 
-// Earliest returns the earliest possible time for this date.
+// Earliest returns the earliest possible time for this date, in the
+// (proleptic) Gregorian calendar. Dates in other calendars are converted
+// through their Julian Day Number, so the result can be compared across calendars.
 func (gd *GedcomDate) Earliest() time.Time {
 	if !gd.IsValid() {
 		return time.Time{}
 	}
 
 	if gd.IsRange() {
-		month := gd.StartMonth
-		if month == 0 {
-			month = 1
-		}
-		day := gd.StartDay
-		if day == 0 {
-			day = 1
-		}
-		return time.Date(gd.StartYear, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		first, _ := jdnBounds(gd.calendar(), gd.StartYear, gd.StartMonth, gd.StartDay)
+		return jdnToTime(first)
 	}
 
-	month := gd.Month
-	if month == 0 {
-		month = 1
-	}
-	day := gd.Day
-	if day == 0 {
-		day = 1
-	}
+	first, _ := jdnBounds(gd.calendar(), gd.Year, gd.Month, gd.Day)
+	earliest := jdnToTime(first)
 
 	// Adjust for date types
 	switch gd.Type {
 	case DateTypeBefore:
 		// Before: use earliest possible (year 1)
-		return time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
-	case DateTypeAfter:
-		// After: use the date itself
-		return time.Date(gd.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-	case DateTypeAbout:
-		// About: use the date itself (could subtract years for range, but keeping simple)
-		return time.Date(gd.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		minimum := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+		if earliest.Before(minimum) {
+			return earliest
+		}
+		return minimum
 	default:
-		return time.Date(gd.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		// After, About and exact dates use the date itself
+		return earliest
 	}
 }
 
-// Latest returns the latest possible time for this date.
+// endOfDay is added to midnight to get the latest time of a day.
+const endOfDay = 23*time.Hour + 59*time.Minute + 59*time.Second
+
+// Latest returns the latest possible time for this date, in the (proleptic)
+// Gregorian calendar. Partial dates end on the last day of their month or year.
 func (gd *GedcomDate) Latest() time.Time {
 	if !gd.IsValid() {
 		return time.Time{}
 	}
 
 	if gd.IsRange() {
-		month := gd.EndMonth
-		if month == 0 {
-			month = 12
-		}
-		day := gd.EndDay
-		if day == 0 {
-			day = 31 // Last day of month (approximate)
-		}
-		return time.Date(gd.EndYear, time.Month(month), day, 23, 59, 59, 0, time.UTC)
+		_, last := jdnBounds(gd.endCalendar(), gd.EndYear, gd.EndMonth, gd.EndDay)
+		return jdnToTime(last).Add(endOfDay)
 	}
 
-	month := gd.Month
-	if month == 0 {
-		month = 12
-	}
-	day := gd.Day
-	if day == 0 {
-		day = 31 // Last day of month (approximate)
-	}
+	_, last := jdnBounds(gd.calendar(), gd.Year, gd.Month, gd.Day)
+	latest := jdnToTime(last).Add(endOfDay)
 
 	// Adjust for date types
 	switch gd.Type {
-	case DateTypeBefore:
-		// Before: use the date itself
-		return time.Date(gd.Year, time.Month(month), day, 23, 59, 59, 0, time.UTC)
 	case DateTypeAfter:
 		// After: use latest possible (year 9999)
-		return time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
-	case DateTypeAbout:
-		// About: use the date itself (could add years for range, but keeping simple)
-		return time.Date(gd.Year, time.Month(month), day, 23, 59, 59, 0, time.UTC)
+		maximum := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+		if latest.After(maximum) {
+			return latest
+		}
+		return maximum
 	default:
-		return time.Date(gd.Year, time.Month(month), day, 23, 59, 59, 0, time.UTC)
+		// Before, About and exact dates use the date itself
+		return latest
+	}
+}
+
+// calendar returns the calendar used to convert the date (or range start).
+// Unset and unknown calendars are treated as Gregorian.
+func (gd *GedcomDate) calendar() Calendar {
+	if gd.Calendar == "" || gd.Calendar == CalendarUnknown {
+		return CalendarGregorian
+	}
+	return gd.Calendar
+}
+
+// endCalendar returns the calendar used to convert the range end.
+func (gd *GedcomDate) endCalendar() Calendar {
+	if gd.EndCalendar == "" || gd.EndCalendar == CalendarUnknown {
+		return gd.calendar()
 	}
+	return gd.EndCalendar
 }
 
 // Compare compares two dates. Returns:
@@ -496,7 +800,8 @@ func (gd *GedcomDate) Latest() time.Time {
 //   - 0 if dates are equal
 //   - 1 if this date is after other
 //
-// Uses earliest time for comparison.
+// Uses earliest time for comparison, so dates in different calendars compare
+// by the day they denote.
 func (gd *GedcomDate) Compare(other *GedcomDate) int {
 	if !gd.IsValid() || !other.IsValid() {
 		return 0
@@ -515,21 +820,40 @@ func (gd *GedcomDate) Compare(other *GedcomDate) int {
 }
 
 // String returns a string representation of the date.
+// Dates parsed from GEDCOM 5.5.1 calendar escapes or B.C. are written the
+// same way; other non-Gregorian dates use GEDCOM 7.0 calendar keywords.
 func (gd *GedcomDate) String() string {
+	if gd.IsPhrase() {
+		return "(" + gd.Phrase + ")"
+	}
 	if !gd.IsValid() {
 		return gd.Original
 	}
 
 	if gd.IsRange() {
-		start := formatDateComponents(gd.StartYear, gd.StartMonth, gd.StartDay)
-		end := formatDateComponents(gd.EndYear, gd.EndMonth, gd.EndDay)
+		start := gd.formatDate(gd.Calendar, gd.StartYear, gd.StartMonth, gd.StartDay, gd.DualYear)
+		endCalendar := gd.EndCalendar
+		if endCalendar == "" {
+			endCalendar = gd.Calendar
+		}
+		end := gd.formatDate(endCalendar, gd.EndYear, gd.EndMonth, gd.EndDay, gd.EndDualYear)
+		if endCalendar == CalendarGregorian && gd.Calendar != "" && gd.Calendar != CalendarGregorian {
+			// Without its calendar, the end would be read in the start's
+			end = gd.calendarMark(CalendarGregorian) + " " + end
+		}
 		if gd.Type == DateTypeBetween {
 			return fmt.Sprintf("BET %s AND %s", start, end)
 		}
 		return fmt.Sprintf("FROM %s TO %s", start, end)
 	}
 
-	dateStr := formatDateComponents(gd.Year, gd.Month, gd.Day)
+	dateStr := gd.formatDate(gd.Calendar, gd.Year, gd.Month, gd.Day, gd.DualYear)
+	if gd.Type == DateTypeInterpreted {
+		if gd.Phrase == "" {
+			return "INT " + dateStr
+		}
+		return fmt.Sprintf("INT %s (%s)", dateStr, gd.Phrase)
+	}
 	if gd.Type != DateTypeExact {
 		return fmt.Sprintf("%s %s", gd.Type, dateStr)
 	}
@@ -537,24 +861,86 @@ func (gd *GedcomDate) String() string {
 	return dateStr
 }
 
+// formatDate formats one date of gd in calendar, prefixing the calendar
+// escape or GEDCOM 7.0 keyword for non-Gregorian calendars.
+func (gd *GedcomDate) formatDate(calendar Calendar, year, month, day int, dual bool) string {
+	if year == 0 {
+		return ""
+	}
+
+	formatted := formatCalendarParts(calendar, formatYear(year, dual, gd.gedcom5), month, day)
+	if calendar == "" || calendar == CalendarGregorian {
+		return formatted
+	}
+	return gd.calendarMark(calendar) + " " + formatted
+}
+
+// calendarMark returns the 5.5.1 calendar escape or 7.0 keyword that names
+// calendar in gd's style.
+func (gd *GedcomDate) calendarMark(calendar Calendar) string {
+	if gd.gedcom5 || calendar == CalendarRoman || calendar == CalendarUnknown {
+		// The Roman and unknown calendars only exist as 5.5.1 escapes
+		return calendarEscape(calendar)
+	}
+	return calendarKeyword(calendar)
+}
+
 // formatDateComponents formats year, month, day as GEDCOM date string.
 func formatDateComponents(year, month, day int) string {
+	return formatCalendarDate(CalendarGregorian, year, month, day)
+}
+
+// formatCalendarDate formats year, month, day using the month codes of the
+// given calendar. Negative years are written with the BCE epoch.
+func formatCalendarDate(calendar Calendar, year, month, day int) string {
 	if year == 0 {
 		return ""
 	}
+	return formatCalendarParts(calendar, formatYear(year, false, false), month, day)
+}
+
+// formatYear formats a year for GEDCOM. Negative years get the BCE epoch
+// ("B.C." in GEDCOM 5.5.1 style) and dual years are written as "1750/51".
+func formatYear(year int, dual bool, gedcom5 bool) string {
+	epoch := ""
+	if year < 0 {
+		year = -year
+		epoch = " BCE"
+		if gedcom5 {
+			epoch = " B.C."
+		}
+	}
+
+	if dual {
+		return fmt.Sprintf("%d/%02d%s", year-1, year%100, epoch)
+	}
+	return fmt.Sprintf("%d%s", year, epoch)
+}
 
+// formatCalendarParts formats a date with an already formatted year using
+// the month codes of the given calendar.
+func formatCalendarParts(calendar Calendar, yearStr string, month, day int) string {
 	if month == 0 {
-		return fmt.Sprintf("%d", year)
+		return yearStr
 	}
 
-	monthNames := []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
-	monthStr := monthNames[month]
+	monthNames := gregorianMonthNames
+	switch calendar {
+	case CalendarHebrew:
+		monthNames = hebrewMonthNames
+	case CalendarFrench:
+		monthNames = frenchMonthNames
+	}
+	monthStr := ""
+	if month < len(monthNames) {
+		monthStr = monthNames[month]
+	}
 
 	if day == 0 {
-		return fmt.Sprintf("%s %d", monthStr, year)
+		return fmt.Sprintf("%s %s", monthStr, yearStr)
 	}
 
-	return fmt.Sprintf("%d %s %d", day, monthStr, year)
+	return fmt.Sprintf("%d %s %s", day, monthStr, yearStr)
 }
 
 // DateConstraintFromString returns the constraint for the provided keyword.
@@ -577,6 +963,16 @@ func DateConstraintFromString(word string) DateConstraint {
 		return DateConstraintBefore
 	}
 
+	// Qualifiers of the registered locales ("vers", "vor", "omstreeks", ...)
+	switch NormalizeDate(lowerWord) {
+	case "ABT":
+		return DateConstraintAbout
+	case "AFT":
+		return DateConstraintAfter
+	case "BEF":
+		return DateConstraintBefore
+	}
+
 	return DateConstraintExact
 }
 
@@ -649,6 +1045,15 @@ func (gd *GedcomDate) getSingleDateYears() float64 {
 	hasMonth := gd.Month != 0
 	hasYear := gd.Year != 0
 
+	if hasYear && (gd.calendar() != CalendarGregorian || gd.Year < 0) {
+		// Other calendars and BCE years are converted through the Julian Day Number
+		first, last := jdnBounds(gd.calendar(), gd.Year, gd.Month, gd.Day)
+		if hasDay && hasMonth {
+			return yearsAt(first)
+		}
+		return (yearsAt(first) + yearsAt(last)) / 2
+	}
+
 	if hasDay && hasMonth && hasYear {
 		// Calculate the total number of days in this year to account for leap years
 		t := time.Date(gd.Year, time.Month(gd.Month), gd.Day, 0, 0, 0, 0, time.UTC)
@@ -685,13 +1090,27 @@ func (gd *GedcomDate) getSingleDateYears() float64 {
 	return 0
 }
 
+// yearsAt returns the Years value of a single day given as a Julian Day Number.
+func yearsAt(jdn int) float64 {
+	t := jdnToTime(jdn)
+	daysInYear := time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC).
+		AddDate(0, 0, -1).YearDay() + 1
+
+	year := t.Year()
+	if year <= 0 {
+		year-- // astronomical year 0 is 1 BCE
+	}
+	return float64(year) + float64(t.YearDay())/float64(daysInYear)
+}
+
 // getStartDateForYears returns a GedcomDate representing the start of the range.
 func (gd *GedcomDate) getStartDateForYears() *GedcomDate {
 	return &GedcomDate{
-		Year:  gd.StartYear,
-		Month: gd.StartMonth,
-		Day:   gd.StartDay,
-		Type:  DateTypeExact,
+		Calendar: gd.calendar(),
+		Year:     gd.StartYear,
+		Month:    gd.StartMonth,
+		Day:      gd.StartDay,
+		Type:     DateTypeExact,
 		IsParsed: true,
 	}
 }
@@ -699,10 +1118,11 @@ func (gd *GedcomDate) getStartDateForYears() *GedcomDate {
 // getEndDateForYears returns a GedcomDate representing the end of the range.
 func (gd *GedcomDate) getEndDateForYears() *GedcomDate {
 	return &GedcomDate{
-		Year:  gd.EndYear,
-		Month: gd.EndMonth,
-		Day:   gd.EndDay,
-		Type:  DateTypeExact,
+		Calendar: gd.endCalendar(),
+		Year:     gd.EndYear,
+		Month:    gd.EndMonth,
+		Day:      gd.EndDay,
+		Type:     DateTypeExact,
 		IsParsed: true,
 	}
 }
@@ -810,8 +1230,8 @@ func (gd *GedcomDate) Equals(other *GedcomDate) bool {
 	return matchers[c2][c1](gd, other)
 }
 
-// Is compares two dates. Dates are only considered to be the same if the day,
-// month, year and constraint are all the same.
+// Is compares two dates. Dates are only considered to be the same if the
+// calendar, day, month, year and constraint are all the same.
 func (gd *GedcomDate) Is(other *GedcomDate) bool {
 	if !gd.IsValid() || !other.IsValid() {
 		return false
@@ -821,6 +1241,10 @@ func (gd *GedcomDate) Is(other *GedcomDate) bool {
 		return false
 	}
 
+	if gd.calendar() != other.calendar() || gd.endCalendar() != other.endCalendar() {
+		return false
+	}
+
 	if gd.IsRange() {
 		return gd.StartDay == other.StartDay &&
 			gd.StartMonth == other.StartMonth &&
@@ -843,6 +1267,13 @@ func equalsA(d1, d2 *GedcomDate) bool {
 		return false // Ranges need special handling
 	}
 
+	if d1.calendar() != d2.calendar() {
+		// Compare the days the dates cover
+		first1, last1 := jdnBounds(d1.calendar(), d1.Year, d1.Month, d1.Day)
+		first2, last2 := jdnBounds(d2.calendar(), d2.Year, d2.Month, d2.Day)
+		return first1 == first2 && last1 == last2
+	}
+
 	if d1.Day != d2.Day {
 		return false
 	}
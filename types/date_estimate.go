@@ -0,0 +1,101 @@
+package types
+
+import "time"
+
+// DateEstimate is the estimated birth date of an individual whose birth date
+// is not recorded, inferred from the dates of relatives and events. It is
+// never written into the tree; filters and exporters use it only when asked
+// to, and show it as an estimate.
+type DateEstimate struct {
+	// Birth is the range of possible birth dates. When only one bound is
+	// known, the start and end are the same BEFORE or AFTER date.
+	Birth DateRange
+
+	// Explanation gives the reason for each bound, such as "Latest 1 JUN
+	// 1830: father of @I3@ (born no later than 1 JUN 1840), at least 10 at a
+	// child's birth".
+	Explanation []string
+
+	// Deceased is set when the latest possible birth is longer before the
+	// time the estimate was made at than the longest lifespan it was made
+	// with.
+	Deceased bool
+}
+
+// NewDateEstimate returns the estimate of a birth between earliest and
+// latest. A zero time leaves that bound open; both zero gives nil.
+func NewDateEstimate(earliest, latest time.Time, explanation []string) *DateEstimate {
+	var start, end *GedcomDate
+	switch {
+	case earliest.IsZero() && latest.IsZero():
+		return nil
+	case earliest.IsZero():
+		start = dateFromTime(latest, DateTypeBefore)
+		end = start
+	case latest.IsZero():
+		start = dateFromTime(earliest, DateTypeAfter)
+		end = start
+	default:
+		start = dateFromTime(earliest, DateTypeExact)
+		end = dateFromTime(latest, DateTypeExact)
+	}
+	return &DateEstimate{
+		Birth:       NewDateRange(start, end),
+		Explanation: explanation,
+	}
+}
+
+// DateEstimates maps individual xref IDs to their estimates.
+type DateEstimates map[string]*DateEstimate
+
+// Earliest returns the earliest possible birth time, or the zero time if
+// there is no earliest bound.
+func (de *DateEstimate) Earliest() time.Time {
+	start := de.Birth.StartDate()
+	if !start.IsValid() || start.Type == DateTypeBefore {
+		return time.Time{}
+	}
+	return start.Earliest()
+}
+
+// Latest returns the latest possible birth time, or the zero time if there
+// is no latest bound.
+func (de *DateEstimate) Latest() time.Time {
+	end := de.Birth.EndDate()
+	if !end.IsValid() || end.Type == DateTypeAfter {
+		return time.Time{}
+	}
+	return end.Latest()
+}
+
+// Midpoint returns the middle of the estimated range. ok is false when the
+// range has only one bound.
+func (de *DateEstimate) Midpoint() (midpoint time.Time, ok bool) {
+	earliest, latest := de.Earliest(), de.Latest()
+	if earliest.IsZero() || latest.IsZero() {
+		return time.Time{}, false
+	}
+	return earliest.Add(latest.Sub(earliest) / 2), true
+}
+
+// String returns the estimate marked as one: "EST BET 2 JAN 1857 AND 1 JAN
+// 1858", or "BEF 1 JUN 1830 (estimated)" or "AFT 2 JAN 1857 (estimated)" when
+// only one bound is known, as GEDCOM has no estimated BEF or AFT date.
+func (de *DateEstimate) String() string {
+	start, end := de.Birth.StartDate(), de.Birth.EndDate()
+	switch start.Type {
+	case DateTypeBefore:
+		return "BEF " + boundString(start) + " (estimated)"
+	case DateTypeAfter:
+		return "AFT " + boundString(start) + " (estimated)"
+	}
+	return "EST BET " + boundString(start) + " AND " + boundString(end)
+}
+
+// boundString returns a bound of an estimate as a date without its BEFORE
+// or AFTER.
+func boundString(bound *GedcomDate) string {
+	date := *bound
+	date.Type = DateTypeExact
+	return date.String()
+}
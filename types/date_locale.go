@@ -0,0 +1,362 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DateLocale holds the words a language uses in dates: month names and the
+// qualifiers that map to GEDCOM keywords. Words are matched case-insensitively
+// and a trailing period is ignored, so "Janv." matches "janv".
+//
+// Locales are registered with RegisterDateLocale and used by NormalizeDate,
+// ParseDate and NewDateRangeWithString. Month names and the About, Before,
+// After, Between and And words of every registered locale apply to all
+// dates. From, To and Ignore hold short everyday words ("de", "ad", "am"), so
+// they apply only when the locale is selected: with NormalizeDateWithLocale
+// or ParseDateWithLocale, or for the records of a tree whose DateLocale it is.
+type DateLocale struct {
+	Name string
+
+	// Languages lists the GEDCOM language names and language tags that
+	// select the locale (see DateLocaleFor).
+	Languages []string
+
+	// Months maps month names and abbreviations to month numbers (1-12).
+	Months map[string]int
+
+	// Qualifier words for ABT, BEF, AFT, BET, AND, FROM and TO.
+	About   []string
+	Before  []string
+	After   []string
+	Between []string
+	And     []string
+	From    []string
+	To      []string
+
+	// Ignore lists filler words that are dropped, such as articles or the
+	// Latin "die" and "anno" in "die 3 Maii anno 1650". Like From and To,
+	// they are only used when the locale is selected.
+	Ignore []string
+}
+
+// DateLocaleEnglish holds the English words already accepted by ParseDate.
+// NormalizeDate uses it to rewrite them in standard GEDCOM form.
+var DateLocaleEnglish = &DateLocale{
+	Name:      "English",
+	Languages: []string{"English", "en"},
+	Months:  monthMap,
+	About:   strings.Split(DateWordsAbout, "|"),
+	Before:  strings.Split(DateWordsBefore, "|"),
+	After:   strings.Split(DateWordsAfter, "|"),
+	Between: []string{"bet", "between"},
+	And:     []string{"and"},
+	From:    []string{"from"},
+	To:      []string{"to"},
+}
+
+// DateLocaleFrench holds French month names and qualifiers.
+var DateLocaleFrench = &DateLocale{
+	Name:      "French",
+	Languages: []string{"French", "fr"},
+	Months: map[string]int{
+		"janvier": 1, "janv": 1,
+		"février": 2, "fevrier": 2, "févr": 2, "fevr": 2, "fév": 2, "fev": 2,
+		"mars":  3,
+		"avril": 4, "avr": 4,
+		"mai":     5,
+		"juin":    6,
+		"juillet": 7, "juil": 7,
+		"août": 8, "aout": 8,
+		"septembre": 9, "sept": 9,
+		"octobre":  10,
+		"novembre": 11,
+		"décembre": 12, "decembre": 12, "déc": 12,
+	},
+	About:   []string{"vers", "environ", "env"},
+	Before:  []string{"avant", "av"},
+	After:   []string{"après", "apres", "ap"},
+	Between: []string{"entre"},
+	And:     []string{"et"},
+	From:    []string{"de", "du", "depuis"},
+	To:      []string{"à", "au", "jusqu'à", "jusqu'au"},
+	Ignore:  []string{"le", "l'an"},
+}
+
+// DateLocaleGerman holds German month names and qualifiers.
+var DateLocaleGerman = &DateLocale{
+	Name:      "German",
+	Languages: []string{"German", "de"},
+	Months: map[string]int{
+		"januar": 1, "jänner": 1, "jaenner": 1,
+		"februar": 2, "feber": 2,
+		"märz": 3, "maerz": 3, "mär": 3,
+		"april":     4,
+		"mai":       5,
+		"juni":      6,
+		"juli":      7,
+		"august":    8,
+		"september": 9, "sept": 9,
+		"oktober": 10, "okt": 10,
+		"november": 11,
+		"dezember": 12, "dez": 12,
+	},
+	About:   []string{"um", "etwa", "zirka", "ungefähr"},
+	Before:  []string{"vor"},
+	After:   []string{"nach"},
+	Between: []string{"zwischen"},
+	And:     []string{"und"},
+	From:    []string{"von", "vom", "seit"},
+	To:      []string{"bis"},
+	Ignore:  []string{"am", "im", "den"},
+}
+
+// DateLocaleDutch holds Dutch month names and qualifiers.
+var DateLocaleDutch = &DateLocale{
+	Name:      "Dutch",
+	Languages: []string{"Dutch", "nl", "Flemish"},
+	Months: map[string]int{
+		"januari":  1,
+		"februari": 2,
+		"maart":    3, "mrt": 3,
+		"april":     4,
+		"mei":       5,
+		"juni":      6,
+		"juli":      7,
+		"augustus":  8,
+		"september": 9,
+		"oktober":   10, "okt": 10,
+		"november": 11,
+		"december": 12,
+	},
+	About:   []string{"omstreeks", "omstr", "circa", "ongeveer", "rond"},
+	Before:  []string{"voor", "vóór"},
+	After:   []string{"na"},
+	Between: []string{"tussen"},
+	And:     []string{"en"},
+	From:    []string{"van", "vanaf"},
+	To:      []string{"tot"},
+	Ignore:  []string{"op"},
+}
+
+// DateLocaleLatin holds Latin month names as found in parish registers,
+// including genitive forms ("Maii") and numeric abbreviations ("7bris").
+var DateLocaleLatin = &DateLocale{
+	Name:      "Latin",
+	Languages: []string{"Latin", "la"},
+	Months: map[string]int{
+		"januarius": 1, "ianuarius": 1, "januarii": 1, "ianuarii": 1,
+		"februarius": 2, "februarii": 2,
+		"martius": 3, "martii": 3,
+		"aprilis": 4,
+		"maius":   5, "maii": 5,
+		"junius": 6, "iunius": 6, "junii": 6, "iunii": 6,
+		"julius": 7, "iulius": 7, "julii": 7, "iulii": 7,
+		"augustus": 8, "augusti": 8,
+		"septembris": 9, "7bris": 9, "7ber": 9,
+		"octobris": 10, "8bris": 10, "8ber": 10,
+		"novembris": 11, "9bris": 11, "9ber": 11,
+		"decembris": 12, "10bris": 12, "xbris": 12, "10ber": 12, "xber": 12,
+	},
+	About:   []string{"circa", "circiter"},
+	Before:  []string{"ante"},
+	After:   []string{"post"},
+	Between: []string{"inter"},
+	And:     []string{"et"},
+	From:    []string{"ab"},
+	To:      []string{"ad"},
+	Ignore:  []string{"die", "anno", "a.d.", "usque"},
+}
+
+// dateLocales is the registry of locales used when normalizing dates.
+var dateLocales = struct {
+	sync.RWMutex
+	locales  []*DateLocale
+	words    map[string]string                 // lowercased word -> GEDCOM token ("" to drop)
+	selected map[*DateLocale]map[string]string // words with a locale selected
+}{}
+
+func init() {
+	for _, locale := range []*DateLocale{DateLocaleEnglish, DateLocaleFrench, DateLocaleGerman, DateLocaleDutch, DateLocaleLatin} {
+		RegisterDateLocale(locale)
+	}
+}
+
+// RegisterDateLocale adds a locale used by NormalizeDate, ParseDate and
+// NewDateRangeWithString. English, French, German, Dutch and Latin are
+// registered by default. When locales share a word, the first registered wins.
+func RegisterDateLocale(locale *DateLocale) {
+	if locale == nil {
+		return
+	}
+
+	dateLocales.Lock()
+	defer dateLocales.Unlock()
+
+	dateLocales.locales = append(dateLocales.locales, locale)
+	dateLocales.words = buildDateWords(dateLocales.locales)
+	dateLocales.selected = nil
+}
+
+// DateLocales returns the registered locales in registration order.
+func DateLocales() []*DateLocale {
+	dateLocales.RLock()
+	defer dateLocales.RUnlock()
+
+	return append([]*DateLocale(nil), dateLocales.locales...)
+}
+
+// DateLocaleFor returns the registered locale for a GEDCOM language name or
+// language tag, such as the header's LANG. Region subtags are ignored
+// ("fr-CA" finds French). Returns nil if no locale lists the language.
+func DateLocaleFor(language string) *DateLocale {
+	language = strings.TrimSpace(language)
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		language = language[:i]
+	}
+	if language == "" {
+		return nil
+	}
+	for _, locale := range DateLocales() {
+		for _, candidate := range locale.Languages {
+			if strings.EqualFold(candidate, language) {
+				return locale
+			}
+		}
+	}
+	return nil
+}
+
+// buildDateWords builds the word lookup for NormalizeDate from locales.
+func buildDateWords(locales []*DateLocale) map[string]string {
+	words := make(map[string]string)
+	for _, locale := range locales {
+		addDateWords(words, locale, false)
+	}
+	return words
+}
+
+// addDateWords adds the words of locale to words, keeping words already
+// there. The From, To and Ignore words are only added if selected is set.
+func addDateWords(words map[string]string, locale *DateLocale, selected bool) {
+	add := func(word, token string) {
+		word = strings.TrimSuffix(strings.ToLower(word), ".")
+		if _, exists := words[word]; !exists && word != "" {
+			words[word] = token
+		}
+	}
+
+	for name, month := range locale.Months {
+		if month >= 1 && month <= 12 {
+			add(name, gregorianMonthNames[month])
+		}
+	}
+	qualifiers := []struct {
+		words []string
+		token string
+	}{
+		{locale.About, "ABT"},
+		{locale.Before, "BEF"},
+		{locale.After, "AFT"},
+		{locale.Between, "BET"},
+		{locale.And, "AND"},
+	}
+	if selected {
+		qualifiers = append(qualifiers, []struct {
+			words []string
+			token string
+		}{{locale.From, "FROM"}, {locale.To, "TO"}, {locale.Ignore, ""}}...)
+	}
+	for _, qualifier := range qualifiers {
+		for _, word := range qualifier.words {
+			add(word, qualifier.token)
+		}
+	}
+}
+
+// dateWords returns the word lookup for NormalizeDateWithLocale: the
+// selected locale's words first, then those of every registered locale.
+func dateWords(locale *DateLocale) map[string]string {
+	dateLocales.RLock()
+	words, ok := dateLocales.words, locale == nil
+	if !ok {
+		words, ok = dateLocales.selected[locale]
+	}
+	dateLocales.RUnlock()
+	if ok {
+		return words
+	}
+
+	dateLocales.Lock()
+	defer dateLocales.Unlock()
+	words = make(map[string]string)
+	addDateWords(words, locale, true)
+	for word, token := range dateLocales.words {
+		if _, exists := words[word]; !exists {
+			words[word] = token
+		}
+	}
+	if dateLocales.selected == nil {
+		dateLocales.selected = make(map[*DateLocale]map[string]string)
+	}
+	dateLocales.selected[locale] = words
+	return words
+}
+
+// ordinalDayPattern matches numbers written with a trailing period or a day
+// number with an ordinal suffix: "3.", "1820.", "1er", "1re", "2e", "3te".
+var ordinalDayPattern = regexp.MustCompile(`(?i)^(?:(\d{1,2})(?:er|re|e|te|ste|de)|(\d{1,4})\.)$`)
+
+// NormalizeDate rewrites a date written with any registered locale into
+// standard GEDCOM form: month names become JAN..DEC, qualifiers become ABT,
+// BEF, AFT and BET/AND, and ordinal day numbers lose their suffix.
+// "12 juin 1820" becomes "12 JUN 1820" and "omstreeks 1800" becomes
+// "ABT 1800". Calendar escapes, other calendars' month codes and phrases in
+// parentheses are left unchanged. FROM/TO words and filler words need a
+// selected locale (see NormalizeDateWithLocale).
+func NormalizeDate(dateStr string) string {
+	return NormalizeDateWithLocale(dateStr, nil)
+}
+
+// NormalizeDateWithLocale is like NormalizeDate with locale selected, so
+// that its FROM/TO words are rewritten and its filler words dropped as well:
+// with DateLocaleLatin, "die 3 Maii anno 1650" becomes "3 MAY 1650". A nil
+// locale is the same as NormalizeDate.
+func NormalizeDateWithLocale(dateStr string, locale *DateLocale) string {
+	dateStr = strings.TrimSpace(dateStr)
+	if dateStr == "" || phrasePattern.MatchString(dateStr) {
+		return dateStr
+	}
+
+	// Keep the phrase of an interpreted date as written
+	phrase := ""
+	if i := strings.Index(dateStr, "("); i > 0 && strings.HasSuffix(dateStr, ")") {
+		dateStr, phrase = strings.TrimSpace(dateStr[:i]), " "+dateStr[i:]
+	}
+
+	words := dateWords(locale)
+
+	fields := strings.Fields(normalizeCalendarEscapes(dateStr))
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		word := strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(field), ","), ".")
+		if strings.HasPrefix(word, "@#d") {
+			normalized = append(normalized, strings.ToUpper(strings.ReplaceAll(word, "_", " ")))
+			continue
+		}
+		if matches := ordinalDayPattern.FindStringSubmatch(strings.TrimSuffix(field, ",")); matches != nil {
+			normalized = append(normalized, matches[1]+matches[2])
+			continue
+		}
+		if token, ok := words[word]; ok {
+			if token != "" {
+				normalized = append(normalized, token)
+			}
+			continue
+		}
+		normalized = append(normalized, strings.ToUpper(field))
+	}
+
+	return strings.Join(normalized, " ") + phrase
+}
@@ -0,0 +1,190 @@
+package types
+
+import "testing"
+
+func TestNormalizeDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"12 juin 1820", "12 JUN 1820"},
+		{"1er janvier 1800", "1 JAN 1800"},
+		{"vers 1800", "ABT 1800"},
+		{"entre 1800 et 1810", "BET 1800 AND 1810"},
+		{"3 Mai 1799", "3 MAY 1799"},
+		{"um 3. März 1799", "ABT 3 MAR 1799"},
+		{"zwischen Januar 1800 und Dez. 1801", "BET JAN 1800 AND DEC 1801"},
+		{"omstreeks 1800", "ABT 1800"},
+		{"na 2 mei 1801", "AFT 2 MAY 1801"},
+		{"tussen maart 1800 en okt 1801", "BET MAR 1800 AND OCT 1801"},
+		{"circa Januarius 1650", "ABT JAN 1650"},
+		{"ante 10bris 1650", "BEF DEC 1650"},
+		{"Abt. january 1850", "ABT JAN 1850"},
+		{"15 JAN 1800", "15 JAN 1800"},
+		{"@#DFRENCH R@ 18 BRUM 8", "@#DFRENCH R@ 18 BRUM 8"},
+		{"(vers la fin de mai)", "(vers la fin de mai)"},
+		{"INT 1850 (vers la guerre)", "INT 1850 (vers la guerre)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeDate(tt.input); got != tt.want {
+				t.Errorf("NormalizeDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeDateWithLocale(t *testing.T) {
+	tests := []struct {
+		input  string
+		locale *DateLocale
+		want   string
+	}{
+		{"avant le 3 août 1799", DateLocaleFrench, "BEF 3 AUG 1799"},
+		{"de 1800 à 1810", DateLocaleFrench, "FROM 1800 TO 1810"},
+		{"von 1800 bis 1810", DateLocaleGerman, "FROM 1800 TO 1810"},
+		{"am 3. Mai 1799", DateLocaleGerman, "3 MAY 1799"},
+		{"die 3 Maii anno 1650", DateLocaleLatin, "3 MAY 1650"},
+		{"ab 1650 ad 1660", DateLocaleLatin, "FROM 1650 TO 1660"},
+		// Without a selected locale, FROM/TO and filler words are kept
+		{"die 3 Maii anno 1650", nil, "DIE 3 MAY ANNO 1650"},
+		{"von 1800 bis 1810", nil, "VON 1800 BIS 1810"},
+		{"3 MAY 1799 AM", nil, "3 MAY 1799 AM"},
+		// Another locale's filler words are kept too
+		{"am 3. Mai 1799", DateLocaleFrench, "AM 3 MAY 1799"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeDateWithLocale(tt.input, tt.locale); got != tt.want {
+				t.Errorf("NormalizeDateWithLocale(%q, %v) = %q, want %q", tt.input, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateLocaleFor(t *testing.T) {
+	tests := map[string]*DateLocale{
+		"French":  DateLocaleFrench,
+		"de-AT":   DateLocaleGerman,
+		"Latin":   DateLocaleLatin,
+		"nl":      DateLocaleDutch,
+		"Klingon": nil,
+		"":        nil,
+	}
+	for language, want := range tests {
+		if got := DateLocaleFor(language); got != want {
+			t.Errorf("DateLocaleFor(%q) = %v, want %v", language, got, want)
+		}
+	}
+}
+
+func TestGedcomTree_DateLocale(t *testing.T) {
+	tree := NewGedcomTree()
+	indiLine := NewGedcomLine(0, "INDI", "", "@I1@")
+	birt := NewGedcomLine(1, "BIRT", "", "")
+	birt.AddChild(NewGedcomLine(2, "DATE", "die 3 Maii anno 1650", ""))
+	indiLine.AddChild(birt)
+	indi := NewIndividualRecord(indiLine)
+	tree.AddRecord(indi)
+
+	if _, err := indi.GetBirthDateParsed(); err == nil {
+		t.Error("expected Latin filler words to need a selected locale")
+	}
+
+	headerLine := NewGedcomLine(0, "HEAD", "", "")
+	headerLine.AddChild(NewGedcomLine(1, "LANG", "Latin", ""))
+	tree.AddRecord(NewHeaderRecord(headerLine))
+	if tree.DateLocale() != DateLocaleLatin {
+		t.Fatalf("DateLocale() = %v, want Latin from the header", tree.DateLocale())
+	}
+	date, err := indi.GetBirthDateParsed()
+	if err != nil || date.Year != 1650 || date.Month != 5 || date.Day != 3 {
+		t.Errorf("GetBirthDateParsed() = %v, %v, want 3 MAY 1650", date, err)
+	}
+	if birth := indi.Birth(); birth == nil || !birth.HasDate() {
+		t.Error("Birth() should have the date with the header's locale")
+	}
+
+	tree.SetDateLocale(DateLocaleFrench)
+	if _, err := indi.GetBirthDateParsed(); err == nil {
+		t.Error("expected SetDateLocale to take precedence over the header")
+	}
+}
+
+func TestParseDate_Locales(t *testing.T) {
+	tests := []struct {
+		input    string
+		dateType DateType
+		year     int
+		month    int
+		day      int
+	}{
+		{"12 juin 1820", DateTypeExact, 1820, 6, 12},
+		{"3 Mai 1799", DateTypeExact, 1799, 5, 3},
+		{"circa Januarius 1650", DateTypeAbout, 1650, 1, 0},
+		{"omstreeks 1800", DateTypeAbout, 1800, 0, 0},
+		{"nach Oktober 1700", DateTypeAfter, 1700, 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+			}
+			if date.Type != tt.dateType || date.Year != tt.year || date.Month != tt.month || date.Day != tt.day {
+				t.Errorf("got %s %d-%d-%d, want %s %d-%d-%d", date.Type, date.Year, date.Month, date.Day,
+					tt.dateType, tt.year, tt.month, tt.day)
+			}
+			if date.Original != tt.input {
+				t.Errorf("Original = %q, want %q", date.Original, tt.input)
+			}
+		})
+	}
+
+	date, err := ParseDate("entre 1800 et 1810")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Type != DateTypeBetween || date.StartYear != 1800 || date.EndYear != 1810 {
+		t.Errorf("unexpected range %+v", date)
+	}
+
+	dr := NewDateRangeWithString("tussen 1800 en 1810")
+	if dr.StartDate().Year != 1800 || dr.EndDate().Year != 1810 {
+		t.Errorf("NewDateRangeWithString() = %d..%d", dr.StartDate().Year, dr.EndDate().Year)
+	}
+
+	if DateConstraintFromString("vers") != DateConstraintAbout {
+		t.Error("expected \"vers\" to be an about constraint")
+	}
+}
+
+func TestRegisterDateLocale(t *testing.T) {
+	if _, err := ParseDate("hacia 5 mayo 1790"); err == nil {
+		t.Fatal("Spanish is not registered by default")
+	}
+
+	RegisterDateLocale(&DateLocale{
+		Name:   "Spanish",
+		Months: map[string]int{"mayo": 5},
+		About:  []string{"hacia"},
+	})
+
+	date, err := ParseDate("hacia 5 mayo 1790")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Type != DateTypeAbout || date.Month != 5 || date.Day != 5 {
+		t.Errorf("unexpected date %+v", date)
+	}
+
+	found := false
+	for _, locale := range DateLocales() {
+		if locale.Name == "Spanish" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("DateLocales() does not include the registered locale")
+	}
+}
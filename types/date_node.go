@@ -12,6 +12,12 @@ type DateNode struct {
 	// Original is the original date string
 	Original string
 
+	// phrase is the GEDCOM 7.0 DATE.PHRASE, if any
+	phrase string
+
+	// locale is the selected date locale, if any
+	locale *DateLocale
+
 	// AlreadyParsed tracks if we've already parsed the date (for caching)
 	alreadyParsed bool
 }
@@ -33,28 +39,49 @@ func NewDateNode(dateStr string) *DateNode {
 }
 
 // NewDateNodeFromLine creates a DateNode from a GedcomLine (DATE tag).
+// A GEDCOM 7.0 PHRASE substructure is parsed with the date (see ParseDateWithPhrase).
 func NewDateNodeFromLine(line *GedcomLine) *DateNode {
+	return newDateNodeFromLine(line, nil)
+}
+
+// newDateNodeFromLine is NewDateNodeFromLine with a selected date locale.
+func newDateNodeFromLine(line *GedcomLine, locale *DateLocale) *DateNode {
 	if line == nil || line.Tag != "DATE" {
 		return nil
 	}
 
-	return NewDateNode(line.Value)
+	phrase := line.GetValue("PHRASE")
+	if line.Value == "" && phrase == "" {
+		return &DateNode{}
+	}
+
+	dn := &DateNode{
+		Original: line.Value,
+		phrase:   phrase,
+		locale:   locale,
+	}
+	dn.parse()
+	return dn
 }
 
 // parse parses the date string into GedcomDate and DateRange.
 func (dn *DateNode) parse() {
-	if dn.alreadyParsed || dn.Original == "" {
+	if dn.alreadyParsed || (dn.Original == "" && dn.phrase == "") {
 		return
 	}
 
 	// Parse as GedcomDate
-	date, err := ParseDate(dn.Original)
+	date, err := parseDateWithPhrase(dn.Original, dn.phrase, dn.locale)
 	if err == nil {
 		dn.Date = date
 	}
 
 	// Also create DateRange for compatibility
-	dn.DateRange = NewDateRangeWithString(dn.Original)
+	if dn.Original != "" {
+		dn.DateRange = newDateRangeWithLocale(dn.Original, dn.locale)
+	} else {
+		dn.DateRange = NewDateRangeWithString(date.String())
+	}
 
 	dn.alreadyParsed = true
 }
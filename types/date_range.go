@@ -30,8 +30,15 @@ func NewDateRange(start, end *GedcomDate) DateRange {
 }
 
 // NewDateRangeWithString creates a DateRange from a GEDCOM date string.
+// Dates in the languages of the registered locales are accepted (see NormalizeDate).
 func NewDateRangeWithString(s string) DateRange {
-	dateString := cleanSpace(s)
+	return newDateRangeWithLocale(s, nil)
+}
+
+// newDateRangeWithLocale is NewDateRangeWithString with a selected locale
+// (see NormalizeDateWithLocale).
+func newDateRangeWithLocale(s string, locale *DateLocale) DateRange {
+	dateString := cleanSpace(NormalizeDateWithLocale(s, locale))
 
 	// Try to match a range first
 	dateRangeRegexp := regexp.MustCompile(
@@ -193,6 +200,10 @@ func (dr DateRange) IsPhrase() bool {
 
 // String returns a string representation of the date range.
 func (dr DateRange) String() string {
+	if dr.IsPhrase() {
+		return dr.StartDate().String()
+	}
+
 	start, end := dr.StartAndEndDates()
 	if start.Equals(end) {
 		return start.String()
@@ -199,6 +199,9 @@ func TestGedcomDate_ToISO8601(t *testing.T) {
 		{"Month-year", "JAN 1800", "1800-01"},
 		{"Year only", "1800", "1800"},
 		{"Between", "BET 1800 AND 1850", "1800"},
+		{"BCE", "15 MAR 44 B.C.", "-0043-03-15"},
+		{"1 BCE", "1 BCE", "0000"},
+		{"Early CE", "JAN 33", "0033-01"},
 	}
 
 	for _, tt := range tests {
@@ -282,3 +285,110 @@ func TestGedcomDate_String(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDate_Interpreted(t *testing.T) {
+	date, err := ParseDate("INT 1850 (about the time of the war)")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Type != DateTypeInterpreted || !date.IsInterpreted() {
+		t.Errorf("Type = %q, want %q", date.Type, DateTypeInterpreted)
+	}
+	if date.Year != 1850 || date.Phrase != "about the time of the war" {
+		t.Errorf("Year = %d, Phrase = %q", date.Year, date.Phrase)
+	}
+	if !date.IsValid() {
+		t.Error("interpreted date should be valid")
+	}
+	if got := date.String(); got != "INT 1850 (about the time of the war)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	// Comparisons use the interpreted value
+	other, _ := ParseDate("1 JAN 1860")
+	if date.Compare(other) != -1 || !date.IsBefore(other) {
+		t.Error("expected INT 1850 to be before 1 JAN 1860")
+	}
+	same, _ := ParseDate("1850")
+	if date.Similarity(same, DefaultMaxYearsForSimilarity) != 1 {
+		t.Errorf("Similarity() = %f, want 1", date.Similarity(same, DefaultMaxYearsForSimilarity))
+	}
+	if !date.Equals(same) {
+		t.Error("expected INT 1850 to equal 1850")
+	}
+
+	date, err = ParseDate("int 15 jan 1850")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Type != DateTypeInterpreted || date.Day != 15 || date.Phrase != "" {
+		t.Errorf("unexpected date %+v", date)
+	}
+	if got := date.String(); got != "INT 15 JAN 1850" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if _, err := ParseDate("INT sometime (unknown)"); err == nil {
+		t.Error("expected error for interpreted date without a date value")
+	}
+}
+
+func TestParseDate_Phrase(t *testing.T) {
+	date, err := ParseDate("(during the great flood)")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if !date.IsPhrase() || date.Type != DateTypePhrase {
+		t.Errorf("Type = %q, want %q", date.Type, DateTypePhrase)
+	}
+	if date.Phrase != "during the great flood" {
+		t.Errorf("Phrase = %q", date.Phrase)
+	}
+	if date.IsValid() {
+		t.Error("phrase-only date has no value and should not be valid")
+	}
+	if got := date.String(); got != "(during the great flood)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	other, _ := ParseDate("1850")
+	if date.Compare(other) != 0 || date.IsBefore(other) || date.IsAfter(other) {
+		t.Error("phrase-only dates should not order against other dates")
+	}
+}
+
+func TestParseDateWithPhrase(t *testing.T) {
+	date, err := ParseDateWithPhrase("1850", "about the time of the war")
+	if err != nil {
+		t.Fatalf("ParseDateWithPhrase() error = %v", err)
+	}
+	if date.Type != DateTypeExact || date.Year != 1850 || date.Phrase != "about the time of the war" {
+		t.Errorf("unexpected date %+v", date)
+	}
+	if date.IsInterpreted() || date.String() != "1850" {
+		t.Errorf("String() = %q, want the DATE value without the phrase", date.String())
+	}
+
+	date, err = ParseDateWithPhrase("BET 1800 AND 1810", "early in the century")
+	if err != nil {
+		t.Fatalf("ParseDateWithPhrase() error = %v", err)
+	}
+	if date.Type != DateTypeBetween || date.Phrase != "early in the century" || date.String() != "BET 1800 AND 1810" {
+		t.Errorf("unexpected date %+v", date)
+	}
+
+	date, err = ParseDateWithPhrase("", "the year of the comet")
+	if err != nil {
+		t.Fatalf("ParseDateWithPhrase() error = %v", err)
+	}
+	if !date.IsPhrase() || date.String() != "(the year of the comet)" {
+		t.Errorf("unexpected date %+v", date)
+	}
+
+	line := NewGedcomLine(2, "DATE", "1850", "")
+	line.AddChild(NewGedcomLine(3, "PHRASE", "about the time of the war", ""))
+	node := NewDateNodeFromLine(line)
+	if !node.IsValid() || node.Date.IsInterpreted() || node.Date.Phrase != "about the time of the war" {
+		t.Errorf("expected DATE with PHRASE to keep its type and carry the phrase, got %+v", node.Date)
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+// ErrorCode identifies a kind of problem found while reading a GEDCOM file.
+// Codes are stable across releases, so tools can filter on them (for example,
+// to fail a CI job only on level jumps) without matching message text.
+//
+// Each code refines one ErrorType, the broad category shared with
+// StandardError: ErrorCode.Type returns it, and a GedcomError with a code
+// carries both (see ErrorManager.AddDiagnostic).
+type ErrorCode string
+
+const (
+	// Line syntax and hierarchy
+	CodeInvalidLevel        ErrorCode = "invalid-level"        // Line does not start with a level number
+	CodeMissingTag          ErrorCode = "missing-tag"          // Line has a level (and xref) but no tag
+	CodeLevelJump           ErrorCode = "level-jump"           // Level is more than one below its parent's
+	CodeOrphanedLine        ErrorCode = "orphaned-line"        // Line at level > 0 before any record
+	CodeInvalidContinuation ErrorCode = "invalid-continuation" // CONC/CONT nested under CONC/CONT
+
+	// Encoding
+	CodeEncodingMismatch    ErrorCode = "encoding-mismatch"    // HEAD.CHAR differs from the detected encoding
+	CodeUnsupportedEncoding ErrorCode = "unsupported-encoding" // HEAD.CHAR names an encoding that cannot be decoded
+	CodeEncodingError       ErrorCode = "encoding-error"       // Encoding could not be detected or decoded
+
+	// Files
+	CodeInvalidFile    ErrorCode = "invalid-file"    // File is missing, empty or not a GEDCOM file
+	CodeIOError        ErrorCode = "io-error"        // File could not be read
+	CodeInvalidArchive ErrorCode = "invalid-archive" // GEDZIP archive is malformed
+	CodeMissingMedia   ErrorCode = "missing-media"   // OBJE FILE not found in a GEDZIP archive
+
+	// GEDCOM version
+	CodeVersionMismatch     ErrorCode = "version-mismatch"     // Structure belongs to another GEDCOM version
+	CodeUndeclaredExtension ErrorCode = "undeclared-extension" // GEDCOM 7.0 extension tag missing from HEAD.SCHMA
+)
+
+// Type returns the ErrorType the code belongs to: ErrorTypeIO for files that
+// cannot be read, ErrorTypeValidation for structures of the wrong GEDCOM
+// version and ErrorTypeParse for the rest. An empty code has no type.
+func (c ErrorCode) Type() ErrorType {
+	switch c {
+	case "":
+		return ""
+	case CodeIOError, CodeMissingMedia:
+		return ErrorTypeIO
+	case CodeVersionMismatch, CodeUndeclaredExtension:
+		return ErrorTypeValidation
+	default:
+		return ErrorTypeParse
+	}
+}
@@ -20,6 +20,7 @@
 //   - FamilyRecord: Represents a family unit (FAM)
 //   - HeaderRecord: Represents the file header (HEAD)
 //   - NoteRecord: Represents a note (NOTE)
+//   - SharedNoteRecord: Represents a GEDCOM 7.0 shared note (SNOTE)
 //   - SourceRecord: Represents a source citation (SOUR)
 //   - RepositoryRecord: Represents a repository (REPO)
 //   - SubmitterRecord: Represents a submitter (SUBM)
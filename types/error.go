@@ -21,14 +21,27 @@ type GedcomError struct {
 	Message    string
 	LineNumber int
 	Context    string
+
+	Type       ErrorType // Broad category, the Type of Code when there is one
+	Code       ErrorCode // Stable identifier of the problem (see diagnostic.go)
+	Column     int       // 1-based column where the problem starts, 0 if unknown
+	Raw        string    // Offending line as it appears in the file
+	Suggestion string    // How to fix the problem, if known
 }
 
 // Error implements the error interface
 func (e *GedcomError) Error() string {
-	if e.LineNumber > 0 {
-		return fmt.Sprintf("%s: %s (Line %d)", e.Severity, e.Message, e.LineNumber)
+	prefix := string(e.Severity)
+	if e.Code != "" {
+		prefix = fmt.Sprintf("%s[%s]", e.Severity, e.Code)
+	}
+	switch {
+	case e.LineNumber > 0 && e.Column > 0:
+		return fmt.Sprintf("%s: %s (Line %d, Column %d)", prefix, e.Message, e.LineNumber, e.Column)
+	case e.LineNumber > 0:
+		return fmt.Sprintf("%s: %s (Line %d)", prefix, e.Message, e.LineNumber)
 	}
-	return fmt.Sprintf("%s: %s", e.Severity, e.Message)
+	return fmt.Sprintf("%s: %s", prefix, e.Message)
 }
 
 // String returns a string representation of the error
@@ -61,6 +74,29 @@ func (em *ErrorManager) AddError(severity ErrorSeverity, message string, lineNum
 	})
 }
 
+// AddDiagnostic adds a structured error, such as one with a code and column.
+// A diagnostic with a code but no Type gets the code's Type.
+func (em *ErrorManager) AddDiagnostic(diag *GedcomError) {
+	if diag.Type == "" {
+		diag.Type = diag.Code.Type()
+	}
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.errors = append(em.errors, diag)
+}
+
+// AddCodedError is like AddError but also records a diagnostic code.
+func (em *ErrorManager) AddCodedError(code ErrorCode, severity ErrorSeverity, message string, lineNumber int, context string) {
+	em.AddDiagnostic(&GedcomError{
+		Severity:   severity,
+		Message:    message,
+		LineNumber: lineNumber,
+		Context:    context,
+		Type:       code.Type(),
+		Code:       code,
+	})
+}
+
 // Errors returns a copy of all errors
 func (em *ErrorManager) Errors() []*GedcomError {
 	em.mu.RLock()
@@ -102,6 +138,19 @@ func (em *ErrorManager) GetErrorsBySeverity(severity ErrorSeverity) []*GedcomErr
 	return result
 }
 
+// GetErrorsByCode returns all errors with the specified code
+func (em *ErrorManager) GetErrorsByCode(code ErrorCode) []*GedcomError {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	result := make([]*GedcomError, 0)
+	for _, err := range em.errors {
+		if err.Code == code {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
 // GetErrorSummary returns a summary of errors by severity
 func (em *ErrorManager) GetErrorSummary() map[ErrorSeverity]int {
 	em.mu.RLock()
@@ -120,6 +169,16 @@ func (em *ErrorManager) Clear() {
 	em.errors = em.errors[:0]
 }
 
+// Truncate removes all errors after the first n, e.g. to drop those of an
+// attempt that is started over.
+func (em *ErrorManager) Truncate(n int) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if n >= 0 && n < len(em.errors) {
+		em.errors = em.errors[:n]
+	}
+}
+
 // Count returns the total number of errors
 func (em *ErrorManager) Count() int {
 	em.mu.RLock()
@@ -30,6 +30,17 @@ func TestGedcomError_Error(t *testing.T) {
 			},
 			wantPrefix: "severe: Severe error",
 		},
+		{
+			name: "diagnostic with code and column",
+			error: &GedcomError{
+				Severity:   SeverityWarning,
+				Message:    "Level jump",
+				LineNumber: 7,
+				Column:     3,
+				Code:       CodeLevelJump,
+			},
+			wantPrefix: "warning[level-jump]: Level jump (Line 7, Column 3)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,3 +193,48 @@ func TestGedcomError_String(t *testing.T) {
 }
 
 
+
+func TestErrorManager_GetErrorsByCode(t *testing.T) {
+	em := NewErrorManager()
+	em.AddError(SeverityWarning, "Uncoded", 1, "Test")
+	em.AddCodedError(CodeIOError, SeveritySevere, "Read failed", 0, "File I/O")
+	em.AddDiagnostic(&GedcomError{Severity: SeverityWarning, Message: "Bad level", LineNumber: 3, Column: 1, Code: CodeInvalidLevel})
+
+	if got := em.GetErrorsByCode(CodeInvalidLevel); len(got) != 1 || got[0].LineNumber != 3 {
+		t.Errorf("GetErrorsByCode(invalid-level) = %v", got)
+	}
+	if got := em.GetErrorsByCode(CodeIOError); len(got) != 1 || got[0].Context != "File I/O" {
+		t.Errorf("GetErrorsByCode(io-error) = %v", got)
+	}
+	if got := em.GetErrorsByCode(CodeLevelJump); len(got) != 0 {
+		t.Errorf("GetErrorsByCode(level-jump) = %v, want none", got)
+	}
+}
+
+func TestErrorCode_Type(t *testing.T) {
+	em := NewErrorManager()
+	em.AddCodedError(CodeIOError, SeveritySevere, "Read failed", 0, "File I/O")
+	em.AddDiagnostic(&GedcomError{Severity: SeverityWarning, Message: "Bad level", LineNumber: 3, Code: CodeInvalidLevel})
+	em.AddDiagnostic(&GedcomError{Severity: SeverityWarning, Message: "Wrong version", LineNumber: 5, Code: CodeVersionMismatch})
+	em.AddError(SeverityWarning, "Uncoded", 1, "Test")
+
+	errs := em.Errors()
+	want := []ErrorType{ErrorTypeIO, ErrorTypeParse, ErrorTypeValidation, ""}
+	for i, e := range errs {
+		if e.Type != want[i] {
+			t.Errorf("%q: Type = %q, want %q", e.Message, e.Type, want[i])
+		}
+	}
+	if !IsParseError(errs[1]) || IsParseError(errs[0]) {
+		t.Error("IsParseError() should follow the diagnostic's Type")
+	}
+	if !IsValidationError(errs[2]) {
+		t.Error("IsValidationError() should be true for version-mismatch")
+	}
+	if got := GetErrorType(errs[0]); got != ErrorTypeIO {
+		t.Errorf("GetErrorType(io-error) = %q, want %q", got, ErrorTypeIO)
+	}
+	if got := GetErrorType(errs[3]); got != ErrorTypeInternal {
+		t.Errorf("GetErrorType(uncoded) = %q, want %q", got, ErrorTypeInternal)
+	}
+}
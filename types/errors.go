@@ -108,6 +108,9 @@ func IsParseError(err error) bool {
 	if se, ok := err.(*StandardError); ok {
 		return se.Type == ErrorTypeParse
 	}
+	if ge, ok := err.(*GedcomError); ok {
+		return ge.Type == ErrorTypeParse
+	}
 	return false
 }
 
@@ -116,6 +119,9 @@ func IsValidationError(err error) bool {
 	if se, ok := err.(*StandardError); ok {
 		return se.Type == ErrorTypeValidation
 	}
+	if ge, ok := err.(*GedcomError); ok {
+		return ge.Type == ErrorTypeValidation
+	}
 	return false
 }
 
@@ -124,6 +130,9 @@ func IsQueryError(err error) bool {
 	if se, ok := err.(*StandardError); ok {
 		return se.Type == ErrorTypeQuery
 	}
+	if ge, ok := err.(*GedcomError); ok {
+		return ge.Type == ErrorTypeQuery
+	}
 	return false
 }
 
@@ -132,6 +141,9 @@ func IsStorageError(err error) bool {
 	if se, ok := err.(*StandardError); ok {
 		return se.Type == ErrorTypeStorage
 	}
+	if ge, ok := err.(*GedcomError); ok {
+		return ge.Type == ErrorTypeStorage
+	}
 	return false
 }
 
@@ -151,6 +163,9 @@ func GetErrorType(err error) ErrorType {
 	if se, ok := err.(*StandardError); ok {
 		return se.Type
 	}
+	if ge, ok := err.(*GedcomError); ok && ge.Type != "" {
+		return ge.Type
+	}
 	return ErrorTypeInternal // Default type
 }
 
@@ -16,6 +16,14 @@ type Event struct {
 	// Place is the structured place associated with the event.
 	Place *PlaceNode
 
+	// Age is the AGE of the individual at the event, if stated.
+	Age *Age
+
+	// HusbandAge and WifeAge are the HUSB.AGE and WIFE.AGE of a family
+	// event, if stated.
+	HusbandAge *Age
+	WifeAge    *Age
+
 	// Sources contains source citations for this event.
 	Sources []string
 
@@ -54,6 +62,31 @@ func (e *Event) HasPlace() bool {
 	return e.Place != nil && e.Place.IsValid()
 }
 
+// BirthRange returns the birth dates implied by the event date and the
+// stated Age (see BirthRangeFromAge). ok is false if either is missing.
+func (e *Event) BirthRange() (DateRange, bool) {
+	return e.birthRangeFromAge(e.Age)
+}
+
+// HusbandBirthRange returns the husband's birth dates implied by the event
+// date and HusbandAge.
+func (e *Event) HusbandBirthRange() (DateRange, bool) {
+	return e.birthRangeFromAge(e.HusbandAge)
+}
+
+// WifeBirthRange returns the wife's birth dates implied by the event date
+// and WifeAge.
+func (e *Event) WifeBirthRange() (DateRange, bool) {
+	return e.birthRangeFromAge(e.WifeAge)
+}
+
+func (e *Event) birthRangeFromAge(age *Age) (DateRange, bool) {
+	if !e.HasDate() {
+		return NewZeroDateRange(), false
+	}
+	return BirthRangeFromAge(e.Date.Date, age)
+}
+
 // String returns a string representation of the event.
 func (e *Event) String() string {
 	if e.IsCustom() {
@@ -5,6 +5,11 @@ import "fmt"
 // ParseEvent parses an event from a GedcomLine.
 // Handles both standard events (BIRT, DEAT, etc.) and custom events (EVEN with TYPE).
 func ParseEvent(eventLine *GedcomLine) (*Event, error) {
+	return parseEvent(eventLine, nil)
+}
+
+// parseEvent is ParseEvent with a selected date locale.
+func parseEvent(eventLine *GedcomLine, locale *DateLocale) (*Event, error) {
 	if eventLine == nil {
 		return nil, fmt.Errorf("event line is nil")
 	}
@@ -37,7 +42,7 @@ func ParseEvent(eventLine *GedcomLine) (*Event, error) {
 	// Parse date
 	dateLines := eventLine.GetLines("DATE")
 	if len(dateLines) > 0 {
-		event.Date = NewDateNodeFromLine(dateLines[0])
+		event.Date = newDateNodeFromLine(dateLines[0], locale)
 	}
 
 	// Parse place
@@ -46,6 +51,17 @@ func ParseEvent(eventLine *GedcomLine) (*Event, error) {
 		event.Place = NewPlaceNodeFromLine(placeLines[0])
 	}
 
+	// Parse ages: the individual's, or the spouses' on family events
+	if ageLines := eventLine.GetLines("AGE"); len(ageLines) > 0 {
+		event.Age = NewAgeFromLine(ageLines[0])
+	}
+	if ageLines := eventLine.GetLines("HUSB.AGE"); len(ageLines) > 0 {
+		event.HusbandAge = NewAgeFromLine(ageLines[0])
+	}
+	if ageLines := eventLine.GetLines("WIFE.AGE"); len(ageLines) > 0 {
+		event.WifeAge = NewAgeFromLine(ageLines[0])
+	}
+
 	// Parse sources
 	sourceLines := eventLine.GetLines("SOUR")
 	event.Sources = make([]string, 0, len(sourceLines))
@@ -89,10 +105,16 @@ func ExtractEvents(record Record) []*Event {
 		"CAST", "DSCR", "NATI", "PROP", "RELI", "TITL", "EVEN",
 	}
 
+	// Dates are read with the date locale of the record's tree
+	var locale *DateLocale
+	if r, ok := record.(interface{ dateLocale() *DateLocale }); ok {
+		locale = r.dateLocale()
+	}
+
 	for _, tag := range eventTags {
 		eventLines := record.GetLines(tag)
 		for _, eventLine := range eventLines {
-			event, err := ParseEvent(eventLine)
+			event, err := parseEvent(eventLine, locale)
 			if err == nil && event != nil {
 				events = append(events, event)
 			}
@@ -15,18 +15,21 @@ func NewFamilyRecord(line *GedcomLine) *FamilyRecord {
 }
 
 // GetHusband returns the husband's xref (HUSB).
+// A GEDCOM 7.0 @VOID@ pointer is returned as "".
 func (fr *FamilyRecord) GetHusband() string {
-	return fr.GetValue("HUSB")
+	return pointerValue(fr.GetValue("HUSB"))
 }
 
 // GetWife returns the wife's xref (WIFE).
+// A GEDCOM 7.0 @VOID@ pointer is returned as "".
 func (fr *FamilyRecord) GetWife() string {
-	return fr.GetValue("WIFE")
+	return pointerValue(fr.GetValue("WIFE"))
 }
 
 // GetChildren returns all children xrefs (CHIL).
+// GEDCOM 7.0 @VOID@ pointers are skipped.
 func (fr *FamilyRecord) GetChildren() []string {
-	return fr.GetValues("CHIL")
+	return pointerValues(fr.GetValues("CHIL"))
 }
 
 // GetMarriageDate returns the marriage date.
@@ -92,7 +95,7 @@ func (fr *FamilyRecord) GetMarriageDateParsed() (*GedcomDate, error) {
 	if dateStr == "" {
 		return nil, fmt.Errorf("no marriage date found")
 	}
-	return ParseDate(dateStr)
+	return ParseDateWithLocale(dateStr, fr.dateLocale())
 }
 
 // GetDivorceDateParsed returns the divorce date as a parsed GedcomDate.
@@ -103,20 +106,22 @@ func (fr *FamilyRecord) GetDivorceDateParsed() (*GedcomDate, error) {
 	if dateStr == "" {
 		return nil, nil
 	}
-	return ParseDate(dateStr)
+	return ParseDateWithLocale(dateStr, fr.dateLocale())
 }
 
-// GetMarriagePlaceParsed returns the marriage place as a parsed GedcomPlace.
+// GetMarriagePlaceParsed returns the marriage place as a parsed GedcomPlace, with its
+// coordinates and the tree's place form.
 // Returns error if place string is empty or cannot be parsed.
 func (fr *FamilyRecord) GetMarriagePlaceParsed() (*GedcomPlace, error) {
 	placeStr := fr.GetMarriagePlace()
 	if placeStr == "" {
 		return nil, fmt.Errorf("no marriage place found")
 	}
-	return ParsePlace(placeStr)
+	return fr.getPlace("MARR.PLAC")
 }
 
-// GetDivorcePlaceParsed returns the divorce place as a parsed GedcomPlace.
+// GetDivorcePlaceParsed returns the divorce place as a parsed GedcomPlace, with its
+// coordinates and the tree's place form.
 // Returns nil without error if place string is empty.
 // Returns error only if place string cannot be parsed.
 func (fr *FamilyRecord) GetDivorcePlaceParsed() (*GedcomPlace, error) {
@@ -124,7 +129,7 @@ func (fr *FamilyRecord) GetDivorcePlaceParsed() (*GedcomPlace, error) {
 	if placeStr == "" {
 		return nil, nil
 	}
-	return ParsePlace(placeStr)
+	return fr.getPlace("DIV.PLAC")
 }
 
 // ============================================================================
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// foldLetters folds the letters that do not decompose into a base letter and
+// a diacritic.
+var foldLetters = strings.NewReplacer("ø", "o", "æ", "ae", "œ", "oe", "ß", "ss", "ł", "l", "đ", "d", "ð", "d", "þ", "th")
+
+// FoldName returns the form names of people and places are compared and
+// searched in: lower case, without diacritics or punctuation, with single
+// spaces between words. "García-Márquez" and "garcia marquez" both become
+// "garcia marquez".
+func FoldName(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		folded = name
+	}
+	folded = foldLetters.Replace(strings.ToLower(folded))
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
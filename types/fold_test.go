@@ -0,0 +1,22 @@
+package types
+
+import "testing"
+
+func TestFoldName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"García-Márquez", "garcia marquez"},
+		{"  Søren   Kierkegaard ", "soren kierkegaard"},
+		{"Guðrún", "gudrun"},
+		{"Þórshöfn", "thorshofn"},
+		{"van 't Hof", "van t hof"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := FoldName(tt.name); got != tt.want {
+			t.Errorf("FoldName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package types
+
+import "strings"
+
+// GEDCOM versions recognized from HEAD.GEDC.VERS.
+const (
+	GedcomVersion551 = "5.5.1"
+	GedcomVersion70  = "7.0"
+)
+
+// VoidPointer is the GEDCOM 7.0 null pointer. It stands in for a pointer to a
+// record that does not exist, e.g. "1 HUSB @VOID@" with a PHRASE naming an
+// unknown husband.
+const VoidPointer = "@VOID@"
+
+// IsGedcom7 reports whether a HEAD.GEDC.VERS value denotes GEDCOM 7.x.
+func IsGedcom7(version string) bool {
+	version = strings.TrimSpace(version)
+	return version == "7" || strings.HasPrefix(version, "7.")
+}
+
+// IsVoidPointer reports whether value is the GEDCOM 7.0 @VOID@ pointer.
+func IsVoidPointer(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), VoidPointer)
+}
+
+// pointerValue returns value unless it is @VOID@, in which case it returns "".
+func pointerValue(value string) string {
+	if IsVoidPointer(value) {
+		return ""
+	}
+	return value
+}
+
+// pointerValues removes @VOID@ pointers from values.
+func pointerValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !IsVoidPointer(value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// ExternalID is a GEDCOM 7.0 EXID structure: an identifier maintained by an
+// external authority. Type is a URI naming that authority and may be empty.
+type ExternalID struct {
+	ID   string
+	Type string
+}
+
+// GetExternalIDs returns all external identifiers (EXID) of the record.
+func (br *BaseRecord) GetExternalIDs() []ExternalID {
+	lines := br.GetLines("EXID")
+	ids := make([]ExternalID, 0, len(lines))
+	for _, line := range lines {
+		ids = append(ids, ExternalID{
+			ID:   line.Value,
+			Type: line.GetValue("TYPE"),
+		})
+	}
+	return ids
+}
+
+// GetSharedNotes returns all shared note xrefs (SNOTE), excluding @VOID@.
+// This is the GEDCOM 7.0 counterpart of GetNotes for NOTE pointers.
+func (br *BaseRecord) GetSharedNotes() []string {
+	return pointerValues(br.GetValues("SNOTE"))
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsGedcom7(t *testing.T) {
+	tests := map[string]bool{
+		"7.0":    true,
+		"7.0.14": true,
+		" 7.1 ":  true,
+		"7":      true,
+		"5.5.1":  false,
+		"5.5.5":  false,
+		"":       false,
+		"70":     false,
+	}
+	for version, want := range tests {
+		if got := IsGedcom7(version); got != want {
+			t.Errorf("IsGedcom7(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestVoidPointers(t *testing.T) {
+	famLine := NewGedcomLine(0, "FAM", "", "@F1@")
+	famLine.AddChild(NewGedcomLine(1, "HUSB", "@VOID@", ""))
+	famLine.AddChild(NewGedcomLine(1, "WIFE", "@I2@", ""))
+	famLine.AddChild(NewGedcomLine(1, "CHIL", "@VOID@", ""))
+	famLine.AddChild(NewGedcomLine(1, "CHIL", "@I3@", ""))
+	fam := NewFamilyRecord(famLine)
+
+	if got := fam.GetHusband(); got != "" {
+		t.Errorf("GetHusband() = %q, want empty for @VOID@", got)
+	}
+	if got := fam.GetWife(); got != "@I2@" {
+		t.Errorf("GetWife() = %q, want %q", got, "@I2@")
+	}
+	if got := fam.GetChildren(); !reflect.DeepEqual(got, []string{"@I3@"}) {
+		t.Errorf("GetChildren() = %v, want [@I3@]", got)
+	}
+	// The raw value is still available to callers that care about the difference
+	if got := fam.GetValue("HUSB"); got != VoidPointer {
+		t.Errorf("GetValue(HUSB) = %q, want %q", got, VoidPointer)
+	}
+
+	indiLine := NewGedcomLine(0, "INDI", "", "@I1@")
+	indiLine.AddChild(NewGedcomLine(1, "FAMC", "@VOID@", ""))
+	indiLine.AddChild(NewGedcomLine(1, "FAMS", "@F1@", ""))
+	indi := NewIndividualRecord(indiLine)
+	if got := indi.GetFamiliesAsChild(); len(got) != 0 {
+		t.Errorf("GetFamiliesAsChild() = %v, want none", got)
+	}
+	if got := indi.GetFamiliesAsSpouse(); !reflect.DeepEqual(got, []string{"@F1@"}) {
+		t.Errorf("GetFamiliesAsSpouse() = %v, want [@F1@]", got)
+	}
+}
+
+func TestBaseRecord_GetExternalIDs(t *testing.T) {
+	line := NewGedcomLine(0, "INDI", "", "@I1@")
+	exid := NewGedcomLine(1, "EXID", "123", "")
+	exid.AddChild(NewGedcomLine(2, "TYPE", "http://example.com/ids", ""))
+	line.AddChild(exid)
+	line.AddChild(NewGedcomLine(1, "EXID", "abc", ""))
+	line.AddChild(NewGedcomLine(1, "SNOTE", "@N1@", ""))
+	line.AddChild(NewGedcomLine(1, "SNOTE", "@VOID@", ""))
+	record := NewIndividualRecord(line)
+
+	want := []ExternalID{
+		{ID: "123", Type: "http://example.com/ids"},
+		{ID: "abc"},
+	}
+	if got := record.GetExternalIDs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetExternalIDs() = %+v, want %+v", got, want)
+	}
+	if got := record.GetSharedNotes(); !reflect.DeepEqual(got, []string{"@N1@"}) {
+		t.Errorf("GetSharedNotes() = %v, want [@N1@]", got)
+	}
+}
+
+func TestHeaderRecord_GetSchemaTags(t *testing.T) {
+	line := NewGedcomLine(0, "HEAD", "", "")
+	gedc := NewGedcomLine(1, "GEDC", "", "")
+	gedc.AddChild(NewGedcomLine(2, "VERS", "7.0", ""))
+	line.AddChild(gedc)
+	schma := NewGedcomLine(1, "SCHMA", "", "")
+	schma.AddChild(NewGedcomLine(2, "TAG", "_SKYPEID http://xmlns.com/foaf/0.1/skypeID", ""))
+	schma.AddChild(NewGedcomLine(2, "TAG", "_MEMBER http://xmlns.com/foaf/0.1/member", ""))
+	line.AddChild(schma)
+	header := NewHeaderRecord(line)
+
+	if !header.IsGedcom7() {
+		t.Error("expected IsGedcom7() to be true")
+	}
+	want := map[string]string{
+		"_SKYPEID": "http://xmlns.com/foaf/0.1/skypeID",
+		"_MEMBER":  "http://xmlns.com/foaf/0.1/member",
+	}
+	if got := header.GetSchemaTags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSchemaTags() = %v, want %v", got, want)
+	}
+}
+
+func TestParseDate_Gedcom7Grammar(t *testing.T) {
+	tests := []struct {
+		input    string
+		calendar Calendar
+		year     int
+		month    int
+		day      int
+		str      string
+	}{
+		{"GREGORIAN 15 JAN 1800", CalendarGregorian, 1800, 1, 15, "15 JAN 1800"},
+		{"JULIAN 29 FEB 1700", CalendarJulian, 1700, 2, 29, "JULIAN 29 FEB 1700"},
+		{"HEBREW 1 TSH 5600", CalendarHebrew, 5600, 1, 1, "HEBREW 1 TSH 5600"},
+		{"FRENCH_R 18 BRUM 8", CalendarFrench, 8, 2, 18, "FRENCH_R 18 BRUM 8"},
+		{"44 BCE", CalendarGregorian, -44, 0, 0, "44 BCE"},
+		{"15 MAR 44 BCE", CalendarGregorian, -44, 3, 15, "15 MAR 44 BCE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+			}
+			if date.Calendar != tt.calendar {
+				t.Errorf("Calendar = %q, want %q", date.Calendar, tt.calendar)
+			}
+			if date.Year != tt.year || date.Month != tt.month || date.Day != tt.day {
+				t.Errorf("got %d-%d-%d, want %d-%d-%d", date.Year, date.Month, date.Day, tt.year, tt.month, tt.day)
+			}
+			if got := date.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+
+	if _, err := ParseDate("29 FEB 1700"); err == nil {
+		t.Error("29 FEB 1700 is not a Gregorian date and should fail")
+	}
+	if _, err := ParseDate("HEBREW 1 JAN 5600"); err == nil {
+		t.Error("JAN is not a Hebrew month and should fail")
+	}
+
+	date, err := ParseDate("BET JULIAN 1700 AND JULIAN 1710")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Calendar != CalendarJulian || date.StartYear != 1700 || date.EndYear != 1710 {
+		t.Errorf("unexpected range %+v", date)
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "strings"
+
 // HeaderRecord represents a Header (HEAD) record with metadata methods.
 type HeaderRecord struct {
 	*BaseRecord
@@ -22,6 +24,38 @@ func (hr *HeaderRecord) GetGedcomForm() string {
 	return hr.GetValue("GEDC.FORM")
 }
 
+// IsGedcom7 returns true if the header declares GEDCOM 7.x.
+func (hr *HeaderRecord) IsGedcom7() bool {
+	return IsGedcom7(hr.GetGedcomVersion())
+}
+
+// GetSchemaTags returns the extension tag declarations (SCHMA.TAG) of a
+// GEDCOM 7.0 header, mapping each extension tag to its defining URI.
+// For example "2 TAG _SKYPEID http://xmlns.com/foaf/0.1/skypeID" maps
+// "_SKYPEID" to "http://xmlns.com/foaf/0.1/skypeID".
+func (hr *HeaderRecord) GetSchemaTags() map[string]string {
+	tags := make(map[string]string)
+	for _, value := range hr.GetValues("SCHMA.TAG") {
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		uri := ""
+		if len(fields) > 1 {
+			uri = fields[1]
+		}
+		tags[fields[0]] = uri
+	}
+	return tags
+}
+
+// GetPlaceForm returns the jurisdiction names of the default place form
+// (PLAC.FORM), e.g. ["City", "County", "State", "Country"], or nil if the
+// header declares none.
+func (hr *HeaderRecord) GetPlaceForm() []string {
+	return ParsePlaceForm(hr.GetValue("PLAC.FORM"))
+}
+
 // GetCharacterEncoding returns the character encoding (CHAR).
 func (hr *HeaderRecord) GetCharacterEncoding() string {
 	return hr.GetValue("CHAR")
@@ -0,0 +1,186 @@
+package types
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// identityTags are the tags a record identifier is read from, in order of
+// preference: the vendor _UID, the GEDCOM 7.0 UID and the GEDCOM 7.0 EXID.
+var identityTags = []string{"_UID", "UID", "EXID"}
+
+// uuidNamespace is the name space of the UUIDs derived by DeriveUUID.
+var uuidNamespace = [16]byte{
+	0x6c, 0x69, 0x67, 0x6e, 0x65, 0x6f, 0x75, 0x73,
+	0x2d, 0x67, 0x65, 0x64, 0x63, 0x6f, 0x6d, 0x21,
+}
+
+// DeriveUUID returns a name-based UUID (version 5) for name within
+// namespace. The same arguments always give the same UUID.
+func DeriveUUID(namespace, name string) string {
+	h := sha1.New()
+	h.Write(uuidNamespace[:])
+	h.Write([]byte(namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(name))
+	b := h.Sum(nil)[:16]
+
+	// Set version (5) and variant bits according to RFC 4122
+	b[6] = (b[6] & 0x0f) | 0x50
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return formatUUID(b)
+}
+
+// formatUUID formats 16 bytes in the canonical 8-4-4-4-12 form.
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%12x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// parseUUID returns the canonical form of a UUID written with or without
+// hyphens or braces, or "" if value is not one. A 36-digit value is taken to
+// be a _UID with a 4-digit checksum, as written by PAF and many other
+// applications, and the checksum is dropped.
+func parseUUID(value string) string {
+	digits := strings.NewReplacer("-", "", "{", "", "}", "").Replace(strings.TrimSpace(value))
+	if len(digits) != 32 && len(digits) != 36 {
+		return ""
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return ""
+	}
+	return formatUUID(b[:16])
+}
+
+// RecordIdentifier returns the UUID stated by the record's first _UID, UID or
+// EXID line, or "" if it has none. Values in UUID form are used as they are;
+// other values are turned into a UUID with DeriveUUID, for an EXID within the
+// name space of its TYPE.
+func RecordIdentifier(record Record) string {
+	for _, tag := range identityTags {
+		for _, line := range record.GetLines(tag) {
+			value := strings.TrimSpace(line.Value)
+			if value == "" {
+				continue
+			}
+			if uuid := parseUUID(value); uuid != "" {
+				return uuid
+			}
+			if tag == "EXID" {
+				return DeriveUUID("EXID "+line.GetValue("TYPE"), value)
+			}
+			return DeriveUUID(tag, value)
+		}
+	}
+	return ""
+}
+
+// HasIdentityTag reports whether the record has a _UID, UID or EXID line.
+func HasIdentityTag(record Record) bool {
+	for _, tag := range identityTags {
+		if len(record.GetLines(tag)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// StableUUID returns a UUID for record that stays the same across parses:
+// the one from RecordIdentifier, or else one derived from namespace and the
+// record's xref. Records without an xref are named by their type and line
+// number instead.
+func StableUUID(record Record, namespace string) string {
+	if uuid := RecordIdentifier(record); uuid != "" {
+		return uuid
+	}
+	return DeriveUUID(namespace, recordName(record))
+}
+
+// recordName names record within its file, see StableUUID.
+func recordName(record Record) string {
+	if xref := record.XrefID(); xref != "" {
+		return xref
+	}
+	if record.Type() == RecordTypeHEAD || record.Type() == RecordTypeTRLR {
+		return string(record.Type())
+	}
+	return fmt.Sprintf("%s@%d", record.Type(), record.FirstLine().LineNumber)
+}
+
+// FormatUID returns uuid as a _UID value: 32 upper-case hex digits followed
+// by the 4-digit checksum used by PAF and the applications that follow it.
+func FormatUID(uuid string) string {
+	canonical := parseUUID(uuid)
+	if canonical == "" {
+		return ""
+	}
+	b, _ := hex.DecodeString(strings.ReplaceAll(canonical, "-", ""))
+
+	var sumA, sumB byte
+	for _, v := range b {
+		sumA += v
+		sumB += sumA
+	}
+	return fmt.Sprintf("%X%02X%02X", b, sumA, sumB)
+}
+
+// AssignStableUUIDs replaces the UUID of every record with its StableUUID, so
+// that GetRecordByUUID finds the same record in every parse of the file.
+// namespace identifies the file; parsers use its HEAD.FILE or its name.
+//
+// If several records state the same identifier, the first keeps it and the
+// others get one derived from their xref.
+func (gt *GedcomTree) AssignStableUUIDs(namespace string) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	records := gt.sortedRecords()
+	gt.uuidIndex = make(map[string]Record, len(records))
+	assigner := NewUUIDAssigner(namespace)
+	for _, record := range records {
+		gt.uuidIndex[assigner.Assign(record)] = record
+	}
+}
+
+// UUIDAssigner gives records their StableUUID one at a time, for parsers that
+// hand out records in file order instead of building a tree. Records get the
+// same UUIDs as from AssignStableUUIDs on the whole file.
+type UUIDAssigner struct {
+	namespace string
+	used      map[string]bool
+}
+
+// NewUUIDAssigner returns a UUIDAssigner for the file identified by namespace.
+func NewUUIDAssigner(namespace string) *UUIDAssigner {
+	return &UUIDAssigner{
+		namespace: namespace,
+		used:      make(map[string]bool),
+	}
+}
+
+// Namespace returns the name space UUIDs are derived in.
+func (a *UUIDAssigner) Namespace() string {
+	return a.namespace
+}
+
+// Assign replaces the UUID of record with its StableUUID and returns it. An
+// identifier already given to an earlier record is replaced by one derived
+// from the record's xref.
+func (a *UUIDAssigner) Assign(record Record) string {
+	uuid := StableUUID(record, a.namespace)
+	if a.used[uuid] {
+		uuid = DeriveUUID(a.namespace, recordName(record))
+	}
+	if a.used[uuid] {
+		uuid = generateUUID()
+	}
+
+	if br, ok := record.(interface{ setUUID(string) }); ok {
+		br.setUUID(uuid)
+	}
+	a.used[record.UUID()] = true
+	return record.UUID()
+}
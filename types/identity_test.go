@@ -0,0 +1,99 @@
+package types
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestDeriveUUID(t *testing.T) {
+	a := DeriveUUID("family.ged", "@I1@")
+	if a != DeriveUUID("family.ged", "@I1@") {
+		t.Error("DeriveUUID() is not deterministic")
+	}
+	if a == DeriveUUID("family.ged", "@I2@") || a == DeriveUUID("other.ged", "@I1@") {
+		t.Error("DeriveUUID() should differ for other names and name spaces")
+	}
+
+	v5 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if !v5.MatchString(a) {
+		t.Errorf("DeriveUUID() = %q, want UUID v5 format", a)
+	}
+}
+
+func TestFormatUID(t *testing.T) {
+	uuid := "0f7d2e1c-4a6b-4c55-9e3d-2b1a09f8e7d6"
+	uid := FormatUID(uuid)
+	if len(uid) != 36 || uid[:32] != "0F7D2E1C4A6B4C559E3D2B1A09F8E7D6" {
+		t.Errorf("FormatUID() = %q", uid)
+	}
+	if got := parseUUID(uid); got != uuid {
+		t.Errorf("parseUUID(FormatUID()) = %q, want %q", got, uuid)
+	}
+	if FormatUID("not a uuid") != "" {
+		t.Error("FormatUID() of an invalid UUID should be empty")
+	}
+}
+
+func TestRecordIdentifier(t *testing.T) {
+	uuid := "0f7d2e1c-4a6b-4c55-9e3d-2b1a09f8e7d6"
+	record := func(tag, value string) Record {
+		line := NewGedcomLine(0, "INDI", "", "@I1@")
+		id := NewGedcomLine(1, tag, value, "")
+		if tag == "EXID" {
+			id.AddChild(NewGedcomLine(2, "TYPE", "https://example.com/people", ""))
+		}
+		line.AddChild(id)
+		return NewIndividualRecord(line)
+	}
+
+	tests := []struct {
+		name   string
+		record Record
+		want   string
+	}{
+		{"_UID with checksum", record("_UID", FormatUID(uuid)), uuid},
+		{"UID", record("UID", uuid), uuid},
+		{"UID in braces", record("UID", "{"+uuid+"}"), uuid},
+		{"UID not in UUID form", record("UID", "person-1"), DeriveUUID("UID", "person-1")},
+		{"EXID", record("EXID", "1234"), DeriveUUID("EXID https://example.com/people", "1234")},
+		{"none", NewIndividualRecord(NewGedcomLine(0, "INDI", "", "@I1@")), ""},
+	}
+
+	for _, tt := range tests {
+		if got := RecordIdentifier(tt.record); got != tt.want {
+			t.Errorf("%s: RecordIdentifier() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGedcomTree_AssignStableUUIDs(t *testing.T) {
+	uuid := "0f7d2e1c-4a6b-4c55-9e3d-2b1a09f8e7d6"
+	build := func() *GedcomTree {
+		tree := mutationTestTree()
+		tree.GetIndividual("@I1@").FirstLine().AddChild(NewGedcomLine(1, "_UID", FormatUID(uuid), ""))
+		tree.GetIndividual("@I2@").FirstLine().AddChild(NewGedcomLine(1, "UID", uuid, ""))
+		tree.AssignStableUUIDs("family.ged")
+		return tree
+	}
+	tree, again := build(), build()
+
+	for _, record := range tree.GetAllRecords() {
+		if tree.GetRecordByUUID(record.UUID()) != record {
+			t.Errorf("%s not indexed by its UUID", record.XrefID())
+		}
+		if other := again.GetRecordByUUID(record.UUID()); other == nil || other.XrefID() != record.XrefID() {
+			t.Errorf("%s has another UUID in the second tree", record.XrefID())
+		}
+	}
+
+	if got := tree.GetIndividual("@I1@").UUID(); got != uuid {
+		t.Errorf("@I1@ UUID = %q, want %q", got, uuid)
+	}
+	// @I2@ states the same identifier, so it falls back to its xref
+	if got := tree.GetIndividual("@I2@").UUID(); got != DeriveUUID("family.ged", "@I2@") {
+		t.Errorf("@I2@ UUID = %q", got)
+	}
+	if got := tree.GetFamily("@F1@").UUID(); got != DeriveUUID("family.ged", "@F1@") {
+		t.Errorf("@F1@ UUID = %q", got)
+	}
+}
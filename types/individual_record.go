@@ -79,13 +79,15 @@ func (ir *IndividualRecord) GetDeathData() map[string]interface{} {
 }
 
 // GetFamiliesAsSpouse returns all family xrefs where this individual is a spouse (FAMS).
+// GEDCOM 7.0 @VOID@ pointers are skipped.
 func (ir *IndividualRecord) GetFamiliesAsSpouse() []string {
-	return ir.GetValues("FAMS")
+	return pointerValues(ir.GetValues("FAMS"))
 }
 
 // GetFamiliesAsChild returns all family xrefs where this individual is a child (FAMC).
+// GEDCOM 7.0 @VOID@ pointers are skipped.
 func (ir *IndividualRecord) GetFamiliesAsChild() []string {
-	return ir.GetValues("FAMC")
+	return pointerValues(ir.GetValues("FAMC"))
 }
 
 // GetOccupation returns the occupation value.
@@ -141,7 +143,7 @@ func (ir *IndividualRecord) GetBirthDateParsed() (*GedcomDate, error) {
 	if dateStr == "" {
 		return nil, fmt.Errorf("no birth date found")
 	}
-	return ParseDate(dateStr)
+	return ParseDateWithLocale(dateStr, ir.dateLocale())
 }
 
 // GetDeathDateParsed returns the death date as a parsed GedcomDate.
@@ -151,30 +153,33 @@ func (ir *IndividualRecord) GetDeathDateParsed() (*GedcomDate, error) {
 	if dateStr == "" {
 		return nil, fmt.Errorf("no death date found")
 	}
-	return ParseDate(dateStr)
+	return ParseDateWithLocale(dateStr, ir.dateLocale())
 }
 
-// GetBirthPlaceParsed returns the birth place as a parsed GedcomPlace.
+// GetBirthPlaceParsed returns the birth place as a parsed GedcomPlace, with its
+// coordinates and the tree's place form.
 // Returns error if place string is empty or cannot be parsed.
 func (ir *IndividualRecord) GetBirthPlaceParsed() (*GedcomPlace, error) {
 	placeStr := ir.GetBirthPlace()
 	if placeStr == "" {
 		return nil, fmt.Errorf("no birth place found")
 	}
-	return ParsePlace(placeStr)
+	return ir.getPlace("BIRT.PLAC")
 }
 
-// GetDeathPlaceParsed returns the death place as a parsed GedcomPlace.
+// GetDeathPlaceParsed returns the death place as a parsed GedcomPlace, with its
+// coordinates and the tree's place form.
 // Returns error if place string is empty or cannot be parsed.
 func (ir *IndividualRecord) GetDeathPlaceParsed() (*GedcomPlace, error) {
 	placeStr := ir.GetDeathPlace()
 	if placeStr == "" {
 		return nil, fmt.Errorf("no death place found")
 	}
-	return ParsePlace(placeStr)
+	return ir.getPlace("DEAT.PLAC")
 }
 
-// GetNamesParsed returns all names as parsed GedcomName objects.
+// GetNamesParsed returns all names as parsed GedcomName objects, with the
+// tree's naming convention (see GedcomTree.NameConvention).
 // Supports multiple NAME records per individual (GEDCOM 5.5.1).
 // Returns empty slice if no names found.
 func (ir *IndividualRecord) GetNamesParsed() ([]*GedcomName, error) {
@@ -183,9 +188,10 @@ func (ir *IndividualRecord) GetNamesParsed() ([]*GedcomName, error) {
 		return []*GedcomName{}, nil
 	}
 
+	convention := ir.nameConvention()
 	names := make([]*GedcomName, 0, len(nameLines))
 	for _, nameLine := range nameLines {
-		name, err := ParseName(nameLine)
+		name, err := ParseNameWithConvention(nameLine, convention)
 		if err != nil {
 			// Continue parsing other names even if one fails
 			continue
@@ -198,7 +204,8 @@ func (ir *IndividualRecord) GetNamesParsed() ([]*GedcomName, error) {
 	return names, nil
 }
 
-// GetPrimaryName returns the first (primary) name as a parsed GedcomName.
+// GetPrimaryName returns the first (primary) name as a parsed GedcomName,
+// with the tree's naming convention (see GedcomTree.NameConvention).
 // Returns nil if no names found.
 func (ir *IndividualRecord) GetPrimaryName() (*GedcomName, error) {
 	nameLines := ir.GetLines("NAME")
@@ -206,7 +213,7 @@ func (ir *IndividualRecord) GetPrimaryName() (*GedcomName, error) {
 		return nil, fmt.Errorf("no name found")
 	}
 
-	return ParseName(nameLines[0])
+	return ParseNameWithConvention(nameLines[0], ir.nameConvention())
 }
 
 // GetNameByType returns a name of the specified type.
@@ -285,7 +292,7 @@ func (ir *IndividualRecord) Birth() *Event {
 		return nil
 	}
 
-	event, err := ParseEvent(birthLines[0])
+	event, err := parseEvent(birthLines[0], ir.dateLocale())
 	if err != nil || event == nil {
 		return nil
 	}
@@ -300,7 +307,7 @@ func (ir *IndividualRecord) Death() *Event {
 		return nil
 	}
 
-	event, err := ParseEvent(deathLines[0])
+	event, err := parseEvent(deathLines[0], ir.dateLocale())
 	if err != nil || event == nil {
 		return nil
 	}
@@ -355,7 +362,7 @@ func (ir *IndividualRecord) Baptism() *Event {
 		return nil
 	}
 
-	event, err := ParseEvent(baptismLines[0])
+	event, err := parseEvent(baptismLines[0], ir.dateLocale())
 	if err != nil || event == nil {
 		return nil
 	}
@@ -369,7 +376,7 @@ func (ir *IndividualRecord) Burial() *Event {
 		return nil
 	}
 
-	event, err := ParseEvent(burialLines[0])
+	event, err := parseEvent(burialLines[0], ir.dateLocale())
 	if err != nil || event == nil {
 		return nil
 	}
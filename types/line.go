@@ -13,8 +13,11 @@ import (
 // The hierarchical structure is maintained through:
 //   - Parent pointer: Links to the parent line
 //   - Children map: Groups child lines by tag for efficient access
+//   - Child order: The original order of all children, see ChildLines
 //
 // This structure allows representing the complete nested hierarchy of a GEDCOM file.
+// Children should be added and removed with AddChild and RemoveChild so that the
+// map and the order stay in step.
 type GedcomLine struct {
 	Level      int                    // 0, 1, 2, etc.
 	Tag        string                 // TAG name (e.g., "NAME", "BIRT")
@@ -23,6 +26,18 @@ type GedcomLine struct {
 	LineNumber int                    // Original line number in file
 	Parent     *GedcomLine            // Parent line (nil for level 0)
 	Children   map[string][]*GedcomLine // Children grouped by tag
+
+	order    []*GedcomLine // Children in file (insertion) order
+	raw      string        // Original text of the line, see Raw
+	original *lineState    // Fields at the time MarkPristine was called
+}
+
+// lineState is a snapshot of the fields that make up a line's text.
+type lineState struct {
+	level  int
+	tag    string
+	value  string
+	xrefID string
 }
 
 // NewGedcomLine creates a new GedcomLine with the specified fields.
@@ -42,9 +57,147 @@ func (gl *GedcomLine) AddChild(child *GedcomLine) {
 		gl.Children = make(map[string][]*GedcomLine)
 	}
 	gl.Children[child.Tag] = append(gl.Children[child.Tag], child)
+	gl.order = append(gl.order, child)
 	child.Parent = gl
 }
 
+// InsertChild inserts child at position index of ChildLines and sets the
+// child's parent. An index outside the range appends the child. Among children
+// with the same tag, child is placed after those that come before index.
+func (gl *GedcomLine) InsertChild(index int, child *GedcomLine) {
+	order := gl.ChildLines()
+	if index < 0 || index >= len(order) {
+		gl.AddChild(child)
+		return
+	}
+
+	sameTag := 0
+	for _, line := range order[:index] {
+		if line.Tag == child.Tag {
+			sameTag++
+		}
+	}
+
+	siblings := gl.Children[child.Tag]
+	gl.Children[child.Tag] = append(siblings[:sameTag:sameTag], append([]*GedcomLine{child}, siblings[sameTag:]...)...)
+	gl.order = append(order[:index:index], append([]*GedcomLine{child}, order[index:]...)...)
+	child.Parent = gl
+}
+
+// RemoveChild removes child from this line. Returns false if child is not a
+// child of this line.
+func (gl *GedcomLine) RemoveChild(child *GedcomLine) bool {
+	siblings := gl.Children[child.Tag]
+	index := -1
+	for i, sibling := range siblings {
+		if sibling == child {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+
+	if len(siblings) == 1 {
+		delete(gl.Children, child.Tag)
+	} else {
+		gl.Children[child.Tag] = append(siblings[:index:index], siblings[index+1:]...)
+	}
+	for i, line := range gl.order {
+		if line == child {
+			gl.order = append(gl.order[:i:i], gl.order[i+1:]...)
+			break
+		}
+	}
+	child.Parent = nil
+	return true
+}
+
+// ChildLines returns all children in their original order (file order for
+// parsed lines, insertion order otherwise). The returned slice must not be
+// modified.
+//
+// Children written directly into the Children map are picked up as well and
+// placed after the known ones, ordered by line number.
+func (gl *GedcomLine) ChildLines() []*GedcomLine {
+	count := 0
+	for _, children := range gl.Children {
+		count += len(children)
+	}
+	if count != len(gl.order) {
+		gl.syncOrder()
+	}
+	return gl.order
+}
+
+// syncOrder rebuilds the child order after the Children map was changed directly.
+func (gl *GedcomLine) syncOrder() {
+	present := make(map[*GedcomLine]bool)
+	for _, children := range gl.Children {
+		for _, child := range children {
+			present[child] = true
+		}
+	}
+
+	order := make([]*GedcomLine, 0, len(present))
+	for _, child := range gl.order {
+		if present[child] {
+			order = append(order, child)
+			delete(present, child)
+		}
+	}
+
+	added := make([]*GedcomLine, 0, len(present))
+	for child := range present {
+		added = append(added, child)
+	}
+	sort.SliceStable(added, func(i, j int) bool {
+		if added[i].LineNumber != added[j].LineNumber {
+			return added[i].LineNumber < added[j].LineNumber
+		}
+		return added[i].Tag < added[j].Tag
+	})
+
+	gl.order = append(order, added...)
+}
+
+// Raw returns the original text of the line as it appeared in the file,
+// including line terminators, any CONC/CONT lines that continued it and any
+// blank lines before it. It is only recorded by parsers running in lossless
+// mode and is empty otherwise.
+func (gl *GedcomLine) Raw() string {
+	return gl.raw
+}
+
+// AppendRaw appends original text to the line. Used by parsers in lossless mode.
+func (gl *GedcomLine) AppendRaw(text string) {
+	gl.raw += text
+}
+
+// MarkPristine records the current state of the line and all its descendants,
+// so that later changes can be detected with IsModified. Parsers call it once
+// a record is complete.
+func (gl *GedcomLine) MarkPristine() {
+	gl.original = &lineState{level: gl.Level, tag: gl.Tag, value: gl.Value, xrefID: gl.XrefID}
+	for _, child := range gl.ChildLines() {
+		child.MarkPristine()
+	}
+}
+
+// IsModified returns true if the line's level, tag, value or xref changed since
+// MarkPristine was called, or if it was never marked. Only the line itself is
+// compared; children are checked individually.
+func (gl *GedcomLine) IsModified() bool {
+	if gl.original == nil {
+		return true
+	}
+	return gl.original.level != gl.Level ||
+		gl.original.tag != gl.Tag ||
+		gl.original.value != gl.Value ||
+		gl.original.xrefID != gl.XrefID
+}
+
 // GetValue retrieves a value using dot notation selector (e.g., "BIRT.DATE").
 // Returns empty string if not found.
 func (gl *GedcomLine) GetValue(selector string) string {
@@ -100,25 +253,14 @@ func (gl *GedcomLine) GetLines(selector string) []*GedcomLine {
 
 // ToGED converts the line and all its children to GEDCOM format.
 // Returns a slice of strings, one per line.
-// Children are sorted by tag for consistent output.
+// Children are written in their original order (see ChildLines).
 func (gl *GedcomLine) ToGED() []string {
 	lines := []string{gl.toGEDLine()}
-	
-	// Collect all tags and sort them for consistent output
-	tags := make([]string, 0, len(gl.Children))
-	for tag := range gl.Children {
-		tags = append(tags, tag)
-	}
-	sort.Strings(tags)
-	
-	// Process children in sorted tag order
-	for _, tag := range tags {
-		children := gl.Children[tag]
-		for _, child := range children {
-			lines = append(lines, child.ToGED()...)
-		}
+
+	for _, child := range gl.ChildLines() {
+		lines = append(lines, child.ToGED()...)
 	}
-	
+
 	return lines
 }
 
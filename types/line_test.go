@@ -2,6 +2,7 @@ package types
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -190,3 +191,123 @@ func TestGedcomLine_AddChild_NilChildren(t *testing.T) {
 	}
 }
 
+
+func TestGedcomLine_ChildLinesOrder(t *testing.T) {
+	indi := NewGedcomLine(0, "INDI", "", "@I1@")
+	name := NewGedcomLine(1, "NAME", "John /Doe/", "")
+	birt := NewGedcomLine(1, "BIRT", "", "")
+	note := NewGedcomLine(1, "NOTE", "first", "")
+	name2 := NewGedcomLine(1, "NAME", "Jack /Doe/", "")
+	for _, child := range []*GedcomLine{name, birt, note, name2} {
+		indi.AddChild(child)
+	}
+
+	got := indi.ChildLines()
+	want := []*GedcomLine{name, birt, note, name2}
+	if len(got) != len(want) {
+		t.Fatalf("ChildLines() returned %d lines, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ChildLines()[%d] = %s, want %s", i, got[i].Tag, want[i].Tag)
+		}
+	}
+
+	// Tag-based access is unaffected
+	if indi.GetValue("NAME") != "John /Doe/" {
+		t.Errorf("GetValue(NAME) = %q", indi.GetValue("NAME"))
+	}
+
+	ged := indi.ToGED()
+	wantGED := []string{"0 @I1@ INDI", "1 NAME John /Doe/", "1 BIRT", "1 NOTE first", "1 NAME Jack /Doe/"}
+	if strings.Join(ged, "\n") != strings.Join(wantGED, "\n") {
+		t.Errorf("ToGED() = %q, want %q", ged, wantGED)
+	}
+
+	if !indi.RemoveChild(birt) {
+		t.Fatal("RemoveChild() = false, want true")
+	}
+	if indi.RemoveChild(birt) {
+		t.Error("RemoveChild() of a removed line = true, want false")
+	}
+	if len(indi.ChildLines()) != 3 || indi.ChildLines()[1] != note {
+		t.Errorf("ChildLines() after RemoveChild = %v", indi.ChildLines())
+	}
+	if _, ok := indi.Children["BIRT"]; ok {
+		t.Error("expected BIRT to be removed from Children")
+	}
+
+	// Lines written directly into the map are appended by line number
+	sex := &GedcomLine{Level: 1, Tag: "SEX", Value: "M", LineNumber: 5}
+	indi.Children["SEX"] = []*GedcomLine{sex}
+	if lines := indi.ChildLines(); len(lines) != 4 || lines[3] != sex {
+		t.Errorf("expected SEX to be appended, got %d lines", len(lines))
+	}
+}
+
+func TestGedcomLine_InsertChild(t *testing.T) {
+	indi := NewGedcomLine(0, "INDI", "", "@I1@")
+	name := NewGedcomLine(1, "NAME", "John /Doe/", "")
+	birt := NewGedcomLine(1, "BIRT", "", "")
+	name2 := NewGedcomLine(1, "NAME", "Jack /Doe/", "")
+	indi.AddChild(name)
+	indi.AddChild(birt)
+
+	indi.InsertChild(0, name2)
+	if lines := indi.ChildLines(); len(lines) != 3 || lines[0] != name2 || lines[1] != name {
+		t.Errorf("ChildLines() after InsertChild(0) = %v", lines)
+	}
+	if names := indi.GetLines("NAME"); len(names) != 2 || names[0] != name2 {
+		t.Errorf("expected inserted NAME first among NAME lines, got %v", names)
+	}
+	if name2.Parent != indi {
+		t.Error("expected parent to be set")
+	}
+
+	sex := NewGedcomLine(1, "SEX", "M", "")
+	indi.InsertChild(10, sex)
+	if lines := indi.ChildLines(); lines[len(lines)-1] != sex {
+		t.Error("expected out of range index to append")
+	}
+
+	indi.RemoveChild(name2)
+	indi.InsertChild(2, name2)
+	ged := strings.Join(indi.ToGED(), "\n")
+	want := "0 @I1@ INDI\n1 NAME John /Doe/\n1 BIRT\n1 NAME Jack /Doe/\n1 SEX M"
+	if ged != want {
+		t.Errorf("ToGED() = %q, want %q", ged, want)
+	}
+}
+
+func TestGedcomLine_IsModified(t *testing.T) {
+	indi := NewGedcomLine(0, "INDI", "", "@I1@")
+	name := NewGedcomLine(1, "NAME", "John /Doe/", "")
+	indi.AddChild(name)
+
+	if !indi.IsModified() {
+		t.Error("expected unmarked line to be modified")
+	}
+
+	indi.MarkPristine()
+	if indi.IsModified() || name.IsModified() {
+		t.Error("expected lines to be unmodified after MarkPristine")
+	}
+
+	indi.SetValue("NAME", "Jack /Doe/")
+	if !name.IsModified() {
+		t.Error("expected NAME to be modified after SetValue")
+	}
+	if indi.IsModified() {
+		t.Error("expected INDI to be unmodified")
+	}
+
+	indi.SetValue("NAME", "John /Doe/")
+	if name.IsModified() {
+		t.Error("expected NAME to be unmodified after restoring its value")
+	}
+
+	name.AppendRaw("1 NAME John /Doe/\r\n")
+	if name.Raw() != "1 NAME John /Doe/\r\n" {
+		t.Errorf("Raw() = %q", name.Raw())
+	}
+}
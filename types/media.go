@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MediaSource gives access to the bytes of media files referenced by
+// OBJE FILE values. A tree parsed from a file on disk resolves references
+// against the file's directory; a tree parsed from a GEDZIP archive
+// resolves them against the archive entries.
+type MediaSource interface {
+	// OpenMedia opens the media file referenced by a FILE value.
+	OpenMedia(ref string) (io.ReadCloser, error)
+}
+
+// DirMediaSource resolves media file references relative to a directory.
+// Absolute paths are opened as they are.
+type DirMediaSource struct {
+	Dir string
+}
+
+// NewDirMediaSource creates a DirMediaSource rooted at dir.
+func NewDirMediaSource(dir string) *DirMediaSource {
+	return &DirMediaSource{Dir: dir}
+}
+
+// OpenMedia implements MediaSource.
+func (ds *DirMediaSource) OpenMedia(ref string) (io.ReadCloser, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("empty media file reference")
+	}
+	if filepath.IsAbs(ref) {
+		return os.Open(ref)
+	}
+	if rel, ok := LocalMediaPath(ref); ok {
+		return os.Open(filepath.Join(ds.Dir, filepath.FromSlash(rel)))
+	}
+	return nil, fmt.Errorf("media file %q is not a local file", ref)
+}
+
+// LocalMediaPath returns the slash-separated relative path a FILE value refers
+// to. GEDCOM 7.0 FILE values are URI references: local files are relative
+// paths with percent-encoding ("photos/John%20Doe.jpg"), which is also how
+// files are named inside a GEDZIP archive. It returns false for references
+// that are not relative local paths, such as absolute URLs, absolute paths
+// and paths escaping the base directory.
+func LocalMediaPath(ref string) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(ref)
+	if err == nil && (parsed.Scheme != "" || parsed.Host != "") {
+		return "", false
+	}
+
+	name := strings.ReplaceAll(ref, "\\", "/")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	if strings.HasPrefix(name, "/") {
+		return "", false
+	}
+
+	name = path.Clean(name)
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalMediaPath(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+		ok   bool
+	}{
+		{"photo.jpg", "photo.jpg", true},
+		{"photos/John%20Doe.jpg", "photos/John Doe.jpg", true},
+		{"./photos/a.jpg", "photos/a.jpg", true},
+		{"photos\\a.jpg", "photos/a.jpg", true},
+		{"https://example.com/a.jpg", "", false},
+		{"file:///home/a.jpg", "", false},
+		{"/home/a.jpg", "", false},
+		{"../a.jpg", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := LocalMediaPath(tt.ref)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LocalMediaPath(%q) = %q, %v; want %q, %v", tt.ref, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGedcomTree_OpenMedia(t *testing.T) {
+	tree := NewGedcomTree()
+	if _, err := tree.OpenMedia("a.jpg"); err == nil {
+		t.Error("expected error without a media source")
+	}
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "photos"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "photos", "a b.jpg"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tree.SetMediaSource(NewDirMediaSource(dir))
+
+	for _, ref := range []string{"photos/a b.jpg", "photos/a%20b.jpg", filepath.Join(dir, "photos", "a b.jpg")} {
+		rc, err := tree.OpenMedia(ref)
+		if err != nil {
+			t.Errorf("OpenMedia(%q) error = %v", ref, err)
+			continue
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if string(data) != "data" {
+			t.Errorf("OpenMedia(%q) read %q", ref, data)
+		}
+	}
+}
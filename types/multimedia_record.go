@@ -17,6 +17,12 @@ func (mr *MultimediaRecord) GetFile() string {
 	return mr.GetValue("FILE")
 }
 
+// GetFiles returns all file references (FILE). GEDCOM 7.0 allows several
+// FILE structures per multimedia record, e.g. a scan and its thumbnail.
+func (mr *MultimediaRecord) GetFiles() []string {
+	return mr.GetValues("FILE")
+}
+
 // GetForm returns the media format (FORM).
 func (mr *MultimediaRecord) GetForm() string {
 	return mr.GetValue("FORM")
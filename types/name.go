@@ -31,18 +31,47 @@ type GedcomName struct {
 	Surname       string // SURN: Last name
 	Suffix        string // NSFX: Jr., Sr., III, etc.
 
+	// Convention-specific parts (see NameConvention)
+	Surnames   []string        // Surnames of a compound surname, in written order
+	Patronymic string          // Patronymic surname, such as "Jónsdóttir"
+	Convention *NameConvention // Convention the name was parsed with
+
+	// Romanized (ROMN) and phonetic (FONE) variants of the name
+	Romanized []NameVariant
+	Phonetic  []NameVariant
+
 	// Parsed status
 	IsParsed   bool
 	ParseError error
 }
 
+// NameVariant is a romanized (NAME.ROMN) or phonetic (NAME.FONE) variant of
+// a name.
+type NameVariant struct {
+	Name *GedcomName
+	Type string // Romanization or phonetic method, e.g. "hepburn" or "kana"
+}
+
 // ParseName parses a GEDCOM NAME line and returns a GedcomName.
 // Takes a GedcomLine (NAME record) as input to access sub-tags.
 // Supports full GEDCOM 5.5.1 specification:
-//   - Sub-tags: NPFX, GIVN, NICK, SPFX, SURN, NSFX, TYPE
+//   - Sub-tags: NPFX, GIVN, NICK, SPFX, SURN, NSFX, TYPE, ROMN, FONE
 //   - NAME value parsing when sub-tags are missing
 //   - Multiple name formats: "Given /Surname/", "Given Surname", etc.
+//
+// Names are parsed with NameConventionWestern. Individuals in a tree parse
+// their names with the tree's convention instead (GedcomTree.NameConvention).
 func ParseName(nameLine *GedcomLine) (*GedcomName, error) {
+	return ParseNameWithConvention(nameLine, nil)
+}
+
+// ParseNameWithConvention parses a NAME line like ParseName, with the given
+// naming convention, such as NameConventionFor(language) or
+// DetectNameConvention(nameLine.Value). A nil convention is
+// NameConventionWestern. The SURN and SPFX pieces are kept as they are
+// written; only a surname taken from the NAME value is split by the
+// convention.
+func ParseNameWithConvention(nameLine *GedcomLine, convention *NameConvention) (*GedcomName, error) {
 	if nameLine == nil {
 		return nil, fmt.Errorf("name line is nil")
 	}
@@ -58,30 +87,12 @@ func ParseName(nameLine *GedcomLine) (*GedcomName, error) {
 		ParseError: nil,
 	}
 
-	// Extract sub-tags (GEDCOM 5.5.1 specification)
-	if prefixLines := nameLine.GetLines("NPFX"); len(prefixLines) > 0 {
-		name.Prefix = strings.TrimSpace(prefixLines[0].Value)
-	}
-
-	if givenLines := nameLine.GetLines("GIVN"); len(givenLines) > 0 {
-		name.Given = strings.TrimSpace(givenLines[0].Value)
-	}
-
-	if nickLines := nameLine.GetLines("NICK"); len(nickLines) > 0 {
-		name.Nickname = strings.TrimSpace(nickLines[0].Value)
+	if convention == nil {
+		convention = NameConventionWestern
 	}
+	name.Convention = convention
 
-	if spfxLines := nameLine.GetLines("SPFX"); len(spfxLines) > 0 {
-		name.SurnamePrefix = strings.TrimSpace(spfxLines[0].Value)
-	}
-
-	if surnLines := nameLine.GetLines("SURN"); len(surnLines) > 0 {
-		name.Surname = strings.TrimSpace(surnLines[0].Value)
-	}
-
-	if suffixLines := nameLine.GetLines("NSFX"); len(suffixLines) > 0 {
-		name.Suffix = strings.TrimSpace(suffixLines[0].Value)
-	}
+	parseNamePieces(name, nameLine)
 
 	// Extract name type
 	if typeLines := nameLine.GetLines("TYPE"); len(typeLines) > 0 {
@@ -105,9 +116,23 @@ func ParseName(nameLine *GedcomLine) (*GedcomName, error) {
 		}
 	}
 
-	// If sub-tags are missing, try to parse from NAME value
-	if name.Given == "" && name.Surname == "" && name.Original != "" {
-		parseNameValue(name, name.Original)
+	// Romanized and phonetic variants, parsed with the same convention
+	for _, tag := range []string{"ROMN", "FONE"} {
+		for _, variantLine := range nameLine.GetLines(tag) {
+			variant := &GedcomName{
+				Original:   strings.TrimSpace(variantLine.Value),
+				Type:       name.Type,
+				Convention: convention,
+			}
+			parseNamePieces(variant, variantLine)
+			variant.IsParsed = variant.IsValid()
+			nameVariant := NameVariant{Name: variant, Type: strings.TrimSpace(variantLine.GetValue("TYPE"))}
+			if tag == "ROMN" {
+				name.Romanized = append(name.Romanized, nameVariant)
+			} else {
+				name.Phonetic = append(name.Phonetic, nameVariant)
+			}
+		}
 	}
 
 	// Validate
@@ -120,8 +145,48 @@ func ParseName(nameLine *GedcomLine) (*GedcomName, error) {
 	return name, nil
 }
 
+// parseNamePieces sets the components of name from the name pieces under
+// line (NPFX, GIVN, NICK, SPFX, SURN, NSFX), or from its value when there is
+// no GIVN or SURN, and then applies the name's convention. Pieces may be
+// comma-separated lists ("van,der"); a SURN list gives the Surnames.
+func parseNamePieces(name *GedcomName, line *GedcomLine) {
+	piece := func(tag string) []string {
+		var values []string
+		if lines := line.GetLines(tag); len(lines) > 0 {
+			for _, value := range strings.Split(lines[0].Value, ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+		}
+		return values
+	}
+
+	name.Prefix = strings.Join(piece("NPFX"), " ")
+	name.Given = strings.Join(piece("GIVN"), " ")
+	name.Nickname = strings.Join(piece("NICK"), " ")
+	name.SurnamePrefix = strings.Join(piece("SPFX"), " ")
+	if surnames := piece("SURN"); len(surnames) > 0 {
+		name.Surname = strings.Join(surnames, " ")
+		if len(surnames) > 1 {
+			name.Surnames = surnames
+		}
+	}
+	name.Suffix = strings.Join(piece("NSFX"), ", ")
+
+	// If sub-tags are missing, try to parse from NAME value
+	fromValue := false
+	if name.Given == "" && name.Surname == "" && name.Original != "" {
+		parseNameValue(name, name.Original)
+		fromValue = true
+	}
+
+	name.convention().apply(name, fromValue)
+}
+
 // parseNameValue parses the NAME value string when sub-tags are missing.
-// Handles formats like "Given /Surname/", "Dr. Given /Surname/ Jr.", etc.
+// Handles formats like "Given /Surname/", "Dr. Given /Surname/ Jr.",
+// "/Surname/ Given", etc.
 func parseNameValue(name *GedcomName, nameStr string) {
 	nameStr = strings.TrimSpace(nameStr)
 	if nameStr == "" {
@@ -146,8 +211,12 @@ func parseNameValue(name *GedcomName, nameStr string) {
 		// Extract surname between slashes
 		name.Surname = strings.TrimSpace(nameStr[startIdx:endIdx])
 
-		// Extract given name (everything before the first slash)
+		// Extract given name (everything before the first slash, or after
+		// the surname for surname-first conventions)
 		givenPart := strings.TrimSpace(nameStr[:startIdx-1])
+		if givenPart == "" && name.convention().SurnameFirst {
+			givenPart = strings.TrimSpace(nameStr[endIdx+1:])
+		}
 		if givenPart != "" {
 			// Try to extract prefix and suffix from given part
 			parts := strings.Fields(givenPart)
@@ -185,8 +254,12 @@ func parseNameValue(name *GedcomName, nameStr string) {
 	} else {
 		// No slashes found, try to parse as unstructured name
 		// Simple heuristic: split on spaces, assume last word is surname
+		// (first word for surname-first conventions)
 		parts := strings.Fields(nameStr)
-		if len(parts) > 1 {
+		if len(parts) > 1 && name.convention().SurnameFirst {
+			name.Surname = parts[0]
+			name.Given = strings.Join(parts[1:], " ")
+		} else if len(parts) > 1 {
 			// Last part might be surname
 			name.Surname = parts[len(parts)-1]
 			// Everything else is given name
@@ -199,7 +272,8 @@ func parseNameValue(name *GedcomName, nameStr string) {
 }
 
 // reconstructFullName reconstructs the full name from components.
-// Format: "Prefix Given SurnamePrefix Surname Suffix"
+// Format: "Prefix Given SurnamePrefix Surname Suffix", or
+// "Prefix SurnamePrefix Surname Given Suffix" for surname-first conventions.
 func (gn *GedcomName) reconstructFullName() string {
 	parts := make([]string, 0, 6)
 
@@ -207,7 +281,8 @@ func (gn *GedcomName) reconstructFullName() string {
 		parts = append(parts, gn.Prefix)
 	}
 
-	if gn.Given != "" {
+	surnameFirst := gn.convention().SurnameFirst
+	if gn.Given != "" && !surnameFirst {
 		parts = append(parts, gn.Given)
 	}
 
@@ -219,6 +294,10 @@ func (gn *GedcomName) reconstructFullName() string {
 		parts = append(parts, gn.Surname)
 	}
 
+	if gn.Given != "" && surnameFirst {
+		parts = append(parts, gn.Given)
+	}
+
 	if gn.Suffix != "" {
 		parts = append(parts, gn.Suffix)
 	}
@@ -292,3 +371,114 @@ func (gn *GedcomName) HasSurnamePrefix() bool {
 	return gn.SurnamePrefix != ""
 }
 
+// convention returns the name's convention, NameConventionWestern if none
+// was set.
+func (gn *GedcomName) convention() *NameConvention {
+	if gn.Convention != nil {
+		return gn.Convention
+	}
+	return NameConventionWestern
+}
+
+// primarySurname returns the surname a person is filed under: the first or,
+// for PrimarySurnameLast conventions, the last of several surnames.
+func (gn *GedcomName) primarySurname() string {
+	if len(gn.Surnames) > 1 {
+		if gn.convention().PrimarySurnameLast {
+			return gn.Surnames[len(gn.Surnames)-1]
+		}
+		return gn.Surnames[0]
+	}
+	return gn.Surname
+}
+
+// SortKey returns the key names are sorted by, folded with FoldName: either
+// "surname, given", or "given surname" for conventions that sort by given
+// name. Compound surnames start with the primary surname. SPFX particles
+// follow the given name ("berg, jan van der"); particles inside compound
+// surnames are left out.
+func (gn *GedcomName) SortKey() string {
+	convention := gn.convention()
+	surnames := []string{convention.withoutParticles(gn.Surname)}
+	if len(gn.Surnames) > 1 {
+		primary := gn.primarySurname()
+		surnames = []string{convention.withoutParticles(primary)}
+		for _, surname := range gn.Surnames {
+			if surname != primary {
+				surnames = append(surnames, convention.withoutParticles(surname))
+			}
+		}
+	}
+	surname := FoldName(strings.Join(surnames, " "))
+	given := FoldName(gn.Given + " " + gn.SurnamePrefix)
+
+	if convention.SortByGiven {
+		return strings.TrimSpace(given + " " + surname)
+	}
+	if surname == "" || given == "" {
+		return surname + given
+	}
+	return surname + ", " + given
+}
+
+// SearchKeys returns the forms a search for the name should match, folded
+// with FoldName: the full name in both orders, the given name, the surname
+// with and without particles, each compound surname, the patronymic, and
+// the keys of the romanized and phonetic variants.
+func (gn *GedcomName) SearchKeys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	add := func(values ...string) {
+		for _, value := range values {
+			if key := FoldName(value); key != "" && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	add(gn.Original, gn.FullName(), gn.Given+" "+gn.GetFullSurname(), gn.GetFullSurname()+" "+gn.Given)
+	add(gn.Given, gn.GetFullSurname(), gn.Surname, gn.Patronymic)
+	add(gn.Surnames...)
+	for _, variants := range [][]NameVariant{gn.Romanized, gn.Phonetic} {
+		for _, variant := range variants {
+			if variant.Name != nil {
+				add(variant.Name.SearchKeys()...)
+			}
+		}
+	}
+	return keys
+}
+
+// GivenKey returns the given name folded with FoldName.
+func (gn *GedcomName) GivenKey() string {
+	return FoldName(gn.Given)
+}
+
+// FamilyKey returns the part of the surname that identifies the family,
+// folded with FoldName: the primary surname without particles, or the stem
+// of a patronymic, so that "Jensdatter" and "Jensen" compare equal.
+func (gn *GedcomName) FamilyKey() string {
+	if gn.Patronymic != "" {
+		if stem, ok := gn.convention().patronymicStem(gn.Patronymic); ok {
+			return stem
+		}
+	}
+
+	return FoldName(gn.convention().withoutParticles(gn.primarySurname()))
+}
+
+// Matches reports whether pattern occurs in one of the name's search keys,
+// comparing folded with FoldName.
+func (gn *GedcomName) Matches(pattern string) bool {
+	pattern = FoldName(pattern)
+	if pattern == "" {
+		return false
+	}
+	for _, key := range gn.SearchKeys() {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+	return false
+}
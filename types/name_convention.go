@@ -0,0 +1,378 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NameConvention describes how a naming culture writes, sorts and compares
+// personal names: the order of given name and surname, surname particles,
+// compound surnames and patronymics.
+//
+// Conventions are registered with RegisterNameConvention. ParseName uses
+// NameConventionWestern, and individuals in a tree the convention for the
+// header's LANG (GedcomTree.NameConvention); ParseNameWithConvention uses the
+// one given, e.g. DetectNameConvention(value).
+type NameConvention struct {
+	Name string
+
+	// Languages lists the GEDCOM language names and language tags whose
+	// names follow the convention, such as "Icelandic" and "is".
+	Languages []string
+
+	// SurnameFirst is set when the surname is written before the given name.
+	// It decides how a NAME without slashes is split and the order of
+	// FullName.
+	SurnameFirst bool
+
+	// Particles are the lowercase words, such as the Dutch "van", "der" and
+	// "'t", that are split off the front of a surname into SurnamePrefix when
+	// the NAME has no SPFX or SURN. They are not part of the sort key.
+	Particles []string
+
+	// CompoundSurnames is set when a surname of several words is made of
+	// several surnames, such as the paternal and maternal surnames of
+	// "García Márquez". The conjunctions "y" and "e" between them are dropped.
+	CompoundSurnames bool
+
+	// PrimarySurnameLast is set when the last of the compound surnames is the
+	// one a person is filed under (Portuguese), rather than the first
+	// (Spanish).
+	PrimarySurnameLast bool
+
+	// PatronymicSuffixes are the lowercase endings of patronymic surnames,
+	// including the genitive of the father's name, such as "sdóttir" and
+	// "sson". The rest of the surname is the stem compared by FamilyKey, so
+	// "Jónsdóttir" and "Jónsson" both have the stem "jon".
+	PatronymicSuffixes []string
+
+	// SortByGiven is set when people are sorted by given name (Icelandic).
+	SortByGiven bool
+
+	// Detect reports whether a NAME value follows the convention. A
+	// convention without Detect is only used when chosen explicitly.
+	Detect func(value string) bool
+}
+
+// NameConventionWestern is the "Given /Surname/" layout ParseName has always
+// assumed. It is used when no other convention is chosen or detected.
+var NameConventionWestern = &NameConvention{
+	Name:      "Western",
+	Languages: []string{"English", "en", "French", "fr", "German", "de", "Italian", "it"},
+}
+
+// NameConventionSurnameFirst is the surname-first order of Hungarian, Chinese,
+// Japanese, Korean and Vietnamese names. It is detected when a NAME value
+// starts with the slashed surname: "/Nagy/ Imre".
+var NameConventionSurnameFirst = &NameConvention{
+	Name: "Surname first",
+	Languages: []string{
+		"Hungarian", "hu", "Chinese", "zh", "Japanese", "ja", "Korean", "ko", "Vietnamese", "vi",
+	},
+	SurnameFirst: true,
+	Detect: func(value string) bool {
+		_, surnameFirst := nameValueSurname(value)
+		return surnameFirst
+	},
+}
+
+// NameConventionIcelandic has patronymic (and matronymic) surnames and sorts
+// by given name. It is detected by a surname ending in -dóttir; sons'
+// -sson names are only treated as Icelandic when chosen explicitly, as they
+// are also Swedish.
+var NameConventionIcelandic = &NameConvention{
+	Name:      "Icelandic",
+	Languages: []string{"Icelandic", "is"},
+	PatronymicSuffixes: []string{
+		"ardóttir", "ardottir", "sdóttir", "sdottir", "arson", "sson", "dóttir", "dottir", "son",
+	},
+	SortByGiven: true,
+	Detect: func(value string) bool {
+		return surnameHasSuffix(value, "dóttir", "dottir")
+	},
+}
+
+// NameConventionScandinavian has the historical Danish, Norwegian and
+// Swedish patronymics (Jensen, Jensdatter, Andersson, Andersdotter). It is
+// detected by a surname ending in -datter or -dotter, as -sen and -son
+// surnames are usually fixed family names.
+var NameConventionScandinavian = &NameConvention{
+	Name:      "Scandinavian",
+	Languages: []string{"Danish", "da", "Norwegian", "no", "nb", "nn", "Swedish", "sv"},
+	PatronymicSuffixes: []string{
+		"sdatter", "sdotter", "datter", "dotter", "sson", "ssøn", "sen", "son",
+	},
+	Detect: func(value string) bool {
+		return surnameHasSuffix(value, "datter", "dotter")
+	},
+}
+
+// NameConventionDutch splits tussenvoegsels such as "van der" and "van 't"
+// off the surname and sorts by the rest: "Jan /van der Berg/" sorts as
+// "berg, jan van der". It is detected by a surname starting with "van",
+// "ten", "ter", "te" or "'t".
+var NameConventionDutch = &NameConvention{
+	Name:      "Dutch",
+	Languages: []string{"Dutch", "nl", "Flemish"},
+	Particles: []string{
+		"van", "de", "der", "den", "het", "'t", "ten", "ter", "te", "in", "op", "onder", "uit", "aan", "bij", "voor", "over",
+	},
+	Detect: func(value string) bool {
+		surname, _ := nameValueSurname(value)
+		words := strings.Fields(strings.ToLower(surname))
+		if len(words) < 2 {
+			return false
+		}
+		switch words[0] {
+		case "van", "ten", "ter", "te", "'t":
+			return true
+		}
+		return false
+	},
+}
+
+// NameConventionSpanish has a paternal and a maternal surname, filed under
+// the paternal (first) one. It is detected by a surname of two or more
+// capitalized words: "Gabriel /García Márquez/".
+var NameConventionSpanish = &NameConvention{
+	Name:             "Spanish",
+	Languages:        []string{"Spanish", "es", "Catalan", "ca"},
+	Particles:        []string{"de", "del", "la", "las", "los"},
+	CompoundSurnames: true,
+	Detect: func(value string) bool {
+		surname, _ := nameValueSurname(value)
+		capitalized := 0
+		for _, word := range strings.Fields(surname) {
+			for _, r := range word {
+				if unicode.IsUpper(r) {
+					capitalized++
+				}
+				break
+			}
+		}
+		return capitalized >= 2
+	},
+}
+
+// NameConventionPortuguese has a maternal and a paternal surname, filed
+// under the paternal (last) one. It cannot be told from Spanish by the name
+// alone and is only used when chosen explicitly.
+var NameConventionPortuguese = &NameConvention{
+	Name:               "Portuguese",
+	Languages:          []string{"Portuguese", "pt"},
+	Particles:          []string{"de", "da", "do", "das", "dos"},
+	CompoundSurnames:   true,
+	PrimarySurnameLast: true,
+}
+
+// nameConventions is the registry of naming conventions.
+var nameConventions = struct {
+	sync.RWMutex
+	conventions []*NameConvention
+}{}
+
+func init() {
+	for _, convention := range []*NameConvention{
+		NameConventionSurnameFirst,
+		NameConventionIcelandic,
+		NameConventionScandinavian,
+		NameConventionDutch,
+		NameConventionSpanish,
+		NameConventionPortuguese,
+		NameConventionWestern,
+	} {
+		RegisterNameConvention(convention)
+	}
+}
+
+// RegisterNameConvention adds a naming convention used by
+// DetectNameConvention and NameConventionFor. Conventions are tried in
+// registration order; a convention registered later is tried after the
+// built-in ones.
+func RegisterNameConvention(convention *NameConvention) {
+	if convention == nil {
+		return
+	}
+
+	nameConventions.Lock()
+	defer nameConventions.Unlock()
+
+	nameConventions.conventions = append(nameConventions.conventions, convention)
+}
+
+// NameConventions returns the registered conventions in registration order.
+func NameConventions() []*NameConvention {
+	nameConventions.RLock()
+	defer nameConventions.RUnlock()
+
+	return append([]*NameConvention(nil), nameConventions.conventions...)
+}
+
+// NameConventionFor returns the registered convention for a GEDCOM language
+// name or language tag, such as the header's LANG. Region subtags are
+// ignored ("pt-BR" finds Portuguese). Returns nil if no convention lists the
+// language.
+func NameConventionFor(language string) *NameConvention {
+	language = strings.TrimSpace(language)
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		language = language[:i]
+	}
+	if language == "" {
+		return nil
+	}
+	for _, convention := range NameConventions() {
+		for _, candidate := range convention.Languages {
+			if strings.EqualFold(candidate, language) {
+				return convention
+			}
+		}
+	}
+	return nil
+}
+
+// DetectNameConvention returns the first registered convention whose Detect
+// accepts the NAME value, or NameConventionWestern.
+func DetectNameConvention(value string) *NameConvention {
+	for _, convention := range NameConventions() {
+		if convention.Detect != nil && convention.Detect(value) {
+			return convention
+		}
+	}
+	return NameConventionWestern
+}
+
+// nameValueSurname returns the surname of a NAME value: the part between
+// slashes, or the last word without slashes. surnameFirst is set when the
+// slashed surname comes first and is followed by a given name.
+func nameValueSurname(value string) (surname string, surnameFirst bool) {
+	value = strings.TrimSpace(value)
+	start := strings.Index(value, "/")
+	if start < 0 {
+		words := strings.Fields(value)
+		if len(words) < 2 {
+			return "", false
+		}
+		return words[len(words)-1], false
+	}
+	end := strings.Index(value[start+1:], "/")
+	if end < 0 {
+		return "", false
+	}
+	end += start + 1
+	surname = strings.TrimSpace(value[start+1 : end])
+	surnameFirst = start == 0 && surname != "" && strings.TrimSpace(value[end+1:]) != ""
+	return surname, surnameFirst
+}
+
+// surnameHasSuffix reports whether the surname of a NAME value ends with
+// one of the suffixes, ignoring case.
+func surnameHasSuffix(value string, suffixes ...string) bool {
+	surname, _ := nameValueSurname(value)
+	words := strings.Fields(strings.ToLower(surname))
+	if len(words) == 0 {
+		return false
+	}
+	last := words[len(words)-1]
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(last, suffix) && len(last) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isParticle reports whether word is one of the convention's particles.
+func (nc *NameConvention) isParticle(word string) bool {
+	word = strings.ToLower(word)
+	for _, particle := range nc.Particles {
+		if word == particle {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutParticles returns surname without its leading particles, keeping
+// at least one word: "de la Cruz" gives "Cruz".
+func (nc *NameConvention) withoutParticles(surname string) string {
+	words := strings.Fields(surname)
+	for len(words) > 1 && nc.isParticle(words[0]) {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// apply fills the convention-specific parts of a parsed name: the surname
+// prefix, the compound surnames and the patronymic. The surname prefix is
+// only split off a surname taken from the NAME value (fromValue), as SURN
+// and SPFX say where the surname starts.
+func (nc *NameConvention) apply(name *GedcomName, fromValue bool) {
+	if fromValue && name.SurnamePrefix == "" && len(nc.Particles) > 0 {
+		words := strings.Fields(name.Surname)
+		i := 0
+		for i < len(words)-1 && nc.isParticle(words[i]) {
+			i++
+		}
+		if i > 0 {
+			name.SurnamePrefix = strings.Join(words[:i], " ")
+			name.Surname = strings.Join(words[i:], " ")
+		}
+	}
+
+	if len(name.Surnames) == 0 && name.Surname != "" {
+		if nc.CompoundSurnames {
+			name.Surnames = nc.splitSurnames(name.Surname)
+		} else {
+			name.Surnames = []string{name.Surname}
+		}
+	}
+
+	if len(nc.PatronymicSuffixes) > 0 && len(name.Surnames) > 0 {
+		last := name.Surnames[len(name.Surnames)-1]
+		if _, ok := nc.patronymicStem(last); ok {
+			name.Patronymic = last
+		}
+	}
+}
+
+// splitSurnames splits a compound surname into its surnames, keeping
+// particles with the surname they precede: "Pérez de la Cruz" gives
+// "Pérez" and "de la Cruz".
+func (nc *NameConvention) splitSurnames(surname string) []string {
+	var surnames []string
+	var particles []string
+	for _, word := range strings.Fields(surname) {
+		switch {
+		case nc.isParticle(word):
+			particles = append(particles, word)
+		case len(surnames) > 0 && len(particles) == 0 && (strings.EqualFold(word, "y") || strings.EqualFold(word, "e")):
+			// Conjunction between two surnames
+		default:
+			surnames = append(surnames, strings.Join(append(particles, word), " "))
+			particles = nil
+		}
+	}
+	if len(particles) > 0 {
+		if len(surnames) == 0 {
+			return []string{strings.Join(particles, " ")}
+		}
+		surnames[len(surnames)-1] += " " + strings.Join(particles, " ")
+	}
+	return surnames
+}
+
+// patronymicStem returns surname without its patronymic suffix, folded.
+// ok is false if surname has none of the convention's suffixes.
+func (nc *NameConvention) patronymicStem(surname string) (stem string, ok bool) {
+	lower := strings.ToLower(strings.TrimSpace(surname))
+	suffixes := append([]string(nil), nc.PatronymicSuffixes...)
+	sort.SliceStable(suffixes, func(i, j int) bool { return len(suffixes[i]) > len(suffixes[j]) })
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(lower, suffix) && len(lower) > len(suffix) {
+			return FoldName(strings.TrimSuffix(lower, suffix)), true
+		}
+	}
+	return "", false
+}
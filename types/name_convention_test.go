@@ -0,0 +1,300 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectNameConvention(t *testing.T) {
+	tests := []struct {
+		value string
+		want  *NameConvention
+	}{
+		{"John /Smith/", NameConventionWestern},
+		{"/Nagy/ Imre", NameConventionSurnameFirst},
+		{"/山田/ 太郎", NameConventionSurnameFirst},
+		{"Guðrún /Jónsdóttir/", NameConventionIcelandic},
+		{"Maren /Jensdatter/", NameConventionScandinavian},
+		{"Anna /Andersdotter/", NameConventionScandinavian},
+		{"Jan /van der Berg/", NameConventionDutch},
+		{"Gabriel /García Márquez/", NameConventionSpanish},
+		{"Juan /de la Cruz/", NameConventionWestern},
+		{"Maria /von Trapp/", NameConventionWestern},
+		{"Hans /Jensen/", NameConventionWestern},
+		{"", NameConventionWestern},
+	}
+	for _, tt := range tests {
+		if got := DetectNameConvention(tt.value); got != tt.want {
+			t.Errorf("DetectNameConvention(%q) = %s, want %s", tt.value, got.Name, tt.want.Name)
+		}
+	}
+}
+
+func TestNameConventionFor(t *testing.T) {
+	tests := []struct {
+		language string
+		want     *NameConvention
+	}{
+		{"Icelandic", NameConventionIcelandic},
+		{"hungarian", NameConventionSurnameFirst},
+		{"pt-BR", NameConventionPortuguese},
+		{"ja", NameConventionSurnameFirst},
+		{"Klingon", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := NameConventionFor(tt.language); got != tt.want {
+			t.Errorf("NameConventionFor(%q) = %v, want %v", tt.language, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterNameConvention(t *testing.T) {
+	welsh := &NameConvention{Name: "Welsh", Languages: []string{"Welsh", "cy"}}
+	RegisterNameConvention(welsh)
+	defer func() {
+		nameConventions.Lock()
+		nameConventions.conventions = nameConventions.conventions[:len(nameConventions.conventions)-1]
+		nameConventions.Unlock()
+	}()
+
+	if got := NameConventionFor("cy"); got != welsh {
+		t.Errorf("NameConventionFor(cy) = %v, want the registered convention", got)
+	}
+	if got := DetectNameConvention("Dafydd /ap Gruffydd/"); got != NameConventionWestern {
+		t.Errorf("convention without Detect was detected: %s", got.Name)
+	}
+}
+
+func TestParseName_SurnameFirst(t *testing.T) {
+	line := NewGedcomLine(1, "NAME", "/Nagy/ Imre", "")
+	name, err := ParseNameWithConvention(line, NameConventionSurnameFirst)
+	if err != nil {
+		t.Fatalf("ParseNameWithConvention failed: %v", err)
+	}
+	if name.Surname != "Nagy" || name.Given != "Imre" {
+		t.Errorf("Surname/Given = %q/%q, want Nagy/Imre", name.Surname, name.Given)
+	}
+	if name.FullName() != "Nagy Imre" {
+		t.Errorf("FullName() = %q, want %q", name.FullName(), "Nagy Imre")
+	}
+	if name.SortKey() != "nagy, imre" {
+		t.Errorf("SortKey() = %q", name.SortKey())
+	}
+
+	// Western names have their given name before the surname
+	name, _ = ParseName(line)
+	if name.Surname != "Nagy" || name.Given != "" {
+		t.Errorf("Western: Surname/Given = %q/%q, want Nagy and no given name", name.Surname, name.Given)
+	}
+
+	// Without slashes the convention decides which word is the surname
+	name, _ = ParseNameWithConvention(NewGedcomLine(1, "NAME", "Nagy Imre", ""), NameConventionSurnameFirst)
+	if name.Surname != "Nagy" || name.Given != "Imre" {
+		t.Errorf("unslashed: Surname/Given = %q/%q, want Nagy/Imre", name.Surname, name.Given)
+	}
+	name, _ = ParseName(NewGedcomLine(1, "NAME", "Imre Nagy", ""))
+	if name.Surname != "Nagy" {
+		t.Errorf("unslashed Western: Surname = %q, want Nagy", name.Surname)
+	}
+}
+
+func TestParseName_Western(t *testing.T) {
+	// ParseName does not detect a convention
+	name, _ := ParseName(NewGedcomLine(1, "NAME", "Ludwig /van Beethoven/", ""))
+	if name.Convention != NameConventionWestern || name.SurnamePrefix != "" || name.Surname != "van Beethoven" {
+		t.Errorf("SurnamePrefix/Surname = %q/%q, want the surname as written", name.SurnamePrefix, name.Surname)
+	}
+	name, _ = ParseName(NewGedcomLine(1, "NAME", "Gabriel /García Márquez/", ""))
+	if name.Surname != "García Márquez" || !reflect.DeepEqual(name.Surnames, []string{"García Márquez"}) {
+		t.Errorf("Surname/Surnames = %q/%q, want one surname", name.Surname, name.Surnames)
+	}
+
+	// SURN and SPFX take precedence over a convention
+	line := NewGedcomLine(1, "NAME", "Ludwig /van Beethoven/", "")
+	line.AddChild(NewGedcomLine(2, "SURN", "van Beethoven", ""))
+	name, _ = ParseNameWithConvention(line, NameConventionDutch)
+	if name.SurnamePrefix != "" || name.Surname != "van Beethoven" {
+		t.Errorf("SURN: SurnamePrefix/Surname = %q/%q, want the SURN", name.SurnamePrefix, name.Surname)
+	}
+}
+
+func TestGedcomTree_NameConvention(t *testing.T) {
+	tree := NewGedcomTree()
+	indiLine := NewGedcomLine(0, "INDI", "", "@I1@")
+	indiLine.AddChild(NewGedcomLine(1, "NAME", "Jan /van der Berg/", ""))
+	indi := NewIndividualRecord(indiLine)
+	tree.AddRecord(indi)
+
+	if name, _ := indi.GetPrimaryName(); name.Surname != "van der Berg" {
+		t.Errorf("no LANG: Surname = %q, want it as written", name.Surname)
+	}
+
+	headerLine := NewGedcomLine(0, "HEAD", "", "")
+	headerLine.AddChild(NewGedcomLine(1, "LANG", "Dutch", ""))
+	tree.AddRecord(NewHeaderRecord(headerLine))
+	if tree.NameConvention() != NameConventionDutch {
+		t.Fatalf("NameConvention() = %v, want Dutch from the header", tree.NameConvention())
+	}
+	if name, _ := indi.GetPrimaryName(); name.SurnamePrefix != "van der" || name.Surname != "Berg" {
+		t.Errorf("LANG Dutch: SurnamePrefix/Surname = %q/%q", name.SurnamePrefix, name.Surname)
+	}
+
+	tree.SetNameConvention(NameConventionWestern)
+	if names, _ := indi.GetNamesParsed(); len(names) != 1 || names[0].Surname != "van der Berg" {
+		t.Error("expected SetNameConvention to take precedence over the header")
+	}
+}
+
+func TestParseName_Patronymic(t *testing.T) {
+	name, _ := ParseNameWithConvention(NewGedcomLine(1, "NAME", "Guðrún /Jónsdóttir/", ""), NameConventionIcelandic)
+	if name.Convention != NameConventionIcelandic || name.Patronymic != "Jónsdóttir" {
+		t.Errorf("Convention/Patronymic = %s/%q", name.Convention.Name, name.Patronymic)
+	}
+	if name.Surname != "Jónsdóttir" {
+		t.Errorf("Surname = %q, want the patronymic", name.Surname)
+	}
+	if name.SortKey() != "gudrun jonsdottir" {
+		t.Errorf("SortKey() = %q, want sorting by given name", name.SortKey())
+	}
+	if name.FamilyKey() != "jon" {
+		t.Errorf("FamilyKey() = %q, want %q", name.FamilyKey(), "jon")
+	}
+
+	brother, _ := ParseNameWithConvention(NewGedcomLine(1, "NAME", "Einar /Jónsson/", ""), NameConventionIcelandic)
+	if brother.FamilyKey() != name.FamilyKey() {
+		t.Errorf("FamilyKey() of Jónsson = %q, want %q", brother.FamilyKey(), name.FamilyKey())
+	}
+
+	daughter, _ := ParseNameWithConvention(NewGedcomLine(1, "NAME", "Maren /Jensdatter/", ""), NameConventionScandinavian)
+	sonLine := NewGedcomLine(1, "NAME", "Hans /Jensen/", "")
+	son, _ := ParseNameWithConvention(sonLine, NameConventionScandinavian)
+	if daughter.FamilyKey() != "jen" || son.FamilyKey() != daughter.FamilyKey() {
+		t.Errorf("FamilyKey() = %q/%q, want equal stems", daughter.FamilyKey(), son.FamilyKey())
+	}
+	if daughter.SortKey() != "jensdatter, maren" {
+		t.Errorf("SortKey() = %q", daughter.SortKey())
+	}
+
+	// In the Western convention, Jensen is a fixed surname
+	son, _ = ParseName(sonLine)
+	if son.Patronymic != "" || son.FamilyKey() != "jensen" {
+		t.Errorf("Western: Patronymic/FamilyKey = %q/%q", son.Patronymic, son.FamilyKey())
+	}
+}
+
+func TestParseName_CompoundSurnames(t *testing.T) {
+	name, _ := ParseNameWithConvention(NewGedcomLine(1, "NAME", "Gabriel /García Márquez/", ""), NameConventionSpanish)
+	if !reflect.DeepEqual(name.Surnames, []string{"García", "Márquez"}) {
+		t.Errorf("Surnames = %q", name.Surnames)
+	}
+	if name.Surname != "García Márquez" {
+		t.Errorf("Surname = %q", name.Surname)
+	}
+	if name.FamilyKey() != "garcia" || name.SortKey() != "garcia marquez, gabriel" {
+		t.Errorf("FamilyKey/SortKey = %q/%q", name.FamilyKey(), name.SortKey())
+	}
+
+	name, _ = ParseNameWithConvention(NewGedcomLine(1, "NAME", "José /Ortega y Gasset/", ""), NameConventionSpanish)
+	if !reflect.DeepEqual(name.Surnames, []string{"Ortega", "Gasset"}) {
+		t.Errorf("Surnames with conjunction = %q", name.Surnames)
+	}
+
+	name, _ = ParseNameWithConvention(NewGedcomLine(1, "NAME", "Ana /Pérez de la Cruz/", ""), NameConventionSpanish)
+	if !reflect.DeepEqual(name.Surnames, []string{"Pérez", "de la Cruz"}) {
+		t.Errorf("Surnames with particles = %q", name.Surnames)
+	}
+
+	// Portuguese files under the last (paternal) surname
+	name, _ = ParseNameWithConvention(NewGedcomLine(1, "NAME", "Luís /Vaz de Camões/", ""), NameConventionPortuguese)
+	if !reflect.DeepEqual(name.Surnames, []string{"Vaz", "de Camões"}) {
+		t.Errorf("Portuguese Surnames = %q", name.Surnames)
+	}
+	if name.FamilyKey() != "camoes" || name.SortKey() != "camoes vaz, luis" {
+		t.Errorf("Portuguese FamilyKey/SortKey = %q/%q", name.FamilyKey(), name.SortKey())
+	}
+
+	// SURN lists are GEDCOM's own way of writing several surnames
+	line := NewGedcomLine(1, "NAME", "Gabriel /García Márquez/", "")
+	line.AddChild(NewGedcomLine(2, "GIVN", "Gabriel,José", ""))
+	line.AddChild(NewGedcomLine(2, "SURN", "García,Márquez", ""))
+	name, _ = ParseName(line)
+	if name.Given != "Gabriel José" || !reflect.DeepEqual(name.Surnames, []string{"García", "Márquez"}) {
+		t.Errorf("pieces: Given = %q, Surnames = %q", name.Given, name.Surnames)
+	}
+}
+
+func TestParseName_Tussenvoegsels(t *testing.T) {
+	name, _ := ParseNameWithConvention(NewGedcomLine(1, "NAME", "Jan /van der Berg/", ""), NameConventionDutch)
+	if name.SurnamePrefix != "van der" || name.Surname != "Berg" {
+		t.Errorf("SurnamePrefix/Surname = %q/%q", name.SurnamePrefix, name.Surname)
+	}
+	if name.GetFullSurname() != "van der Berg" || name.FullName() != "Jan van der Berg" {
+		t.Errorf("GetFullSurname/FullName = %q/%q", name.GetFullSurname(), name.FullName())
+	}
+	if name.SortKey() != "berg, jan van der" {
+		t.Errorf("SortKey() = %q", name.SortKey())
+	}
+
+	name, _ = ParseNameWithConvention(NewGedcomLine(1, "NAME", "Pieter /van 't Hof/", ""), NameConventionDutch)
+	if name.SurnamePrefix != "van 't" || name.Surname != "Hof" {
+		t.Errorf("van 't: SurnamePrefix/Surname = %q/%q", name.SurnamePrefix, name.Surname)
+	}
+
+	// SPFX lists are joined
+	line := NewGedcomLine(1, "NAME", "Jan /van der Berg/", "")
+	line.AddChild(NewGedcomLine(2, "SPFX", "van,der", ""))
+	line.AddChild(NewGedcomLine(2, "SURN", "Berg", ""))
+	name, _ = ParseName(line)
+	if name.SurnamePrefix != "van der" || name.Surname != "Berg" {
+		t.Errorf("SPFX list: SurnamePrefix/Surname = %q/%q", name.SurnamePrefix, name.Surname)
+	}
+}
+
+func TestParseName_Variants(t *testing.T) {
+	line := NewGedcomLine(1, "NAME", "/山田/ 太郎", "")
+	romn := NewGedcomLine(2, "ROMN", "/Yamada/ Tarō", "")
+	romn.AddChild(NewGedcomLine(3, "TYPE", "hepburn", ""))
+	line.AddChild(romn)
+	fone := NewGedcomLine(2, "FONE", "/やまだ/ たろう", "")
+	fone.AddChild(NewGedcomLine(3, "TYPE", "kana", ""))
+	line.AddChild(fone)
+
+	name, err := ParseNameWithConvention(line, DetectNameConvention(line.Value))
+	if err != nil {
+		t.Fatalf("ParseNameWithConvention failed: %v", err)
+	}
+	if name.Surname != "山田" || name.Given != "太郎" {
+		t.Errorf("Surname/Given = %q/%q", name.Surname, name.Given)
+	}
+	if len(name.Romanized) != 1 || name.Romanized[0].Type != "hepburn" {
+		t.Fatalf("Romanized = %+v", name.Romanized)
+	}
+	romanized := name.Romanized[0].Name
+	if romanized.Surname != "Yamada" || romanized.Given != "Tarō" || romanized.FullName() != "Yamada Tarō" {
+		t.Errorf("romanized = %q/%q", romanized.Surname, romanized.Given)
+	}
+	if len(name.Phonetic) != 1 || name.Phonetic[0].Name.Surname != "やまだ" {
+		t.Errorf("Phonetic = %+v", name.Phonetic)
+	}
+
+	for _, pattern := range []string{"yamada", "Taro", "山田", "たろう"} {
+		if !name.Matches(pattern) {
+			t.Errorf("Matches(%q) = false", pattern)
+		}
+	}
+	if name.Matches("suzuki") {
+		t.Error("Matches(suzuki) = true")
+	}
+}
+
+func TestGedcomName_SearchKeys(t *testing.T) {
+	name, _ := ParseNameWithConvention(NewGedcomLine(1, "NAME", "Gabriel /García Márquez/", ""), NameConventionSpanish)
+	want := []string{"gabriel garcia marquez", "garcia marquez gabriel", "gabriel", "garcia marquez", "garcia", "marquez"}
+	if got := name.SearchKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchKeys() = %q, want %q", got, want)
+	}
+	if !name.Matches("Marquez") || !name.Matches("garcía") {
+		t.Error("Matches() should ignore case and diacritics")
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+import "strings"
+
+// PedigreeType is the relationship of a child to the parents of a family,
+// from INDI.FAMC.PEDI.
+type PedigreeType string
+
+const (
+	PedigreeBirth   PedigreeType = "birth"
+	PedigreeAdopted PedigreeType = "adopted"
+	PedigreeFoster  PedigreeType = "foster"
+	PedigreeSealing PedigreeType = "sealing"
+	PedigreeOther   PedigreeType = "other"
+	PedigreeUnknown PedigreeType = "" // No PEDI given
+)
+
+// ParsePedigree returns the PedigreeType for a PEDI value. Besides the GEDCOM
+// 5.5.1 and 7.0 values it accepts the words vendors use for the relationship
+// of a child to each parent ("Natural", "Biological", "Step", ...). Values it
+// does not recognize return PedigreeOther; an empty value returns
+// PedigreeUnknown.
+func ParsePedigree(value string) PedigreeType {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return PedigreeUnknown
+	case "birth", "natural", "biological", "bio":
+		return PedigreeBirth
+	case "adopted", "adoptive", "adoption":
+		return PedigreeAdopted
+	case "foster":
+		return PedigreeFoster
+	case "sealing", "sealed":
+		return PedigreeSealing
+	default:
+		return PedigreeOther
+	}
+}
+
+// GetPedigree returns the pedigree of the individual's link to family, from
+// the PEDI line of the matching FAMC. Returns PedigreeUnknown if the
+// individual is not a child of family or the link has no PEDI.
+func (ir *IndividualRecord) GetPedigree(familyXref string) PedigreeType {
+	for _, famc := range ir.GetLines("FAMC") {
+		if famc.Value == familyXref {
+			return ParsePedigree(famc.GetValue("PEDI"))
+		}
+	}
+	return PedigreeUnknown
+}
@@ -0,0 +1,46 @@
+package types
+
+import "testing"
+
+func TestParsePedigree(t *testing.T) {
+	tests := []struct {
+		value string
+		want  PedigreeType
+	}{
+		{"birth", PedigreeBirth},
+		{"BIRTH", PedigreeBirth},
+		{"Natural", PedigreeBirth},
+		{"adopted", PedigreeAdopted},
+		{"ADOPTED", PedigreeAdopted},
+		{"Foster", PedigreeFoster},
+		{"sealing", PedigreeSealing},
+		{"Step", PedigreeOther},
+		{"OTHER", PedigreeOther},
+		{"", PedigreeUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ParsePedigree(tt.value); got != tt.want {
+			t.Errorf("ParsePedigree(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIndividualRecord_GetPedigree(t *testing.T) {
+	line := NewGedcomLine(0, "INDI", "", "@I1@")
+	famc1 := NewGedcomLine(1, "FAMC", "@F1@", "")
+	famc1.AddChild(NewGedcomLine(2, "PEDI", "adopted", ""))
+	line.AddChild(famc1)
+	line.AddChild(NewGedcomLine(1, "FAMC", "@F2@", ""))
+	indi := NewIndividualRecord(line)
+
+	if got := indi.GetPedigree("@F1@"); got != PedigreeAdopted {
+		t.Errorf("GetPedigree(@F1@) = %q, want %q", got, PedigreeAdopted)
+	}
+	if got := indi.GetPedigree("@F2@"); got != PedigreeUnknown {
+		t.Errorf("GetPedigree(@F2@) = %q, want unknown", got)
+	}
+	if got := indi.GetPedigree("@F3@"); got != PedigreeUnknown {
+		t.Errorf("GetPedigree(@F3@) = %q, want unknown", got)
+	}
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -17,15 +19,33 @@ type GedcomPlace struct {
 	Country    string
 	PostalCode string
 
-	// Geographic data (optional, for future geocoding support)
-	Latitude  float64
-	Longitude float64
+	// Jurisdictions maps each jurisdiction named by the place form
+	// (PLAC.FORM), lowercased, to its component. Nil without a form.
+	Form          []string
+	Jurisdictions map[string]string
+
+	// Geographic data from PLAC.MAP.LATI/LONG, in decimal degrees
+	// (south and west are negative)
+	Latitude       float64
+	Longitude      float64
+	HasCoordinates bool
+
+	// Romanized (ROMN) and phonetic (FONE) variants of the place name
+	Romanized []PlaceVariant
+	Phonetic  []PlaceVariant
 
 	// Parsed status
 	IsParsed   bool
 	ParseError error
 }
 
+// PlaceVariant is a romanized (PLAC.ROMN) or phonetic (PLAC.FONE) variant of
+// a place name.
+type PlaceVariant struct {
+	Value string // The place name in the variant form
+	Type  string // TYPE: romanization or phonetic method, e.g. "pinyin"
+}
+
 // ParsePlace parses a GEDCOM place string and extracts hierarchical components.
 // Supports various place formats:
 //   - "Rapid City" (simple)
@@ -108,6 +128,229 @@ func ParsePlace(placeStr string) (*GedcomPlace, error) {
 	return place, nil
 }
 
+// ParsePlaceWithForm parses a place string whose components follow form, the
+// jurisdiction names of a PLAC.FORM such as
+// "City, County, State, Country". Components are matched to form by
+// position, empty components included, and City, County, State, Country and
+// PostalCode are set from the jurisdiction names rather than guessed from the
+// number of components. Without a form it behaves like ParsePlace.
+func ParsePlaceWithForm(placeStr string, form []string) (*GedcomPlace, error) {
+	place, err := ParsePlace(placeStr)
+	if err != nil || len(form) == 0 {
+		return place, err
+	}
+
+	place.City, place.County, place.State, place.Country, place.PostalCode = "", "", "", "", ""
+	place.Form = form
+	place.Jurisdictions = make(map[string]string)
+	for i, part := range strings.Split(placeStr, ",") {
+		if i >= len(form) {
+			break
+		}
+		value := strings.TrimSpace(part)
+		name := strings.ToLower(form[i])
+		if value == "" || name == "" {
+			continue
+		}
+		place.Jurisdictions[name] = value
+		if field := place.jurisdictionField(name); field != nil && *field == "" {
+			*field = value
+		}
+	}
+	return place, nil
+}
+
+// jurisdictionField returns the field of gp a jurisdiction name maps to, or
+// nil for names with no matching field (they remain in Jurisdictions).
+func (gp *GedcomPlace) jurisdictionField(name string) *string {
+	switch name {
+	case "city", "town", "village", "hamlet", "locality", "municipality", "township":
+		return &gp.City
+	case "county", "district", "shire":
+		return &gp.County
+	case "state", "province", "region", "territory":
+		return &gp.State
+	case "country", "nation":
+		return &gp.Country
+	case "postal code", "postcode", "zip", "zip code":
+		return &gp.PostalCode
+	default:
+		return nil
+	}
+}
+
+// FormatWithForm returns the place as a place value in form, the reverse of
+// ParsePlaceWithForm: each jurisdiction of form gets its component from
+// Jurisdictions, or else from the field its name maps to, and jurisdictions
+// the place has no component for are left empty, so that
+// "Saint Louis, , Missouri, United States" keeps Missouri in the State slot
+// of "City, County, State, Country". Without a form the components are
+// joined as they are.
+func (gp *GedcomPlace) FormatWithForm(form []string) string {
+	if len(form) == 0 {
+		return gp.ToFormatted(", ")
+	}
+
+	parts := make([]string, len(form))
+	used := make(map[*string]bool)
+	for i, name := range form {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if value := gp.Jurisdictions[name]; value != "" {
+			parts[i] = value
+			continue
+		}
+		// Only the first jurisdiction of a field gets it, as in parsing
+		if field := gp.jurisdictionField(name); field != nil && !used[field] {
+			parts[i] = *field
+			used[field] = true
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParsePlaceLine parses a PLAC line with its substructures: the components
+// are mapped with the line's own FORM if it has one, otherwise with form
+// (usually the header's, see GedcomTree.GetPlaceForm); MAP.LATI/LONG set the
+// coordinates; ROMN and FONE give the variants.
+func ParsePlaceLine(line *GedcomLine, form []string) (*GedcomPlace, error) {
+	if line == nil {
+		return nil, fmt.Errorf("no place line")
+	}
+	if lineForm := ParsePlaceForm(line.GetValue("FORM")); len(lineForm) > 0 {
+		form = lineForm
+	}
+
+	place, err := ParsePlaceWithForm(line.Value, form)
+	if place == nil {
+		return nil, err
+	}
+	place.Latitude, place.Longitude, place.HasCoordinates = PlaceCoordinates(line)
+	place.Romanized = placeVariants(line, "ROMN")
+	place.Phonetic = placeVariants(line, "FONE")
+	return place, err
+}
+
+// ParsePlaceForm splits a PLAC.FORM value into its jurisdiction names.
+func ParsePlaceForm(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts
+}
+
+// PlaceCoordinates returns the coordinates in the MAP structure of a PLAC
+// line. ok is false unless both LATI and LONG are present and valid.
+func PlaceCoordinates(line *GedcomLine) (latitude, longitude float64, ok bool) {
+	if line == nil {
+		return 0, 0, false
+	}
+	latitude, latErr := ParseLatitude(line.GetValue("MAP.LATI"))
+	longitude, lonErr := ParseLongitude(line.GetValue("MAP.LONG"))
+	if latErr != nil || lonErr != nil {
+		return 0, 0, false
+	}
+	return latitude, longitude, true
+}
+
+// ParseCoordinate parses a GEDCOM LATI or LONG value such as "N18.150944" or
+// "W168.150944" into signed decimal degrees, negative for south and west.
+// Plain signed numbers are accepted too, but not a sign after a hemisphere
+// letter. Values beyond 180 degrees, or beyond 90 degrees north or south, are
+// rejected.
+func ParseCoordinate(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty coordinate")
+	}
+
+	sign, limit := 1.0, 180.0
+	hemisphere := true
+	switch value[0] {
+	case 'N', 'n':
+		limit = 90
+	case 'S', 's':
+		sign, limit = -1, 90
+	case 'E', 'e':
+	case 'W', 'w':
+		sign = -1
+	default:
+		hemisphere = false
+	}
+	if hemisphere {
+		value = strings.TrimSpace(value[1:])
+		// The hemisphere gives the sign, so another one would contradict it
+		if value != "" && (value[0] == '-' || value[0] == '+') {
+			return 0, fmt.Errorf("invalid coordinate %q: sign after hemisphere", value)
+		}
+	}
+
+	degrees, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinate %q: %w", value, err)
+	}
+	if math.IsNaN(degrees) || math.Abs(degrees) > limit {
+		return 0, fmt.Errorf("coordinate %q out of range: must be within %g degrees", value, limit)
+	}
+	return sign * degrees, nil
+}
+
+// ParseLatitude parses a LATI value like ParseCoordinate, rejecting values
+// beyond 90 degrees and values marked E or W.
+func ParseLatitude(value string) (float64, error) {
+	if v := strings.TrimSpace(value); v != "" && strings.ContainsRune("EeWw", rune(v[0])) {
+		return 0, fmt.Errorf("invalid latitude %q: must be N or S", value)
+	}
+	degrees, err := ParseCoordinate(value)
+	if err == nil && math.Abs(degrees) > 90 {
+		return 0, fmt.Errorf("latitude %q out of range: must be within 90 degrees", value)
+	}
+	return degrees, err
+}
+
+// ParseLongitude parses a LONG value like ParseCoordinate, rejecting values
+// marked N or S.
+func ParseLongitude(value string) (float64, error) {
+	if v := strings.TrimSpace(value); v != "" && strings.ContainsRune("NnSs", rune(v[0])) {
+		return 0, fmt.Errorf("invalid longitude %q: must be E or W", value)
+	}
+	return ParseCoordinate(value)
+}
+
+// FormatLatitude formats signed decimal degrees as a GEDCOM LATI value, such
+// as "N38.627" or "S33.8688".
+func FormatLatitude(degrees float64) string {
+	return formatCoordinate(degrees, "N", "S")
+}
+
+// FormatLongitude formats signed decimal degrees as a GEDCOM LONG value, such
+// as "W90.1994" or "E151.2093".
+func FormatLongitude(degrees float64) string {
+	return formatCoordinate(degrees, "E", "W")
+}
+
+// formatCoordinate formats degrees with the positive or negative prefix.
+func formatCoordinate(degrees float64, positive, negative string) string {
+	prefix := positive
+	if degrees < 0 {
+		prefix = negative
+		degrees = -degrees
+	}
+	return prefix + strconv.FormatFloat(degrees, 'f', -1, 64)
+}
+
+// placeVariants returns the ROMN or FONE variants under a PLAC line.
+func placeVariants(line *GedcomLine, tag string) []PlaceVariant {
+	var variants []PlaceVariant
+	for _, child := range line.GetLines(tag) {
+		variants = append(variants, PlaceVariant{Value: child.Value, Type: child.GetValue("TYPE")})
+	}
+	return variants
+}
+
 // isLikelyCountry attempts to determine if a component is likely a country.
 // This is a simple heuristic - could be enhanced with a country list.
 func isLikelyCountry(component string) bool {
@@ -131,6 +374,13 @@ func (gp *GedcomPlace) ToFormatted(separator string) string {
 	return strings.Join(gp.Components, separator)
 }
 
+// GetJurisdiction returns the component for a jurisdiction named in the place
+// form, e.g. "Parish". Matching ignores case. Returns empty string without a
+// form or if the place has no such component.
+func (gp *GedcomPlace) GetJurisdiction(name string) string {
+	return gp.Jurisdictions[strings.ToLower(strings.TrimSpace(name))]
+}
+
 // GetComponent returns the component at the specified level.
 // Level 0 is the most specific (city), higher levels are broader.
 // Returns empty string if level is out of range.
@@ -163,6 +413,14 @@ func (gp *GedcomPlace) Normalize() *GedcomPlace {
 		Country:    strings.TrimSpace(gp.Country),
 		PostalCode: strings.TrimSpace(gp.PostalCode),
 		IsParsed:   true,
+
+		Form:           gp.Form,
+		Jurisdictions:  gp.Jurisdictions,
+		Latitude:       gp.Latitude,
+		Longitude:      gp.Longitude,
+		HasCoordinates: gp.HasCoordinates,
+		Romanized:      gp.Romanized,
+		Phonetic:       gp.Phonetic,
 	}
 
 	// Normalize components
@@ -74,11 +74,16 @@ func NewPlaceNodeFromLine(line *GedcomLine) *PlaceNode {
 
 	pn := NewPlaceNode(line.Value)
 
-	// Extract latitude/longitude if present
-	if latLines := line.GetLines("LATI"); len(latLines) > 0 {
+	// Extract latitude/longitude if present (PLAC.MAP.LATI/LONG; some
+	// files put LATI/LONG directly under PLAC)
+	if latLines := line.GetLines("MAP.LATI"); len(latLines) > 0 {
+		pn.Latitude = latLines[0].Value
+	} else if latLines := line.GetLines("LATI"); len(latLines) > 0 {
 		pn.Latitude = latLines[0].Value
 	}
-	if lonLines := line.GetLines("LONG"); len(lonLines) > 0 {
+	if lonLines := line.GetLines("MAP.LONG"); len(lonLines) > 0 {
+		pn.Longitude = lonLines[0].Value
+	} else if lonLines := line.GetLines("LONG"); len(lonLines) > 0 {
 		pn.Longitude = lonLines[0].Value
 	}
 
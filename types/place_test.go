@@ -179,3 +179,185 @@ func TestParsePlace_EmptyString(t *testing.T) {
 		t.Error("ParsePlace should return error for empty string")
 	}
 }
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"N18.150944", 18.150944, false},
+		{"S33.8688", -33.8688, false},
+		{"E151.2093", 151.2093, false},
+		{"W168.150944", -168.150944, false},
+		{"-12.5", -12.5, false},
+		{"", 0, true},
+		{"Nabc", 0, true},
+		{"N91", 0, true},
+		{"W180.5", 0, true},
+		{"-181", 0, true},
+		{"NaN", 0, true},
+		{"N-18.15", 0, true},
+		{"W+10", 0, true},
+		{"S -33.8688", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCoordinate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCoordinate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCoordinate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseLatitudeLongitude(t *testing.T) {
+	if got, err := ParseLatitude("S33.8688"); err != nil || got != -33.8688 {
+		t.Errorf("ParseLatitude(S33.8688) = %v, %v", got, err)
+	}
+	for _, value := range []string{"95", "-90.5", "E10"} {
+		if _, err := ParseLatitude(value); err == nil {
+			t.Errorf("ParseLatitude(%q) should fail", value)
+		}
+	}
+	if got, err := ParseLongitude("-179.5"); err != nil || got != -179.5 {
+		t.Errorf("ParseLongitude(-179.5) = %v, %v", got, err)
+	}
+	for _, value := range []string{"E181", "N10"} {
+		if _, err := ParseLongitude(value); err == nil {
+			t.Errorf("ParseLongitude(%q) should fail", value)
+		}
+	}
+}
+
+func TestFormatCoordinates(t *testing.T) {
+	if got := FormatLatitude(38.62727); got != "N38.62727" {
+		t.Errorf("FormatLatitude(38.62727) = %q", got)
+	}
+	if got := FormatLatitude(-33.8688); got != "S33.8688" {
+		t.Errorf("FormatLatitude(-33.8688) = %q", got)
+	}
+	if got := FormatLongitude(-90.19789); got != "W90.19789" {
+		t.Errorf("FormatLongitude(-90.19789) = %q", got)
+	}
+	if got, err := ParseCoordinate(FormatLongitude(151.2093)); err != nil || got != 151.2093 {
+		t.Errorf("ParseCoordinate(FormatLongitude(151.2093)) = %v, %v", got, err)
+	}
+}
+
+func TestParsePlaceWithForm(t *testing.T) {
+	form := ParsePlaceForm("Parish, Town, County, Country")
+	place, err := ParsePlaceWithForm("St Mary, , Kent, England", form)
+	if err != nil {
+		t.Fatalf("ParsePlaceWithForm failed: %v", err)
+	}
+
+	// Mapped by jurisdiction name, not by position
+	if place.City != "" || place.County != "Kent" || place.Country != "England" || place.State != "" {
+		t.Errorf("City/County/State/Country = %q/%q/%q/%q", place.City, place.County, place.State, place.Country)
+	}
+	if got := place.GetJurisdiction("parish"); got != "St Mary" {
+		t.Errorf("GetJurisdiction(parish) = %q, want %q", got, "St Mary")
+	}
+	if got := place.GetJurisdiction("Town"); got != "" {
+		t.Errorf("GetJurisdiction(Town) = %q, want empty", got)
+	}
+	if len(place.Components) != 3 {
+		t.Errorf("Components = %v, want 3 non-empty components", place.Components)
+	}
+
+	place, _ = ParsePlaceWithForm("Boston, 02108, USA", ParsePlaceForm("City, Postal Code, Country"))
+	if place.City != "Boston" || place.PostalCode != "02108" || place.Country != "USA" {
+		t.Errorf("City/PostalCode/Country = %q/%q/%q", place.City, place.PostalCode, place.Country)
+	}
+
+	// Without a form, components are guessed by position
+	place, _ = ParsePlaceWithForm("St Mary, Kent, England", nil)
+	if place.City != "St Mary" || place.Jurisdictions != nil {
+		t.Errorf("no form: City = %q, Jurisdictions = %v", place.City, place.Jurisdictions)
+	}
+}
+
+func TestGedcomPlace_FormatWithForm(t *testing.T) {
+	form := ParsePlaceForm("City, County, State, Country")
+	place := &GedcomPlace{City: "Saint Louis", State: "Missouri", Country: "United States"}
+	value := place.FormatWithForm(form)
+	if value != "Saint Louis, , Missouri, United States" {
+		t.Errorf("FormatWithForm() = %q", value)
+	}
+	parsed, _ := ParsePlaceWithForm(value, form)
+	if parsed.City != "Saint Louis" || parsed.County != "" || parsed.State != "Missouri" || parsed.Country != "United States" {
+		t.Errorf("round trip: City/County/State/Country = %q/%q/%q/%q", parsed.City, parsed.County, parsed.State, parsed.Country)
+	}
+
+	// Jurisdictions without a field come from Jurisdictions
+	parsed, _ = ParsePlaceWithForm("St Mary, , Kent, England", ParsePlaceForm("Parish, Town, County, Country"))
+	if got := parsed.FormatWithForm(parsed.Form); got != "St Mary, , Kent, England" {
+		t.Errorf("FormatWithForm(own form) = %q", got)
+	}
+}
+
+func TestParsePlaceLine(t *testing.T) {
+	line := NewGedcomLine(2, "PLAC", "東京, 日本", "")
+	line.AddChild(NewGedcomLine(3, "FORM", "City, Country", ""))
+	mapLine := NewGedcomLine(3, "MAP", "", "")
+	mapLine.AddChild(NewGedcomLine(4, "LATI", "N35.6895", ""))
+	mapLine.AddChild(NewGedcomLine(4, "LONG", "E139.6917", ""))
+	line.AddChild(mapLine)
+	romn := NewGedcomLine(3, "ROMN", "Tokyo, Nihon", "")
+	romn.AddChild(NewGedcomLine(4, "TYPE", "hepburn", ""))
+	line.AddChild(romn)
+	line.AddChild(NewGedcomLine(3, "FONE", "とうきょう, にほん", ""))
+
+	// The line's FORM takes precedence over the form passed in
+	place, err := ParsePlaceLine(line, []string{"Prefecture", "Country"})
+	if err != nil {
+		t.Fatalf("ParsePlaceLine failed: %v", err)
+	}
+	if place.City != "東京" || place.Country != "日本" {
+		t.Errorf("City/Country = %q/%q", place.City, place.Country)
+	}
+	if !place.HasCoordinates || place.Latitude != 35.6895 || place.Longitude != 139.6917 {
+		t.Errorf("coordinates = %v, %v (%v)", place.Latitude, place.Longitude, place.HasCoordinates)
+	}
+	if len(place.Romanized) != 1 || place.Romanized[0] != (PlaceVariant{Value: "Tokyo, Nihon", Type: "hepburn"}) {
+		t.Errorf("Romanized = %+v", place.Romanized)
+	}
+	if len(place.Phonetic) != 1 || place.Phonetic[0].Value != "とうきょう, にほん" {
+		t.Errorf("Phonetic = %+v", place.Phonetic)
+	}
+}
+
+func TestIndividualRecord_GetBirthPlaceParsed_HeaderForm(t *testing.T) {
+	tree := NewGedcomTree()
+	head := NewGedcomLine(0, "HEAD", "", "")
+	plac := NewGedcomLine(1, "PLAC", "", "")
+	plac.AddChild(NewGedcomLine(2, "FORM", "Town, Province, Country", ""))
+	head.AddChild(plac)
+	tree.AddRecord(NewHeaderRecord(head))
+
+	line := NewGedcomLine(0, "INDI", "", "@I1@")
+	birt := NewGedcomLine(1, "BIRT", "", "")
+	birt.AddChild(NewGedcomLine(2, "PLAC", "Gouda, Zuid-Holland, Nederland", ""))
+	line.AddChild(birt)
+	indi := NewIndividualRecord(line)
+	tree.AddRecord(indi)
+
+	if form := tree.GetPlaceForm(); len(form) != 3 || form[1] != "Province" {
+		t.Errorf("GetPlaceForm() = %v", form)
+	}
+
+	place, err := indi.GetBirthPlaceParsed()
+	if err != nil {
+		t.Fatalf("GetBirthPlaceParsed failed: %v", err)
+	}
+	if place.City != "Gouda" || place.State != "Zuid-Holland" || place.Country != "Nederland" {
+		t.Errorf("City/State/Country = %q/%q/%q", place.City, place.State, place.Country)
+	}
+	if place.HasCoordinates {
+		t.Error("HasCoordinates = true for a place without MAP")
+	}
+}
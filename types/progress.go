@@ -0,0 +1,116 @@
+package types
+
+import "context"
+
+// ProgressReporter receives progress updates from long-running operations:
+// parsing, graph building, filter queries, path finding and duplicate
+// detection. done counts the work units completed so far in stage; total is
+// the number expected, or 0 when it is not known in advance (for example
+// when parsing from a stream).
+//
+// Reporters are attached to a context with WithProgress and picked up by the
+// Context variants of those operations (ParseContext, BuildGraphContext,
+// ExecuteContext, FindDuplicatesContext, ...).
+type ProgressReporter interface {
+	ReportProgress(stage string, done, total int64)
+}
+
+// ProgressFunc adapts a function to the ProgressReporter interface.
+type ProgressFunc func(stage string, done, total int64)
+
+// ReportProgress calls f(stage, done, total).
+func (f ProgressFunc) ReportProgress(stage string, done, total int64) {
+	f(stage, done, total)
+}
+
+// progressKey is the context key for the ProgressReporter.
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx that carries reporter.
+func WithProgress(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressKey{}, reporter)
+}
+
+// ProgressFromContext returns the ProgressReporter attached to ctx with
+// WithProgress, or nil if there is none.
+func ProgressFromContext(ctx context.Context) ProgressReporter {
+	reporter, _ := ctx.Value(progressKey{}).(ProgressReporter)
+	return reporter
+}
+
+// maxProgressStep is the largest number of units between two checkpoints,
+// which bounds how much work is done after a context is cancelled.
+const maxProgressStep = 1024
+
+// ProgressTracker counts the work units of one stage of an operation. At
+// checkpoints (the first unit, then about every 1% of total and at least
+// every 1024 units) it reports to the context's ProgressReporter and checks
+// whether the context is done. It is not safe for concurrent use.
+type ProgressTracker struct {
+	ctx      context.Context
+	reporter ProgressReporter
+	stage    string
+	total    int64
+	done     int64
+	step     int64
+	next     int64
+}
+
+// NewProgressTracker returns a tracker for stage, which is expected to take
+// total units (0 if unknown), and reports that the stage has started.
+func NewProgressTracker(ctx context.Context, stage string, total int64) *ProgressTracker {
+	step := total / 100
+	if step < 1 {
+		step = 1
+	}
+	if step > maxProgressStep || total <= 0 {
+		step = maxProgressStep
+	}
+
+	pt := &ProgressTracker{
+		ctx:      ctx,
+		reporter: ProgressFromContext(ctx),
+		stage:    stage,
+		total:    total,
+		step:     step,
+	}
+	pt.report()
+	return pt
+}
+
+// Add records n more completed units. At a checkpoint it returns the
+// context's error if the context has been cancelled or its deadline passed.
+func (pt *ProgressTracker) Add(n int64) error {
+	pt.done += n
+	if pt.done < pt.next {
+		return nil
+	}
+	pt.next = pt.done + pt.step
+	pt.report()
+	return pt.ctx.Err()
+}
+
+// Err returns the context's error without recording progress.
+func (pt *ProgressTracker) Err() error {
+	return pt.ctx.Err()
+}
+
+// Done returns the number of units recorded so far.
+func (pt *ProgressTracker) Done() int64 {
+	return pt.done
+}
+
+// Finish reports the stage as complete.
+func (pt *ProgressTracker) Finish() {
+	if pt.total > pt.done {
+		pt.done = pt.total
+	}
+	pt.report()
+}
+
+// report sends the current count to the reporter, if there is one.
+func (pt *ProgressTracker) report() {
+	if pt.reporter != nil {
+		pt.reporter.ReportProgress(pt.stage, pt.done, pt.total)
+	}
+}
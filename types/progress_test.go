@@ -0,0 +1,77 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// progressRecorder collects ReportProgress calls.
+type progressRecorder struct {
+	stages []string
+	done   []int64
+	total  int64
+}
+
+func (pr *progressRecorder) ReportProgress(stage string, done, total int64) {
+	pr.stages = append(pr.stages, stage)
+	pr.done = append(pr.done, done)
+	pr.total = total
+}
+
+func TestProgressTracker_Reports(t *testing.T) {
+	recorder := &progressRecorder{}
+	ctx := WithProgress(context.Background(), recorder)
+
+	tracker := NewProgressTracker(ctx, "Working", 500)
+	for i := 0; i < 500; i++ {
+		if err := tracker.Add(1); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	tracker.Finish()
+
+	// Start, one report per 1% (5 units) and the final report
+	if len(recorder.done) != 102 {
+		t.Errorf("got %d reports, want 102", len(recorder.done))
+	}
+	if recorder.done[0] != 0 || recorder.done[len(recorder.done)-1] != 500 {
+		t.Errorf("reports run from %d to %d, want 0 to 500", recorder.done[0], recorder.done[len(recorder.done)-1])
+	}
+	if recorder.total != 500 || recorder.stages[0] != "Working" {
+		t.Errorf("stage %q total %d, want %q 500", recorder.stages[0], recorder.total, "Working")
+	}
+}
+
+func TestProgressTracker_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tracker := NewProgressTracker(ctx, "Working", 0)
+	cancel()
+
+	var err error
+	for i := 0; i < 2*maxProgressStep && err == nil; i++ {
+		err = tracker.Add(1)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Add() error = %v, want context.Canceled", err)
+	}
+	if tracker.Done() > maxProgressStep {
+		t.Errorf("cancellation noticed after %d units, want at most %d", tracker.Done(), maxProgressStep)
+	}
+}
+
+func TestProgressFunc(t *testing.T) {
+	var got int64
+	ctx := WithProgress(context.Background(), ProgressFunc(func(stage string, done, total int64) {
+		got = done
+	}))
+
+	tracker := NewProgressTracker(ctx, "Working", 10)
+	tracker.Add(3)
+	if got != 3 {
+		t.Errorf("ProgressFunc saw %d, want 3", got)
+	}
+	if ProgressFromContext(context.Background()) != nil {
+		t.Error("ProgressFromContext() without a reporter should be nil")
+	}
+}
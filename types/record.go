@@ -14,6 +14,8 @@ const (
 	RecordTypeINDI RecordType = "INDI"
 	RecordTypeFAM  RecordType = "FAM"
 	RecordTypeNOTE RecordType = "NOTE"
+	// RecordTypeSNOTE is the GEDCOM 7.0 shared note record.
+	RecordTypeSNOTE RecordType = "SNOTE"
 	RecordTypeSOUR RecordType = "SOUR"
 	RecordTypeREPO RecordType = "REPO"
 	RecordTypeSUBM RecordType = "SUBM"
@@ -37,11 +39,12 @@ type BaseRecord struct {
 	firstLine  *GedcomLine
 	recordType RecordType
 	tree       *GedcomTree // Reference to the tree this record belongs to (set when added to tree)
-	uuid       string      // System-generated UUID (v4 format)
+	uuid       string      // System-generated UUID, see UUID
 }
 
 // NewBaseRecord creates a new BaseRecord from a GedcomLine.
-// A system-generated UUID is automatically assigned to the record.
+// A random UUID is assigned to the record; parsers replace it with a stable
+// one once the record is complete (see GedcomTree.AssignStableUUIDs).
 func NewBaseRecord(line *GedcomLine) *BaseRecord {
 	return &BaseRecord{
 		firstLine:  line,
@@ -74,11 +77,18 @@ func (br *BaseRecord) XrefID() string {
 	return br.firstLine.XrefID
 }
 
-// UUID returns the system-generated UUID of the record.
+// UUID returns the system-generated UUID of the record. For parsed records
+// it is stable across parses of the same file, see StableUUID.
 func (br *BaseRecord) UUID() string {
 	return br.uuid
 }
 
+// setUUID replaces the UUID. Called by GedcomTree.AssignStableUUIDs, which
+// keeps the UUID index in step.
+func (br *BaseRecord) setUUID(uuid string) {
+	br.uuid = uuid
+}
+
 // FirstLine returns the first line (level 0) of the record.
 func (br *BaseRecord) FirstLine() *GedcomLine {
 	return br.firstLine
@@ -118,6 +128,38 @@ func (br *BaseRecord) GetSources() []string {
 	return br.GetValues("SOUR")
 }
 
+// getPlace parses the first PLAC line matching selector (e.g. "BIRT.PLAC")
+// with the place form of the record's tree, if it belongs to one.
+func (br *BaseRecord) getPlace(selector string) (*GedcomPlace, error) {
+	lines := br.GetLines(selector)
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no place found")
+	}
+	var form []string
+	if tree := br.getTree(); tree != nil {
+		form = tree.GetPlaceForm()
+	}
+	return ParsePlaceLine(lines[0], form)
+}
+
+// dateLocale returns the date locale of the record's tree, or nil if the
+// record is not in a tree or the tree has none.
+func (br *BaseRecord) dateLocale() *DateLocale {
+	if tree := br.getTree(); tree != nil {
+		return tree.DateLocale()
+	}
+	return nil
+}
+
+// nameConvention returns the naming convention of the record's tree, or nil
+// if the record is not in a tree or the tree has none.
+func (br *BaseRecord) nameConvention() *NameConvention {
+	if tree := br.getTree(); tree != nil {
+		return tree.NameConvention()
+	}
+	return nil
+}
+
 // getTree returns the tree this record belongs to.
 // Returns nil if the record hasn't been added to a tree yet.
 func (br *BaseRecord) getTree() *GedcomTree {
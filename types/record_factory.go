@@ -24,6 +24,8 @@ func (rf *RecordFactory) CreateRecord(line *GedcomLine) Record {
 		return NewHeaderRecord(line)
 	case RecordTypeNOTE:
 		return NewNoteRecord(line)
+	case RecordTypeSNOTE:
+		return NewSharedNoteRecord(line)
 	case RecordTypeSOUR:
 		return NewSourceRecord(line)
 	case RecordTypeREPO:
@@ -0,0 +1,53 @@
+package types
+
+// SharedNoteRecord represents a GEDCOM 7.0 Shared Note (SNOTE) record.
+// It replaces the 5.5.1 NOTE record: text that can be referenced from many
+// structures with "1 SNOTE @N1@".
+type SharedNoteRecord struct {
+	*BaseRecord
+}
+
+// NoteTranslation is a TRAN substructure of a note: the same text in
+// another language or media type.
+type NoteTranslation struct {
+	Text     string
+	MimeType string
+	Language string
+}
+
+// NewSharedNoteRecord creates a new SharedNoteRecord from a GedcomLine.
+func NewSharedNoteRecord(line *GedcomLine) *SharedNoteRecord {
+	return &SharedNoteRecord{
+		BaseRecord: NewBaseRecord(line),
+	}
+}
+
+// GetText returns the note text (value of the SNOTE line, including CONT lines).
+func (snr *SharedNoteRecord) GetText() string {
+	return snr.GetValue("")
+}
+
+// GetMimeType returns the media type of the text (MIME), e.g. "text/html".
+// An empty string means text/plain.
+func (snr *SharedNoteRecord) GetMimeType() string {
+	return snr.GetValue("MIME")
+}
+
+// GetLanguage returns the language of the text (LANG).
+func (snr *SharedNoteRecord) GetLanguage() string {
+	return snr.GetValue("LANG")
+}
+
+// GetTranslations returns all translations of the note (TRAN).
+func (snr *SharedNoteRecord) GetTranslations() []NoteTranslation {
+	lines := snr.GetLines("TRAN")
+	translations := make([]NoteTranslation, 0, len(lines))
+	for _, line := range lines {
+		translations = append(translations, NoteTranslation{
+			Text:     line.Value,
+			MimeType: line.GetValue("MIME"),
+			Language: line.GetValue("LANG"),
+		})
+	}
+	return translations
+}
@@ -0,0 +1,60 @@
+package types
+
+import "testing"
+
+func TestSharedNoteRecord(t *testing.T) {
+	line := NewGedcomLine(0, "SNOTE", "<p>Born in a storm</p>", "@N1@")
+	line.AddChild(NewGedcomLine(1, "MIME", "text/html", ""))
+	line.AddChild(NewGedcomLine(1, "LANG", "en", ""))
+	tran := NewGedcomLine(1, "TRAN", "Né pendant une tempête", "")
+	tran.AddChild(NewGedcomLine(2, "LANG", "fr", ""))
+	line.AddChild(tran)
+
+	record := NewRecordFactory().CreateRecord(line)
+	snote, ok := record.(*SharedNoteRecord)
+	if !ok {
+		t.Fatalf("expected *SharedNoteRecord, got %T", record)
+	}
+	if snote.Type() != RecordTypeSNOTE {
+		t.Errorf("Type() = %q, want %q", snote.Type(), RecordTypeSNOTE)
+	}
+	if got := snote.GetText(); got != "<p>Born in a storm</p>" {
+		t.Errorf("GetText() = %q", got)
+	}
+	if got := snote.GetMimeType(); got != "text/html" {
+		t.Errorf("GetMimeType() = %q, want %q", got, "text/html")
+	}
+	if got := snote.GetLanguage(); got != "en" {
+		t.Errorf("GetLanguage() = %q, want %q", got, "en")
+	}
+
+	translations := snote.GetTranslations()
+	if len(translations) != 1 {
+		t.Fatalf("expected 1 translation, got %d", len(translations))
+	}
+	if translations[0].Language != "fr" || translations[0].Text != "Né pendant une tempête" {
+		t.Errorf("unexpected translation %+v", translations[0])
+	}
+}
+
+func TestGedcomTree_SharedNotes(t *testing.T) {
+	tree := NewGedcomTree()
+	tree.AddRecord(NewSharedNoteRecord(NewGedcomLine(0, "SNOTE", "Shared", "@N1@")))
+	tree.AddRecord(NewNoteRecord(NewGedcomLine(0, "NOTE", "Plain", "@N2@")))
+
+	if tree.GetSharedNote("@N1@") == nil {
+		t.Error("expected @N1@ to be a shared note")
+	}
+	if tree.GetSharedNote("@N2@") != nil {
+		t.Error("NOTE record should not be returned as a shared note")
+	}
+	if len(tree.GetAllSharedNotes()) != 1 {
+		t.Errorf("expected 1 shared note, got %d", len(tree.GetAllSharedNotes()))
+	}
+	if len(tree.GetAllNotes()) != 1 {
+		t.Errorf("expected 1 note, got %d", len(tree.GetAllNotes()))
+	}
+	if tree.GetRecordByXref("@N1@") == nil {
+		t.Error("shared note should be indexed by xref")
+	}
+}
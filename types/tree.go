@@ -2,6 +2,9 @@
 package types
 
 import (
+	"fmt"
+	"io"
+	"sort"
 	"sync"
 )
 
@@ -24,6 +27,7 @@ type GedcomTree struct {
 	individuals  map[string]Record // key: xref_id
 	families     map[string]Record
 	notes        map[string]Record
+	sharedNotes  map[string]Record // GEDCOM 7.0 SNOTE records
 	sources      map[string]Record
 	repositories map[string]Record
 	submitters   map[string]Record
@@ -38,6 +42,26 @@ type GedcomTree struct {
 	// Metadata
 	encoding string
 	version  string
+
+	// Access to the bytes of referenced media files (may be nil)
+	mediaSource MediaSource
+
+	// Date locale set with SetDateLocale (nil to use the header's LANG)
+	dateLocale *DateLocale
+
+	// Naming convention set with SetNameConvention (nil to use the header's LANG)
+	nameConvention *NameConvention
+
+	// File layout recorded by lossless parsing (may be nil)
+	sourceLayout *SourceLayout
+}
+
+// SourceLayout describes file-level details of a parsed GEDCOM file that
+// are not part of any record. Parsers record it in lossless mode so that
+// exporters can reproduce the file byte-for-byte.
+type SourceLayout struct {
+	BOM        bool   // The file started with a UTF-8 byte order mark
+	LineEnding string // Terminator of the first line: "\n" or "\r\n"
 }
 
 // NewGedcomTree creates a new empty GedcomTree.
@@ -46,6 +70,7 @@ func NewGedcomTree() *GedcomTree {
 		individuals:  make(map[string]Record),
 		families:     make(map[string]Record),
 		notes:        make(map[string]Record),
+		sharedNotes:  make(map[string]Record),
 		sources:      make(map[string]Record),
 		repositories: make(map[string]Record),
 		submitters:   make(map[string]Record),
@@ -60,7 +85,11 @@ func NewGedcomTree() *GedcomTree {
 func (gt *GedcomTree) AddRecord(record Record) {
 	gt.mu.Lock()
 	defer gt.mu.Unlock()
+	gt.addLocked(record)
+}
 
+// addLocked adds a record to the indexes. The caller must hold the write lock.
+func (gt *GedcomTree) addLocked(record Record) {
 	recordType := record.Type()
 	xrefID := record.XrefID()
 
@@ -103,6 +132,10 @@ func (gt *GedcomTree) addToTypeMap(recordType RecordType, xrefID string, record
 		if xrefID != "" {
 			gt.notes[xrefID] = record
 		}
+	case RecordTypeSNOTE:
+		if xrefID != "" {
+			gt.sharedNotes[xrefID] = record
+		}
 	case RecordTypeSOUR:
 		if xrefID != "" {
 			gt.sources[xrefID] = record
@@ -170,6 +203,18 @@ func (gt *GedcomTree) GetAllNotes() map[string]Record {
 	return gt.getAllRecords(gt.notes)
 }
 
+// GetAllSharedNotes returns all GEDCOM 7.0 shared note (SNOTE) records.
+func (gt *GedcomTree) GetAllSharedNotes() map[string]Record {
+	return gt.getAllRecords(gt.sharedNotes)
+}
+
+// GetSharedNote returns a shared note record by xref ID.
+func (gt *GedcomTree) GetSharedNote(xrefID string) Record {
+	gt.mu.RLock()
+	defer gt.mu.RUnlock()
+	return gt.sharedNotes[xrefID]
+}
+
 // GetAllSources returns all source records.
 func (gt *GedcomTree) GetAllSources() map[string]Record {
 	return gt.getAllRecords(gt.sources)
@@ -190,6 +235,33 @@ func (gt *GedcomTree) GetAllMultimedia() map[string]Record {
 	return gt.getAllRecords(gt.multimedia)
 }
 
+// GetAllRecords returns every record in the tree, including records of
+// unknown types and the trailer, in original file order. Records that were
+// not parsed from a file (line number 0) come last, in no particular order.
+func (gt *GedcomTree) GetAllRecords() []Record {
+	gt.mu.RLock()
+	defer gt.mu.RUnlock()
+	return gt.sortedRecords()
+}
+
+// sortedRecords returns every record in file order, see GetAllRecords. The
+// caller must hold the lock.
+func (gt *GedcomTree) sortedRecords() []Record {
+	records := make([]Record, 0, len(gt.uuidIndex))
+	for _, record := range gt.uuidIndex {
+		records = append(records, record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i].FirstLine().LineNumber, records[j].FirstLine().LineNumber
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		return a < b
+	})
+	return records
+}
+
 // GetRecordByXref returns any record by its xref ID.
 func (gt *GedcomTree) GetRecordByXref(xrefID string) Record {
 	gt.mu.RLock()
@@ -232,3 +304,110 @@ func (gt *GedcomTree) GetVersion() string {
 	return gt.version
 }
 
+// SetMediaSource sets where media file references (OBJE FILE) are resolved.
+func (gt *GedcomTree) SetMediaSource(source MediaSource) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.mediaSource = source
+}
+
+// GetMediaSource returns the media source, or nil if none is set.
+func (gt *GedcomTree) GetMediaSource() MediaSource {
+	gt.mu.RLock()
+	defer gt.mu.RUnlock()
+	return gt.mediaSource
+}
+
+// OpenMedia opens the media file referenced by a FILE value, e.g. the result
+// of MultimediaRecord.GetFile. The caller must close the returned reader.
+func (gt *GedcomTree) OpenMedia(ref string) (io.ReadCloser, error) {
+	source := gt.GetMediaSource()
+	if source == nil {
+		return nil, fmt.Errorf("no media source for %q", ref)
+	}
+	return source.OpenMedia(ref)
+}
+
+// SetSourceLayout sets the file layout recorded by lossless parsing.
+func (gt *GedcomTree) SetSourceLayout(layout *SourceLayout) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.sourceLayout = layout
+}
+
+// GetSourceLayout returns the file layout recorded by lossless parsing, or nil.
+func (gt *GedcomTree) GetSourceLayout() *SourceLayout {
+	gt.mu.RLock()
+	defer gt.mu.RUnlock()
+	return gt.sourceLayout
+}
+
+// IsGedcom7 returns true if the tree was parsed from a GEDCOM 7.x file.
+func (gt *GedcomTree) IsGedcom7() bool {
+	return IsGedcom7(gt.GetVersion())
+}
+
+// SetDateLocale selects the locale whose FROM/TO and filler words are used
+// when the tree's records parse their dates (see DateLocale). A nil locale
+// goes back to the one selected by the header's LANG.
+func (gt *GedcomTree) SetDateLocale(locale *DateLocale) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.dateLocale = locale
+}
+
+// DateLocale returns the locale set with SetDateLocale, or else the
+// registered locale for the header's LANG (see DateLocaleFor). Returns nil
+// if neither selects one.
+func (gt *GedcomTree) DateLocale() *DateLocale {
+	gt.mu.RLock()
+	locale := gt.dateLocale
+	gt.mu.RUnlock()
+	if locale != nil {
+		return locale
+	}
+
+	header := gt.GetHeader()
+	if header == nil {
+		return nil
+	}
+	return DateLocaleFor(header.GetValue("LANG"))
+}
+
+// SetNameConvention selects the naming convention the tree's individuals
+// parse their names with (see NameConvention). A nil convention goes back to
+// the one selected by the header's LANG.
+func (gt *GedcomTree) SetNameConvention(convention *NameConvention) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.nameConvention = convention
+}
+
+// NameConvention returns the convention set with SetNameConvention, or else
+// the registered convention for the header's LANG (see NameConventionFor).
+// Returns nil if there is neither; names are then parsed as Western.
+func (gt *GedcomTree) NameConvention() *NameConvention {
+	gt.mu.RLock()
+	convention := gt.nameConvention
+	gt.mu.RUnlock()
+	if convention != nil {
+		return convention
+	}
+
+	header := gt.GetHeader()
+	if header == nil {
+		return nil
+	}
+	return NameConventionFor(header.GetValue("LANG"))
+}
+
+// GetPlaceForm returns the jurisdiction names of the header's PLAC.FORM,
+// which apply to every place in the file that has no FORM of its own.
+func (gt *GedcomTree) GetPlaceForm() []string {
+	header := gt.GetHeader()
+	if header == nil {
+		return nil
+	}
+	return ParsePlaceForm(header.GetValue("PLAC.FORM"))
+}
+
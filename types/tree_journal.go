@@ -0,0 +1,604 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OperationKind identifies an edit recorded in a Journal.
+type OperationKind string
+
+const (
+	OpSetValue      OperationKind = "set_value"      // GedcomLine.SetValue
+	OpInsertLine    OperationKind = "insert_line"    // GedcomLine.InsertChild or AddChild
+	OpRemoveLine    OperationKind = "remove_line"    // GedcomLine.RemoveChild
+	OpAddRecord     OperationKind = "add_record"     // GedcomTree.AddRecord
+	OpRemoveRecord  OperationKind = "remove_record"  // GedcomTree.RemoveRecord
+	OpReplaceRecord OperationKind = "replace_record" // GedcomTree.ReplaceRecord
+	OpRenameXref    OperationKind = "rename_xref"    // GedcomTree.RenameXref
+)
+
+// headerKey addresses the header record in an Operation.
+const headerKey = "HEAD"
+
+// Operation is one edit made in a transaction.
+//
+// Lines are addressed by the record holding them and the path of child
+// positions (see ChildLines) from the record's first line, so that a journal
+// can be replayed against another copy of the same file.
+type Operation struct {
+	Kind     OperationKind `json:"op"`
+	Record   string        `json:"record,omitempty"`   // Xref of the record, "HEAD" for the header; the old xref for OpRenameXref
+	Path     []int         `json:"path,omitempty"`     // Child positions from the record's first line to the line
+	Selector string        `json:"selector,omitempty"` // Selector of OpSetValue
+	Value    string        `json:"value,omitempty"`    // Value of OpSetValue, new xref of OpRenameXref
+	Index    int           `json:"index,omitempty"`    // Position of the line inserted by OpInsertLine
+	Line     *LineSnapshot `json:"line,omitempty"`     // Inserted line, or added or replacing record
+
+	apply  func() // Makes the edit again after revert
+	revert func() // Undoes the edit
+}
+
+// LineSnapshot is a copy of a line and its descendants as stored in a journal.
+// Levels follow from the position of the line.
+type LineSnapshot struct {
+	Tag      string          `json:"tag"`
+	Value    string          `json:"value,omitempty"`
+	XrefID   string          `json:"xref,omitempty"`
+	Children []*LineSnapshot `json:"children,omitempty"`
+}
+
+// snapshotLine copies line and its descendants.
+func snapshotLine(line *GedcomLine) *LineSnapshot {
+	snapshot := &LineSnapshot{Tag: line.Tag, Value: line.Value, XrefID: line.XrefID}
+	for _, child := range line.ChildLines() {
+		snapshot.Children = append(snapshot.Children, snapshotLine(child))
+	}
+	return snapshot
+}
+
+// toLine creates the lines of the snapshot, starting at level.
+func (ls *LineSnapshot) toLine(level int) *GedcomLine {
+	line := NewGedcomLine(level, ls.Tag, ls.Value, ls.XrefID)
+	for _, child := range ls.Children {
+		line.AddChild(child.toLine(level + 1))
+	}
+	return line
+}
+
+// Journal records the edits made to a tree in transactions, so that they can
+// be undone, redone and saved as JSON to be replayed against another copy of
+// the file.
+//
+// Only edits made through a Transaction are recorded. A Journal is not safe
+// for concurrent use; the tree's own lock still guards its indexes.
+type Journal struct {
+	tree   *GedcomTree
+	open   *Transaction   // Transaction in progress, if any
+	done   []*Transaction // Committed transactions, oldest first
+	undone []*Transaction // Undone transactions, most recently undone last
+}
+
+// NewJournal creates a journal for edits to tree.
+func NewJournal(tree *GedcomTree) *Journal {
+	return &Journal{tree: tree}
+}
+
+// Begin starts a transaction. Only one transaction can be open at a time.
+func (j *Journal) Begin() (*Transaction, error) {
+	if j.open != nil {
+		return nil, fmt.Errorf("a transaction is already open")
+	}
+	j.open = &Transaction{journal: j}
+	return j.open, nil
+}
+
+// CanUndo reports whether there is a committed transaction to undo.
+func (j *Journal) CanUndo() bool {
+	return len(j.done) > 0
+}
+
+// CanRedo reports whether there is an undone transaction to redo.
+func (j *Journal) CanRedo() bool {
+	return len(j.undone) > 0
+}
+
+// Undo reverts the most recent committed transaction.
+func (j *Journal) Undo() error {
+	if j.open != nil {
+		return fmt.Errorf("cannot undo while a transaction is open")
+	}
+	if len(j.done) == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+	tx := j.done[len(j.done)-1]
+	j.done = j.done[:len(j.done)-1]
+	tx.revert()
+	j.undone = append(j.undone, tx)
+	return nil
+}
+
+// Redo makes the edits of the most recently undone transaction again. Any new
+// commit clears the transactions that can be redone.
+func (j *Journal) Redo() error {
+	if j.open != nil {
+		return fmt.Errorf("cannot redo while a transaction is open")
+	}
+	if len(j.undone) == 0 {
+		return fmt.Errorf("nothing to redo")
+	}
+	tx := j.undone[len(j.undone)-1]
+	j.undone = j.undone[:len(j.undone)-1]
+	for _, op := range tx.ops {
+		op.apply()
+	}
+	j.done = append(j.done, tx)
+	return nil
+}
+
+// journalData is the JSON form of a journal.
+type journalData struct {
+	Transactions []transactionData `json:"transactions"`
+}
+
+// transactionData is the JSON form of a transaction.
+type transactionData struct {
+	Operations []*Operation `json:"operations"`
+}
+
+// MarshalJSON returns the committed transactions that have not been undone,
+// in the order they were made.
+func (j *Journal) MarshalJSON() ([]byte, error) {
+	data := journalData{Transactions: make([]transactionData, 0, len(j.done))}
+	for _, tx := range j.done {
+		data.Transactions = append(data.Transactions, transactionData{Operations: tx.ops})
+	}
+	return json.Marshal(data)
+}
+
+// Replay makes the edits of a journal saved with MarshalJSON, one transaction
+// at a time, and records them in this journal. The tree should be a copy of
+// the one the journal was made on. If an operation fails, its transaction is
+// rolled back and the transactions before it stay committed.
+func (j *Journal) Replay(data []byte) error {
+	var journal journalData
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return fmt.Errorf("invalid journal: %w", err)
+	}
+
+	for i, transaction := range journal.Transactions {
+		tx, err := j.Begin()
+		if err != nil {
+			return err
+		}
+		for k, op := range transaction.Operations {
+			if err := tx.replay(op); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("transaction %d, operation %d (%s): %w", i+1, k+1, op.Kind, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locate returns the record key and path of line, see Operation.
+func (j *Journal) locate(line *GedcomLine) (string, []int, error) {
+	if line == nil {
+		return "", nil, fmt.Errorf("line is nil")
+	}
+
+	var path []int
+	for line.Parent != nil {
+		index := childIndex(line.Parent, line)
+		if index < 0 {
+			return "", nil, fmt.Errorf("%s line is not a child of its parent", line.Tag)
+		}
+		path = append([]int{index}, path...)
+		line = line.Parent
+	}
+
+	key := line.XrefID
+	var record Record
+	switch {
+	case key != "":
+		record = j.tree.GetRecordByXref(key)
+	case line.Tag == headerKey:
+		key = headerKey
+		record = j.tree.GetHeader()
+	}
+	if record == nil || record.FirstLine() != line {
+		return "", nil, fmt.Errorf("%s line is not in the tree", line.Tag)
+	}
+	return key, path, nil
+}
+
+// resolve returns the line at path in the record with key, see Operation.
+func (j *Journal) resolve(key string, path []int) (*GedcomLine, error) {
+	var record Record
+	if key == headerKey {
+		record = j.tree.GetHeader()
+	} else {
+		record = j.tree.GetRecordByXref(key)
+	}
+	if record == nil {
+		return nil, fmt.Errorf("no record %s", key)
+	}
+
+	line := record.FirstLine()
+	for _, index := range path {
+		children := line.ChildLines()
+		if index < 0 || index >= len(children) {
+			return nil, fmt.Errorf("no line at %v in record %s", path, key)
+		}
+		line = children[index]
+	}
+	return line, nil
+}
+
+// Transaction groups edits to a tree. Edits take effect immediately; Rollback
+// undoes them and Commit adds them to the journal for Undo and Redo.
+type Transaction struct {
+	journal *Journal
+	ops     []*Operation
+	closed  bool
+}
+
+// Operations returns the edits made so far, in order.
+func (tx *Transaction) Operations() []Operation {
+	ops := make([]Operation, len(tx.ops))
+	for i, op := range tx.ops {
+		ops[i] = *op
+	}
+	return ops
+}
+
+// Commit ends the transaction and keeps its edits. A transaction without
+// edits is not added to the journal.
+func (tx *Transaction) Commit() error {
+	if err := tx.close(); err != nil {
+		return err
+	}
+	if len(tx.ops) > 0 {
+		tx.journal.done = append(tx.journal.done, tx)
+		tx.journal.undone = nil
+	}
+	return nil
+}
+
+// Rollback ends the transaction and undoes its edits.
+func (tx *Transaction) Rollback() error {
+	if err := tx.close(); err != nil {
+		return err
+	}
+	tx.revert()
+	return nil
+}
+
+// close marks the transaction as ended.
+func (tx *Transaction) close() error {
+	if err := tx.check(); err != nil {
+		return err
+	}
+	tx.closed = true
+	tx.journal.open = nil
+	return nil
+}
+
+// check returns an error if the transaction has ended.
+func (tx *Transaction) check() error {
+	if tx.closed {
+		return fmt.Errorf("transaction is already committed or rolled back")
+	}
+	return nil
+}
+
+// revert undoes the edits in reverse order.
+func (tx *Transaction) revert() {
+	for i := len(tx.ops) - 1; i >= 0; i-- {
+		tx.ops[i].revert()
+	}
+}
+
+// SetValue calls line.SetValue(selector, value), see GedcomLine.SetValue.
+// Undo restores the old value, or removes the lines SetValue created.
+func (tx *Transaction) SetValue(line *GedcomLine, selector, value string) error {
+	if err := tx.check(); err != nil {
+		return err
+	}
+	key, path, err := tx.journal.locate(line)
+	if err != nil {
+		return err
+	}
+
+	// Find the line that will be changed, or where the first line is created
+	target, parent, tag := line, (*GedcomLine)(nil), ""
+	if selector != "" {
+		for _, part := range strings.Split(selector, ".") {
+			children := target.Children[part]
+			if len(children) == 0 {
+				parent, tag = target, part
+				break
+			}
+			target = children[0]
+		}
+	}
+	old := target.Value
+
+	line.SetValue(selector, value)
+
+	op := &Operation{Kind: OpSetValue, Record: key, Path: path, Selector: selector, Value: value}
+	if parent != nil {
+		created := parent.Children[tag][0]
+		op.apply = func() { parent.AddChild(created) }
+		op.revert = func() { parent.RemoveChild(created) }
+	} else {
+		op.apply = func() { target.Value = value }
+		op.revert = func() { target.Value = old }
+	}
+	tx.ops = append(tx.ops, op)
+	return nil
+}
+
+// AddLine adds child as the last child of parent, see InsertLine.
+func (tx *Transaction) AddLine(parent, child *GedcomLine) error {
+	return tx.InsertLine(parent, -1, child)
+}
+
+// InsertLine inserts child at position index of parent's children, see
+// GedcomLine.InsertChild. The levels of child and its descendants are set
+// from the level of parent.
+func (tx *Transaction) InsertLine(parent *GedcomLine, index int, child *GedcomLine) error {
+	if err := tx.check(); err != nil {
+		return err
+	}
+	if child == nil || child.Parent != nil {
+		return fmt.Errorf("line to insert must not be nil or have a parent")
+	}
+	key, path, err := tx.journal.locate(parent)
+	if err != nil {
+		return err
+	}
+
+	setLevels(child, parent.Level+1)
+	parent.InsertChild(index, child)
+	index = childIndex(parent, child)
+
+	tx.ops = append(tx.ops, &Operation{
+		Kind:   OpInsertLine,
+		Record: key,
+		Path:   path,
+		Index:  index,
+		Line:   snapshotLine(child),
+		apply:  func() { parent.InsertChild(index, child) },
+		revert: func() { parent.RemoveChild(child) },
+	})
+	return nil
+}
+
+// RemoveLine removes line and its descendants from its parent. Level 0 lines
+// are removed with RemoveRecord.
+func (tx *Transaction) RemoveLine(line *GedcomLine) error {
+	if err := tx.check(); err != nil {
+		return err
+	}
+	if line != nil && line.Parent == nil {
+		return fmt.Errorf("%s line has no parent, use RemoveRecord", line.Tag)
+	}
+	key, path, err := tx.journal.locate(line)
+	if err != nil {
+		return err
+	}
+
+	parent, index := line.Parent, path[len(path)-1]
+	parent.RemoveChild(line)
+
+	tx.ops = append(tx.ops, &Operation{
+		Kind:   OpRemoveLine,
+		Record: key,
+		Path:   path,
+		apply:  func() { parent.RemoveChild(line) },
+		revert: func() { parent.InsertChild(index, line) },
+	})
+	return nil
+}
+
+// AddRecord adds record to the tree. Unlike GedcomTree.AddRecord, the record
+// must have an xref that is not in use, or be a header for a tree without one.
+func (tx *Transaction) AddRecord(record Record) error {
+	if err := tx.check(); err != nil {
+		return err
+	}
+	tree := tx.journal.tree
+
+	key := record.XrefID()
+	switch {
+	case key != "":
+		if tree.GetRecordByXref(key) != nil {
+			return fmt.Errorf("xref %s is already in use", key)
+		}
+	case record.Type() == RecordTypeHEAD:
+		if tree.GetHeader() != nil {
+			return fmt.Errorf("tree already has a header")
+		}
+		key = headerKey
+	default:
+		return fmt.Errorf("%s record has no xref", record.Type())
+	}
+
+	tree.AddRecord(record)
+
+	tx.ops = append(tx.ops, &Operation{
+		Kind:   OpAddRecord,
+		Record: key,
+		Line:   snapshotLine(record.FirstLine()),
+		apply:  func() { tree.AddRecord(record) },
+		revert: func() { tree.detachRecord(record) },
+	})
+	return nil
+}
+
+// RemoveRecord removes a record and the pointers to it, see
+// GedcomTree.RemoveRecord. Undo puts the pointer lines back where they were.
+func (tx *Transaction) RemoveRecord(xrefID string) (*MutationReport, error) {
+	if err := tx.check(); err != nil {
+		return nil, err
+	}
+	tree := tx.journal.tree
+
+	tree.mu.Lock()
+	report, positions, err := tree.removeRecordLocked(xrefID)
+	tree.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	record, refs := report.Removed[0], report.References
+	tx.ops = append(tx.ops, &Operation{
+		Kind:   OpRemoveRecord,
+		Record: xrefID,
+		apply: func() {
+			tree.detachRecord(record)
+			for i, ref := range refs {
+				positions[i].parent.RemoveChild(ref.Line)
+			}
+		},
+		revert: func() {
+			for i := len(refs) - 1; i >= 0; i-- {
+				positions[i].parent.InsertChild(positions[i].index, refs[i].Line)
+			}
+			tree.AddRecord(record)
+		},
+	})
+	return report, nil
+}
+
+// ReplaceRecord replaces the record with the same xref, see
+// GedcomTree.ReplaceRecord.
+func (tx *Transaction) ReplaceRecord(record Record) (*MutationReport, error) {
+	if err := tx.check(); err != nil {
+		return nil, err
+	}
+	tree := tx.journal.tree
+
+	report, err := tree.ReplaceRecord(record)
+	if err != nil || len(report.Removed) == 0 {
+		return report, err
+	}
+
+	key := record.XrefID()
+	if key == "" {
+		key = headerKey
+	}
+	old := report.Removed[0]
+	tx.ops = append(tx.ops, &Operation{
+		Kind:   OpReplaceRecord,
+		Record: key,
+		Line:   snapshotLine(record.FirstLine()),
+		apply:  func() { tree.ReplaceRecord(record) },
+		revert: func() { tree.ReplaceRecord(old) },
+	})
+	return report, nil
+}
+
+// RenameXref changes the xref of a record and the pointers to it, see
+// GedcomTree.RenameXref. Undo restores exactly the pointers that were
+// rewritten.
+func (tx *Transaction) RenameXref(oldXref, newXref string) (*MutationReport, error) {
+	if err := tx.check(); err != nil {
+		return nil, err
+	}
+	tree := tx.journal.tree
+
+	report, err := tree.RenameXref(oldXref, newXref)
+	if err != nil || report.Xrefs == nil {
+		return report, err
+	}
+
+	record, refs := tree.GetRecordByXref(newXref), report.References
+	tx.ops = append(tx.ops, &Operation{
+		Kind:   OpRenameXref,
+		Record: oldXref,
+		Value:  newXref,
+		apply:  func() { tree.rekey(record, newXref, refs) },
+		revert: func() { tree.rekey(record, oldXref, refs) },
+	})
+	return report, nil
+}
+
+// replay makes the edit described by op, as read from JSON.
+func (tx *Transaction) replay(op *Operation) error {
+	j := tx.journal
+	switch op.Kind {
+	case OpSetValue:
+		line, err := j.resolve(op.Record, op.Path)
+		if err != nil {
+			return err
+		}
+		return tx.SetValue(line, op.Selector, op.Value)
+	case OpInsertLine:
+		parent, err := j.resolve(op.Record, op.Path)
+		if err != nil {
+			return err
+		}
+		if op.Line == nil {
+			return fmt.Errorf("no line to insert")
+		}
+		return tx.InsertLine(parent, op.Index, op.Line.toLine(parent.Level+1))
+	case OpRemoveLine:
+		line, err := j.resolve(op.Record, op.Path)
+		if err != nil {
+			return err
+		}
+		return tx.RemoveLine(line)
+	case OpAddRecord, OpReplaceRecord:
+		if op.Line == nil {
+			return fmt.Errorf("no record")
+		}
+		record := NewRecordFactory().CreateRecord(op.Line.toLine(0))
+		if op.Kind == OpAddRecord {
+			return tx.AddRecord(record)
+		}
+		_, err := tx.ReplaceRecord(record)
+		return err
+	case OpRemoveRecord:
+		_, err := tx.RemoveRecord(op.Record)
+		return err
+	case OpRenameXref:
+		_, err := tx.RenameXref(op.Record, op.Value)
+		return err
+	}
+	return fmt.Errorf("unknown operation %q", op.Kind)
+}
+
+// detachRecord removes record from the indexes without touching pointers to
+// it.
+func (gt *GedcomTree) detachRecord(record Record) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.removeLocked(record)
+}
+
+// rekey gives record the xref xrefID and points refs at it.
+func (gt *GedcomTree) rekey(record Record, xrefID string, refs []ReferenceChange) {
+	gt.mu.Lock()
+	gt.removeLocked(record)
+	record.FirstLine().XrefID = xrefID
+	gt.addLocked(record)
+	gt.mu.Unlock()
+
+	for _, ref := range refs {
+		ref.Line.Value = xrefID
+	}
+}
+
+// setLevels sets the level of line to level and renumbers its descendants.
+func setLevels(line *GedcomLine, level int) {
+	line.Level = level
+	for _, child := range line.ChildLines() {
+		setLevels(child, level+1)
+	}
+}
@@ -0,0 +1,219 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// treeText returns all records of tree in GEDCOM form, to compare states.
+// Records are sorted by their text, since records without a line number have
+// no fixed order.
+func treeText(tree *GedcomTree) string {
+	var records []string
+	for _, record := range tree.GetAllRecords() {
+		records = append(records, strings.Join(record.FirstLine().ToGED(), "\n"))
+	}
+	sort.Strings(records)
+	return strings.Join(records, "\n")
+}
+
+// editTree makes one of each kind of edit in a transaction.
+func editTree(t *testing.T, tx *Transaction, tree *GedcomTree) {
+	t.Helper()
+	father := tree.GetIndividual("@I1@").FirstLine()
+
+	steps := []error{
+		tx.SetValue(father, "BIRT.SOUR.PAGE", "p. 13"),
+		tx.SetValue(father, "DEAT.DATE", "1950"),
+		tx.InsertLine(father, 1, NewGedcomLine(5, "SEX", "M", "")),
+		tx.RemoveLine(tree.GetIndividual("@I2@").FirstLine().GetLines("FAMS")[0]),
+		tx.AddRecord(CreateTestIndividual("@I4@", "Ann /Doe/")),
+	}
+	_, err := tx.RemoveRecord("@N1@")
+	steps = append(steps, err)
+	_, err = tx.ReplaceRecord(CreateTestIndividual("@I3@", "James /Doe/"))
+	steps = append(steps, err)
+	_, err = tx.RenameXref("@F1@", "@F7@")
+	steps = append(steps, err)
+
+	for i, err := range steps {
+		if err != nil {
+			t.Fatalf("edit %d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	tree := mutationTestTree()
+	before := treeText(tree)
+
+	journal := NewJournal(tree)
+	tx, err := journal.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	editTree(t, tx, tree)
+
+	father := tree.GetIndividual("@I1@")
+	if father.GetValue("BIRT.SOUR.PAGE") != "p. 13" || father.GetValue("DEAT.DATE") != "1950" ||
+		father.FirstLine().ChildLines()[1].Tag != "SEX" || father.GetValue("SEX") != "M" {
+		t.Errorf("edits not applied:\n%s", treeText(tree))
+	}
+	if got := father.GetLines("SEX")[0].Level; got != 1 {
+		t.Errorf("inserted line level = %d, want 1", got)
+	}
+	if len(tx.Operations()) != 8 {
+		t.Errorf("Operations() = %d, want 8", len(tx.Operations()))
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if after := treeText(tree); after != before {
+		t.Errorf("tree after rollback:\n%s\nwant:\n%s", after, before)
+	}
+	if tree.GetFamily("@F1@") == nil || tree.GetRecordByXref("@F7@") != nil || tree.GetIndividual("@I4@") != nil {
+		t.Error("indexes not restored")
+	}
+	if journal.CanUndo() {
+		t.Error("rolled back transaction should not be undoable")
+	}
+	if err := tx.Commit(); err == nil {
+		t.Error("Commit() after Rollback() should fail")
+	}
+}
+
+func TestJournal_UndoRedo(t *testing.T) {
+	tree := mutationTestTree()
+	before := treeText(tree)
+	journal := NewJournal(tree)
+
+	tx, _ := journal.Begin()
+	if _, err := journal.Begin(); err == nil {
+		t.Error("Begin() with an open transaction should fail")
+	}
+	editTree(t, tx, tree)
+	if err := journal.Undo(); err == nil {
+		t.Error("Undo() with an open transaction should fail")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	edited := treeText(tree)
+
+	tx, _ = journal.Begin()
+	if err := tx.SetValue(tree.GetIndividual("@I4@").FirstLine(), "SEX", "F"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	tx.Commit()
+
+	if err := journal.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if got := treeText(tree); got != edited {
+		t.Errorf("after one undo:\n%s\nwant:\n%s", got, edited)
+	}
+	if err := journal.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if got := treeText(tree); got != before {
+		t.Errorf("after two undos:\n%s\nwant:\n%s", got, before)
+	}
+	if err := journal.Undo(); err == nil {
+		t.Error("Undo() with nothing to undo should fail")
+	}
+
+	if err := journal.Redo(); err != nil {
+		t.Fatalf("Redo() error = %v", err)
+	}
+	if got := treeText(tree); got != edited {
+		t.Errorf("after redo:\n%s\nwant:\n%s", got, edited)
+	}
+	if tree.GetFamily("@F7@") == nil || tree.GetIndividual("@I2@").GetValue("FAMS") != "" {
+		t.Error("redo did not restore indexes and pointers")
+	}
+
+	// A new commit drops what could be redone
+	tx, _ = journal.Begin()
+	tx.SetValue(tree.GetIndividual("@I1@").FirstLine(), "SEX", "U")
+	tx.Commit()
+	if journal.CanRedo() {
+		t.Error("CanRedo() after a new commit = true")
+	}
+}
+
+func TestJournal_Replay(t *testing.T) {
+	tree := mutationTestTree()
+	journal := NewJournal(tree)
+	tx, _ := journal.Begin()
+	editTree(t, tx, tree)
+	tx.Commit()
+
+	data, err := json.Marshal(journal)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	copyTree := mutationTestTree()
+	replayed := NewJournal(copyTree)
+	if err := replayed.Replay(data); err != nil {
+		t.Fatalf("Replay() error = %v\n%s", err, data)
+	}
+	if got, want := treeText(copyTree), treeText(tree); got != want {
+		t.Errorf("replayed tree:\n%s\nwant:\n%s", got, want)
+	}
+	if copyTree.GetFamily("@F7@") == nil || copyTree.GetRecordByXref("@N1@") != nil {
+		t.Error("replayed indexes differ")
+	}
+
+	// Replayed edits can be undone too
+	if err := replayed.Undo(); err != nil || treeText(copyTree) != treeText(mutationTestTree()) {
+		t.Errorf("Undo() of replay error = %v", err)
+	}
+
+	// An operation that does not apply rolls back its transaction
+	bad := []byte(`{"transactions":[{"operations":[
+		{"op":"set_value","record":"@I1@","selector":"SEX","value":"F"},
+		{"op":"remove_line","record":"@I1@","path":[42]}]}]}`)
+	other := mutationTestTree()
+	before := treeText(other)
+	if err := NewJournal(other).Replay(bad); err == nil || !strings.Contains(err.Error(), "operation 2") {
+		t.Errorf("Replay() error = %v, want failure at operation 2", err)
+	}
+	if treeText(other) != before {
+		t.Error("failed transaction was not rolled back")
+	}
+}
+
+func TestTransaction_Errors(t *testing.T) {
+	tree := mutationTestTree()
+	journal := NewJournal(tree)
+	tx, _ := journal.Begin()
+
+	outside := NewGedcomLine(0, "INDI", "", "@X1@")
+	if err := tx.SetValue(outside, "SEX", "M"); err == nil {
+		t.Error("SetValue() on a line outside the tree should fail")
+	}
+	if err := tx.RemoveLine(tree.GetIndividual("@I1@").FirstLine()); err == nil {
+		t.Error("RemoveLine() of a level 0 line should fail")
+	}
+	if err := tx.AddRecord(CreateTestIndividual("@I1@", "")); err == nil {
+		t.Error("AddRecord() with a used xref should fail")
+	}
+	if err := tx.AddLine(tree.GetIndividual("@I1@").FirstLine(), tree.GetIndividual("@I2@").FirstLine().GetLines("NAME")[0]); err == nil {
+		t.Error("AddLine() of a line with a parent should fail")
+	}
+	if len(tx.Operations()) != 0 {
+		t.Errorf("failed edits were recorded: %v", tx.Operations())
+	}
+
+	tx.Commit()
+	if journal.CanUndo() {
+		t.Error("empty transaction should not be undoable")
+	}
+	if err := tx.SetValue(tree.GetIndividual("@I1@").FirstLine(), "SEX", "M"); err == nil {
+		t.Error("SetValue() after Commit() should fail")
+	}
+}
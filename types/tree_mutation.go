@@ -0,0 +1,222 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReferenceChange describes a line that pointed at a record changed by
+// RemoveRecord or RenameXref.
+type ReferenceChange struct {
+	Record   Record      // Record containing the line
+	Line     *GedcomLine // Line holding the pointer
+	OldValue string      // Pointer before the change
+	NewValue string      // Pointer after the change, "" if the line was removed
+}
+
+// Removed reports whether the referencing line was removed from its record.
+func (rc ReferenceChange) Removed() bool {
+	return rc.NewValue == ""
+}
+
+// MutationReport lists what a tree mutation changed.
+//
+// Query graphs, indexes and other structures built from the tree are not
+// updated by mutations; rebuild them after changing the tree.
+type MutationReport struct {
+	Removed    []Record          // Records taken out of the tree
+	Added      []Record          // Records put into the tree
+	Xrefs      map[string]string // Renamed xrefs, old -> new
+	References []ReferenceChange // Rewritten or removed pointer lines, in file order
+}
+
+// RemoveRecord removes the record with xrefID from the tree, together with
+// every line elsewhere in the tree that points at it (FAMS, FAMC, HUSB, WIFE,
+// CHIL, SOUR, NOTE, OBJE and any other pointer). Lines below a removed
+// pointer, such as the PAGE of a citation, go with it.
+func (gt *GedcomTree) RemoveRecord(xrefID string) (*MutationReport, error) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	report, _, err := gt.removeRecordLocked(xrefID)
+	return report, err
+}
+
+// linePosition is where a line was among the children of its parent.
+type linePosition struct {
+	parent *GedcomLine
+	index  int
+}
+
+// removeRecordLocked implements RemoveRecord. It also returns the position of
+// each referencing line at the time it was removed, so that the lines can be
+// put back in reverse order. The caller must hold the write lock.
+func (gt *GedcomTree) removeRecordLocked(xrefID string) (*MutationReport, []linePosition, error) {
+	record := gt.xrefIndex[xrefID]
+	if record == nil {
+		return nil, nil, fmt.Errorf("no record with xref %s", xrefID)
+	}
+
+	report := &MutationReport{Removed: []Record{record}}
+	var positions []linePosition
+	gt.removeLocked(record)
+	for _, ref := range gt.findReferencesLocked(xrefID) {
+		positions = append(positions, linePosition{ref.Line.Parent, childIndex(ref.Line.Parent, ref.Line)})
+		ref.Line.Parent.RemoveChild(ref.Line)
+		report.References = append(report.References, ref)
+	}
+	return report, positions, nil
+}
+
+// ReplaceRecord puts record in the place of the record with the same xref
+// (or of the header, for a HEAD record). The replacement must be of the same
+// type. Pointers to the xref are left as they are. A replacement that was not
+// parsed from a file takes the line number of the record it replaces, so that
+// it keeps its position in GetAllRecords.
+func (gt *GedcomTree) ReplaceRecord(record Record) (*MutationReport, error) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	var old Record
+	xrefID := record.XrefID()
+	switch {
+	case xrefID != "":
+		old = gt.xrefIndex[xrefID]
+	case record.Type() == RecordTypeHEAD:
+		old = gt.header
+	}
+	if old == nil {
+		return nil, fmt.Errorf("no %s record %s to replace", record.Type(), xrefID)
+	}
+	if old.Type() != record.Type() {
+		return nil, fmt.Errorf("cannot replace %s record %s with a %s record", old.Type(), xrefID, record.Type())
+	}
+	if old == record {
+		return &MutationReport{}, nil
+	}
+
+	if first := record.FirstLine(); first.LineNumber == 0 {
+		first.LineNumber = old.FirstLine().LineNumber
+	}
+	gt.removeLocked(old)
+	gt.addLocked(record)
+	return &MutationReport{Removed: []Record{old}, Added: []Record{record}}, nil
+}
+
+// RenameXref changes the xref of a record from oldXref to newXref and
+// rewrites every pointer to it across the tree.
+func (gt *GedcomTree) RenameXref(oldXref, newXref string) (*MutationReport, error) {
+	if !isXref(newXref) {
+		return nil, fmt.Errorf("invalid xref %q: must be enclosed in @", newXref)
+	}
+	if IsVoidPointer(newXref) {
+		return nil, fmt.Errorf("invalid xref %q: reserved for null pointers", newXref)
+	}
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	record := gt.xrefIndex[oldXref]
+	if record == nil {
+		return nil, fmt.Errorf("no record with xref %s", oldXref)
+	}
+	if oldXref == newXref {
+		return &MutationReport{}, nil
+	}
+	if gt.xrefIndex[newXref] != nil {
+		return nil, fmt.Errorf("xref %s is already in use", newXref)
+	}
+
+	gt.removeLocked(record)
+	record.FirstLine().XrefID = newXref
+	gt.addLocked(record)
+
+	report := &MutationReport{Xrefs: map[string]string{oldXref: newXref}}
+	for _, ref := range gt.findReferencesLocked(oldXref) {
+		ref.Line.Value = newXref
+		ref.NewValue = newXref
+		report.References = append(report.References, ref)
+	}
+	return report, nil
+}
+
+// removeLocked removes record from the indexes and type maps. The caller must
+// hold the write lock.
+func (gt *GedcomTree) removeLocked(record Record) {
+	xrefID := record.XrefID()
+	if xrefID != "" && gt.xrefIndex[xrefID] == record {
+		delete(gt.xrefIndex, xrefID)
+	}
+	delete(gt.uuidIndex, record.UUID())
+
+	if record.Type() == RecordTypeHEAD && gt.header == record {
+		gt.header = nil
+	} else if records := gt.typeMap(record.Type()); records != nil && records[xrefID] == record {
+		delete(records, xrefID)
+	}
+
+	if br, ok := record.(interface{ setTree(*GedcomTree) }); ok {
+		br.setTree(nil)
+	}
+}
+
+// typeMap returns the type-specific map that holds records of recordType, or
+// nil for types that are not kept in one (HEAD, TRLR and unknown types).
+func (gt *GedcomTree) typeMap(recordType RecordType) map[string]Record {
+	switch recordType {
+	case RecordTypeINDI:
+		return gt.individuals
+	case RecordTypeFAM:
+		return gt.families
+	case RecordTypeNOTE:
+		return gt.notes
+	case RecordTypeSNOTE:
+		return gt.sharedNotes
+	case RecordTypeSOUR:
+		return gt.sources
+	case RecordTypeREPO:
+		return gt.repositories
+	case RecordTypeSUBM:
+		return gt.submitters
+	case RecordTypeOBJE:
+		return gt.multimedia
+	}
+	return nil
+}
+
+// findReferencesLocked returns every line below level 0 whose value is the
+// pointer xrefID, in file order. The caller must hold the lock.
+func (gt *GedcomTree) findReferencesLocked(xrefID string) []ReferenceChange {
+	var refs []ReferenceChange
+	var walk func(record Record, line *GedcomLine)
+	walk = func(record Record, line *GedcomLine) {
+		for _, child := range line.ChildLines() {
+			if child.Value == xrefID {
+				refs = append(refs, ReferenceChange{Record: record, Line: child, OldValue: xrefID})
+				continue
+			}
+			walk(record, child)
+		}
+	}
+	for _, record := range gt.sortedRecords() {
+		if first := record.FirstLine(); first != nil {
+			walk(record, first)
+		}
+	}
+	return refs
+}
+
+// isXref reports whether value has the form of a cross-reference, e.g. "@I1@".
+func isXref(value string) bool {
+	return len(value) > 2 && strings.HasPrefix(value, "@") && strings.HasSuffix(value, "@") &&
+		!strings.ContainsAny(value[1:len(value)-1], "@ \t\r\n")
+}
+
+// childIndex returns the position of child in parent.ChildLines(), or -1.
+func childIndex(parent, child *GedcomLine) int {
+	for i, line := range parent.ChildLines() {
+		if line == child {
+			return i
+		}
+	}
+	return -1
+}
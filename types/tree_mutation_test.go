@@ -0,0 +1,157 @@
+package types
+
+import "testing"
+
+// mutationTestTree returns a tree with a family of three, a source cited by
+// the father and a note attached to the family.
+func mutationTestTree() *GedcomTree {
+	tree := NewGedcomTree()
+	tree.AddRecord(NewHeaderRecord(NewGedcomLine(0, "HEAD", "", "")))
+
+	father := CreateTestIndividual("@I1@", "John /Doe/")
+	father.FirstLine().AddChild(NewGedcomLine(1, "FAMS", "@F1@", ""))
+	birth := NewGedcomLine(1, "BIRT", "", "")
+	citation := NewGedcomLine(2, "SOUR", "@S1@", "")
+	citation.AddChild(NewGedcomLine(3, "PAGE", "p. 12", ""))
+	birth.AddChild(citation)
+	father.FirstLine().AddChild(birth)
+
+	mother := CreateTestIndividual("@I2@", "Jane /Roe/")
+	mother.FirstLine().AddChild(NewGedcomLine(1, "FAMS", "@F1@", ""))
+	child := CreateTestIndividual("@I3@", "Jim /Doe/")
+	child.FirstLine().AddChild(NewGedcomLine(1, "FAMC", "@F1@", ""))
+
+	fam := CreateTestFamily("@F1@", "@I1@", "@I2@", []string{"@I3@"})
+	fam.FirstLine().AddChild(NewGedcomLine(1, "NOTE", "@N1@", ""))
+
+	for i, record := range []Record{father, mother, child, fam,
+		NewSourceRecord(NewGedcomLine(0, "SOUR", "", "@S1@")),
+		NewNoteRecord(NewGedcomLine(0, "NOTE", "", "@N1@"))} {
+		record.FirstLine().LineNumber = (i + 1) * 10
+		tree.AddRecord(record)
+	}
+	return tree
+}
+
+func TestGedcomTree_RemoveRecord(t *testing.T) {
+	tree := mutationTestTree()
+	father := tree.GetIndividual("@I1@")
+
+	report, err := tree.RemoveRecord("@I1@")
+	if err != nil {
+		t.Fatalf("RemoveRecord() error = %v", err)
+	}
+	if tree.GetIndividual("@I1@") != nil || tree.GetRecordByXref("@I1@") != nil || tree.GetRecordByUUID(father.UUID()) != nil {
+		t.Error("record still indexed after removal")
+	}
+	if len(report.Removed) != 1 || report.Removed[0] != father {
+		t.Errorf("Removed = %v, want the father", report.Removed)
+	}
+	if len(report.References) != 1 || !report.References[0].Removed() || report.References[0].Line.Tag != "HUSB" {
+		t.Fatalf("References = %+v, want the HUSB line", report.References)
+	}
+	if fam := tree.GetFamily("@F1@"); fam.GetValue("HUSB") != "" || fam.GetValue("WIFE") != "@I2@" {
+		t.Error("HUSB line should be removed and WIFE kept")
+	}
+
+	// A citation goes with the lines below it
+	tree.AddRecord(father)
+	report, err = tree.RemoveRecord("@S1@")
+	if err != nil {
+		t.Fatalf("RemoveRecord() error = %v", err)
+	}
+	if len(report.References) != 1 || report.References[0].Record != father {
+		t.Fatalf("References = %+v, want the citation on the father", report.References)
+	}
+	if len(father.GetLines("BIRT.SOUR")) != 0 || len(tree.GetAllSources()) != 0 {
+		t.Error("source and its citation should be gone")
+	}
+
+	if _, err := tree.RemoveRecord("@X9@"); err == nil {
+		t.Error("expected an error for an unknown xref")
+	}
+}
+
+func TestGedcomTree_ReplaceRecord(t *testing.T) {
+	tree := mutationTestTree()
+	old := tree.GetIndividual("@I2@")
+
+	replacement := CreateTestIndividual("@I2@", "Jane /Smith/")
+	report, err := tree.ReplaceRecord(replacement)
+	if err != nil {
+		t.Fatalf("ReplaceRecord() error = %v", err)
+	}
+	if tree.GetIndividual("@I2@") != replacement || tree.GetRecordByUUID(old.UUID()) != nil || tree.GetRecordByUUID(replacement.UUID()) != replacement {
+		t.Error("indexes not updated")
+	}
+	if report.Removed[0] != old || report.Added[0] != replacement || len(report.References) != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	if replacement.FirstLine().LineNumber != 20 || tree.GetAllRecords()[1] != replacement {
+		t.Error("replacement should keep the position of the old record")
+	}
+	if tree.GetFamily("@F1@").GetValue("WIFE") != "@I2@" {
+		t.Error("pointers to the record should be kept")
+	}
+
+	if _, err := tree.ReplaceRecord(CreateTestFamily("@I1@", "", "", nil)); err == nil {
+		t.Error("expected an error when changing the record type")
+	}
+	if _, err := tree.ReplaceRecord(CreateTestIndividual("@I9@", "")); err == nil {
+		t.Error("expected an error when there is nothing to replace")
+	}
+
+	header := NewHeaderRecord(NewGedcomLine(0, "HEAD", "", ""))
+	if _, err := tree.ReplaceRecord(header); err != nil || tree.GetHeader() != header {
+		t.Errorf("ReplaceRecord(HEAD) error = %v", err)
+	}
+}
+
+func TestGedcomTree_RenameXref(t *testing.T) {
+	tree := mutationTestTree()
+	fam := tree.GetFamily("@F1@")
+
+	report, err := tree.RenameXref("@F1@", "@F100@")
+	if err != nil {
+		t.Fatalf("RenameXref() error = %v", err)
+	}
+	if fam.XrefID() != "@F100@" || tree.GetFamily("@F100@") != fam || tree.GetFamily("@F1@") != nil || tree.GetRecordByXref("@F1@") != nil {
+		t.Error("family not re-keyed")
+	}
+	if report.Xrefs["@F1@"] != "@F100@" {
+		t.Errorf("Xrefs = %v", report.Xrefs)
+	}
+
+	var tags []string
+	for _, ref := range report.References {
+		if ref.Removed() || ref.OldValue != "@F1@" || ref.Line.Value != "@F100@" {
+			t.Errorf("reference %+v not rewritten", ref)
+		}
+		tags = append(tags, ref.Record.XrefID()+" "+ref.Line.Tag)
+	}
+	want := []string{"@I1@ FAMS", "@I2@ FAMS", "@I3@ FAMC"}
+	if len(tags) != len(want) {
+		t.Fatalf("references = %v, want %v", tags, want)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("reference %d = %s, want %s", i, tags[i], want[i])
+		}
+	}
+
+	// Pointers from the renamed record itself are unaffected
+	if _, err := tree.RenameXref("@N1@", "@N2@"); err != nil || fam.GetValue("NOTE") != "@N2@" {
+		t.Errorf("RenameXref(@N1@) error = %v, NOTE = %q", err, fam.GetValue("NOTE"))
+	}
+
+	for _, tt := range []struct{ old, new string }{
+		{"@I1@", "@I2@"},
+		{"@I1@", "I5"},
+		{"@I1@", "@VOID@"},
+		{"@X9@", "@X10@"},
+	} {
+		if _, err := tree.RenameXref(tt.old, tt.new); err == nil {
+			t.Errorf("RenameXref(%s, %s) should fail", tt.old, tt.new)
+		}
+	}
+}
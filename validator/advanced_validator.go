@@ -28,6 +28,7 @@ type ValidationConfig struct {
 	MaxDeathAge      int // Maximum reasonable age at death (default: 120)
 	SpouseAgeGapWarn int // Age gap between spouses to trigger warning (default: 30)
 	SpouseAgeGapHint int // Age gap between spouses to trigger hint (default: 40)
+	StatedAgeSlack   int // Years a stated AGE may differ from the age computed from the birth date (default: 1)
 
 	// Date thresholds
 	MinHistoricalDate int // Minimum reasonable historical date in CE (default: 500)
@@ -52,6 +53,7 @@ func NewValidationConfig() *ValidationConfig {
 		MaxDeathAge:       120,
 		SpouseAgeGapWarn:  30,
 		SpouseAgeGapHint:  40,
+		StatedAgeSlack:    1,
 		MinHistoricalDate: 500,
 		MaxFutureDate:     2026, // Current year + 1, should be dynamic
 		DateRangeWarn:     50,
@@ -89,7 +89,7 @@ func (crv *CrossReferenceValidator) validateFamilyReferences(tree *types.GedcomT
 	for xrefID, record := range families {
 		// Validate HUSB reference
 		husbValue := record.GetValue("HUSB")
-		if husbValue != "" {
+		if husbValue != "" && !types.IsVoidPointer(husbValue) {
 			if _, exists := individuals[husbValue]; !exists {
 				husbLines := record.GetLines("HUSB")
 				if len(husbLines) > 0 {
@@ -103,7 +103,7 @@ func (crv *CrossReferenceValidator) validateFamilyReferences(tree *types.GedcomT
 
 		// Validate WIFE reference
 		wifeValue := record.GetValue("WIFE")
-		if wifeValue != "" {
+		if wifeValue != "" && !types.IsVoidPointer(wifeValue) {
 			if _, exists := individuals[wifeValue]; !exists {
 				wifeLines := record.GetLines("WIFE")
 				if len(wifeLines) > 0 {
@@ -118,6 +118,9 @@ func (crv *CrossReferenceValidator) validateFamilyReferences(tree *types.GedcomT
 		// Validate CHIL references
 		chilRefs := record.GetValues("CHIL")
 		for _, chilRef := range chilRefs {
+			if types.IsVoidPointer(chilRef) {
+				continue
+			}
 			if _, exists := individuals[chilRef]; !exists {
 				chilLines := record.GetLines("CHIL")
 				if len(chilLines) > 0 {
@@ -140,6 +143,9 @@ func (crv *CrossReferenceValidator) validateIndividualReferences(tree *types.Ged
 		// Validate FAMS references
 		famsRefs := record.GetValues("FAMS")
 		for _, famsRef := range famsRefs {
+			if types.IsVoidPointer(famsRef) {
+				continue
+			}
 			if _, exists := families[famsRef]; !exists {
 				famsLines := record.GetLines("FAMS")
 				if len(famsLines) > 0 {
@@ -154,6 +160,9 @@ func (crv *CrossReferenceValidator) validateIndividualReferences(tree *types.Ged
 		// Validate FAMC references
 		famcRefs := record.GetValues("FAMC")
 		for _, famcRef := range famcRefs {
+			if types.IsVoidPointer(famcRef) {
+				continue
+			}
 			if _, exists := families[famcRef]; !exists {
 				famcLines := record.GetLines("FAMC")
 				if len(famcLines) > 0 {
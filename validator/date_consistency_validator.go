@@ -125,6 +125,23 @@ func (dcv *DateConsistencyValidator) validateIndividualDates(indi *types.Individ
 		}
 	}
 
+	// Check ages stated on events against the birth date (Warning)
+	if birthDate != nil && birthDate.IsValid() {
+		for _, event := range types.ExtractEvents(indi) {
+			if event.Age == nil || !event.HasDate() {
+				continue
+			}
+			if message, mismatch := dcv.checkStatedAge(birthDate, event.Date.Date, event.Age, config); mismatch {
+				errors = append(errors, &types.GedcomError{
+					Severity:   types.SeverityWarning,
+					Message:    fmt.Sprintf("INDI %s: Stated age at %s %s", xrefID, event.EffectiveType(), message),
+					LineNumber: event.OriginalLine.LineNumber,
+					Context:    "Date Consistency",
+				})
+			}
+		}
+	}
+
 	// Check birth before marriage events
 	marriageFamilies := indi.GetFamiliesAsSpouse()
 	for _, famXref := range marriageFamilies {
@@ -203,6 +220,42 @@ func (dcv *DateConsistencyValidator) validateFamilyDates(fam *types.FamilyRecord
 		}
 	}
 
+	// Check spouses' ages stated on family events against their birth dates (Warning)
+	for _, event := range types.ExtractEvents(fam) {
+		if !event.HasDate() {
+			continue
+		}
+		spouses := []struct {
+			role string
+			xref string
+			age  *types.Age
+		}{
+			{"husband", fam.GetHusband(), event.HusbandAge},
+			{"wife", fam.GetWife(), event.WifeAge},
+		}
+		for _, spouse := range spouses {
+			if spouse.age == nil || spouse.xref == "" {
+				continue
+			}
+			spouseRecord, ok := tree.GetIndividual(spouse.xref).(*types.IndividualRecord)
+			if !ok {
+				continue
+			}
+			birthDate, _ := spouseRecord.GetBirthDateParsed()
+			if birthDate == nil || !birthDate.IsValid() {
+				continue
+			}
+			if message, mismatch := dcv.checkStatedAge(birthDate, event.Date.Date, spouse.age, config); mismatch {
+				errors = append(errors, &types.GedcomError{
+					Severity:   types.SeverityWarning,
+					Message:    fmt.Sprintf("FAM %s: Stated %s age at %s %s", xrefID, spouse.role, event.EffectiveType(), message),
+					LineNumber: event.OriginalLine.LineNumber,
+					Context:    "Date Consistency",
+				})
+			}
+		}
+	}
+
 	// Check marriage before children's births
 	if marriageDate != nil && marriageDate.IsValid() {
 		children := fam.GetChildren()
@@ -384,6 +437,26 @@ func (dcv *DateConsistencyValidator) validateCrossRecordDates(tree *types.Gedcom
 	return errors
 }
 
+// checkStatedAge compares an age stated at an event with the birth date.
+// The birth dates the age implies (see types.BirthRangeFromAge) are widened
+// by config.StatedAgeSlack years, as ages in records are often a year out.
+// It returns the end of the error message if the birth date falls outside.
+func (dcv *DateConsistencyValidator) checkStatedAge(birthDate, eventDate *types.GedcomDate, age *types.Age, config *ValidationConfig) (string, bool) {
+	birthRange, ok := types.BirthRangeFromAge(eventDate, age)
+	if !ok {
+		return "", false
+	}
+
+	earliest := birthRange.StartDate().Earliest().AddDate(-config.StatedAgeSlack, 0, 0)
+	latest := birthRange.EndDate().Latest().AddDate(config.StatedAgeSlack, 0, 0)
+	if !birthDate.Latest().Before(earliest) && !birthDate.Earliest().After(latest) {
+		return "", false
+	}
+
+	return fmt.Sprintf("(%s) does not match birth date (%s): computed age is %d years",
+		age.String(), birthDate.String(), dcv.calculateAge(birthDate, eventDate)), true
+}
+
 // calculateAge calculates the age in years between two dates.
 // Returns 0 if dates are invalid or cannot be calculated.
 func (dcv *DateConsistencyValidator) calculateAge(startDate, endDate *types.GedcomDate) int {
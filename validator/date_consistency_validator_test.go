@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/lesfleursdelanuitdev/ligneous-gedcom/types"
@@ -320,3 +321,63 @@ func TestAdvancedValidator_Integration(t *testing.T) {
 		t.Error("Expected errors to be collected")
 	}
 }
+
+func TestDateConsistencyValidator_StatedAge(t *testing.T) {
+	// Born 1820; census says 40 in 1861 (fine), death says 42 in 1880 (wrong)
+	indiLine := types.NewGedcomLine(0, "INDI", "", "@I1@")
+	birtLine := types.NewGedcomLine(1, "BIRT", "", "")
+	birtLine.AddChild(types.NewGedcomLine(2, "DATE", "20 MAY 1820", ""))
+	censLine := types.NewGedcomLine(1, "CENS", "", "")
+	censLine.AddChild(types.NewGedcomLine(2, "DATE", "7 APR 1861", ""))
+	censLine.AddChild(types.NewGedcomLine(2, "AGE", "40y", ""))
+	deatLine := types.NewGedcomLine(1, "DEAT", "", "")
+	deatLine.AddChild(types.NewGedcomLine(2, "DATE", "3 MAR 1880", ""))
+	deatLine.AddChild(types.NewGedcomLine(2, "AGE", "42y", ""))
+	indiLine.AddChild(birtLine)
+	indiLine.AddChild(censLine)
+	indiLine.AddChild(deatLine)
+	indiLine.AddChild(types.NewGedcomLine(1, "FAMS", "@F1@", ""))
+
+	// Married 1845 at a stated age of "<21y", but he was 25
+	famLine := types.NewGedcomLine(0, "FAM", "", "@F1@")
+	famLine.AddChild(types.NewGedcomLine(1, "HUSB", "@I1@", ""))
+	marrLine := types.NewGedcomLine(1, "MARR", "", "")
+	marrLine.AddChild(types.NewGedcomLine(2, "DATE", "1 JUN 1845", ""))
+	husbLine := types.NewGedcomLine(2, "HUSB", "", "")
+	husbLine.AddChild(types.NewGedcomLine(3, "AGE", "<21y", ""))
+	marrLine.AddChild(husbLine)
+	famLine.AddChild(marrLine)
+
+	tree := types.NewGedcomTree()
+	tree.AddRecord(types.NewIndividualRecord(indiLine))
+	tree.AddRecord(types.NewFamilyRecord(famLine))
+
+	validator := NewDateConsistencyValidator(types.NewErrorManager())
+	errors := validator.Validate(tree, NewValidationConfig())
+
+	var messages []string
+	for _, err := range errors {
+		if strings.Contains(err.Message, "Stated") {
+			messages = append(messages, err.Message)
+		}
+	}
+
+	want := []string{
+		"INDI @I1@: Stated age at DEAT (42y) does not match birth date (20 MAY 1820): computed age is 59 years",
+		"FAM @F1@: Stated husband age at MARR (< 21y) does not match birth date (20 MAY 1820): computed age is 25 years",
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("Expected %d stated age warnings, got %d: %v", len(want), len(messages), messages)
+	}
+	for _, w := range want {
+		found := false
+		for _, m := range messages {
+			if m == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected warning %q, got %v", w, messages)
+		}
+	}
+}